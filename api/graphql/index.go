@@ -0,0 +1,104 @@
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go/errors"
+
+	"maguro-alternative/varcel-go/pkg/codec"
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/graph"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+)
+
+// Params はGraphQLのリクエスト
+type Params struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Result はJSON以外で返す場合のGraphQLのレスポンス
+type Result struct {
+	Data   interface{}          `json:"data,omitempty"`
+	Errors []*errors.QueryError `json:"errors,omitempty"`
+}
+
+func Handler(w http.ResponseWriter, r *http.Request) {
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	var params Params
+	switch r.Method {
+	case http.MethodGet:
+		// GETはクエリパラメータで受け取る
+		params.Query = r.URL.Query().Get("query")
+		params.OperationName = r.URL.Query().Get("operationName")
+		if v := r.URL.Query().Get("variables"); v != "" {
+			err := json.Unmarshal([]byte(v), &params.Variables)
+			if err != nil {
+				logging.Error(r.Context(), "decode", err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	case http.MethodPost:
+		err := response.Decode(r, &params)
+		if err != nil {
+			logging.Error(r.Context(), "decode", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if params.Query == "" {
+		http.Error(w, "query is required", http.StatusBadRequest)
+		return
+	}
+	db, err := database.Open(r.Context())
+	if err != nil {
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer db.Close()
+	// 権限表はREST APIのハンドラと同じもので、リゾルバごとにreadを確認する
+	matrix, err := rbac.Load(r.Context(), db)
+	if err != nil {
+		logging.Error(r.Context(), "select", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ctx := graph.NewContext(r.Context(), db, matrix)
+	result := graph.Schema.Exec(ctx, params.Query, params.OperationName, params.Variables)
+	for _, e := range result.Errors {
+		logging.Error(r.Context(), "graphql", e)
+	}
+	// dataはjson.RawMessageのため、JSON以外で返す場合は値に戻してからエンコードする
+	var v interface{} = result
+	if codec.ForResponse(r) != codec.JSON {
+		var data interface{}
+		if len(result.Data) > 0 {
+			err = json.Unmarshal(result.Data, &data)
+			if err != nil {
+				logging.Error(r.Context(), "decode", err)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+		v = &Result{Data: data, Errors: result.Errors}
+	}
+	err = response.Write(w, r, v)
+	if err != nil {
+		logging.Error(r.Context(), "encode", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
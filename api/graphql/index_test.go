@@ -0,0 +1,66 @@
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/dbtest"
+	"maguro-alternative/varcel-go/pkg/rbac"
+)
+
+type graphResult struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Path       []interface{}          `json:"path"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func setup(t *testing.T) {
+	t.Helper()
+	db := dbtest.Open(t)
+	dbtest.Exec(t, db, `INSERT INTO source (id, name, url, type) VALUES (1, 's', '', 'anime')`)
+	dbtest.Exec(t, db, `INSERT INTO entry (id, source_id, name, image, content) VALUES (1, 1, 'a', '', '')`)
+	dbtest.Exec(t, db, `INSERT INTO bwh (entry_id, bust, waist, hip) VALUES (1, 80, 56, 82)`)
+}
+
+func query(t *testing.T, role, q string) graphResult {
+	t.Helper()
+	var res graphResult
+	apitest.Decode(t, apitest.Do(t, handle, role, http.MethodPost, "/api/graphql", Params{Query: q}), http.StatusOK, &res)
+	return res
+}
+
+func TestAuthorize(t *testing.T) {
+	apitest.Setup(t)
+	setup(t)
+
+	// 既定の権限表ではviewerは全て読める
+	res := query(t, "", `{ entries { name bwh { bust } source { name } } }`)
+	if len(res.Errors) != 0 || string(res.Data["entries"]) != `[{"name":"a","bwh":{"bust":80},"source":{"name":"s"}}]` {
+		t.Fatalf("result = %+v", res)
+	}
+
+	// bwhを読めないロールには、bwhのフィールドだけ拒否の理由を返す
+	rbac.Use(rbac.Matrix{{Role: rbac.Viewer, Resource: "entry", Action: string(rbac.Read)}, {Role: rbac.Admin, Resource: "*", Action: "*"}})
+	res = query(t, "", `{ entries { name bwh { bust } } }`)
+	if len(res.Errors) != 1 || string(res.Data["entries"]) != `[{"name":"a","bwh":null}]` {
+		t.Fatalf("result = %+v", res)
+	}
+	ext := res.Errors[0].Extensions
+	if ext["code"] != "forbidden" || ext["resource"] != "bwh" || ext["role"] != rbac.Viewer || res.Errors[0].Message != `role "viewer" may not read bwh; allowed roles: admin` {
+		t.Fatalf("error = %+v", res.Errors[0])
+	}
+
+	res = query(t, "", `{ sources { name } }`)
+	if len(res.Errors) != 1 || res.Errors[0].Extensions["resource"] != "source" {
+		t.Fatalf("result = %+v", res)
+	}
+	res = query(t, rbac.Admin, `{ sources { name } entries { bwh { bust } } }`)
+	if len(res.Errors) != 0 {
+		t.Fatalf("result = %+v", res)
+	}
+}
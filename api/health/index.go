@@ -0,0 +1,143 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/buildinfo"
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/response"
+)
+
+const (
+	statusOK       = "ok"
+	statusDegraded = "degraded"
+)
+
+// DBのチェックにかける時間の上限。HEALTH_DB_TIMEOUTで変更できる
+const defaultTimeout = 2 * time.Second
+
+type Health struct {
+	Status string         `json:"status"`
+	Checks Checks         `json:"checks"`
+	Build  buildinfo.Info `json:"build"`
+	Pool   *PoolStats     `json:"pool,omitempty"`
+}
+
+type Checks struct {
+	Database   DatabaseCheck  `json:"database"`
+	Migrations MigrationCheck `json:"migrations"`
+}
+
+type DatabaseCheck struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type MigrationCheck struct {
+	Status   string `json:"status"`
+	Applied  int64  `json:"applied"`
+	Expected int64  `json:"expected"`
+	Error    string `json:"error,omitempty"`
+}
+
+type PoolStats struct {
+	MaxOpenConnections int     `json:"max_open_connections"`
+	OpenConnections    int     `json:"open_connections"`
+	InUse              int     `json:"in_use"`
+	Idle               int     `json:"idle"`
+	WaitCount          int64   `json:"wait_count"`
+	WaitDurationMs     float64 `json:"wait_duration_ms"`
+}
+
+func Handler(w http.ResponseWriter, r *http.Request) {
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	health := Health{
+		Status: statusOK,
+		Build:  buildinfo.Read(),
+	}
+	expected, err := database.ExpectedVersion()
+	if err != nil {
+		logging.Error(r.Context(), "migration", err)
+		health.Checks.Migrations.Error = err.Error()
+	}
+	health.Checks.Migrations.Expected = expected
+	ctx, cancel := context.WithTimeout(r.Context(), timeout())
+	defer cancel()
+
+	// DBへの接続確認
+	start := time.Now()
+	db, err := database.Open(ctx)
+	health.Checks.Database.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	if err != nil {
+		logging.Error(r.Context(), "sql_open", err)
+		health.Status = statusDegraded
+		health.Checks.Database.Status = statusDegraded
+		health.Checks.Database.Error = err.Error()
+		health.Checks.Migrations.Status = statusDegraded
+		if health.Checks.Migrations.Error == "" {
+			health.Checks.Migrations.Error = "database unavailable"
+		}
+		writeHealth(w, r, &health)
+		return
+	}
+	defer db.Close()
+	health.Checks.Database.Status = statusOK
+
+	// スキーマのバージョン確認
+	health.Checks.Migrations.Status = statusOK
+	applied, err := db.AppliedVersion(ctx)
+	if err != nil {
+		logging.Error(r.Context(), "migration", err)
+		health.Checks.Migrations.Error = err.Error()
+	}
+	health.Checks.Migrations.Applied = applied
+	if health.Checks.Migrations.Error != "" || applied != expected {
+		health.Status = statusDegraded
+		health.Checks.Migrations.Status = statusDegraded
+	}
+
+	stats := db.Stats()
+	health.Pool = &PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     float64(stats.WaitDuration.Microseconds()) / 1000,
+	}
+	writeHealth(w, r, &health)
+}
+
+// writeHealth は劣化している場合に503を返す
+func writeHealth(w http.ResponseWriter, r *http.Request, health *Health) {
+	w.Header().Set("Cache-Control", "no-store")
+	status := http.StatusOK
+	if health.Status != statusOK {
+		status = http.StatusServiceUnavailable
+	}
+	err := response.WriteStatus(w, r, status, health)
+	if err != nil {
+		logging.Error(r.Context(), "encode", err)
+	}
+}
+
+func timeout() time.Duration {
+	d, err := time.ParseDuration(os.Getenv("HEALTH_DB_TIMEOUT"))
+	if err != nil || d <= 0 {
+		return defaultTimeout
+	}
+	return d
+}
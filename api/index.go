@@ -1,10 +1,124 @@
 package handler
 
 import (
-	"fmt"
+	"context"
 	"net/http"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/catalog"
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	spec "maguro-alternative/varcel-go/pkg/openapi"
+	"maguro-alternative/varcel-go/pkg/response"
 )
 
+// ドキュメントとして参照するハンドラのソース
+const sourceURL = "https://github.com/maguro-alternative/vercel-go/blob/main/"
+
+// 件数の取得にかける時間の上限
+const countTimeout = time.Second
+
+type Index struct {
+	Name      string            `json:"name"`
+	Version   string            `json:"version"`
+	Links     map[string]string `json:"links"`
+	Resources []Resource        `json:"resources"`
+}
+
+type Resource struct {
+	catalog.Resource
+	Docs string `json:"docs"`
+	// Rows は?counts=trueの場合のみ返す
+	Rows *int64 `json:"rows,omitempty"`
+}
+
+type tableCount struct {
+	Table string `db:"relname"`
+	Rows  int64  `db:"n_live_tup"`
+}
+
 func Handler(w http.ResponseWriter, r *http.Request) {
-	fmt.Fprintf(w, "Vercel Serverless Functions Go Example")
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	index := Index{
+		Name:    "vercel-go",
+		Version: "v1",
+		Links: map[string]string{
+			"self":    "/api",
+			"health":  "/api/health",
+			"graphql": "/api/graphql",
+			"openapi": spec.Path,
+			"rpc":     "/api/rpc",
+		},
+	}
+	var counts map[string]int64
+	if r.URL.Query().Get("counts") == "true" {
+		counts = liveRows(r.Context())
+	}
+	for _, res := range catalog.Resources {
+		item := Resource{Resource: res, Docs: sourceURL + res.Source}
+		if n, ok := counts[res.Table]; ok {
+			item.Rows = &n
+		}
+		index.Resources = append(index.Resources, item)
+	}
+	err := response.Write(w, r, &index)
+	if err != nil {
+		logging.Error(r.Context(), "encode", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// liveRows は統計情報からテーブルごとの行数を取得する
+// 取得できない場合は件数なしでカタログを返すため、nilを返す
+func liveRows(ctx context.Context) map[string]int64 {
+	ctx, cancel := context.WithTimeout(ctx, countTimeout)
+	defer cancel()
+	db, err := database.Open(ctx)
+	if err != nil {
+		logging.Error(ctx, "sql_open", err)
+		return nil
+	}
+	defer db.Close()
+	var rows []tableCount
+	query := `
+		SELECT
+			relname,
+			n_live_tup
+		FROM
+			pg_stat_user_tables
+	`
+	switch db.Dialect.Name {
+	case database.MySQL.Name:
+		// InnoDBの行数は推定値
+		query = `
+			SELECT
+				table_name AS relname,
+				COALESCE(table_rows, 0) AS n_live_tup
+			FROM
+				information_schema.tables
+			WHERE
+				table_schema = DATABASE()
+		`
+	case database.SQLite.Name:
+		// SQLiteには行数の統計がない
+		return nil
+	}
+	err = db.SelectContext(ctx, &rows, query)
+	if err != nil {
+		logging.Error(ctx, "select", err)
+		return nil
+	}
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Table] = row.Rows
+	}
+	return counts
 }
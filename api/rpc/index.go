@@ -0,0 +1,16 @@
+package rpc
+
+import (
+	"net/http"
+
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/rpc"
+)
+
+var handler = middleware.Logging(middleware.Tracing(middleware.CORS(middleware.ServerTiming(rpc.NewHandler()))))
+
+// Handler はConnectプロトコル(HTTP/1.1のunary)でRPCを受け付ける
+// vercel.jsonで /api/rpc/* をこのハンドラに転送している
+func Handler(w http.ResponseWriter, r *http.Request) {
+	handler.ServeHTTP(w, r)
+}
@@ -1,34 +1,23 @@
 package bwh
 
 import (
-	"encoding/json"
-	"log"
-	"io"
-	"fmt"
 	"net/http"
 
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
 	validation "github.com/go-ozzo/ozzo-validation"
-)
 
-type BWH struct {
-	EntryID int64  `db:"entry_id" json:"entry_id"`
-	Bust    int64  `db:"bust" json:"bust"`
-	Waist   int64  `db:"waist" json:"waist"`
-	Hip     int64  `db:"hip" json:"hip"`
-	Height  *int64 `db:"height" json:"height"`
-	Weight  *int64 `db:"weight" json:"weight"`
-}
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/jsonapi"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
+)
 
-func (b *BWH) Validate() error {
-	return validation.ValidateStruct(b,
-		validation.Field(&b.EntryID, validation.Required),
-		validation.Field(&b.Bust, validation.Required),
-		validation.Field(&b.Waist, validation.Required),
-		validation.Field(&b.Hip, validation.Required),
-	)
-}
+// BWH はbwhテーブルの行。RPCと共通の型を使う
+type BWH = model.BWH
 
 type BWHsJson struct {
 	BWHs []BWH `json:"bwhs"`
@@ -51,271 +40,174 @@ func (i *IDs) Validate() error {
 }
 
 func Handler(w http.ResponseWriter, r *http.Request) {
-	db, err := sqlx.Open("postgres", "")
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
 	if err != nil {
-		log.Printf("sql.Open error %s", err)
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 	defer db.Close()
+	// RPCと同じstoreで読み書きする
+	serve(w, r, &store.BWHs{DB: db})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, bwhs store.BWHRepository) {
 	switch r.Method {
 	case http.MethodGet:
-		var bwhsJson BWHsJson
-		query := `
-			SELECT
-				entry_id,
-				bust,
-				waist,
-				hip,
-				height,
-				weight
-			FROM
-				bwh
-			WHERE
-				entry_id IN (?)
-		`
-		// クエリパラメータからentry_idを取得
-		queryIDs, ok := r.URL.Query()["entry_id"]
-		// クエリパラメータにentry_idがない場合は全件取得
-		if !ok {
-			query := `
-				SELECT
-					entry_id,
-					bust,
-					waist,
-					hip,
-					height,
-					weight
-				FROM
-					bwh
-			`
-			err := db.SelectContext(r.Context(), &bwhsJson.BWHs, query)
-			if err != nil {
-				log.Println(fmt.Sprintf("select error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// json返却
-			err = json.NewEncoder(w).Encode(&bwhsJson)
-			if err != nil {
-				log.Println(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			return
-		// クエリパラメータのentry_idが1つの場合はそのentry_idのみ取得
-		} else if len(queryIDs) == 1 {
-			query := `
-				SELECT
-					entry_id,
-					bust,
-					waist,
-					hip,
-					height,
-					weight
-				FROM
-					bwh
-				WHERE
-					entry_id = $1
-			`
-			err := db.SelectContext(r.Context(), &bwhsJson.BWHs, query, queryIDs[0])
-			if err != nil {
-				log.Println(fmt.Sprintf("select error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// json返却
-			err = json.NewEncoder(w).Encode(&bwhsJson)
-			if err != nil {
-				log.Println(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+		// 権限の確認
+		if !rbac.Authorize(w, r, "bwh", rbac.Read) {
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, queryIDs)
+		var bwhsJson BWHsJson
+		// クエリパラメータからentry_idを取得
+		queryIDs := r.URL.Query()["entry_id"]
+		ids, err := params.Int64s(queryIDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("in error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(queryIDs), query)
-		err = db.SelectContext(r.Context(), &bwhsJson.BWHs, query, args...)
+		// entry_idが指定されていない場合は全件取得
+		var total int
+		bwhsJson.BWHs, total, err = jsonapi.List(r, bwhs, ids)
 		if err != nil {
-			log.Println(fmt.Sprintf("select error: %v", err))
+			logging.Error(r.Context(), "select", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// json返却
-		err = json.NewEncoder(w).Encode(&bwhsJson)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, jsonapi.WithTotal(&bwhsJson, total))
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPost:
-		var bwhsJson BWHsJson
-		query := `
-			INSERT INTO bwh (
-				entry_id,
-				bust,
-				waist,
-				hip,
-				height,
-				weight
-			) VALUES (
-				:entry_id,
-				:bust,
-				:waist,
-				:hip,
-				:height,
-				:weight
-			)
-		`
-		// json読み込み
-		jsonBytes, err := io.ReadAll(r.Body)
-		if err != nil {
-			log.Println(fmt.Sprintf("read error: %v", err))
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		// 権限の確認
+		if !rbac.Authorize(w, r, "bwh", rbac.Create) {
+			return
 		}
-		err = json.Unmarshal(jsonBytes, &bwhsJson)
+		var bwhsJson BWHsJson
+		// リクエストボディを読み込む
+		err := response.Decode(r, &bwhsJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("json unmarshal error: %v", err))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = bwhsJson.Validate()
 		if err != nil {
-			log.Println(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-		}
-		if len(bwhsJson.BWHs) == 0 {
-			log.Println("json unexpected error: empty body")
-			http.Error(w, "json unexpected error: empty body", http.StatusBadRequest)
+			return
 		}
 		for _, bwh := range bwhsJson.BWHs {
-			// DBへの登録
-			_, err = db.NamedExecContext(r.Context(), query, bwh)
+			err = bwh.Validate()
 			if err != nil {
-				log.Println(fmt.Sprintf("insert error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				logging.Error(r.Context(), "validation", err)
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
 			}
 		}
-		// json返却
-		err = json.NewEncoder(w).Encode(&bwhsJson)
+		// 登録
+		bwhsJson.BWHs, err = bwhs.Create(r.Context(), bwhsJson.BWHs)
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "insert", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &bwhsJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPut:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "bwh", rbac.Update) {
+			return
+		}
 		var bwhsJson BWHsJson
-		query := `
-			UPDATE
-				bwh
-			SET
-				bust = :bust,
-				waist = :waist,
-				hip = :hip,
-				height = :height,
-				weight = :weight
-			WHERE
-				entry_id = :entry_id
-		`
-		// json読み込み
-		jsonBytes, err := io.ReadAll(r.Body)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &bwhsJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("read error: %v", err))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		err = json.Unmarshal(jsonBytes, &bwhsJson)
-		if err != nil {
-			log.Println(fmt.Sprintf("json unmarshal error: %v", err))
-			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = bwhsJson.Validate()
 		if err != nil {
-			log.Println(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
 		}
 		for _, bwh := range bwhsJson.BWHs {
-			// jsonバリデーション
 			err = bwh.Validate()
 			if err != nil {
-				log.Println(fmt.Sprintf("validation error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-			}
-			_, err = db.NamedExecContext(r.Context(), query, bwh)
-			if err != nil {
-				log.Println(fmt.Sprintf("update error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
 			}
 		}
-		// json返却
-		err = json.NewEncoder(w).Encode(&bwhsJson)
+		// 更新
+		err = bwhs.Update(r.Context(), bwhsJson.BWHs)
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "update", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-	case http.MethodDelete:
-		var delIDs IDs
-		query := `
-			DELETE FROM
-				bwh
-			WHERE
-				entry_id IN (?)
-		`
-		// json読み込み
-		jsonBytes, err := io.ReadAll(r.Body)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &bwhsJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("read error: %v", err))
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		err = json.Unmarshal(jsonBytes, &delIDs)
+	case http.MethodDelete:
+		// 権限の確認。ボディを読む前に削除の権限を確認する
+		if !rbac.Authorize(w, r, "bwh", rbac.Delete) {
+			return
+		}
+		var delIDs IDs
+		// リクエストボディを読み込む
+		err := response.Decode(r, &delIDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("json unmarshal error: %v", err))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = delIDs.Validate()
 		if err != nil {
-			log.Println(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-		}
-		// idが0の場合は何もしない
-		if len(delIDs.IDs) == 0 {
-			return
-		// idが1の場合はそのidのみ削除
-		} else if len(delIDs.IDs) == 1 {
-			query := `
-				DELETE FROM
-					bwh
-				WHERE
-					entry_id = $1
-			`
-			_, err = db.ExecContext(r.Context(), query, delIDs.IDs[0])
-			if err != nil {
-				log.Println(fmt.Sprintf("delete error: %v", err))
-				http.Error(w, fmt.Sprintf("delete error: %v", err), http.StatusInternalServerError)
-			}
-			err = json.NewEncoder(w).Encode(&delIDs)
-			if err != nil {
-				log.Println(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, fmt.Sprintf("json encode error: %v", err), http.StatusInternalServerError)
-			}
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, delIDs.IDs)
-		if err != nil {
-			log.Println(fmt.Sprintf("in error: %v", err))
-			http.Error(w, fmt.Sprintf("in error: %v", err), http.StatusInternalServerError)
+		// 2件以上の削除は一括削除の権限も確認する
+		if len(delIDs.IDs) > 1 && !rbac.Authorize(w, r, "bwh", rbac.BulkDelete) {
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(delIDs.IDs), query)
-		_, err = db.ExecContext(r.Context(), query, args...)
+		// 削除
+		err = bwhs.Delete(r.Context(), delIDs.IDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("delete error: %v", err))
-			http.Error(w, fmt.Sprintf("delete error: %v", err), http.StatusInternalServerError)
+			logging.Error(r.Context(), "delete", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// json返却
-		err = json.NewEncoder(w).Encode(&delIDs)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &delIDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
-			http.Error(w, fmt.Sprintf("json encode error: %v", err), http.StatusInternalServerError)
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
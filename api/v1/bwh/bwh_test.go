@@ -0,0 +1,98 @@
+package bwh
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+func newRepo() *store.Memory[model.BWH, store.BWHSearch] {
+	bwhs := store.NewMemoryBWHs()
+	bwhs.Seed(
+		BWH{EntryID: 1, Bust: 80, Waist: 58, Hip: 82},
+		BWH{EntryID: 2, Bust: 85, Waist: 57, Hip: 84},
+		BWH{EntryID: 3, Bust: 90, Waist: 60, Hip: 88},
+	)
+	return bwhs
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	bwhs := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, bwhs) }
+
+	var all BWHsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/bwh", nil), http.StatusOK, &all)
+	if len(all.BWHs) != 3 {
+		t.Fatalf("bwhs = %+v", all.BWHs)
+	}
+
+	var some BWHsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/bwh?entry_id=2", nil), http.StatusOK, &some)
+	if len(some.BWHs) != 1 || some.BWHs[0].Bust != 85 {
+		t.Fatalf("bwhs = %+v", some.BWHs)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/bwh?entry_id=x", nil), http.StatusBadRequest, nil)
+}
+
+func TestPost(t *testing.T) {
+	apitest.Setup(t)
+	bwhs := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, bwhs) }
+	height := int64(160)
+	body := BWHsJson{BWHs: []BWH{{EntryID: 4, Bust: 88, Waist: 59, Hip: 86, Height: &height}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPost, "/api/v1/bwh", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/bwh", body), http.StatusOK, nil)
+	got, err := bwhs.Get(context.Background(), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Height == nil || *got.Height != 160 {
+		t.Fatalf("height = %v, want 160", got.Height)
+	}
+
+	// entry_idが重複する行は登録できない
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/bwh", body), http.StatusInternalServerError, nil)
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/bwh", BWHsJson{}), http.StatusUnprocessableEntity, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/bwh", BWHsJson{BWHs: []BWH{{EntryID: 5}}}), http.StatusUnprocessableEntity, nil)
+}
+
+func TestPut(t *testing.T) {
+	apitest.Setup(t)
+	bwhs := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, bwhs) }
+	body := BWHsJson{BWHs: []BWH{{EntryID: 2, Bust: 86, Waist: 56, Hip: 85}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPut, "/api/v1/bwh", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPut, "/api/v1/bwh", body), http.StatusOK, nil)
+	got, err := bwhs.Get(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Bust != 86 || got.Waist != 56 || got.Hip != 85 {
+		t.Fatalf("bwh = %+v", got)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	apitest.Setup(t)
+	bwhs := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, bwhs) }
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/bwh", IDs{}), http.StatusUnprocessableEntity, nil)
+	// editorは一括削除できない
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/bwh", IDs{IDs: []int64{1, 2}}), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/bwh", IDs{IDs: []int64{1}}), http.StatusOK, nil)
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodDelete, "/api/v1/bwh", IDs{IDs: []int64{2, 3}}), http.StatusOK, nil)
+	rows, _ := bwhs.List(context.Background(), nil)
+	if len(rows) != 0 {
+		t.Fatalf("rows = %+v, want none", rows)
+	}
+}
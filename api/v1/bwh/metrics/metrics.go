@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"maguro-alternative/varcel-go/pkg/bodystats"
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// 返す件数の上限
+const maxLimit = 1000
+
+type Metrics = bodystats.Metrics
+
+type MetricsJson struct {
+	Metrics []Metrics `json:"metrics"`
+}
+
+func Handler(w http.ResponseWriter, r *http.Request) {
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
+	if err != nil {
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer db.Close()
+	serve(w, r, &store.BWHs{DB: db})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, bwhs store.BWHRepository) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	// 権限の確認
+	if !rbac.Authorize(w, r, "bwh", rbac.Read) {
+		return
+	}
+	// クエリパラメータから対象のentryと並べ方を取得
+	ids, err := params.Int64s(r.URL.Query()["entry_id"])
+	if err != nil {
+		logging.Error(r.Context(), "validation", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sortBy := ""
+	if v := r.URL.Query()["sort"]; len(v) > 0 {
+		sortBy = v[0]
+		if !slices.Contains(bodystats.Names(), sortBy) {
+			err = fmt.Errorf("invalid sort %q: want one of %s", sortBy, strings.Join(bodystats.Names(), ", "))
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	desc := true
+	if v := r.URL.Query()["order"]; len(v) > 0 {
+		if v[0] != "asc" && v[0] != "desc" {
+			err = fmt.Errorf("invalid order %q: want asc or desc", v[0])
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		desc = v[0] == "desc"
+	}
+	limit := 0
+	if v := r.URL.Query()["limit"]; len(v) > 0 {
+		limit, err = strconv.Atoi(v[0])
+		if err != nil || limit < 1 || limit > maxLimit {
+			err = fmt.Errorf("invalid limit %q: want 1-%d", v[0], maxLimit)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// パーセンタイル順位は指定したentryによらず全件と比べる
+	all, err := bwhs.List(r.Context(), nil)
+	if err != nil {
+		logging.Error(r.Context(), "select", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rows := all
+	if len(ids) > 0 {
+		rows = []model.BWH{}
+		for _, b := range all {
+			for _, id := range ids {
+				if b.EntryID == id {
+					rows = append(rows, b)
+					break
+				}
+			}
+		}
+	}
+	if sortBy != "" {
+		// allを並べ替えないように写してから並べる
+		rows = append([]model.BWH(nil), rows...)
+		err = bodystats.Sort(rows, sortBy, desc)
+		if err != nil {
+			logging.Error(r.Context(), "sort", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if limit > 0 && len(rows) > limit {
+		rows = rows[:limit]
+	}
+	ranker := bodystats.NewRanker(all)
+	metricsJson := MetricsJson{Metrics: make([]Metrics, 0, len(rows))}
+	for _, b := range rows {
+		metricsJson.Metrics = append(metricsJson.Metrics, ranker.Metrics(b))
+	}
+	// レスポンスボディに書き込む
+	err = response.Write(w, r, &metricsJson)
+	if err != nil {
+		logging.Error(r.Context(), "encode", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
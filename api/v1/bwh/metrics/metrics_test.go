@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"net/http"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+func ptr(v int64) *int64 { return &v }
+
+// newRepo は4件の計測値を作る。entry 4は身長と体重がない
+func newRepo() *store.Memory[model.BWH, store.BWHSearch] {
+	bwhs := store.NewMemoryBWHs()
+	bwhs.Seed(
+		model.BWH{EntryID: 1, Bust: 80, Waist: 56, Hip: 80, Height: ptr(150), Weight: ptr(45)},
+		model.BWH{EntryID: 2, Bust: 90, Waist: 60, Hip: 80, Height: ptr(160), Weight: ptr(50)},
+		model.BWH{EntryID: 3, Bust: 90, Waist: 64, Hip: 80, Height: ptr(170), Weight: ptr(60)},
+		model.BWH{EntryID: 4, Bust: 100, Waist: 68, Hip: 80},
+	)
+	return bwhs
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	bwhs := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, bwhs) }
+
+	var all MetricsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/bwh/metrics", nil), http.StatusOK, &all)
+	if len(all.Metrics) != 4 {
+		t.Fatalf("metrics = %+v", all.Metrics)
+	}
+	m := all.Metrics[0]
+	if m.EntryID != 1 || *m.WaistHipRatio != 0.7 || *m.BMI != 20 {
+		t.Fatalf("metrics = %+v", m)
+	}
+	if all.Metrics[3].BMI != nil {
+		t.Fatalf("bmi = %v, want null", *all.Metrics[3].BMI)
+	}
+
+	// 順位は全件と比べ、同じ値は半分ずつ数える
+	var some MetricsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/bwh/metrics?entry_id=2", nil), http.StatusOK, &some)
+	ranks := some.Metrics[0].PercentileRanks
+	if len(some.Metrics) != 1 || ranks["bust"] != 50 || ranks["hip"] != 50 || ranks["height"] != 50 || ranks["bmi"] != 16.7 {
+		t.Fatalf("ranks = %v", ranks)
+	}
+	if _, ok := all.Metrics[3].PercentileRanks["weight"]; ok {
+		t.Fatalf("ranks = %v", all.Metrics[3].PercentileRanks)
+	}
+
+	// BMIは2, 1, 3の順に大きく、値のないentryは順序によらず最後
+	var ranking MetricsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/bwh/metrics?sort=bmi&order=asc&limit=3", nil), http.StatusOK, &ranking)
+	if len(ranking.Metrics) != 3 || ranking.Metrics[0].EntryID != 2 || ranking.Metrics[2].EntryID != 3 {
+		t.Fatalf("ranking = %+v", ranking.Metrics)
+	}
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/bwh/metrics?sort=bmi", nil), http.StatusOK, &ranking)
+	if ranking.Metrics[0].EntryID != 3 || ranking.Metrics[3].EntryID != 4 {
+		t.Fatalf("ranking = %+v", ranking.Metrics)
+	}
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/bwh/metrics?sort=waist_hip_ratio&entry_id=1&entry_id=4", nil), http.StatusOK, &ranking)
+	if len(ranking.Metrics) != 2 || ranking.Metrics[0].EntryID != 4 {
+		t.Fatalf("ranking = %+v", ranking.Metrics)
+	}
+
+	for _, target := range []string{
+		"/api/v1/bwh/metrics?entry_id=x",
+		"/api/v1/bwh/metrics?sort=cup",
+		"/api/v1/bwh/metrics?order=up",
+		"/api/v1/bwh/metrics?limit=0",
+		"/api/v1/bwh/metrics?limit=1001",
+	} {
+		apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, target, nil), http.StatusBadRequest, nil)
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	apitest.Setup(t)
+	bwhs := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, bwhs) }
+
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodPost, "/api/v1/bwh/metrics", nil), http.StatusMethodNotAllowed, nil)
+}
@@ -0,0 +1,208 @@
+package stats
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"maguro-alternative/varcel-go/pkg/bodystats"
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+type (
+	Stats   = bodystats.Stats
+	Summary = bodystats.Summary
+	Bin     = bodystats.Bin
+)
+
+// Group は作品かタグ1つ分の統計
+type Group struct {
+	// ID はsource_idかtag_id
+	ID    int64 `json:"id"`
+	Stats Stats `json:"stats"`
+}
+
+type StatsJson struct {
+	// GroupBy はsourceかtag。指定しない場合はGroupsを返さない
+	GroupBy string  `json:"group_by,omitempty"`
+	Overall Stats   `json:"overall"`
+	Groups  []Group `json:"groups,omitempty"`
+}
+
+// Sources は統計に使うリポジトリ。テストではメモリの実装を渡す
+type Sources struct {
+	BWHs      store.BWHRepository
+	Entries   store.EntryRepository
+	EntryTags store.EntryTagRepository
+}
+
+func Handler(w http.ResponseWriter, r *http.Request) {
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
+	if err != nil {
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer db.Close()
+	serve(w, r, Sources{BWHs: &store.BWHs{DB: db}, Entries: &store.Entries{DB: db}, EntryTags: &store.EntryTags{DB: db}})
+}
+
+// serve はリポジトリを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, sources Sources) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	// 権限の確認
+	if !rbac.Authorize(w, r, "bwh", rbac.Read) {
+		return
+	}
+	// クエリパラメータから対象のentryの条件と統計の取り方を取得
+	sourceIDs, err := params.Int64s(r.URL.Query()["source_id"])
+	if err != nil {
+		logging.Error(r.Context(), "validation", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tagIDs, err := params.Int64s(r.URL.Query()["tag_id"])
+	if err != nil {
+		logging.Error(r.Context(), "validation", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var groupBy string
+	if v := r.URL.Query()["group_by"]; len(v) > 0 {
+		groupBy = v[0]
+		if groupBy != "source" && groupBy != "tag" {
+			err = fmt.Errorf("invalid group_by %q: want source or tag", groupBy)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	opts := bodystats.Options{Bins: bodystats.DefaultBins, Percentiles: bodystats.DefaultPercentiles}
+	if v := r.URL.Query()["bins"]; len(v) > 0 {
+		opts.Bins, err = strconv.Atoi(v[0])
+		if err != nil || opts.Bins < 1 || opts.Bins > bodystats.MaxBins {
+			err = fmt.Errorf("invalid bins %q: want 1-%d", v[0], bodystats.MaxBins)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if v := r.URL.Query()["percentiles"]; len(v) > 0 {
+		opts.Percentiles, err = bodystats.ParsePercentiles(strings.Join(v, ","))
+		if err != nil {
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// 全件を読んで集計する。entryとentry_tagは必要な場合だけ読む
+	bwhs, err := sources.BWHs.List(r.Context(), nil)
+	if err != nil {
+		logging.Error(r.Context(), "select", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var sourceOf map[int64]int64
+	if len(sourceIDs) > 0 || groupBy == "source" {
+		entries, err := sources.Entries.List(r.Context(), nil)
+		if err != nil {
+			logging.Error(r.Context(), "select", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sourceOf = make(map[int64]int64, len(entries))
+		for _, e := range entries {
+			sourceOf[e.ID] = e.SourceID
+		}
+	}
+	var tagsOf map[int64][]int64
+	if len(tagIDs) > 0 || groupBy == "tag" {
+		entryTags, err := sources.EntryTags.List(r.Context(), nil)
+		if err != nil {
+			logging.Error(r.Context(), "select", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		tagsOf = make(map[int64][]int64)
+		for _, et := range entryTags {
+			tagsOf[et.EntryID] = append(tagsOf[et.EntryID], et.TagID)
+		}
+	}
+
+	// source_idとtag_idはそれぞれいずれかに一致するentryに絞り込む
+	rows := make([]model.BWH, 0, len(bwhs))
+	for _, b := range bwhs {
+		if len(sourceIDs) > 0 && !contains(sourceIDs, sourceOf[b.EntryID]) {
+			continue
+		}
+		if len(tagIDs) > 0 && !containsAny(tagIDs, tagsOf[b.EntryID]) {
+			continue
+		}
+		rows = append(rows, b)
+	}
+	calc := bodystats.NewCalculator(rows, opts)
+	statsJson := StatsJson{GroupBy: groupBy, Overall: calc.Stats(rows)}
+
+	// タグでまとめる場合、entryは付いているタグそれぞれのグループに入る
+	// tag_idを指定した場合は指定したタグのグループだけを返す
+	groups := map[int64][]model.BWH{}
+	for _, b := range rows {
+		switch groupBy {
+		case "source":
+			groups[sourceOf[b.EntryID]] = append(groups[sourceOf[b.EntryID]], b)
+		case "tag":
+			for _, tagID := range tagsOf[b.EntryID] {
+				if len(tagIDs) == 0 || contains(tagIDs, tagID) {
+					groups[tagID] = append(groups[tagID], b)
+				}
+			}
+		}
+	}
+	for id, group := range groups {
+		statsJson.Groups = append(statsJson.Groups, Group{ID: id, Stats: calc.Stats(group)})
+	}
+	sort.Slice(statsJson.Groups, func(i, j int) bool { return statsJson.Groups[i].ID < statsJson.Groups[j].ID })
+
+	// レスポンスボディに書き込む
+	err = response.Write(w, r, &statsJson)
+	if err != nil {
+		logging.Error(r.Context(), "encode", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func contains(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(ids []int64, values []int64) bool {
+	for _, v := range values {
+		if contains(ids, v) {
+			return true
+		}
+	}
+	return false
+}
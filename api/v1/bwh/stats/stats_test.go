@@ -0,0 +1,111 @@
+package stats
+
+import (
+	"net/http"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+func ptr(v int64) *int64 { return &v }
+
+// newSources は2つの作品の4件のentryを作る。entry 4は身長と体重がない
+func newSources() Sources {
+	bwhs := store.NewMemoryBWHs()
+	bwhs.Seed(
+		model.BWH{EntryID: 1, Bust: 80, Waist: 56, Hip: 82, Height: ptr(150), Weight: ptr(40)},
+		model.BWH{EntryID: 2, Bust: 90, Waist: 58, Hip: 88, Height: ptr(160), Weight: ptr(50)},
+		model.BWH{EntryID: 3, Bust: 100, Waist: 60, Hip: 90, Height: ptr(170), Weight: ptr(60)},
+		model.BWH{EntryID: 4, Bust: 110, Waist: 62, Hip: 96},
+	)
+	entries := store.NewMemoryEntries(nil)
+	entries.Seed(
+		model.Entry{ID: 1, SourceID: 1, Name: "a"},
+		model.Entry{ID: 2, SourceID: 1, Name: "b"},
+		model.Entry{ID: 3, SourceID: 2, Name: "c"},
+		model.Entry{ID: 4, SourceID: 2, Name: "d"},
+	)
+	entryTags := store.NewMemoryEntryTags()
+	entryTags.Seed(
+		model.EntryTag{ID: 1, EntryID: 1, TagID: 10},
+		model.EntryTag{ID: 2, EntryID: 2, TagID: 10},
+		model.EntryTag{ID: 3, EntryID: 2, TagID: 11},
+		model.EntryTag{ID: 4, EntryID: 4, TagID: 11},
+	)
+	return Sources{BWHs: bwhs, Entries: entries, EntryTags: entryTags}
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	sources := newSources()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, sources) }
+
+	var all StatsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/bwh/stats?bins=3&percentiles=25,90", nil), http.StatusOK, &all)
+	bust := all.Overall.Bust
+	if all.Overall.Count != 4 || bust.Mean != 95 || bust.Median != 95 || bust.Min != 80 || bust.Max != 110 {
+		t.Fatalf("bust = %+v", bust)
+	}
+	if bust.Percentiles["p25"] != 87.5 || bust.Percentiles["p90"] != 107 || len(bust.Percentiles) != 2 {
+		t.Fatalf("percentiles = %v", bust.Percentiles)
+	}
+	// 80〜110を幅11の3区間に分ける
+	want := []Bin{{From: 80, To: 91, Count: 2}, {From: 91, To: 102, Count: 1}, {From: 102, To: 113, Count: 1}}
+	if len(bust.Histogram) != len(want) {
+		t.Fatalf("histogram = %+v", bust.Histogram)
+	}
+	for i := range want {
+		if bust.Histogram[i] != want[i] {
+			t.Fatalf("histogram = %+v", bust.Histogram)
+		}
+	}
+	// 身長と体重は値のある行だけを数える
+	if all.Overall.Height.Count != 3 || all.Overall.Height.Mean != 160 || all.Groups != nil {
+		t.Fatalf("overall = %+v", all)
+	}
+
+	var bySource StatsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/bwh/stats?group_by=source", nil), http.StatusOK, &bySource)
+	if len(bySource.Groups) != 2 || bySource.Groups[0].ID != 1 || bySource.Groups[0].Stats.Waist.Mean != 57 {
+		t.Fatalf("groups = %+v", bySource.Groups)
+	}
+
+	// entry 2は両方のタグのグループに入り、タグのないentry 3はどのグループにも入らない
+	var byTag StatsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/bwh/stats?group_by=tag", nil), http.StatusOK, &byTag)
+	if len(byTag.Groups) != 2 || byTag.Groups[0].Stats.Count != 2 || byTag.Groups[1].Stats.Count != 2 {
+		t.Fatalf("groups = %+v", byTag.Groups)
+	}
+	// グループの値は全体と同じ区間で数える
+	if len(byTag.Groups[1].Stats.Hip.Histogram) != len(byTag.Overall.Hip.Histogram) {
+		t.Fatalf("histogram = %+v", byTag.Groups[1].Stats.Hip.Histogram)
+	}
+
+	var filtered StatsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/bwh/stats?tag_id=11&source_id=2&group_by=tag", nil), http.StatusOK, &filtered)
+	if filtered.Overall.Count != 1 || filtered.Overall.Height != nil || len(filtered.Groups) != 1 || filtered.Groups[0].ID != 11 {
+		t.Fatalf("filtered = %+v", filtered)
+	}
+
+	for _, target := range []string{
+		"/api/v1/bwh/stats?source_id=x",
+		"/api/v1/bwh/stats?tag_id=x",
+		"/api/v1/bwh/stats?group_by=entry",
+		"/api/v1/bwh/stats?bins=0",
+		"/api/v1/bwh/stats?bins=51",
+		"/api/v1/bwh/stats?percentiles=101",
+		"/api/v1/bwh/stats?percentiles=a",
+	} {
+		apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, target, nil), http.StatusBadRequest, nil)
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	apitest.Setup(t)
+	sources := newSources()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, sources) }
+
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodPost, "/api/v1/bwh/stats", nil), http.StatusMethodNotAllowed, nil)
+}
@@ -1,36 +1,23 @@
 package entry
 
 import (
-	"encoding/json"
-	"log"
-	"io"
-	"fmt"
 	"net/http"
-	"time"
 
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
 	validation "github.com/go-ozzo/ozzo-validation"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/jsonapi"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
 )
 
-type Entry struct {
-	ID        int64     `db:"id" json:"id"`
-	SourceID  int64     `db:"source_id" json:"source_id"`
-	Name      string    `db:"name" json:"name"`
-	Image     string    `db:"image" json:"image"`
-	Content   string    `db:"content" json:"content"`
-	CreatedAt time.Time `db:"created_at" json:"created_at"`
-}
-
-func (e *Entry) Validate() error {
-	return validation.ValidateStruct(e,
-		validation.Field(&e.SourceID, validation.Required),
-		validation.Field(&e.Name, validation.Required),
-		validation.Field(&e.Image, validation.Required),
-		validation.Field(&e.Content, validation.Required),
-		validation.Field(&e.CreatedAt, validation.Required),
-	)
-}
+// Entry はentryテーブルの行。RPCと共通の型を使う
+type Entry = model.Entry
 
 type EntriesJson struct {
 	Entries []Entry `json:"entries"`
@@ -60,249 +47,174 @@ func (i *IDs) Validate() error {
 }
 
 func Handler(w http.ResponseWriter, r *http.Request) {
-	db, err := sqlx.Open("postgres", "")
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
 	if err != nil {
-		log.Printf("sql.Open error %s", err)
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 	defer db.Close()
+	// RPCと同じstoreで読み書きする
+	serve(w, r, &store.Entries{DB: db})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, entries store.EntryRepository) {
 	switch r.Method {
 	case http.MethodGet:
-		var entriesJson EntriesJson
-		query := `
-			SELECT
-				source_id,
-				name,
-				image,
-				content,
-				created_at
-			FROM
-				entry
-			WHERE
-				id IN (?)
-		`
-		// クエリパラメータからidを取得
-		queryIDs, ok := r.URL.Query()["id"]
-		// idが指定されていない場合は全件取得
-		if !ok {
-			query = `
-				SELECT
-					source_id,
-					name,
-					image,
-					content,
-					created_at
-				FROM
-					entry
-			`
-			// 全件取得
-			err := db.SelectContext(r.Context(), &entriesJson.Entries, query)
-			if err != nil {
-				log.Println(fmt.Sprintf("select error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// レスポンスボディに書き込む
-			err = json.NewEncoder(w).Encode(&entriesJson)
-			if err != nil {
-				log.Println(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			return
-		} else if len(queryIDs) == 1 {
-			query = `
-				SELECT
-					source_id,
-					name,
-					image,
-					content,
-					created_at
-				FROM
-					entry
-				WHERE
-					id = $1
-			`
-			// 1件取得
-			err := db.SelectContext(r.Context(), &entriesJson.Entries, query, queryIDs[0])
-			if err != nil {
-				log.Println(fmt.Sprintf("select error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// レスポンスボディに書き込む
-			err = json.NewEncoder(w).Encode(&entriesJson)
-			if err != nil {
-				log.Println(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+		// 権限の確認
+		if !rbac.Authorize(w, r, "entry", rbac.Read) {
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, queryIDs)
+		var entriesJson EntriesJson
+		// クエリパラメータからidを取得
+		queryIDs := r.URL.Query()["id"]
+		ids, err := params.Int64s(queryIDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("db.In error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(queryIDs), query)
-		// 複数件取得
-		err = db.SelectContext(r.Context(), &entriesJson.Entries, query, args...)
+		// idが指定されていない場合は全件取得
+		var total int
+		entriesJson.Entries, total, err = jsonapi.List(r, entries, ids)
 		if err != nil {
-			log.Println(fmt.Sprintf("select error: %v", err))
+			logging.Error(r.Context(), "select", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 		// レスポンスボディに書き込む
-		err = json.NewEncoder(w).Encode(&entriesJson)
+		err = response.Write(w, r, jsonapi.WithTotal(&entriesJson, total))
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPost:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "entry", rbac.Create) {
+			return
+		}
 		var entriesJson EntriesJson
-		query := `
-			INSERT INTO entry (
-				source_id,
-				name,
-				image,
-				content,
-				created_at
-			) VALUES (
-				:source_id,
-				:name,
-				:image,
-				:content,
-				:created_at
-			)
-		`
 		// リクエストボディを読み込む
-		jsonBytes, err := io.ReadAll(r.Body)
+		err := response.Decode(r, &entriesJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("read error: %v", err))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		err = json.Unmarshal(jsonBytes, &entriesJson)
+		// リクエストボディのバリデーション
+		err = entriesJson.Validate()
 		if err != nil {
-			log.Println(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-		if len(entriesJson.Entries) == 0 {
-			log.Println("json unexpected error: empty body")
-			http.Error(w, "json unexpected error: empty body", http.StatusBadRequest)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
 		}
 		for _, entry := range entriesJson.Entries {
-			_, err = db.NamedExecContext(r.Context(), query, entry)
+			err = entry.Validate()
 			if err != nil {
-				log.Println(fmt.Sprintf("insert error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				logging.Error(r.Context(), "validation", err)
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
 			}
 		}
+		// 登録
+		entriesJson.Entries, err = entries.Create(r.Context(), entriesJson.Entries)
+		if err != nil {
+			logging.Error(r.Context(), "insert", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 		// レスポンスボディに書き込む
-		err = json.NewEncoder(w).Encode(&entriesJson)
+		err = response.Write(w, r, &entriesJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPut:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "entry", rbac.Update) {
+			return
+		}
 		var entriesJson EntriesJson
-		query := `
-			UPDATE
-				entry
-			SET
-				source_id = :source_id,
-				name = :name,
-				image = :image,
-				content = :content,
-				created_at = :created_at
-			WHERE
-				id = :id
-		`
 		// リクエストボディを読み込む
-		jsonBytes, err := io.ReadAll(r.Body)
+		err := response.Decode(r, &entriesJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("read error: %v", err))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		err = json.Unmarshal(jsonBytes, &entriesJson)
+		// リクエストボディのバリデーション
+		err = entriesJson.Validate()
 		if err != nil {
-			log.Println(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-		}
-		if len(entriesJson.Entries) == 0 {
-			log.Println("json unexpected error: empty body")
-			http.Error(w, "json unexpected error: empty body", http.StatusUnprocessableEntity)
+			return
 		}
 		for _, entry := range entriesJson.Entries {
-			// 更新
-			_, err = db.NamedExecContext(r.Context(), query, entry)
+			err = entry.Validate()
 			if err != nil {
-				log.Println(fmt.Sprintf("update error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				logging.Error(r.Context(), "validation", err)
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
 			}
 		}
+		// 更新
+		err = entries.Update(r.Context(), entriesJson.Entries)
+		if err != nil {
+			logging.Error(r.Context(), "update", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 		// レスポンスボディに書き込む
-		err = json.NewEncoder(w).Encode(&entriesJson)
+		err = response.Write(w, r, &entriesJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodDelete:
+		// 権限の確認。ボディを読む前に削除の権限を確認する
+		if !rbac.Authorize(w, r, "entry", rbac.Delete) {
+			return
+		}
 		var delIDs IDs
-		query := `
-			DELETE FROM
-				entry
-			WHERE
-				id IN (?)
-		`
 		// リクエストボディを読み込む
-		jsonBytes, err := io.ReadAll(r.Body)
+		err := response.Decode(r, &delIDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("read error: %v", err))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		err = json.Unmarshal(jsonBytes, &delIDs)
+		// リクエストボディのバリデーション
+		err = delIDs.Validate()
 		if err != nil {
-			log.Println(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-		if len(delIDs.IDs) == 0 {
-			return
-		// 1件の場合はIN句を使わない
-		} else if len(delIDs.IDs) == 1 {
-			query = `
-				DELETE FROM
-					entry
-				WHERE
-					id = $1
-			`
-			// 1件削除
-			_, err = db.ExecContext(r.Context(), query, delIDs.IDs[0])
-			if err != nil {
-				log.Println(fmt.Sprintf("delete error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// レスポンスボディに書き込む
-			err = json.NewEncoder(w).Encode(&delIDs)
-			if err != nil {
-				log.Println(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, delIDs.IDs)
-		if err != nil {
-			log.Println(fmt.Sprintf("db.In error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+		// 2件以上の削除は一括削除の権限も確認する
+		if len(delIDs.IDs) > 1 && !rbac.Authorize(w, r, "entry", rbac.BulkDelete) {
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(delIDs.IDs), query)
-		_, err = db.ExecContext(r.Context(), query, args...)
+		// 削除
+		err = entries.Delete(r.Context(), delIDs.IDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("delete error: %v", err))
+			logging.Error(r.Context(), "delete", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 		// レスポンスボディに書き込む
-		err = json.NewEncoder(w).Encode(&delIDs)
+		err = response.Write(w, r, &delIDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
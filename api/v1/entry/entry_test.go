@@ -0,0 +1,110 @@
+package entry
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+func newEntry(id int64, name string) Entry {
+	return Entry{
+		ID:        id,
+		SourceID:  1,
+		Name:      name,
+		Image:     "https://example.com/" + name + ".png",
+		Content:   name + "の説明",
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func newRepo() *store.Memory[model.Entry, store.EntrySearch] {
+	entries := store.NewMemoryEntries(nil)
+	entries.Seed(newEntry(1, "a"), newEntry(2, "b"), newEntry(3, "c"))
+	return entries
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	entries := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, entries) }
+
+	var all EntriesJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry", nil), http.StatusOK, &all)
+	if len(all.Entries) != 3 {
+		t.Fatalf("len = %d, want 3", len(all.Entries))
+	}
+
+	var some EntriesJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry?id=3&id=1", nil), http.StatusOK, &some)
+	if len(some.Entries) != 2 || some.Entries[0].ID != 1 || some.Entries[1].ID != 3 {
+		t.Fatalf("entries = %+v", some.Entries)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry?id=x", nil), http.StatusBadRequest, nil)
+}
+
+func TestPost(t *testing.T) {
+	apitest.Setup(t)
+	entries := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, entries) }
+	body := EntriesJson{Entries: []Entry{newEntry(0, "d")}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPost, "/api/v1/entry", body), http.StatusForbidden, nil)
+
+	var created EntriesJson
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/entry", body), http.StatusOK, &created)
+	if len(created.Entries) != 1 || created.Entries[0].ID != 4 {
+		t.Fatalf("entries = %+v", created.Entries)
+	}
+	if _, err := entries.Get(context.Background(), 4); err != nil {
+		t.Fatal(err)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/entry", EntriesJson{}), http.StatusUnprocessableEntity, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/entry", EntriesJson{Entries: []Entry{{SourceID: 1}}}), http.StatusUnprocessableEntity, nil)
+}
+
+func TestPut(t *testing.T) {
+	apitest.Setup(t)
+	entries := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, entries) }
+	updated := newEntry(2, "b2")
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPut, "/api/v1/entry", EntriesJson{Entries: []Entry{updated}}), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPut, "/api/v1/entry", EntriesJson{Entries: []Entry{updated}}), http.StatusOK, nil)
+	got, err := entries.Get(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "b2" {
+		t.Fatalf("name = %q, want b2", got.Name)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	apitest.Setup(t)
+	entries := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, entries) }
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/entry", IDs{}), http.StatusUnprocessableEntity, nil)
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodDelete, "/api/v1/entry", IDs{IDs: []int64{1}}), http.StatusForbidden, nil)
+	// editorは一括削除できない
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/entry", IDs{IDs: []int64{1, 2}}), http.StatusForbidden, nil)
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/entry", IDs{IDs: []int64{1}}), http.StatusOK, nil)
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodDelete, "/api/v1/entry", IDs{IDs: []int64{2, 3}}), http.StatusOK, nil)
+	rows, _ := entries.List(context.Background(), nil)
+	if len(rows) != 0 {
+		t.Fatalf("rows = %+v, want none", rows)
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, newRepo()) }
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodPatch, "/api/v1/entry", nil), http.StatusMethodNotAllowed, nil)
+}
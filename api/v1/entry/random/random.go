@@ -0,0 +1,227 @@
+package random
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+	// Vercelの実行環境にはタイムゾーンのデータベースがないため埋め込む
+	_ "time/tzdata"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// 選んだ行がidの範囲を求めた後に消えていた場合に求め直す回数
+const attempts = 3
+
+// now はdailyの日付を決める現在時刻。テストで差し替える
+var now = time.Now
+
+type Entry = model.Entry
+
+type RandomJson struct {
+	Entry Entry `json:"entry"`
+	// Seed は選ぶのに使ったシード。条件に一致するentryが変わらなければ、同じシードで同じentryを返す
+	Seed string `json:"seed"`
+	// Date はdailyの場合の日付(YYYY-MM-DD)
+	Date string `json:"date,omitempty"`
+}
+
+// repository はentryの検索とidの範囲の取得。store.Entriesとメモリの実装が満たす
+type repository interface {
+	Search(ctx context.Context, q store.EntrySearch) ([]model.Entry, error)
+	IDRange(ctx context.Context, q store.EntrySearch) (lo, hi int64, err error)
+}
+
+func Handler(w http.ResponseWriter, r *http.Request) {
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
+	if err != nil {
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer db.Close()
+	serve(w, r, &store.Entries{DB: db})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+// ORDER BY random()は全ての行に乱数を振って並べ替え、OFFSETは読み飛ばす行を全て読むため使わない
+// 一致する行のidの範囲からシードでidを決め、そのid以上で最初の1行を主キーの順に読む
+// idに欠番があると欠番の直後のentryが選ばれやすくなる
+func serve(w http.ResponseWriter, r *http.Request, entries repository) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	// 権限の確認
+	if !rbac.Authorize(w, r, "entry", rbac.Read) {
+		return
+	}
+	// クエリパラメータから絞り込みの条件を取得
+	var q store.EntrySearch
+	if v := r.URL.Query()["query"]; len(v) > 0 {
+		q.Query = v[0]
+	}
+	filters := []struct {
+		name   string
+		values []string
+		dest   **int64
+	}{
+		{"source_id", r.URL.Query()["source_id"], &q.SourceID},
+		{"tag_id", r.URL.Query()["tag_id"], &q.TagID},
+		{"haircolor_id", r.URL.Query()["haircolor_id"], &q.HairColorID},
+		{"eyecolor_id", r.URL.Query()["eyecolor_id"], &q.EyeColorID},
+		{"hairstyle_id", r.URL.Query()["hairstyle_id"], &q.HairStyleID},
+		{"hairlength_id", r.URL.Query()["hairlength_id"], &q.HairLengthID},
+		{"personality_id", r.URL.Query()["personality_id"], &q.PersonalityID},
+	}
+	for _, f := range filters {
+		id, err := one(f.name, f.values)
+		if err != nil {
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		*f.dest = id
+	}
+	var err error
+	if v := r.URL.Query()["exclude_nsfw"]; len(v) > 0 {
+		q.ExcludeNsfw, err = strconv.ParseBool(v[0])
+		if err != nil {
+			err = fmt.Errorf("invalid exclude_nsfw %q: want true or false", v[0])
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	randomJson := RandomJson{}
+	if v := r.URL.Query()["seed"]; len(v) > 0 {
+		randomJson.Seed = v[0]
+	}
+	daily := false
+	if v := r.URL.Query()["daily"]; len(v) > 0 {
+		daily, err = strconv.ParseBool(v[0])
+		if err != nil {
+			err = fmt.Errorf("invalid daily %q: want true or false", v[0])
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	var expires time.Time
+	if daily {
+		// 日付をシードにするため、同じタイムゾーンでは日付が変わるまで同じentryになる
+		tz := "UTC"
+		if v := r.URL.Query()["tz"]; len(v) > 0 {
+			tz = v[0]
+		}
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			err = fmt.Errorf("invalid tz %q: %w", tz, err)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		today := now().In(loc)
+		randomJson.Date = today.Format(time.DateOnly)
+		expires = time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, loc)
+		seed := "daily:" + randomJson.Date
+		if randomJson.Seed != "" {
+			seed += ":" + randomJson.Seed
+		}
+		randomJson.Seed = seed
+	}
+	if randomJson.Seed == "" {
+		randomJson.Seed = strconv.FormatUint(rand.Uint64(), 10)
+	}
+
+	// 範囲を求めてから読むまでに行が削除された場合は求め直す
+	for i := 0; i < attempts && randomJson.Entry.ID == 0; i++ {
+		lo, hi, err := entries.IDRange(r.Context(), q)
+		if err != nil {
+			logging.Error(r.Context(), "select", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if hi == 0 {
+			http.Error(w, "no entries match the filters", http.StatusNotFound)
+			return
+		}
+		// 選んだid以上の行がなければ先頭に戻る
+		pick := lo + position(randomJson.Seed, hi-lo+1)
+		for _, from := range []int64{pick, lo} {
+			found, err := entries.Search(r.Context(), pickFrom(q, from))
+			if err != nil {
+				logging.Error(r.Context(), "select", err)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if len(found) == 1 {
+				randomJson.Entry = found[0]
+				break
+			}
+		}
+	}
+	if randomJson.Entry.ID == 0 {
+		http.Error(w, "entries changed while picking; retry", http.StatusServiceUnavailable)
+		return
+	}
+	// dailyは日付が変わるまでキャッシュできる。シードのない結果はキャッシュさせない
+	switch {
+	case daily:
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(expires.Sub(now()).Seconds())))
+	case len(r.URL.Query()["seed"]) == 0:
+		w.Header().Set("Cache-Control", "no-store")
+	}
+	// レスポンスボディに書き込む
+	err = response.Write(w, r, &randomJson)
+	if err != nil {
+		logging.Error(r.Context(), "encode", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// one はクエリパラメータを1つの整数として読む。指定がない場合はnil
+func one(name string, values []string) (*int64, error) {
+	ids, err := params.Int64s(values)
+	if err != nil {
+		return nil, err
+	}
+	switch len(ids) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &ids[0], nil
+	}
+	return nil, fmt.Errorf("%s must be specified at most once", name)
+}
+
+// pickFrom はid以上の最初の1行を読む条件を返す
+func pickFrom(q store.EntrySearch, id int64) store.EntrySearch {
+	q.MinID = &id
+	q.Page = store.Page{Limit: 1}
+	return q
+}
+
+// position はシードのハッシュから0以上n未満の位置を決める
+func position(seed string, n int64) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(seed))
+	return int64(h.Sum64() % uint64(n))
+}
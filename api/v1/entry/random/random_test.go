@@ -0,0 +1,185 @@
+package random
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// newEntries は20件のentryを作る。偶数のidは髪色1、3の倍数は成人向けのリンクがある
+func newEntries() *store.Memory[model.Entry, store.EntrySearch] {
+	hairColors := store.NewMemoryAttributes(model.HairColorTable)
+	links := store.NewMemoryLinks()
+	entries := store.NewMemoryEntriesWith(store.MemoryRelations{
+		Attributes: map[string]*store.Memory[model.Attribute, store.AttributeSearch]{model.HairColorTable.Name: hairColors},
+		Links:      links,
+	})
+	for id := int64(1); id <= 20; id++ {
+		entries.Seed(model.Entry{ID: id, SourceID: 1 + id%2, Name: fmt.Sprintf("entry%d", id)})
+		if id%2 == 0 {
+			hairColors.Seed(model.Attribute{EntryID: id, ValueID: 1})
+		}
+		if id%3 == 0 {
+			links.Seed(model.Link{ID: id, EntryID: id, Type: "pixiv", URL: "https://example.com", Nsfw: true})
+		}
+	}
+	return entries
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	entries := newEntries()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, entries) }
+
+	// 同じシードは同じentry
+	var first, second RandomJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry/random?seed=abc", nil), http.StatusOK, &first)
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry/random?seed=abc", nil), http.StatusOK, &second)
+	if first.Entry.ID == 0 || first.Entry.ID != second.Entry.ID || first.Seed != "abc" {
+		t.Fatalf("first = %+v, second = %+v", first, second)
+	}
+
+	// シードがない場合は選んだシードを返し、キャッシュさせない
+	w := apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry/random", nil)
+	var generated RandomJson
+	apitest.Decode(t, w, http.StatusOK, &generated)
+	if generated.Seed == "" || w.Header().Get("Cache-Control") != "no-store" {
+		t.Fatalf("generated = %+v, Cache-Control = %q", generated, w.Header().Get("Cache-Control"))
+	}
+	var replay RandomJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry/random?seed="+generated.Seed, nil), http.StatusOK, &replay)
+	if replay.Entry.ID != generated.Entry.ID {
+		t.Fatalf("replay = %+v, want %+v", replay.Entry, generated.Entry)
+	}
+
+	// 絞り込みの条件に一致するentryだけを選ぶ
+	for i := 0; i < 20; i++ {
+		var got RandomJson
+		apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, fmt.Sprintf("/api/v1/entry/random?seed=%d&haircolor_id=1&exclude_nsfw=true&source_id=1", i), nil), http.StatusOK, &got)
+		if id := got.Entry.ID; id%2 != 0 || id%3 == 0 || got.Entry.SourceID != 1 {
+			t.Fatalf("got = %+v", got)
+		}
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry/random?haircolor_id=2", nil), http.StatusNotFound, nil)
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry/random?eyecolor_id=1", nil), http.StatusNotFound, nil)
+	for _, target := range []string{
+		"/api/v1/entry/random?source_id=x",
+		"/api/v1/entry/random?tag_id=1&tag_id=2",
+		"/api/v1/entry/random?exclude_nsfw=maybe",
+		"/api/v1/entry/random?daily=maybe",
+		"/api/v1/entry/random?daily=true&tz=Mars/Olympus",
+	} {
+		apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, target, nil), http.StatusBadRequest, nil)
+	}
+}
+
+func TestDaily(t *testing.T) {
+	apitest.Setup(t)
+	entries := newEntries()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, entries) }
+	t.Cleanup(func() { now = time.Now })
+
+	// UTCでは2024-05-01 20:00、東京では翌日の05:00
+	now = func() time.Time { return time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC) }
+	w := apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry/random?daily=true", nil)
+	var utc RandomJson
+	apitest.Decode(t, w, http.StatusOK, &utc)
+	if utc.Date != "2024-05-01" || utc.Seed != "daily:2024-05-01" {
+		t.Fatalf("utc = %+v", utc)
+	}
+	if got := w.Header().Get("Cache-Control"); got != "public, max-age=14400" {
+		t.Fatalf("Cache-Control = %q", got)
+	}
+	var tokyo RandomJson
+	w = apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry/random?daily=true&tz=Asia/Tokyo", nil)
+	apitest.Decode(t, w, http.StatusOK, &tokyo)
+	if tokyo.Date != "2024-05-02" || w.Header().Get("Cache-Control") != "public, max-age=68400" {
+		t.Fatalf("tokyo = %+v, Cache-Control = %q", tokyo, w.Header().Get("Cache-Control"))
+	}
+
+	// 同じ日の間は同じentry
+	now = func() time.Time { return time.Date(2024, 5, 1, 23, 59, 0, 0, time.UTC) }
+	var later RandomJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry/random?daily=true", nil), http.StatusOK, &later)
+	if later.Entry.ID != utc.Entry.ID {
+		t.Fatalf("later = %+v, want %+v", later.Entry, utc.Entry)
+	}
+
+	// シードを足すと同じ日でも別の選び方になる
+	var seeded RandomJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry/random?daily=true&seed=x", nil), http.StatusOK, &seeded)
+	if seeded.Seed != "daily:2024-05-01:x" {
+		t.Fatalf("seeded = %+v", seeded)
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	apitest.Setup(t)
+	entries := newEntries()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, entries) }
+
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodPost, "/api/v1/entry/random", nil), http.StatusMethodNotAllowed, nil)
+}
+
+// staleRange はidの範囲を求めた後に末尾の行が削除された状態を再現する
+type staleRange struct {
+	*store.Memory[model.Entry, store.EntrySearch]
+	hi int64
+}
+
+func (s staleRange) IDRange(ctx context.Context, q store.EntrySearch) (int64, int64, error) {
+	lo, _, err := s.Memory.IDRange(ctx, q)
+	return lo, s.hi, err
+}
+
+func TestIDRange(t *testing.T) {
+	apitest.Setup(t)
+
+	// idに欠番があっても範囲内のidから選ぶ
+	entries := store.NewMemoryEntries(nil)
+	for _, id := range []int64{3, 10, 11, 40} {
+		entries.Seed(model.Entry{ID: id, SourceID: 1, Name: fmt.Sprintf("entry%d", id)})
+	}
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, entries) }
+	seen := map[int64]bool{}
+	for i := 0; i < 50; i++ {
+		var got RandomJson
+		apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, fmt.Sprintf("/api/v1/entry/random?seed=%d", i), nil), http.StatusOK, &got)
+		seen[got.Entry.ID] = true
+	}
+	for id := range seen {
+		if id != 3 && id != 10 && id != 11 && id != 40 {
+			t.Fatalf("picked missing id %d", id)
+		}
+	}
+	if !seen[40] {
+		t.Fatalf("seen = %v, want the entry after the largest gap", seen)
+	}
+
+	// 選んだid以上の行がない場合は先頭に戻る
+	stale := staleRange{Memory: entries, hi: 1000}
+	h = func(w http.ResponseWriter, r *http.Request) { serve(w, r, stale) }
+	wrapped := false
+	for i := 0; i < 20; i++ {
+		seed := fmt.Sprint(i)
+		if 3+position(seed, 1000-3+1) <= 40 {
+			continue
+		}
+		wrapped = true
+		var got RandomJson
+		apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry/random?seed="+seed, nil), http.StatusOK, &got)
+		if got.Entry.ID != 3 {
+			t.Fatalf("seed %s: got = %+v, want the first entry", seed, got.Entry)
+		}
+	}
+	if !wrapped {
+		t.Fatal("no seed picked past the last entry")
+	}
+}
@@ -0,0 +1,110 @@
+package similar
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/similarity"
+)
+
+// 返す件数の既定値と上限
+const (
+	defaultK = 10
+	maxK     = 100
+)
+
+// SimilarEntry は類似したキャラクターとスコアの内訳
+type SimilarEntry = similarity.SimilarEntry
+
+type SimilarJson struct {
+	// ID は基準にしたentryのID
+	ID      int64          `json:"id"`
+	Entries []SimilarEntry `json:"entries"`
+}
+
+func Handler(w http.ResponseWriter, r *http.Request) {
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
+	if err != nil {
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer db.Close()
+	serve(w, r, similarity.NewSources(db))
+}
+
+// serve はリポジトリを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, sources similarity.Sources) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	// 権限の確認
+	if !rbac.Authorize(w, r, "entry", rbac.Read) {
+		return
+	}
+	// クエリパラメータから基準のidを取得
+	ids, err := params.Int64s(r.URL.Query()["id"])
+	if err == nil && len(ids) != 1 {
+		err = fmt.Errorf("exactly one id is required")
+	}
+	if err != nil {
+		logging.Error(r.Context(), "validation", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	k := defaultK
+	if v := r.URL.Query()["k"]; len(v) > 0 {
+		k, err = strconv.Atoi(v[0])
+		if err != nil || k < 1 || k > maxK {
+			err = fmt.Errorf("invalid k %q: want 1-%d", v[0], maxK)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	weights, err := similarity.ParseWeights(strings.Join(r.URL.Query()["weights"], ","))
+	if err != nil {
+		logging.Error(r.Context(), "validation", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// 全てのentryの属性を読み込んで比べる
+	profiles, err := sources.Load(r.Context())
+	if err != nil {
+		logging.Error(r.Context(), "select", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var target *similarity.Profile
+	for _, p := range profiles {
+		if p.Entry.ID == ids[0] {
+			target = p
+			break
+		}
+	}
+	if target == nil {
+		http.Error(w, fmt.Sprintf("entry %d not found", ids[0]), http.StatusNotFound)
+		return
+	}
+	similarJson := SimilarJson{ID: target.Entry.ID, Entries: similarity.Rank(target, profiles, weights, k)}
+	// レスポンスボディに書き込む
+	err = response.Write(w, r, &similarJson)
+	if err != nil {
+		logging.Error(r.Context(), "encode", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,148 @@
+package similar
+
+import (
+	"math"
+	"net/http"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/similarity"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+func newSources() similarity.Sources {
+	entries := store.NewMemoryEntries(nil)
+	entries.Seed(
+		model.Entry{ID: 1, SourceID: 1, Name: "a"},
+		model.Entry{ID: 2, SourceID: 1, Name: "b"},
+		model.Entry{ID: 3, SourceID: 1, Name: "c"},
+		model.Entry{ID: 4, SourceID: 1, Name: "d"},
+	)
+	hairColors := store.NewMemoryAttributes(model.HairColorTable)
+	hairColors.Seed(
+		model.Attribute{EntryID: 1, ValueID: 1},
+		model.Attribute{EntryID: 2, ValueID: 1},
+		model.Attribute{EntryID: 3, ValueID: 2},
+	)
+	entryTags := store.NewMemoryEntryTags()
+	entryTags.Seed(
+		model.EntryTag{ID: 1, EntryID: 1, TagID: 10},
+		model.EntryTag{ID: 2, EntryID: 1, TagID: 11},
+		model.EntryTag{ID: 3, EntryID: 2, TagID: 10},
+		model.EntryTag{ID: 4, EntryID: 2, TagID: 11},
+		model.EntryTag{ID: 5, EntryID: 3, TagID: 12},
+		model.EntryTag{ID: 6, EntryID: 4, TagID: 10},
+	)
+	axes := store.NewMemoryRadarAxes()
+	axes.Seed(
+		model.RadarAxis{ID: 1, Key: "ai", LabelJa: "愛", LabelEn: "ai", Min: 0, Max: 100, DisplayOrder: 1},
+		model.RadarAxis{ID: 2, Key: "nu", LabelJa: "ぬ", LabelEn: "nu", Min: 0, Max: 100, DisplayOrder: 2},
+		model.RadarAxis{ID: 3, Key: "moe", LabelJa: "萌え", LabelEn: "moe", Min: 1, Max: 5, DisplayOrder: 3},
+	)
+	charts := store.NewMemoryRadarCharts()
+	charts.Seed(
+		model.RadarChart{EntryID: 1, Values: map[string]int64{"ai": 80, "nu": 20}},
+		model.RadarChart{EntryID: 3, Values: map[string]int64{"ai": 80, "nu": 20}},
+	)
+	return similarity.Sources{
+		Entries:       entries,
+		BWHs:          store.NewMemoryBWHs(),
+		EntryTags:     entryTags,
+		HairColors:    hairColors,
+		HairStyles:    store.NewMemoryAttributes(model.HairStyleTable),
+		HairLengths:   store.NewMemoryAttributes(model.HairLengthTable),
+		EyeColors:     store.NewMemoryAttributes(model.EyeColorTable),
+		Personalities: store.NewMemoryAttributes(model.PersonalityTable),
+		Axes:          axes,
+		Charts:        charts,
+	}
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	sources := newSources()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, sources) }
+
+	var got SimilarJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry/similar?id=1", nil), http.StatusOK, &got)
+	if got.ID != 1 || len(got.Entries) != 3 {
+		t.Fatalf("got = %+v", got)
+	}
+	// 2は髪色とタグが全て一致する。4はタグが半分一致する
+	if got.Entries[0].Entry.ID != 2 || got.Entries[0].Score != 1 {
+		t.Fatalf("first = %+v", got.Entries[0])
+	}
+	if got.Entries[1].Entry.ID != 4 {
+		t.Fatalf("second = %+v", got.Entries[1])
+	}
+	if n := len(got.Entries[0].Breakdown); n != len(similarity.Dimensions) {
+		t.Fatalf("breakdown = %d dimensions, want %d", n, len(similarity.Dimensions))
+	}
+	for _, d := range got.Entries[0].Breakdown {
+		if d.Dimension == similarity.BWH && d.Score != nil {
+			t.Fatalf("bwh score = %v, want null", *d.Score)
+		}
+	}
+
+	// タグを比べなければ、髪色は違うがチャートが一致する3の方が4より上になる
+	var weighted SimilarJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry/similar?id=1&k=2&weights=tags:0", nil), http.StatusOK, &weighted)
+	if len(weighted.Entries) != 2 || weighted.Entries[0].Entry.ID != 2 || weighted.Entries[1].Entry.ID != 3 {
+		t.Fatalf("weighted = %+v", weighted.Entries)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry/similar?id=9", nil), http.StatusNotFound, nil)
+	for _, target := range []string{
+		"/api/v1/entry/similar",
+		"/api/v1/entry/similar?id=x",
+		"/api/v1/entry/similar?id=1&id=2",
+		"/api/v1/entry/similar?id=1&k=0",
+		"/api/v1/entry/similar?id=1&weights=hair:1",
+		"/api/v1/entry/similar?id=1&weights=tags:-1",
+	} {
+		apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, target, nil), http.StatusBadRequest, nil)
+	}
+}
+
+// 軸ごとに範囲で割るため、moe(1〜5)の差2はai(0〜100)の差40より大きい
+func TestChartAxisRange(t *testing.T) {
+	apitest.Setup(t)
+	sources := newSources()
+	charts := store.NewMemoryRadarCharts()
+	charts.Seed(
+		model.RadarChart{EntryID: 1, Values: map[string]int64{"ai": 50, "moe": 1}},
+		model.RadarChart{EntryID: 2, Values: map[string]int64{"ai": 90, "moe": 1}},
+		model.RadarChart{EntryID: 3, Values: map[string]int64{"ai": 50, "moe": 3}},
+		model.RadarChart{EntryID: 4, Values: map[string]int64{"nu": 50}},
+	)
+	sources.Charts = charts
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, sources) }
+
+	var got SimilarJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry/similar?id=1&weights=hair_color:0,tags:0", nil), http.StatusOK, &got)
+	if len(got.Entries) != 3 || got.Entries[0].Entry.ID != 2 || got.Entries[1].Entry.ID != 3 {
+		t.Fatalf("got = %+v", got.Entries)
+	}
+	// 2は(0.4, 0)、3は(0, 0.5)の差の二乗平均平方根を1から引く
+	if s := got.Entries[0].Score; math.Abs(s-(1-math.Sqrt(0.16/2))) > 1e-9 {
+		t.Fatalf("score of 2 = %v", s)
+	}
+	if s := got.Entries[1].Score; math.Abs(s-(1-math.Sqrt(0.25/2))) > 1e-9 {
+		t.Fatalf("score of 3 = %v", s)
+	}
+	// 4は共通する軸がないためチャートを比べない
+	for _, d := range got.Entries[2].Breakdown {
+		if d.Dimension == similarity.HekiRadarChart && d.Score != nil {
+			t.Fatalf("chart score of 4 = %v, want null", *d.Score)
+		}
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	apitest.Setup(t)
+	sources := newSources()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, sources) }
+
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodPost, "/api/v1/entry/similar?id=1", nil), http.StatusMethodNotAllowed, nil)
+}
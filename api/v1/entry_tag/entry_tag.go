@@ -1,30 +1,24 @@
 package entrytag
 
 import (
-	"encoding/json"
-	"log"
-	"io"
-	"fmt"
-	"time"
 	"net/http"
+	"time"
 
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
 	validation "github.com/go-ozzo/ozzo-validation"
-)
 
-type EntryTag struct {
-	ID      int64 `db:"id" json:"id"`
-	EntryID int64 `db:"entry_id" json:"entry_id"`
-	TagID   int64 `db:"tag_id" json:"tag_id"`
-}
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/jsonapi"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
+)
 
-func (e *EntryTag) Validate() error {
-	return validation.ValidateStruct(e,
-		validation.Field(&e.EntryID, validation.Required),
-		validation.Field(&e.TagID, validation.Required),
-	)
-}
+// EntryTag はentry_tagテーブルの行。RPCと共通の型を使う
+type EntryTag = model.EntryTag
 
 type EntryTagsJson struct {
 	EntryTags []EntryTag `json:"entry_tags"`
@@ -68,248 +62,174 @@ func (i *IDs) Validate() error {
 }
 
 func Handler(w http.ResponseWriter, r *http.Request) {
-	db, err := sqlx.Open("postgres", "")
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
 	if err != nil {
-		log.Printf("sql.Open error %s", err)
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 	defer db.Close()
+	// RPCと同じstoreで読み書きする
+	serve(w, r, &store.EntryTags{DB: db})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, entryTags store.EntryTagRepository) {
 	switch r.Method {
 	case http.MethodGet:
-		var entryTagsJson EntryTagsJson
-		query := `
-			SELECT
-				id,
-				entry_id,
-				tag_id
-			FROM
-				entry_tag
-			WHERE
-				id IN (?)
-		`
-		// idが指定されていない場合は全件取得
-		queryIDs, ok := r.URL.Query()["id"]
-		if !ok {
-			query = `
-				SELECT
-					id,
-					entry_id,
-					tag_id
-				FROM
-					entry_tag
-			`
-			err := db.SelectContext(r.Context(), &entryTagsJson.EntryTags, query)
-			if err != nil {
-				log.Println(fmt.Sprintf("select error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// json返却
-			err = json.NewEncoder(w).Encode(&entryTagsJson)
-			if err != nil {
-				log.Println(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			return
-		// idが指定されている場合は指定されたidのみ取得
-		} else if len(queryIDs) == 1 {
-			query = `
-				SELECT
-					id,
-					entry_id,
-					tag_id
-				FROM
-					entry_tag
-				WHERE
-					id = $1
-			`
-			err := db.SelectContext(r.Context(), &entryTagsJson.EntryTags, query, queryIDs[0])
-			if err != nil {
-				log.Println(fmt.Sprintf("select error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// json返却
-			err = json.NewEncoder(w).Encode(&entryTagsJson)
-			if err != nil {
-				log.Println(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+		// 権限の確認
+		if !rbac.Authorize(w, r, "entry_tag", rbac.Read) {
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, queryIDs)
+		var entryTagsJson EntryTagsJson
+		// クエリパラメータからidを取得
+		queryIDs := r.URL.Query()["id"]
+		ids, err := params.Int64s(queryIDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("db.In error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(queryIDs), query)
-		err = db.SelectContext(r.Context(), &entryTagsJson.EntryTags, query, args...)
+		// idが指定されていない場合は全件取得
+		var total int
+		entryTagsJson.EntryTags, total, err = jsonapi.List(r, entryTags, ids)
 		if err != nil {
-			log.Println(fmt.Sprintf("select error: %v", err))
+			logging.Error(r.Context(), "select", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// json返却
-		err = json.NewEncoder(w).Encode(&entryTagsJson)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, jsonapi.WithTotal(&entryTagsJson, total))
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPost:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "entry_tag", rbac.Create) {
+			return
+		}
 		var entryTagsJson EntryTagsJson
-		query := `
-			INSERT INTO entry_tag (
-				entry_id,
-				tag_id
-			) VALUES (
-				:entry_id,
-				:tag_id
-			)
-		`
-		// json読み込み
-		jsonBytes, err := io.ReadAll(r.Body)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &entryTagsJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("read error: %v", err))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		err = json.Unmarshal(jsonBytes, &entryTagsJson)
-		if err != nil {
-			log.Println(fmt.Sprintf("json unmarshal error: %v", err))
-			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = entryTagsJson.Validate()
 		if err != nil {
-			log.Println(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
 		}
 		for _, entryTag := range entryTagsJson.EntryTags {
-			// jsonバリデーション
 			err = entryTag.Validate()
 			if err != nil {
-				log.Println(fmt.Sprintf("validation error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
 			}
-			_, err = db.NamedExecContext(r.Context(), query, entryTag)
-			if err != nil {
-				log.Println(fmt.Sprintf("insert error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
 		}
-		// json返却
-		err = json.NewEncoder(w).Encode(&entryTagsJson)
+		// 登録
+		entryTagsJson.EntryTags, err = entryTags.Create(r.Context(), entryTagsJson.EntryTags)
+		if err != nil {
+			logging.Error(r.Context(), "insert", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &entryTagsJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPut:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "entry_tag", rbac.Update) {
+			return
+		}
 		var entryTagsJson EntryTagsJson
-		query := `
-			UPDATE
-				entry_tag
-			SET
-				entry_id = :entry_id,
-				tag_id = :tag_id
-			WHERE
-				id = :id
-		`
-		jsonBytes, err := io.ReadAll(r.Body)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &entryTagsJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("read error: %v", err))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		err = json.Unmarshal(jsonBytes, &entryTagsJson)
-		if err != nil {
-			log.Println(fmt.Sprintf("json unmarshal error: %v", err))
-			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = entryTagsJson.Validate()
 		if err != nil {
-			log.Println(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
 		}
 		for _, entryTag := range entryTagsJson.EntryTags {
-			// jsonバリデーション
 			err = entryTag.Validate()
 			if err != nil {
-				log.Println(fmt.Sprintf("validation error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
 			}
-			_, err := db.NamedExecContext(r.Context(), query, entryTag)
-			if err != nil {
-				log.Println(fmt.Sprintf("update error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
 		}
-		// json返却
-		err = json.NewEncoder(w).Encode(&entryTagsJson)
+		// 更新
+		err = entryTags.Update(r.Context(), entryTagsJson.EntryTags)
+		if err != nil {
+			logging.Error(r.Context(), "update", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &entryTagsJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodDelete:
+		// 権限の確認。ボディを読む前に削除の権限を確認する
+		if !rbac.Authorize(w, r, "entry_tag", rbac.Delete) {
+			return
+		}
 		var delIDs IDs
-		query := `
-			DELETE FROM
-				entry_tag
-			WHERE
-				id IN (?)
-		`
-		jsonBytes, err := io.ReadAll(r.Body)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &delIDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("read error: %v", err))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		err = json.Unmarshal(jsonBytes, &delIDs)
-		if err != nil {
-			log.Println(fmt.Sprintf("json unmarshal error: %v", err))
-			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = delIDs.Validate()
 		if err != nil {
-			log.Println(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-		}
-		if len(delIDs.IDs) == 0 {
-			return
-		} else if len(delIDs.IDs) == 1 {
-			query = `
-				DELETE FROM
-					entry_tag
-				WHERE
-					id = $1
-			`
-			_, err = db.ExecContext(r.Context(), query, delIDs.IDs[0])
-			if err != nil {
-				log.Println(fmt.Sprintf("delete error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// json返却
-			err = json.NewEncoder(w).Encode(&delIDs)
-			if err != nil {
-				log.Println(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, delIDs.IDs)
-		if err != nil {
-			log.Println(fmt.Sprintf("db.In error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+		// 2件以上の削除は一括削除の権限も確認する
+		if len(delIDs.IDs) > 1 && !rbac.Authorize(w, r, "entry_tag", rbac.BulkDelete) {
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(delIDs.IDs),query)
-		_, err = db.ExecContext(r.Context(), query, args...)
+		// 削除
+		err = entryTags.Delete(r.Context(), delIDs.IDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("delete error: %v", err))
+			logging.Error(r.Context(), "delete", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// json返却
-		err = json.NewEncoder(w).Encode(&delIDs)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &delIDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
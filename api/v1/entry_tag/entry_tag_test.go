@@ -0,0 +1,92 @@
+package entrytag
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+func newRepo() *store.Memory[model.EntryTag, store.EntryTagSearch] {
+	entryTags := store.NewMemoryEntryTags()
+	entryTags.Seed(
+		EntryTag{ID: 1, EntryID: 1, TagID: 1},
+		EntryTag{ID: 2, EntryID: 1, TagID: 2},
+		EntryTag{ID: 3, EntryID: 2, TagID: 1},
+	)
+	return entryTags
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	entryTags := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, entryTags) }
+
+	var all EntryTagsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry_tag", nil), http.StatusOK, &all)
+	if len(all.EntryTags) != 3 {
+		t.Fatalf("entry_tags = %+v", all.EntryTags)
+	}
+
+	var some EntryTagsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry_tag?id=2", nil), http.StatusOK, &some)
+	if len(some.EntryTags) != 1 || some.EntryTags[0].TagID != 2 {
+		t.Fatalf("entry_tags = %+v", some.EntryTags)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry_tag?id=x", nil), http.StatusBadRequest, nil)
+}
+
+func TestPost(t *testing.T) {
+	apitest.Setup(t)
+	entryTags := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, entryTags) }
+	body := EntryTagsJson{EntryTags: []EntryTag{{EntryID: 2, TagID: 3}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPost, "/api/v1/entry_tag", body), http.StatusForbidden, nil)
+
+	var created EntryTagsJson
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/entry_tag", body), http.StatusOK, &created)
+	if len(created.EntryTags) != 1 || created.EntryTags[0].ID != 4 {
+		t.Fatalf("entry_tags = %+v", created.EntryTags)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/entry_tag", EntryTagsJson{}), http.StatusUnprocessableEntity, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/entry_tag", EntryTagsJson{EntryTags: []EntryTag{{EntryID: 2}}}), http.StatusUnprocessableEntity, nil)
+}
+
+func TestPut(t *testing.T) {
+	apitest.Setup(t)
+	entryTags := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, entryTags) }
+	body := EntryTagsJson{EntryTags: []EntryTag{{ID: 3, EntryID: 2, TagID: 4}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPut, "/api/v1/entry_tag", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPut, "/api/v1/entry_tag", body), http.StatusOK, nil)
+	got, err := entryTags.Get(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TagID != 4 {
+		t.Fatalf("tag_id = %d, want 4", got.TagID)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	apitest.Setup(t)
+	entryTags := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, entryTags) }
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/entry_tag", IDs{}), http.StatusUnprocessableEntity, nil)
+	// editorは一括削除できない
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/entry_tag", IDs{IDs: []int64{1, 2}}), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/entry_tag", IDs{IDs: []int64{1}}), http.StatusOK, nil)
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodDelete, "/api/v1/entry_tag", IDs{IDs: []int64{2, 3}}), http.StatusOK, nil)
+	rows, _ := entryTags.List(context.Background(), nil)
+	if len(rows) != 0 {
+		t.Fatalf("rows = %+v, want none", rows)
+	}
+}
@@ -0,0 +1,148 @@
+package recommend
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"maguro-alternative/varcel-go/pkg/cooccurrence"
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// 返す件数の既定値と上限
+const (
+	defaultLimit = 10
+	maxLimit     = 100
+)
+
+// 基準にするentryから読むタグの上限
+const maxEntryTags = 1000
+
+type (
+	RelatedTag   = cooccurrence.RelatedTag
+	RelatedEntry = cooccurrence.RelatedEntry
+)
+
+type RecommendJson struct {
+	// TagIDs は基準にしたタグ。entry_idを指定した場合はそのentryのタグ
+	TagIDs  []int64        `json:"tag_ids"`
+	Tags    []RelatedTag   `json:"tags"`
+	Entries []RelatedEntry `json:"entries"`
+}
+
+func Handler(w http.ResponseWriter, r *http.Request) {
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
+	if err != nil {
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer db.Close()
+	serve(w, r, cooccurrence.NewSources(db))
+}
+
+// serve はリポジトリを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, sources cooccurrence.Sources) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	// 権限の確認
+	if !rbac.Authorize(w, r, "entry_tag", rbac.Read) {
+		return
+	}
+	// クエリパラメータから基準のentryかタグを取得
+	entryIDs, err := params.Int64s(r.URL.Query()["entry_id"])
+	if err != nil {
+		logging.Error(r.Context(), "validation", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tagIDs, err := params.Int64s(r.URL.Query()["tag_id"])
+	if err == nil && len(entryIDs)+len(tagIDs) == 0 {
+		err = errors.New("entry_id or tag_id is required")
+	}
+	if err == nil && len(entryIDs) > 0 && (len(entryIDs) > 1 || len(tagIDs) > 0) {
+		err = errors.New("specify either one entry_id or tag_id")
+	}
+	if err != nil {
+		logging.Error(r.Context(), "validation", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit := defaultLimit
+	if v := r.URL.Query()["limit"]; len(v) > 0 {
+		limit, err = strconv.Atoi(v[0])
+		if err != nil || limit < 1 || limit > maxLimit {
+			err = fmt.Errorf("invalid limit %q: want 1-%d", v[0], maxLimit)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	metric := cooccurrence.PMI
+	if v := r.URL.Query()["metric"]; len(v) > 0 {
+		metric = v[0]
+		if metric != cooccurrence.PMI && metric != cooccurrence.Lift {
+			err = fmt.Errorf("invalid metric %q: want pmi or lift", metric)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var exclude int64
+	if len(entryIDs) == 1 {
+		exclude = entryIDs[0]
+		_, err = sources.Entries.Get(r.Context(), exclude)
+		if errors.Is(err, store.ErrNotFound) {
+			http.Error(w, fmt.Sprintf("entry %d not found", exclude), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			logging.Error(r.Context(), "select", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// entryに付いているタグを基準にする
+		entryTags, err := sources.EntryTags.Search(r.Context(), store.EntryTagSearch{EntryID: &exclude, Page: store.Page{Limit: maxEntryTags}})
+		if err != nil {
+			logging.Error(r.Context(), "select", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, et := range entryTags {
+			tagIDs = append(tagIDs, et.TagID)
+		}
+	}
+	// 同じタグを何度指定しても1回として数える
+	slices.Sort(tagIDs)
+	tagIDs = slices.Compact(tagIDs)
+	recommendJson := RecommendJson{TagIDs: tagIDs}
+	// 統計はcmd/cooccurrenceで作り直したもの
+	recommendJson.Tags, recommendJson.Entries, err = sources.Recommend(r.Context(), tagIDs, exclude, metric, limit)
+	if err != nil {
+		logging.Error(r.Context(), "select", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// レスポンスボディに書き込む
+	err = response.Write(w, r, &recommendJson)
+	if err != nil {
+		logging.Error(r.Context(), "encode", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
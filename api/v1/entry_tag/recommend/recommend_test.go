@@ -0,0 +1,113 @@
+package recommend
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/cooccurrence"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// newSources は4件のentryにタグを付けて統計を作る
+// タグ10と11はいつも一緒に付き、12は10と1回だけ一緒に付く
+func newSources(t *testing.T) cooccurrence.Sources {
+	entries := store.NewMemoryEntries(nil)
+	entries.Seed(
+		model.Entry{ID: 1, SourceID: 1, Name: "a"},
+		model.Entry{ID: 2, SourceID: 1, Name: "b"},
+		model.Entry{ID: 3, SourceID: 1, Name: "c"},
+		model.Entry{ID: 4, SourceID: 1, Name: "d"},
+	)
+	entryTags := store.NewMemoryEntryTags()
+	entryTags.Seed(
+		model.EntryTag{ID: 1, EntryID: 1, TagID: 10},
+		model.EntryTag{ID: 2, EntryID: 1, TagID: 11},
+		model.EntryTag{ID: 3, EntryID: 2, TagID: 10},
+		model.EntryTag{ID: 4, EntryID: 2, TagID: 11},
+		model.EntryTag{ID: 5, EntryID: 3, TagID: 10},
+		model.EntryTag{ID: 6, EntryID: 3, TagID: 12},
+		model.EntryTag{ID: 7, EntryID: 4, TagID: 13},
+	)
+	stats := &store.MemoryTagCooccurrences{}
+	if _, err := cooccurrence.Refresh(context.Background(), entryTags, stats, 1); err != nil {
+		t.Fatal(err)
+	}
+	return cooccurrence.Sources{Entries: entries, EntryTags: entryTags, Stats: stats}
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	sources := newSources(t)
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, sources) }
+
+	var byTag RecommendJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry_tag/recommend?tag_id=11", nil), http.StatusOK, &byTag)
+	if len(byTag.Tags) != 1 || byTag.Tags[0].ID != 10 || byTag.Tags[0].Count != 2 {
+		t.Fatalf("tags = %+v", byTag.Tags)
+	}
+	// 1と2はタグ11が付いている。3はタグ10だけ
+	if len(byTag.Entries) != 3 || byTag.Entries[0].Entry.ID != 1 || byTag.Entries[1].Entry.ID != 2 || byTag.Entries[2].Entry.ID != 3 {
+		t.Fatalf("entries = %+v", byTag.Entries)
+	}
+	if byTag.Entries[0].Entry.Name != "a" {
+		t.Fatalf("entry = %+v, want the stored row", byTag.Entries[0].Entry)
+	}
+
+	var byEntry RecommendJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry_tag/recommend?entry_id=3&metric=lift&limit=1", nil), http.StatusOK, &byEntry)
+	if len(byEntry.TagIDs) != 2 || len(byEntry.Tags) != 1 || len(byEntry.Entries) != 1 {
+		t.Fatalf("got = %+v", byEntry)
+	}
+	for _, e := range byEntry.Entries {
+		if e.Entry.ID == 3 {
+			t.Fatalf("entries include the source entry: %+v", byEntry.Entries)
+		}
+	}
+
+	// 一緒に付いたことのないタグは推薦しない
+	var none RecommendJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry_tag/recommend?tag_id=13", nil), http.StatusOK, &none)
+	if len(none.Tags) != 0 {
+		t.Fatalf("tags = %+v", none.Tags)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry_tag/recommend?entry_id=9", nil), http.StatusNotFound, nil)
+	for _, target := range []string{
+		"/api/v1/entry_tag/recommend",
+		"/api/v1/entry_tag/recommend?tag_id=x",
+		"/api/v1/entry_tag/recommend?entry_id=1&tag_id=10",
+		"/api/v1/entry_tag/recommend?tag_id=10&limit=101",
+		"/api/v1/entry_tag/recommend?tag_id=10&metric=cosine",
+	} {
+		apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, target, nil), http.StatusBadRequest, nil)
+	}
+}
+
+func TestDuplicateTagIDs(t *testing.T) {
+	apitest.Setup(t)
+	sources := newSources(t)
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, sources) }
+
+	var once, repeated RecommendJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry_tag/recommend?tag_id=12&tag_id=11", nil), http.StatusOK, &once)
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/entry_tag/recommend?tag_id=11&tag_id=12&tag_id=11&tag_id=11", nil), http.StatusOK, &repeated)
+	if !reflect.DeepEqual(repeated.TagIDs, []int64{11, 12}) {
+		t.Fatalf("tag_ids = %v, want [11 12]", repeated.TagIDs)
+	}
+	// 重複したタグで推薦の重みが変わらない
+	if !reflect.DeepEqual(once, repeated) {
+		t.Fatalf("repeated = %+v, want %+v", repeated, once)
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	apitest.Setup(t)
+	sources := newSources(t)
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, sources) }
+
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodPost, "/api/v1/entry_tag/recommend?tag_id=10", nil), http.StatusMethodNotAllowed, nil)
+}
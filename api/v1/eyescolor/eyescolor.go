@@ -1,20 +1,24 @@
 package eyescolor
 
 import (
-	"encoding/json"
-	"log"
-	"io"
-	"fmt"
 	"net/http"
 
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
 	validation "github.com/go-ozzo/ozzo-validation"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/jsonapi"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
 )
 
 type EyeColor struct {
-	EntryID int64 `db:"entry_id"`
-	ColorID int64 `db:"color_id"`
+	EntryID int64 `db:"entry_id" json:"entry_id"`
+	ColorID int64 `db:"color_id" json:"color_id"`
 }
 
 func (e *EyeColor) Validate() error {
@@ -44,254 +48,195 @@ func (i *IDs) Validate() error {
 	)
 }
 
+// attribute はstoreで扱う形に変換する
+func (e EyeColor) attribute() model.Attribute {
+	return model.Attribute{EntryID: e.EntryID, ValueID: e.ColorID}
+}
+
+func toAttributes(rows []EyeColor) []model.Attribute {
+	attrs := make([]model.Attribute, len(rows))
+	for i, row := range rows {
+		attrs[i] = row.attribute()
+	}
+	return attrs
+}
+
+func fromAttributes(attrs []model.Attribute) []EyeColor {
+	rows := make([]EyeColor, len(attrs))
+	for i, attr := range attrs {
+		rows[i] = EyeColor{EntryID: attr.EntryID, ColorID: attr.ValueID}
+	}
+	return rows
+}
+
 func Handler(w http.ResponseWriter, r *http.Request) {
-	db, err := sqlx.Open("postgres", "")
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
 	if err != nil {
-		log.Printf("sql.Open error %s", err)
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 	defer db.Close()
+	// 属性テーブル共通のstoreで読み書きする
+	serve(w, r, &store.Attributes{DB: db, Table: model.EyeColorTable})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, attributes store.AttributeRepository) {
 	switch r.Method {
 	case http.MethodGet:
-		var eyeColorsJson EyeColorsJson
-		query := `
-			SELECT
-				entry_id,
-				color_id
-			FROM
-				eyecolor
-			WHERE
-				entry_id IN (?)
-		`
-		queryIDs, ok := r.URL.Query()["entry_id"]
-		if !ok {
-			query = `
-				SELECT
-					entry_id,
-					color_id
-				FROM
-					eyecolor
-			`
-			err := db.SelectContext(r.Context(), &eyeColorsJson.EyeColors, query)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// json返却
-			err = json.NewEncoder(w).Encode(&eyeColorsJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			return
-		} else if len(queryIDs) == 1 {
-			query = `
-				SELECT
-					entry_id,
-					color_id
-				FROM
-					eyecolor
-				WHERE
-					entry_id = $1
-			`
-			err := db.SelectContext(r.Context(), &eyeColorsJson.EyeColors, query, queryIDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// json返却
-			err = json.NewEncoder(w).Encode(&eyeColorsJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+		// 権限の確認
+		if !rbac.Authorize(w, r, "eyescolor", rbac.Read) {
 			return
 		}
-		// entry_idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, queryIDs)
+		var eyeColorsJson EyeColorsJson
+		// クエリパラメータからentry_idを取得
+		queryIDs := r.URL.Query()["entry_id"]
+		ids, err := params.Int64s(queryIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(queryIDs), query)
-		err = db.SelectContext(r.Context(), &eyeColorsJson.EyeColors, query, args...)
+		// entry_idが指定されていない場合は全件取得
+		attrs, total, err := jsonapi.List(r, attributes, ids)
+		eyeColorsJson.EyeColors = fromAttributes(attrs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
+			logging.Error(r.Context(), "select", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// json返却
-		err = json.NewEncoder(w).Encode(&eyeColorsJson)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, jsonapi.WithTotal(&eyeColorsJson, total))
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPost:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "eyescolor", rbac.Create) {
+			return
+		}
 		var eyeColorsJson EyeColorsJson
-		query := `
-			INSERT INTO eyecolor (
-				entry_id,
-				color_id
-			) VALUES (
-				:entry_id,
-				:color_id
-			)
-		`
-		// json読み込み
-		jsonBytes, err := io.ReadAll(r.Body)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &eyeColorsJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("read error: %v", err))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-		err = json.Unmarshal(jsonBytes, &eyeColorsJson)
-		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
-			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = eyeColorsJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
 		for _, eyeColor := range eyeColorsJson.EyeColors {
-			// jsonバリデーション
 			err = eyeColor.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("validation error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 				return
 			}
-			_, err = db.NamedExecContext(r.Context(), query, eyeColor)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
 		}
-		// json返却
-		err = json.NewEncoder(w).Encode(&eyeColorsJson)
+		// 登録
+		attrs, err := attributes.Create(r.Context(), toAttributes(eyeColorsJson.EyeColors))
+		eyeColorsJson.EyeColors = fromAttributes(attrs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "insert", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &eyeColorsJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	case http.MethodPut:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "eyescolor", rbac.Update) {
+			return
+		}
 		var eyeColorsJson EyeColorsJson
-		query := `
-			UPDATE
-				eyecolor
-			SET
-				color_id = :color_id
-			WHERE
-				entry_id = :entry_id
-		`
-		jsonBytes, err := io.ReadAll(r.Body)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &eyeColorsJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("read error: %v", err))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-		err = json.Unmarshal(jsonBytes, &eyeColorsJson)
-		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
-			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = eyeColorsJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
 		for _, eyeColor := range eyeColorsJson.EyeColors {
-			// jsonバリデーション
 			err = eyeColor.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("validation error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 				return
 			}
-			_, err = db.NamedExecContext(r.Context(), query, eyeColor)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
 		}
-		// json返却
-		err = json.NewEncoder(w).Encode(&eyeColorsJson)
+		// 更新
+		err = attributes.Update(r.Context(), toAttributes(eyeColorsJson.EyeColors))
+		if err != nil {
+			logging.Error(r.Context(), "update", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &eyeColorsJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	case http.MethodDelete:
+		// 権限の確認。ボディを読む前に削除の権限を確認する
+		if !rbac.Authorize(w, r, "eyescolor", rbac.Delete) {
+			return
+		}
 		var delIDs IDs
-		query := `
-			DELETE FROM
-				eyecolor
-			WHERE
-				entry_id IN (?)
-		`
-		jsonBytes, err := io.ReadAll(r.Body)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &delIDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("read error: %v", err))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-		err = json.Unmarshal(jsonBytes, &delIDs)
-		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
-			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = delIDs.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
-		if len(delIDs.IDs) == 0 {
-			return
-		} else if len(delIDs.IDs) == 1 {
-			query = `
-				DELETE FROM
-					eyecolor
-				WHERE
-					entry_id = $1
-			`
-			_, err = db.ExecContext(r.Context(), query, delIDs.IDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			err = json.NewEncoder(w).Encode(&delIDs)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+		// 2件以上の削除は一括削除の権限も確認する
+		if len(delIDs.IDs) > 1 && !rbac.Authorize(w, r, "eyescolor", rbac.BulkDelete) {
 			return
 		}
-		// entry_idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, delIDs.IDs)
+		// 削除
+		err = attributes.Delete(r.Context(), delIDs.IDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(delIDs.IDs), query)
-		_, err = db.ExecContext(r.Context(), query, args...)
-		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
+			logging.Error(r.Context(), "delete", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// json返却
-		err = json.NewEncoder(w).Encode(&delIDs)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &delIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -0,0 +1,94 @@
+package eyescolor
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+func newRepo() *store.Memory[model.Attribute, store.AttributeSearch] {
+	attributes := store.NewMemoryAttributes(model.EyeColorTable)
+	attributes.Seed(
+		model.Attribute{EntryID: 1, ValueID: 1},
+		model.Attribute{EntryID: 2, ValueID: 2},
+		model.Attribute{EntryID: 3, ValueID: 3},
+	)
+	return attributes
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	attributes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, attributes) }
+
+	var all EyeColorsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/eyescolor", nil), http.StatusOK, &all)
+	if len(all.EyeColors) != 3 {
+		t.Fatalf("EyeColors = %+v", all.EyeColors)
+	}
+
+	var some EyeColorsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/eyescolor?entry_id=2", nil), http.StatusOK, &some)
+	if len(some.EyeColors) != 1 || some.EyeColors[0].EntryID != 2 || some.EyeColors[0].ColorID != 2 {
+		t.Fatalf("EyeColors = %+v", some.EyeColors)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/eyescolor?entry_id=x", nil), http.StatusBadRequest, nil)
+}
+
+func TestPost(t *testing.T) {
+	apitest.Setup(t)
+	attributes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, attributes) }
+	body := EyeColorsJson{EyeColors: []EyeColor{{EntryID: 4, ColorID: 1}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPost, "/api/v1/eyescolor", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/eyescolor", body), http.StatusOK, nil)
+	if _, err := attributes.Get(context.Background(), 4); err != nil {
+		t.Fatal(err)
+	}
+
+	// 主キーが重複する行は登録できない
+	dup := EyeColorsJson{EyeColors: []EyeColor{{EntryID: 1, ColorID: 1}}}
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/eyescolor", dup), http.StatusInternalServerError, nil)
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/eyescolor", EyeColorsJson{}), http.StatusUnprocessableEntity, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/eyescolor", EyeColorsJson{EyeColors: []EyeColor{{EntryID: 5}}}), http.StatusUnprocessableEntity, nil)
+}
+
+func TestPut(t *testing.T) {
+	apitest.Setup(t)
+	attributes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, attributes) }
+	body := EyeColorsJson{EyeColors: []EyeColor{{EntryID: 2, ColorID: 5}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPut, "/api/v1/eyescolor", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPut, "/api/v1/eyescolor", body), http.StatusOK, nil)
+	got, err := attributes.Get(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ValueID != 5 {
+		t.Fatalf("value_id = %d, want 5", got.ValueID)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	apitest.Setup(t)
+	attributes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, attributes) }
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/eyescolor", IDs{}), http.StatusUnprocessableEntity, nil)
+	// editorは一括削除できない
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/eyescolor", IDs{IDs: []int64{1, 2}}), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/eyescolor", IDs{IDs: []int64{1}}), http.StatusOK, nil)
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodDelete, "/api/v1/eyescolor", IDs{IDs: []int64{2, 3}}), http.StatusOK, nil)
+	rows, _ := attributes.List(context.Background(), nil)
+	if len(rows) != 0 {
+		t.Fatalf("rows = %+v, want none", rows)
+	}
+}
@@ -1,20 +1,24 @@
 package eyescolortype
 
 import (
-	"encoding/json"
-	"log"
-	"io"
-	"fmt"
 	"net/http"
 
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
 	validation "github.com/go-ozzo/ozzo-validation"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/jsonapi"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
 )
 
 type EyeColorType struct {
-	ID    int64  `db:"id"`
-	Color string `db:"color"`
+	ID    int64  `db:"id" json:"id"`
+	Color string `db:"color" json:"color"`
 }
 
 func (e *EyeColorType) Validate() error {
@@ -43,260 +47,195 @@ func (i *IDs) Validate() error {
 	)
 }
 
+// value はstoreで扱う形に変換する
+func (e EyeColorType) value() model.TypeValue {
+	return model.TypeValue{ID: e.ID, Value: e.Color}
+}
+
+func toValues(rows []EyeColorType) []model.TypeValue {
+	values := make([]model.TypeValue, len(rows))
+	for i, row := range rows {
+		values[i] = row.value()
+	}
+	return values
+}
+
+func fromValues(values []model.TypeValue) []EyeColorType {
+	rows := make([]EyeColorType, len(values))
+	for i, value := range values {
+		rows[i] = EyeColorType{ID: value.ID, Color: value.Value}
+	}
+	return rows
+}
+
 func Handler(w http.ResponseWriter, r *http.Request) {
-	db, err := sqlx.Open("postgres", "")
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
 	if err != nil {
-		log.Printf("sql.Open error %s", err)
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 	defer db.Close()
+	// RPCと同じstoreで読み書きする
+	serve(w, r, &store.Types{DB: db, Table: model.EyeColorTypeTable})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, types store.TypeRepository) {
 	switch r.Method {
 	case http.MethodGet:
-		var eyeColorTypesJson EyeColorTypesJson
-		query := `
-			SELECT
-				id,
-				color
-			FROM
-				eyecolor_type
-			WHERE
-				id IN (?)
-		`
-		queryIDs, ok := r.URL.Query()["id"]
-		if !ok {
-			query = `
-				SELECT
-					id,
-					color
-				FROM
-					eyecolor_type
-			`
-			err := db.SelectContext(r.Context(), &eyeColorTypesJson.EyeColorTypes, query)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			// jsonを返す
-			err = json.NewEncoder(w).Encode(&eyeColorTypesJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			return
-		} else if len(queryIDs) == 1 {
-			query = `
-				SELECT
-					id,
-					color
-				FROM
-					eyecolor_type
-				WHERE
-					id = $1
-			`
-			err := db.SelectContext(r.Context(), &eyeColorTypesJson.EyeColorTypes, query, queryIDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			// jsonを返す
-			err = json.NewEncoder(w).Encode(&eyeColorTypesJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
+		// 権限の確認
+		if !rbac.Authorize(w, r, "eyescolor_type", rbac.Read) {
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, queryIDs)
+		var eyeColorTypesJson EyeColorTypesJson
+		// クエリパラメータからidを取得
+		queryIDs := r.URL.Query()["id"]
+		ids, err := params.Int64s(queryIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(queryIDs), query)
-		err = db.SelectContext(r.Context(), &eyeColorTypesJson.EyeColorTypes, query, args...)
+		// idが指定されていない場合は全件取得
+		values, total, err := jsonapi.List(r, types, ids)
+		eyeColorTypesJson.EyeColorTypes = fromValues(values)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
+			logging.Error(r.Context(), "select", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		// jsonを返す
-		err = json.NewEncoder(w).Encode(&eyeColorTypesJson)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, jsonapi.WithTotal(&eyeColorTypesJson, total))
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	case http.MethodPost:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "eyescolor_type", rbac.Create) {
+			return
+		}
 		var eyeColorTypesJson EyeColorTypesJson
-		query := `
-			INSERT INTO eyecolor_type (
-				color
-			) VALUES (
-				:color
-			)
-		`
-		jsonBytes, err := io.ReadAll(r.Body)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &eyeColorTypesJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("read error: %v", err))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-		err = json.Unmarshal(jsonBytes, &eyeColorTypesJson)
-		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
-			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = eyeColorTypesJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
 		for _, eyeColorType := range eyeColorTypesJson.EyeColorTypes {
-			// jsonバリデーション
 			err = eyeColorType.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("validation error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 				return
 			}
-			_, err = db.NamedExecContext(r.Context(), query, eyeColorType)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
 		}
-		// jsonを返す
-		err = json.NewEncoder(w).Encode(&eyeColorTypesJson)
+		// 登録
+		values, err := types.Create(r.Context(), toValues(eyeColorTypesJson.EyeColorTypes))
+		eyeColorTypesJson.EyeColorTypes = fromValues(values)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "insert", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &eyeColorTypesJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	case http.MethodPut:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "eyescolor_type", rbac.Update) {
+			return
+		}
 		var eyeColorTypesJson EyeColorTypesJson
-		query := `
-			UPDATE
-				eyecolor_type
-			SET
-				color = :color
-			WHERE
-				id = :id
-		`
-		jsonBytes, err := io.ReadAll(r.Body)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &eyeColorTypesJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("read error: %v", err))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-		err = json.Unmarshal(jsonBytes, &eyeColorTypesJson)
-		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
-			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = eyeColorTypesJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
 		for _, eyeColorType := range eyeColorTypesJson.EyeColorTypes {
 			err = eyeColorType.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("validation error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 				return
 			}
-			_, err = db.NamedExecContext(r.Context(), query, eyeColorType)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
 		}
-		// jsonを返す
-		err = json.NewEncoder(w).Encode(&eyeColorTypesJson)
+		// 更新
+		err = types.Update(r.Context(), toValues(eyeColorTypesJson.EyeColorTypes))
+		if err != nil {
+			logging.Error(r.Context(), "update", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &eyeColorTypesJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	case http.MethodDelete:
+		// 権限の確認。ボディを読む前に削除の権限を確認する
+		if !rbac.Authorize(w, r, "eyescolor_type", rbac.Delete) {
+			return
+		}
 		var delIDs IDs
-		query := `
-			DELETE FROM
-				eyecolor_type
-			WHERE
-				id IN (?)
-		`
-		jsonBytes, err := io.ReadAll(r.Body)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &delIDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("read error: %v", err))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-		err = json.Unmarshal(jsonBytes, &delIDs)
-		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
-			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = delIDs.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
-		if len(delIDs.IDs) == 0 {
-			return
-		} else if len(delIDs.IDs) == 1 {
-			query = `
-				DELETE FROM
-					eyecolor_type
-				WHERE
-					id = $1
-			`
-			_, err = db.ExecContext(r.Context(), query, delIDs.IDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			// jsonを返す
-			err = json.NewEncoder(w).Encode(&delIDs)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			return
-		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, delIDs.IDs)
-		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+		// 2件以上の削除は一括削除の権限も確認する
+		if len(delIDs.IDs) > 1 && !rbac.Authorize(w, r, "eyescolor_type", rbac.BulkDelete) {
 			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(delIDs.IDs), query)
-		_, err = db.ExecContext(r.Context(), query, args...)
+		// 削除
+		err = types.Delete(r.Context(), delIDs.IDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
+			logging.Error(r.Context(), "delete", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		// jsonを返す
-		err = json.NewEncoder(w).Encode(&delIDs)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &delIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
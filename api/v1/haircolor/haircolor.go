@@ -1,19 +1,24 @@
 package haircolor
 
 import (
-	"encoding/json"
-	"log"
-	"fmt"
 	"net/http"
 
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
 	validation "github.com/go-ozzo/ozzo-validation"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/jsonapi"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
 )
 
 type HairColor struct {
-	EntryID int64 `db:"entry_id"`
-	ColorID int64 `db:"color_id"`
+	EntryID int64 `db:"entry_id" json:"entry_id"`
+	ColorID int64 `db:"color_id" json:"color_id"`
 }
 
 func (h *HairColor) Validate() error {
@@ -43,221 +48,197 @@ func (i *IDs) Validate() error {
 	)
 }
 
+// attribute はstoreで扱う形に変換する
+func (h HairColor) attribute() model.Attribute {
+	return model.Attribute{EntryID: h.EntryID, ValueID: h.ColorID}
+}
+
+func toAttributes(rows []HairColor) []model.Attribute {
+	attrs := make([]model.Attribute, len(rows))
+	for i, row := range rows {
+		attrs[i] = row.attribute()
+	}
+	return attrs
+}
+
+func fromAttributes(attrs []model.Attribute) []HairColor {
+	rows := make([]HairColor, len(attrs))
+	for i, attr := range attrs {
+		rows[i] = HairColor{EntryID: attr.EntryID, ColorID: attr.ValueID}
+	}
+	return rows
+}
+
 func Handler(w http.ResponseWriter, r *http.Request) {
-	db, err := sqlx.Open("postgres", "")
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
 	if err != nil {
-		log.Printf("sql.Open error %s", err)
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 	defer db.Close()
+	// 属性テーブル共通のstoreで読み書きする
+	serve(w, r, &store.Attributes{DB: db, Table: model.HairColorTable})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, attributes store.AttributeRepository) {
 	switch r.Method {
 	case http.MethodGet:
-		var hairColorsJson HairColorsJson
-		query := `
-			SELECT
-				entry_id,
-				color_id
-			FROM
-				haircolor
-			WHERE
-				entry_id IN (?)
-		`
-		queryIDs, ok := r.URL.Query()["entry_id"]
-		if !ok {
-			query = `
-				SELECT
-					entry_id,
-					color_id
-				FROM
-					haircolor
-			`
-			// DBから全件取得
-			err := db.SelectContext(r.Context(), &hairColorsJson.HairColors, query)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// json書き込み
-			err = json.NewEncoder(w).Encode(&hairColorsJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			return
-		} else if len(queryIDs) == 1 {
-			query = `
-				SELECT
-					entry_id,
-					color_id
-				FROM
-					haircolor
-				WHERE
-					entry_id = $1
-			`
-			// DBから1件取得
-			err := db.SelectContext(r.Context(), &hairColorsJson.HairColors, query, queryIDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// json書き込み
-			err = json.NewEncoder(w).Encode(&hairColorsJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+		// 権限の確認
+		if !rbac.Authorize(w, r, "haircolor", rbac.Read) {
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, queryIDs)
+		var hairColorsJson HairColorsJson
+		// クエリパラメータからentry_idを取得
+		queryIDs := r.URL.Query()["entry_id"]
+		ids, err := params.Int64s(queryIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(queryIDs), query)
-		err = db.SelectContext(r.Context(), &hairColorsJson.HairColors, query, args...)
+		// entry_idが指定されていない場合は全件取得
+		attrs, total, err := jsonapi.List(r, attributes, ids)
+		hairColorsJson.HairColors = fromAttributes(attrs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
+			logging.Error(r.Context(), "select", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// json書き込み
-		err = json.NewEncoder(w).Encode(&hairColorsJson)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, jsonapi.WithTotal(&hairColorsJson, total))
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPost:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "haircolor", rbac.Create) {
+			return
+		}
 		var hairColorsJson HairColorsJson
-		query := `
-			INSERT INTO haircolor (
-				entry_id,
-				color_id
-			) VALUES (
-				:entry_id,
-				:color_id
-			)
-		`
-		// json読み込み
-		err := json.NewDecoder(r.Body).Decode(&hairColorsJson)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &hairColorsJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = hairColorsJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
 		}
-		for _, hc := range hairColorsJson.HairColors {
-			// jsonバリデーション
-			err = hc.Validate()
+		for _, hairColor := range hairColorsJson.HairColors {
+			err = hairColor.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("validation error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-			}
-			if _, err := db.NamedExecContext(r.Context(), query, hc); err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
 			}
 		}
-		// json書き込み
-		err = json.NewEncoder(w).Encode(&hairColorsJson)
+		// 登録
+		attrs, err := attributes.Create(r.Context(), toAttributes(hairColorsJson.HairColors))
+		hairColorsJson.HairColors = fromAttributes(attrs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "insert", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &hairColorsJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPut:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "haircolor", rbac.Update) {
+			return
+		}
 		var hairColorsJson HairColorsJson
-		query := `
-			UPDATE
-				haircolor
-			SET
-				color_id = :color_id
-			WHERE
-				entry_id = :entry_id
-		`
-		if err := json.NewDecoder(r.Body).Decode(&hairColorsJson); err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+		// リクエストボディを読み込む
+		err := response.Decode(r, &hairColorsJson)
+		if err != nil {
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// jsonバリデーション
-		if err := hairColorsJson.Validate(); err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
+		// リクエストボディのバリデーション
+		err = hairColorsJson.Validate()
+		if err != nil {
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
 		}
-		for _, hc := range hairColorsJson.HairColors {
-			if err := hc.Validate(); err != nil {
-				log.Printf(fmt.Sprintf("validation error: %v", err))
+		for _, hairColor := range hairColorsJson.HairColors {
+			err = hairColor.Validate()
+			if err != nil {
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-			}
-			if _, err := db.NamedExecContext(r.Context(), query, hc); err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
 			}
 		}
-		// json書き込み
-		err := json.NewEncoder(w).Encode(&hairColorsJson)
+		// 更新
+		err = attributes.Update(r.Context(), toAttributes(hairColorsJson.HairColors))
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "update", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &hairColorsJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodDelete:
+		// 権限の確認。ボディを読む前に削除の権限を確認する
+		if !rbac.Authorize(w, r, "haircolor", rbac.Delete) {
+			return
+		}
 		var delIDs IDs
-		if err := json.NewDecoder(r.Body).Decode(&delIDs); err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+		// リクエストボディを読み込む
+		err := response.Decode(r, &delIDs)
+		if err != nil {
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		query := `
-			DELETE FROM
-				haircolor
-			WHERE
-				entry_id IN (?)
-		`
-		// jsonバリデーション
-		err := delIDs.Validate()
+		// リクエストボディのバリデーション
+		err = delIDs.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-		}
-		if len(delIDs.IDs) == 0 {
 			return
-		} else if len(delIDs.IDs) == 1 {
-			query = `
-				DELETE FROM
-					haircolor
-				WHERE
-					entry_id = $1
-			`
-			if _, err := db.ExecContext(r.Context(), query, delIDs.IDs[0]); err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// json書き込み
-			err = json.NewEncoder(w).Encode(&delIDs)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+		}
+		// 2件以上の削除は一括削除の権限も確認する
+		if len(delIDs.IDs) > 1 && !rbac.Authorize(w, r, "haircolor", rbac.BulkDelete) {
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, delIDs.IDs)
+		// 削除
+		err = attributes.Delete(r.Context(), delIDs.IDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(delIDs.IDs), query)
-		if _, err := db.ExecContext(r.Context(), query, args...); err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
+			logging.Error(r.Context(), "delete", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// json書き込み
-		err = json.NewEncoder(w).Encode(&delIDs)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &delIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
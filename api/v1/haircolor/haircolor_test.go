@@ -0,0 +1,97 @@
+package haircolor
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+func newRepo() *store.Memory[model.Attribute, store.AttributeSearch] {
+	attributes := store.NewMemoryAttributes(model.HairColorTable)
+	attributes.Seed(
+		model.Attribute{EntryID: 1, ValueID: 1},
+		model.Attribute{EntryID: 2, ValueID: 2},
+		model.Attribute{EntryID: 3, ValueID: 3},
+	)
+	return attributes
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	attributes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, attributes) }
+
+	var all HairColorsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/haircolor", nil), http.StatusOK, &all)
+	if len(all.HairColors) != 3 {
+		t.Fatalf("HairColors = %+v", all.HairColors)
+	}
+
+	var some HairColorsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/haircolor?entry_id=2", nil), http.StatusOK, &some)
+	if len(some.HairColors) != 1 || some.HairColors[0].EntryID != 2 || some.HairColors[0].ColorID != 2 {
+		t.Fatalf("HairColors = %+v", some.HairColors)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/haircolor?entry_id=x", nil), http.StatusBadRequest, nil)
+}
+
+func TestPost(t *testing.T) {
+	apitest.Setup(t)
+	attributes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, attributes) }
+	body := HairColorsJson{HairColors: []HairColor{{EntryID: 4, ColorID: 1}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPost, "/api/v1/haircolor", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/haircolor", body), http.StatusOK, nil)
+	if _, err := attributes.Get(context.Background(), 4); err != nil {
+		t.Fatal(err)
+	}
+
+	// 主キーが重複する行は登録できない
+	dup := HairColorsJson{HairColors: []HairColor{{EntryID: 1, ColorID: 1}}}
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/haircolor", dup), http.StatusInternalServerError, nil)
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/haircolor", HairColorsJson{}), http.StatusUnprocessableEntity, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/haircolor", HairColorsJson{HairColors: []HairColor{{EntryID: 5}}}), http.StatusUnprocessableEntity, nil)
+}
+
+func TestPut(t *testing.T) {
+	apitest.Setup(t)
+	attributes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, attributes) }
+	body := HairColorsJson{HairColors: []HairColor{{EntryID: 2, ColorID: 5}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPut, "/api/v1/haircolor", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPut, "/api/v1/haircolor", body), http.StatusOK, nil)
+	got, err := attributes.Get(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ValueID != 5 {
+		t.Fatalf("value_id = %d, want 5", got.ValueID)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	apitest.Setup(t)
+	attributes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, attributes) }
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/haircolor", IDs{}), http.StatusUnprocessableEntity, nil)
+	// 権限がない場合はボディを読む前に拒否する
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodDelete, "/api/v1/haircolor", IDs{}), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodDelete, "/api/v1/haircolor", "not ids"), http.StatusForbidden, nil)
+	// editorは一括削除できない
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/haircolor", IDs{IDs: []int64{1, 2}}), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/haircolor", IDs{IDs: []int64{1}}), http.StatusOK, nil)
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodDelete, "/api/v1/haircolor", IDs{IDs: []int64{2, 3}}), http.StatusOK, nil)
+	rows, _ := attributes.List(context.Background(), nil)
+	if len(rows) != 0 {
+		t.Fatalf("rows = %+v, want none", rows)
+	}
+}
@@ -0,0 +1,89 @@
+package haircolortype
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+func newRepo() *store.Memory[model.TypeValue, store.TypeSearch] {
+	types := store.NewMemoryTypes()
+	types.Seed(model.TypeValue{ID: 1, Value: "A1"}, model.TypeValue{ID: 2, Value: "A2"}, model.TypeValue{ID: 3, Value: "A3"})
+	return types
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	types := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, types) }
+
+	var all HairColorTypesJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/haircolor_type", nil), http.StatusOK, &all)
+	if len(all.HairColorTypes) != 3 || all.HairColorTypes[0].Color != "A1" {
+		t.Fatalf("HairColorTypes = %+v", all.HairColorTypes)
+	}
+
+	var some HairColorTypesJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/haircolor_type?id=2", nil), http.StatusOK, &some)
+	if len(some.HairColorTypes) != 1 || some.HairColorTypes[0].ID != 2 {
+		t.Fatalf("HairColorTypes = %+v", some.HairColorTypes)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/haircolor_type?id=x", nil), http.StatusBadRequest, nil)
+}
+
+func TestPost(t *testing.T) {
+	apitest.Setup(t)
+	types := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, types) }
+	body := HairColorTypesJson{HairColorTypes: []HairColorType{{Color: "A4"}}}
+
+	// *_typeはadminだけが書き込める
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/haircolor_type", body), http.StatusForbidden, nil)
+
+	var created HairColorTypesJson
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodPost, "/api/v1/haircolor_type", body), http.StatusOK, &created)
+	if len(created.HairColorTypes) != 1 || created.HairColorTypes[0].ID != 4 {
+		t.Fatalf("HairColorTypes = %+v", created.HairColorTypes)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodPost, "/api/v1/haircolor_type", HairColorTypesJson{}), http.StatusUnprocessableEntity, nil)
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodPost, "/api/v1/haircolor_type", HairColorTypesJson{HairColorTypes: []HairColorType{{}}}), http.StatusUnprocessableEntity, nil)
+}
+
+func TestPut(t *testing.T) {
+	apitest.Setup(t)
+	types := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, types) }
+	body := HairColorTypesJson{HairColorTypes: []HairColorType{{ID: 2, Color: "B2"}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPut, "/api/v1/haircolor_type", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodPut, "/api/v1/haircolor_type", body), http.StatusOK, nil)
+	got, err := types.Get(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Value != "B2" {
+		t.Fatalf("value = %q, want B2", got.Value)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	apitest.Setup(t)
+	types := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, types) }
+
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodDelete, "/api/v1/haircolor_type", IDs{}), http.StatusUnprocessableEntity, nil)
+	// 権限がない場合はボディを読む前に拒否する
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/haircolor_type", IDs{}), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/haircolor_type", IDs{IDs: []int64{1}}), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodDelete, "/api/v1/haircolor_type", IDs{IDs: []int64{1, 3}}), http.StatusOK, nil)
+	rows, _ := types.List(context.Background(), nil)
+	if len(rows) != 1 || rows[0].ID != 2 {
+		t.Fatalf("rows = %+v", rows)
+	}
+}
@@ -1,19 +1,24 @@
 package hairlength
 
 import (
-	"encoding/json"
-	"log"
-	"fmt"
 	"net/http"
 
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
 	validation "github.com/go-ozzo/ozzo-validation"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/jsonapi"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
 )
 
 type HairLength struct {
-	EntryID          int64 `db:"entry_id"`
-	HairLengthTypeID int64 `db:"hairlength_type_id"`
+	EntryID          int64 `db:"entry_id" json:"entry_id"`
+	HairLengthTypeID int64 `db:"hairlength_type_id" json:"hairlength_type_id"`
 }
 
 func (h *HairLength) Validate() error {
@@ -43,223 +48,197 @@ func (i *IDs) Validate() error {
 	)
 }
 
+// attribute はstoreで扱う形に変換する
+func (h HairLength) attribute() model.Attribute {
+	return model.Attribute{EntryID: h.EntryID, ValueID: h.HairLengthTypeID}
+}
+
+func toAttributes(rows []HairLength) []model.Attribute {
+	attrs := make([]model.Attribute, len(rows))
+	for i, row := range rows {
+		attrs[i] = row.attribute()
+	}
+	return attrs
+}
+
+func fromAttributes(attrs []model.Attribute) []HairLength {
+	rows := make([]HairLength, len(attrs))
+	for i, attr := range attrs {
+		rows[i] = HairLength{EntryID: attr.EntryID, HairLengthTypeID: attr.ValueID}
+	}
+	return rows
+}
+
 func Handler(w http.ResponseWriter, r *http.Request) {
-	db, err := sqlx.Open("postgres", "")
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
 	if err != nil {
-		log.Printf("sql.Open error %s", err)
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 	defer db.Close()
+	// 属性テーブル共通のstoreで読み書きする
+	serve(w, r, &store.Attributes{DB: db, Table: model.HairLengthTable})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, attributes store.AttributeRepository) {
 	switch r.Method {
 	case http.MethodGet:
-		var hairLengthsJson HairLengthsJson
-		query := `
-			SELECT
-				entry_id,
-				hairlength_type_id
-			FROM
-				hairlength
-			WHERE
-				entry_id IN (?)
-		`
-		queryIDs, ok := r.URL.Query()["entry_id"]
-		if !ok {
-			query = `
-				SELECT
-					entry_id,
-					hairlength_type_id
-				FROM
-					hairlength
-			`
-			err := db.SelectContext(r.Context(), &hairLengthsJson.HairLengths, query)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, "db error", http.StatusInternalServerError)
-			}
-			// jsonを返す
-			err = json.NewEncoder(w).Encode(&hairLengthsJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, "json encode error", http.StatusInternalServerError)
-			}
-			return
-		} else if len(queryIDs) == 1 {
-			query = `
-				SELECT
-					entry_id,
-					hairlength_type_id
-				FROM
-					hairlength
-				WHERE
-					entry_id = $1
-			`
-			err := db.SelectContext(r.Context(), &hairLengthsJson.HairLengths, query, queryIDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, "db error", http.StatusInternalServerError)
-			}
-			// jsonを返す
-			err = json.NewEncoder(w).Encode(&hairLengthsJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, "json encode error", http.StatusInternalServerError)
-			}
+		// 権限の確認
+		if !rbac.Authorize(w, r, "hairlength", rbac.Read) {
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, queryIDs)
+		var hairLengthsJson HairLengthsJson
+		// クエリパラメータからentry_idを取得
+		queryIDs := r.URL.Query()["entry_id"]
+		ids, err := params.Int64s(queryIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
-			http.Error(w, "db error", http.StatusInternalServerError)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(queryIDs), query)
-		err = db.SelectContext(r.Context(), &hairLengthsJson.HairLengths, query, args...)
+		// entry_idが指定されていない場合は全件取得
+		attrs, total, err := jsonapi.List(r, attributes, ids)
+		hairLengthsJson.HairLengths = fromAttributes(attrs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
-			http.Error(w, "db error", http.StatusInternalServerError)
+			logging.Error(r.Context(), "select", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// jsonを返す
-		err = json.NewEncoder(w).Encode(&hairLengthsJson)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, jsonapi.WithTotal(&hairLengthsJson, total))
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
-			http.Error(w, "json encode error", http.StatusInternalServerError)
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPost:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "hairlength", rbac.Create) {
+			return
+		}
 		var hairLengthsJson HairLengthsJson
-		query := `
-			INSERT INTO hairlength (
-				entry_id,
-				hairlength_type_id
-			) VALUES (
-				:entry_id,
-				:hairlength_type_id
-			)
-		`
-		if err := json.NewDecoder(r.Body).Decode(&hairLengthsJson); err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
-			http.Error(w, "json decode error", http.StatusBadRequest)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &hairLengthsJson)
+		if err != nil {
+			logging.Error(r.Context(), "decode", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// jsonバリデーション
-		err := hairLengthsJson.Validate()
+		// リクエストボディのバリデーション
+		err = hairLengthsJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
-			http.Error(w, "validation error", http.StatusUnprocessableEntity)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
 		}
-		for _, hl := range hairLengthsJson.HairLengths {
-			// jsonのバリデーションを通過したデータをDBに登録
-			err = hl.Validate()
+		for _, hairLength := range hairLengthsJson.HairLengths {
+			err = hairLength.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("validation error: %v", err))
-				http.Error(w, "validation error", http.StatusUnprocessableEntity)
-			}
-			if _, err := db.NamedExecContext(r.Context(), query, hl); err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, "db error", http.StatusInternalServerError)
+				logging.Error(r.Context(), "validation", err)
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
 			}
 		}
-		// jsonを返す
-		err = json.NewEncoder(w).Encode(&hairLengthsJson)
+		// 登録
+		attrs, err := attributes.Create(r.Context(), toAttributes(hairLengthsJson.HairLengths))
+		hairLengthsJson.HairLengths = fromAttributes(attrs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
-			http.Error(w, "json encode error", http.StatusInternalServerError)
+			logging.Error(r.Context(), "insert", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &hairLengthsJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPut:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "hairlength", rbac.Update) {
+			return
+		}
 		var hairLengthsJson HairLengthsJson
-		query := `
-			UPDATE
-				hairlength
-			SET
-				hairlength_type_id = :hairlength_type_id
-			WHERE
-				entry_id = :entry_id
-		`
-		// jsonをデコード
-		if err := json.NewDecoder(r.Body).Decode(&hairLengthsJson); err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
-			http.Error(w, "json decode error", http.StatusBadRequest)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &hairLengthsJson)
+		if err != nil {
+			logging.Error(r.Context(), "decode", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// jsonバリデーション
-		err := hairLengthsJson.Validate()
+		// リクエストボディのバリデーション
+		err = hairLengthsJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
-			http.Error(w, "validation error", http.StatusUnprocessableEntity)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
 		}
-		for _, hl := range hairLengthsJson.HairLengths {
-			// jsonのバリデーションを通過したデータをDBに登録
-			err = hl.Validate()
+		for _, hairLength := range hairLengthsJson.HairLengths {
+			err = hairLength.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("validation error: %v", err))
-				http.Error(w, "validation error", http.StatusUnprocessableEntity)
-			}
-			if _, err := db.NamedExecContext(r.Context(), query, hl); err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, "db error", http.StatusInternalServerError)
+				logging.Error(r.Context(), "validation", err)
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
 			}
 		}
-		// jsonを返す
-		err = json.NewEncoder(w).Encode(&hairLengthsJson)
+		// 更新
+		err = attributes.Update(r.Context(), toAttributes(hairLengthsJson.HairLengths))
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
-			http.Error(w, "json encode error", http.StatusInternalServerError)
-		}
-	case http.MethodDelete:
-		var delIDs IDs
-		query := `
-			DELETE FROM
-				hairlength
-			WHERE
-				entry_id IN (?)
-		`
-		// jsonをデコード
-		if err := json.NewDecoder(r.Body).Decode(&delIDs); err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
-			http.Error(w, "json decode error", http.StatusBadRequest)
+			logging.Error(r.Context(), "update", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// jsonバリデーション
-		err := delIDs.Validate()
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &hairLengthsJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
-			http.Error(w, "validation error", http.StatusUnprocessableEntity)
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		if len(delIDs.IDs) == 0 {
+	case http.MethodDelete:
+		// 権限の確認。ボディを読む前に削除の権限を確認する
+		if !rbac.Authorize(w, r, "hairlength", rbac.Delete) {
 			return
-		} else if len(delIDs.IDs) == 1 {
-			query = `
-				DELETE FROM
-					hairlength
-				WHERE
-					entry_id = $1
-			`
-			_, err := db.ExecContext(r.Context(), query, delIDs.IDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			err = json.NewEncoder(w).Encode(&delIDs)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+		}
+		var delIDs IDs
+		// リクエストボディを読み込む
+		err := response.Decode(r, &delIDs)
+		if err != nil {
+			logging.Error(r.Context(), "decode", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, delIDs.IDs)
+		// リクエストボディのバリデーション
+		err = delIDs.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(delIDs.IDs), query)
-		_, err = db.ExecContext(r.Context(), query, args...)
+		// 2件以上の削除は一括削除の権限も確認する
+		if len(delIDs.IDs) > 1 && !rbac.Authorize(w, r, "hairlength", rbac.BulkDelete) {
+			return
+		}
+		// 削除
+		err = attributes.Delete(r.Context(), delIDs.IDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
+			logging.Error(r.Context(), "delete", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// レスポンスの作成
-		err = json.NewEncoder(w).Encode(&delIDs)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &delIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
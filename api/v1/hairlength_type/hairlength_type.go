@@ -1,19 +1,24 @@
 package hairlengthtype
 
 import (
-	"encoding/json"
-	"log"
-	"fmt"
 	"net/http"
 
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
 	validation "github.com/go-ozzo/ozzo-validation"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/jsonapi"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
 )
 
 type HairLengthType struct {
-	ID     int64 `db:"id"`
-	Length string `db:"length"`
+	ID     int64 `db:"id" json:"id"`
+	Length string `db:"length" json:"length"`
 }
 
 func (h *HairLengthType) Validate() error {
@@ -42,248 +47,195 @@ func (i *IDs) Validate() error {
 	)
 }
 
+// value はstoreで扱う形に変換する
+func (h HairLengthType) value() model.TypeValue {
+	return model.TypeValue{ID: h.ID, Value: h.Length}
+}
+
+func toValues(rows []HairLengthType) []model.TypeValue {
+	values := make([]model.TypeValue, len(rows))
+	for i, row := range rows {
+		values[i] = row.value()
+	}
+	return values
+}
+
+func fromValues(values []model.TypeValue) []HairLengthType {
+	rows := make([]HairLengthType, len(values))
+	for i, value := range values {
+		rows[i] = HairLengthType{ID: value.ID, Length: value.Value}
+	}
+	return rows
+}
+
 func Handler(w http.ResponseWriter, r *http.Request) {
-	db, err := sqlx.Open("postgres", "")
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
 	if err != nil {
-		log.Printf("sql.Open error %s", err)
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 	defer db.Close()
+	// RPCと同じstoreで読み書きする
+	serve(w, r, &store.Types{DB: db, Table: model.HairLengthTypeTable})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, types store.TypeRepository) {
 	switch r.Method {
 	case http.MethodGet:
-		var hairLengthTypesJson HairLengthTypesJson
-		query := `
-			SELECT
-				id,
-				length
-			FROM
-				hairlength_type
-			WHERE
-				id IN (?)
-		`
-		queryIDs, ok := r.URL.Query()["id"]
-		if !ok {
-			query = `
-				SELECT
-					id,
-					length
-				FROM
-					hairlength_type
-			`
-			err := db.SelectContext(r.Context(), &hairLengthTypesJson.HairLengthTypes, query)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			// json書き込み
-			err = json.NewEncoder(w).Encode(&hairLengthTypesJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			return
-		} else if len(queryIDs) == 1 {
-			query = `
-				SELECT
-					id,
-					length
-				FROM
-					hairlength_type
-				WHERE
-					id = $1
-			`
-			err := db.SelectContext(r.Context(), &hairLengthTypesJson.HairLengthTypes, query, queryIDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			// json書き込み
-			err = json.NewEncoder(w).Encode(&hairLengthTypesJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
+		// 権限の確認
+		if !rbac.Authorize(w, r, "hairlength_type", rbac.Read) {
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, queryIDs)
+		var hairLengthTypesJson HairLengthTypesJson
+		// クエリパラメータからidを取得
+		queryIDs := r.URL.Query()["id"]
+		ids, err := params.Int64s(queryIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(queryIDs), query)
-		err = db.SelectContext(r.Context(), &hairLengthTypesJson.HairLengthTypes, query, args...)
+		// idが指定されていない場合は全件取得
+		values, total, err := jsonapi.List(r, types, ids)
+		hairLengthTypesJson.HairLengthTypes = fromValues(values)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
+			logging.Error(r.Context(), "select", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		// json書き込み
-		err = json.NewEncoder(w).Encode(&hairLengthTypesJson)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, jsonapi.WithTotal(&hairLengthTypesJson, total))
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	case http.MethodPost:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "hairlength_type", rbac.Create) {
+			return
+		}
 		var hairLengthTypesJson HairLengthTypesJson
-		query := `
-			INSERT INTO hairlength_type (
-				length
-			) VALUES (
-				:length
-			)
-		`
-		// json読み込み
-		err := json.NewDecoder(r.Body).Decode(&hairLengthTypesJson)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &hairLengthTypesJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = hairLengthTypesJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("json validate error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
 		for _, hairLengthType := range hairLengthTypesJson.HairLengthTypes {
-			// jsonバリデーション
 			err = hairLengthType.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("json validate error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 				return
 			}
-			_, err = db.NamedExecContext(r.Context(), query, hairLengthType)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
 		}
-		// json書き込み
-		err = json.NewEncoder(w).Encode(&hairLengthTypesJson)
+		// 登録
+		values, err := types.Create(r.Context(), toValues(hairLengthTypesJson.HairLengthTypes))
+		hairLengthTypesJson.HairLengthTypes = fromValues(values)
+		if err != nil {
+			logging.Error(r.Context(), "insert", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &hairLengthTypesJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	case http.MethodPut:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "hairlength_type", rbac.Update) {
+			return
+		}
 		var hairLengthTypesJson HairLengthTypesJson
-		query := `
-			UPDATE
-				hairlength_type
-			SET
-				length = :length
-			WHERE
-				id = :id
-		`
-		// json読み込み
-		err := json.NewDecoder(r.Body).Decode(&hairLengthTypesJson)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &hairLengthTypesJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = hairLengthTypesJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("json validate error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
 		for _, hairLengthType := range hairLengthTypesJson.HairLengthTypes {
-			// jsonバリデーション
 			err = hairLengthType.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("json validate error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 				return
 			}
-			_, err = db.NamedExecContext(r.Context(), query, hairLengthType)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
 		}
-		// json書き込み
-		err = json.NewEncoder(w).Encode(&hairLengthTypesJson)
+		// 更新
+		err = types.Update(r.Context(), toValues(hairLengthTypesJson.HairLengthTypes))
+		if err != nil {
+			logging.Error(r.Context(), "update", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &hairLengthTypesJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	case http.MethodDelete:
+		// 権限の確認。ボディを読む前に削除の権限を確認する
+		if !rbac.Authorize(w, r, "hairlength_type", rbac.Delete) {
+			return
+		}
 		var delIDs IDs
-		query := `
-			DELETE FROM
-				hairlength_type
-			WHERE
-				id IN (?)
-		`
-		// json読み込み
-		err := json.NewDecoder(r.Body).Decode(&delIDs)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &delIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = delIDs.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("json validate error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
-		if len(delIDs.IDs) == 0 {
-			return
-		} else if len(delIDs.IDs) == 1 {
-			query = `
-				DELETE FROM
-					hairlength_type
-				WHERE
-					id = $1
-			`
-			_, err = db.ExecContext(r.Context(), query, delIDs.IDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			err = json.NewEncoder(w).Encode(&delIDs)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+		// 2件以上の削除は一括削除の権限も確認する
+		if len(delIDs.IDs) > 1 && !rbac.Authorize(w, r, "hairlength_type", rbac.BulkDelete) {
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, delIDs.IDs)
+		// 削除
+		err = types.Delete(r.Context(), delIDs.IDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
+			logging.Error(r.Context(), "delete", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(delIDs.IDs), query)
-		_, err = db.ExecContext(r.Context(), query, args...)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &delIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		// json書き込み
-		err = json.NewEncoder(w).Encode(&delIDs)
-		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -291,4 +243,3 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 	}
 }
-
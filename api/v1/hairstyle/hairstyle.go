@@ -1,19 +1,24 @@
 package hairstyle
 
 import (
-	"encoding/json"
-	"log"
-	"fmt"
 	"net/http"
 
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
 	validation "github.com/go-ozzo/ozzo-validation"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/jsonapi"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
 )
 
 type HairStyle struct {
-	EntryID int64 `db:"entry_id"`
-	StyleID int64 `db:"style_id"`
+	EntryID int64 `db:"entry_id" json:"entry_id"`
+	StyleID int64 `db:"style_id" json:"style_id"`
 }
 
 func (h *HairStyle) Validate() error {
@@ -43,225 +48,197 @@ func (i *IDs) Validate() error {
 	)
 }
 
+// attribute はstoreで扱う形に変換する
+func (h HairStyle) attribute() model.Attribute {
+	return model.Attribute{EntryID: h.EntryID, ValueID: h.StyleID}
+}
+
+func toAttributes(rows []HairStyle) []model.Attribute {
+	attrs := make([]model.Attribute, len(rows))
+	for i, row := range rows {
+		attrs[i] = row.attribute()
+	}
+	return attrs
+}
+
+func fromAttributes(attrs []model.Attribute) []HairStyle {
+	rows := make([]HairStyle, len(attrs))
+	for i, attr := range attrs {
+		rows[i] = HairStyle{EntryID: attr.EntryID, StyleID: attr.ValueID}
+	}
+	return rows
+}
+
 func Handler(w http.ResponseWriter, r *http.Request) {
-	db, err := sqlx.Open("postgres", "")
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
 	if err != nil {
-		log.Printf("sql.Open error %s", err)
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 	defer db.Close()
+	// 属性テーブル共通のstoreで読み書きする
+	serve(w, r, &store.Attributes{DB: db, Table: model.HairStyleTable})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, attributes store.AttributeRepository) {
 	switch r.Method {
 	case http.MethodGet:
-		var hairStylesJson HairStylesJson
-		query := `
-			SELECT
-				entry_id,
-				style_id
-			FROM
-				hairstyle
-			WHERE
-				entry_id IN (?)
-		`
-		queryIDs, ok := r.URL.Query()["entry_id"]
-		if !ok {
-			query = `
-				SELECT
-					entry_id,
-					style_id
-				FROM
-					hairstyle
-			`
-			err := db.SelectContext(r.Context(), &hairStylesJson.HairStyles, query)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// json書き込み
-			err = json.NewEncoder(w).Encode(&hairStylesJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			return
-		} else if len(queryIDs) == 1 {
-			query = `
-				SELECT
-					entry_id,
-					style_id
-				FROM
-					hairstyle
-				WHERE
-					entry_id = $1
-			`
-			err := db.SelectContext(r.Context(), &hairStylesJson.HairStyles, query, queryIDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// json書き込み
-			err = json.NewEncoder(w).Encode(&hairStylesJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+		// 権限の確認
+		if !rbac.Authorize(w, r, "hairstyle", rbac.Read) {
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, queryIDs)
+		var hairStylesJson HairStylesJson
+		// クエリパラメータからentry_idを取得
+		queryIDs := r.URL.Query()["entry_id"]
+		ids, err := params.Int64s(queryIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(queryIDs), query)
-		err = db.SelectContext(r.Context(), &hairStylesJson.HairStyles, query, args...)
+		// entry_idが指定されていない場合は全件取得
+		attrs, total, err := jsonapi.List(r, attributes, ids)
+		hairStylesJson.HairStyles = fromAttributes(attrs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
+			logging.Error(r.Context(), "select", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// json書き込み
-		err = json.NewEncoder(w).Encode(&hairStylesJson)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, jsonapi.WithTotal(&hairStylesJson, total))
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPost:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "hairstyle", rbac.Create) {
+			return
+		}
 		var hairStylesJson HairStylesJson
-		query := `
-			INSERT INTO hairstyle (
-				entry_id,
-				style_id
-			) VALUES (
-				:entry_id,
-				:style_id
-			)
-		`
-		// json読み込み
-		if err := json.NewDecoder(r.Body).Decode(&hairStylesJson); err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+		// リクエストボディを読み込む
+		err := response.Decode(r, &hairStylesJson)
+		if err != nil {
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// jsonバリデーション
-		err := hairStylesJson.Validate()
+		// リクエストボディのバリデーション
+		err = hairStylesJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("json validate error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
 		}
-		for _, hs := range hairStylesJson.HairStyles {
-			// jsonバリデーション
-			err = hs.Validate()
+		for _, hairStyle := range hairStylesJson.HairStyles {
+			err = hairStyle.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("json validate error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-			}
-			if _, err := db.NamedExecContext(r.Context(), query, hs); err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
 			}
 		}
-		// json書き込み
-		err = json.NewEncoder(w).Encode(&hairStylesJson)
+		// 登録
+		attrs, err := attributes.Create(r.Context(), toAttributes(hairStylesJson.HairStyles))
+		hairStylesJson.HairStyles = fromAttributes(attrs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "insert", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &hairStylesJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPut:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "hairstyle", rbac.Update) {
+			return
+		}
 		var hairStylesJson HairStylesJson
-		query := `
-			UPDATE
-				hairstyle
-			SET
-				style_id = :style_id
-			WHERE
-				entry_id = :entry_id
-		`
-		// json読み込み
-		if err := json.NewDecoder(r.Body).Decode(&hairStylesJson); err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+		// リクエストボディを読み込む
+		err := response.Decode(r, &hairStylesJson)
+		if err != nil {
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// jsonバリデーション
-		err := hairStylesJson.Validate()
+		// リクエストボディのバリデーション
+		err = hairStylesJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("json validate error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
 		}
-		for _, hs := range hairStylesJson.HairStyles {
-			// jsonバリデーション
-			err = hs.Validate()
+		for _, hairStyle := range hairStylesJson.HairStyles {
+			err = hairStyle.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("json validate error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-			}
-			if _, err := db.NamedExecContext(r.Context(), query, hs); err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
 			}
 		}
-		// json書き込み
-		err = json.NewEncoder(w).Encode(&hairStylesJson)
+		// 更新
+		err = attributes.Update(r.Context(), toAttributes(hairStylesJson.HairStyles))
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "update", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &hairStylesJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodDelete:
+		// 権限の確認。ボディを読む前に削除の権限を確認する
+		if !rbac.Authorize(w, r, "hairstyle", rbac.Delete) {
+			return
+		}
 		var delIDs IDs
-		query := `
-			DELETE FROM
-				hairstyle
-			WHERE
-				entry_id IN (?)
-		`
-		// json読み込み
-		if err := json.NewDecoder(r.Body).Decode(&delIDs); err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+		// リクエストボディを読み込む
+		err := response.Decode(r, &delIDs)
+		if err != nil {
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// jsonバリデーション
-		err := delIDs.Validate()
+		// リクエストボディのバリデーション
+		err = delIDs.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("json validate error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-		}
-		if len(delIDs.IDs) == 0 {
-			return
-		} else if len(delIDs.IDs) == 1 {
-			query = `
-				DELETE FROM
-					hairstyle
-				WHERE
-					entry_id = $1
-			`
-			_, err := db.ExecContext(r.Context(), query, delIDs.IDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// json書き込み
-			err = json.NewEncoder(w).Encode(&delIDs)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, delIDs.IDs)
-		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+		// 2件以上の削除は一括削除の権限も確認する
+		if len(delIDs.IDs) > 1 && !rbac.Authorize(w, r, "hairstyle", rbac.BulkDelete) {
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(delIDs.IDs), query)
-		_, err = db.ExecContext(r.Context(), query, args...)
+		// 削除
+		err = attributes.Delete(r.Context(), delIDs.IDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
+			logging.Error(r.Context(), "delete", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// json書き込み
-		err = json.NewEncoder(w).Encode(&delIDs)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &delIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
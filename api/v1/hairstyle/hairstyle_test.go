@@ -0,0 +1,94 @@
+package hairstyle
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+func newRepo() *store.Memory[model.Attribute, store.AttributeSearch] {
+	attributes := store.NewMemoryAttributes(model.HairStyleTable)
+	attributes.Seed(
+		model.Attribute{EntryID: 1, ValueID: 1},
+		model.Attribute{EntryID: 2, ValueID: 2},
+		model.Attribute{EntryID: 3, ValueID: 3},
+	)
+	return attributes
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	attributes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, attributes) }
+
+	var all HairStylesJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/hairstyle", nil), http.StatusOK, &all)
+	if len(all.HairStyles) != 3 {
+		t.Fatalf("HairStyles = %+v", all.HairStyles)
+	}
+
+	var some HairStylesJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/hairstyle?entry_id=2", nil), http.StatusOK, &some)
+	if len(some.HairStyles) != 1 || some.HairStyles[0].EntryID != 2 || some.HairStyles[0].StyleID != 2 {
+		t.Fatalf("HairStyles = %+v", some.HairStyles)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/hairstyle?entry_id=x", nil), http.StatusBadRequest, nil)
+}
+
+func TestPost(t *testing.T) {
+	apitest.Setup(t)
+	attributes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, attributes) }
+	body := HairStylesJson{HairStyles: []HairStyle{{EntryID: 4, StyleID: 1}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPost, "/api/v1/hairstyle", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/hairstyle", body), http.StatusOK, nil)
+	if _, err := attributes.Get(context.Background(), 4); err != nil {
+		t.Fatal(err)
+	}
+
+	// 主キーが重複する行は登録できない
+	dup := HairStylesJson{HairStyles: []HairStyle{{EntryID: 1, StyleID: 1}}}
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/hairstyle", dup), http.StatusInternalServerError, nil)
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/hairstyle", HairStylesJson{}), http.StatusUnprocessableEntity, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/hairstyle", HairStylesJson{HairStyles: []HairStyle{{EntryID: 5}}}), http.StatusUnprocessableEntity, nil)
+}
+
+func TestPut(t *testing.T) {
+	apitest.Setup(t)
+	attributes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, attributes) }
+	body := HairStylesJson{HairStyles: []HairStyle{{EntryID: 2, StyleID: 5}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPut, "/api/v1/hairstyle", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPut, "/api/v1/hairstyle", body), http.StatusOK, nil)
+	got, err := attributes.Get(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ValueID != 5 {
+		t.Fatalf("value_id = %d, want 5", got.ValueID)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	apitest.Setup(t)
+	attributes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, attributes) }
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/hairstyle", IDs{}), http.StatusUnprocessableEntity, nil)
+	// editorは一括削除できない
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/hairstyle", IDs{IDs: []int64{1, 2}}), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/hairstyle", IDs{IDs: []int64{1}}), http.StatusOK, nil)
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodDelete, "/api/v1/hairstyle", IDs{IDs: []int64{2, 3}}), http.StatusOK, nil)
+	rows, _ := attributes.List(context.Background(), nil)
+	if len(rows) != 0 {
+		t.Fatalf("rows = %+v, want none", rows)
+	}
+}
@@ -1,29 +1,24 @@
 package hekiradarchart
 
 import (
-	"encoding/json"
-	"log"
 	"fmt"
 	"net/http"
 
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
 	validation "github.com/go-ozzo/ozzo-validation"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/jsonapi"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
 )
 
-type HekiRadarChart struct {
-	EntryID int64 `db:"entry_id"`
-	AI      int64 `db:"ai"`
-	NU      int64 `db:"nu"`
-}
-
-func (h *HekiRadarChart) Validate() error {
-	return validation.ValidateStruct(h,
-		validation.Field(&h.EntryID, validation.Required),
-		validation.Field(&h.AI, validation.Required),
-		validation.Field(&h.NU, validation.Required),
-	)
-}
+// HekiRadarChart はheki_radar_chartの行。radar_chartのaiとnuの軸の値と同じ
+type HekiRadarChart = model.HekiRadarChart
 
 type HekiRadarChartsJson struct {
 	HekiRadarCharts []HekiRadarChart `json:"heki_radar_charts"`
@@ -46,254 +41,180 @@ func (i *IDs) Validate() error {
 }
 
 func Handler(w http.ResponseWriter, r *http.Request) {
-	db, err := sqlx.Open("postgres", "")
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
 	if err != nil {
-		log.Printf("sql.Open error %s", err)
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 	defer db.Close()
+	serve(w, r, &store.HekiRadarCharts{DB: db}, &store.RadarAxes{DB: db})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+// aiとnuはradar_axisの軸で、値は登録・更新の前に軸の範囲で確認する
+func serve(w http.ResponseWriter, r *http.Request, charts store.HekiRadarChartRepository, axes store.RadarAxisRepository) {
 	switch r.Method {
 	case http.MethodGet:
-		var hekiRadarChartsJson HekiRadarChartsJson
-		query := `
-			SELECT
-				entry_id,
-				ai,
-				nu
-			FROM
-				heki_radar_chart
-			WHERE
-				entry_id IN (?)
-		`
-		queryIDs, ok := r.URL.Query()["entry_id"]
-		if !ok {
-			query = `
-				SELECT
-					entry_id,
-					ai,
-					nu
-				FROM
-					heki_radar_chart
-			`
-			err := db.SelectContext(r.Context(), &hekiRadarChartsJson.HekiRadarCharts, query)
-			if err != nil {
-				log.Println(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			// json書き込み
-			err = json.NewEncoder(w).Encode(&hekiRadarChartsJson)
-			if err != nil {
-				log.Println(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			return
-		} else if len(queryIDs) == 1 {
-			query = `
-				SELECT
-					entry_id,
-					ai,
-					nu
-				FROM
-					heki_radar_chart
-				WHERE
-					entry_id = $1
-			`
-			err := db.SelectContext(r.Context(), &hekiRadarChartsJson.HekiRadarCharts, query, queryIDs[0])
-			if err != nil {
-				log.Println(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			// json書き込み
-			err = json.NewEncoder(w).Encode(&hekiRadarChartsJson)
-			if err != nil {
-				log.Println(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
+		// 権限の確認
+		if !rbac.Authorize(w, r, "heki_radar_chart", rbac.Read) {
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, queryIDs)
+		var hekiRadarChartsJson HekiRadarChartsJson
+		// クエリパラメータからentry_idを取得
+		queryIDs := r.URL.Query()["entry_id"]
+		ids, err := params.Int64s(queryIDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("db error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(queryIDs), query)
-		err = db.SelectContext(r.Context(), &hekiRadarChartsJson.HekiRadarCharts, query, args...)
+		// entry_idが指定されていない場合は全件取得
+		var total int
+		hekiRadarChartsJson.HekiRadarCharts, total, err = jsonapi.List(r, charts, ids)
 		if err != nil {
-			log.Println(fmt.Sprintf("db error: %v", err))
+			logging.Error(r.Context(), "select", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		// json書き込み
-		err = json.NewEncoder(w).Encode(&hekiRadarChartsJson)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, jsonapi.WithTotal(&hekiRadarChartsJson, total))
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	case http.MethodPost:
-		var HekiRadarChartsJson HekiRadarChartsJson
-		query := `
-			INSERT INTO heki_radar_chart (
-				entry_id,
-				ai,
-				nu
-			) VALUES (
-				:entry_id,
-				:ai,
-				:nu
-			)
-		`
-		err := json.NewDecoder(r.Body).Decode(&HekiRadarChartsJson)
+		// 権限の確認
+		if !rbac.Authorize(w, r, "heki_radar_chart", rbac.Create) {
+			return
+		}
+		var hekiRadarChartsJson HekiRadarChartsJson
+		// リクエストボディを読み込む
+		err := response.Decode(r, &hekiRadarChartsJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		// jsonバリデーション
-		err = HekiRadarChartsJson.Validate()
+		// リクエストボディのバリデーション
+		err = hekiRadarChartsJson.Validate()
 		if err != nil {
-			log.Println(fmt.Sprintf("json validate error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
-		for _, hrc := range HekiRadarChartsJson.HekiRadarCharts {
-			// jsonバリデーション
-			err = hrc.Validate()
+		for _, chart := range hekiRadarChartsJson.HekiRadarCharts {
+			err = chart.Validate()
 			if err != nil {
-				log.Println(fmt.Sprintf("json validate error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 				return
 			}
-			_, err = db.NamedExecContext(r.Context(), query, hrc)
-			if err != nil {
-				log.Println(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
 		}
-		// json書き込み
-		err = json.NewEncoder(w).Encode(&HekiRadarChartsJson)
+		// 軸の範囲の確認
+		if !checkValues(w, r, axes, hekiRadarChartsJson.HekiRadarCharts) {
+			return
+		}
+		// 登録
+		hekiRadarChartsJson.HekiRadarCharts, err = charts.Create(r.Context(), hekiRadarChartsJson.HekiRadarCharts)
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "insert", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &hekiRadarChartsJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	case http.MethodPut:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "heki_radar_chart", rbac.Update) {
+			return
+		}
 		var hekiRadarChartsJson HekiRadarChartsJson
-		query := `
-			UPDATE
-				heki_radar_chart
-			SET
-				ai = :ai,
-				nu = :nu
-			WHERE
-				entry_id = :entry_id
-		`
-		err := json.NewDecoder(r.Body).Decode(&hekiRadarChartsJson)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &hekiRadarChartsJson)
 		if err != nil {
-			log.Println(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = hekiRadarChartsJson.Validate()
 		if err != nil {
-			log.Println(fmt.Sprintf("json validate error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
-		for _, hrc := range hekiRadarChartsJson.HekiRadarCharts {
-			// jsonバリデーション
-			err = hrc.Validate()
+		for _, chart := range hekiRadarChartsJson.HekiRadarCharts {
+			err = chart.Validate()
 			if err != nil {
-				log.Println(fmt.Sprintf("json validate error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 				return
 			}
-			_, err := db.NamedExecContext(r.Context(), query, hrc)
-			if err != nil {
-				log.Println(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
 		}
-		// json書き込み
-		err = json.NewEncoder(w).Encode(&hekiRadarChartsJson)
+		// 軸の範囲の確認
+		if !checkValues(w, r, axes, hekiRadarChartsJson.HekiRadarCharts) {
+			return
+		}
+		// 更新
+		err = charts.Update(r.Context(), hekiRadarChartsJson.HekiRadarCharts)
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "update", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &hekiRadarChartsJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	case http.MethodDelete:
+		// 権限の確認。ボディを読む前に削除の権限を確認する
+		if !rbac.Authorize(w, r, "heki_radar_chart", rbac.Delete) {
+			return
+		}
 		var delIDs IDs
-		query := `
-			DELETE FROM
-				heki_radar_chart
-			WHERE
-				entry_id IN (?)
-		`
-		err := json.NewDecoder(r.Body).Decode(&delIDs)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &delIDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = delIDs.Validate()
 		if err != nil {
-			log.Println(fmt.Sprintf("json validate error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
-		if len(delIDs.IDs) == 0 {
-			return
-		} else if len(delIDs.IDs) == 1 {
-			query = `
-				DELETE FROM
-					heki_radar_chart
-				WHERE
-					entry_id = $1
-			`
-			_, err := db.ExecContext(r.Context(), query, delIDs.IDs[0])
-			if err != nil {
-				log.Println(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			// json書き込み
-			err = json.NewEncoder(w).Encode(&delIDs)
-			if err != nil {
-				log.Println(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			return
-		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, delIDs.IDs)
-		if err != nil {
-			log.Println(fmt.Sprintf("db error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+		// 2件以上の削除は一括削除の権限も確認する
+		if len(delIDs.IDs) > 1 && !rbac.Authorize(w, r, "heki_radar_chart", rbac.BulkDelete) {
 			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(delIDs.IDs), query)
-		_, err = db.ExecContext(r.Context(), query, args...)
+		// 削除
+		err = charts.Delete(r.Context(), delIDs.IDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("db error: %v", err))
+			logging.Error(r.Context(), "delete", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		// json書き込み
-		err = json.NewEncoder(w).Encode(&delIDs)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &delIDs)
 		if err != nil {
-			log.Println(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -301,3 +222,23 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 	}
 }
+
+// checkValues はaiとnuの値をradar_axisの範囲で確認する。範囲の外の場合は422を書き込んでfalseを返す
+func checkValues(w http.ResponseWriter, r *http.Request, axes store.RadarAxisRepository, charts []HekiRadarChart) bool {
+	all, err := axes.List(r.Context(), nil)
+	if err != nil {
+		logging.Error(r.Context(), "select", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	for _, chart := range charts {
+		err = model.CheckRadarValues(all, map[string]int64{model.RadarAxisAI: chart.AI, model.RadarAxisNU: chart.NU})
+		if err != nil {
+			err = fmt.Errorf("entry %d: %w", chart.EntryID, err)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+package hekiradarchart
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/dbtest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// newAxes はaiとnuの軸(0〜10)を作る
+func newAxes() *store.Memory[model.RadarAxis, store.RadarAxisSearch] {
+	axes := store.NewMemoryRadarAxes()
+	axes.Seed(
+		model.RadarAxis{ID: 1, Key: model.RadarAxisAI, LabelJa: "ai", LabelEn: "ai", Min: 0, Max: 10, DisplayOrder: 1},
+		model.RadarAxis{ID: 2, Key: model.RadarAxisNU, LabelJa: "nu", LabelEn: "nu", Min: 0, Max: 10, DisplayOrder: 2},
+	)
+	return axes
+}
+
+func newRepo() *store.Memory[model.HekiRadarChart, store.HekiRadarChartSearch] {
+	charts := store.NewMemoryHekiRadarCharts()
+	charts.Seed(
+		HekiRadarChart{EntryID: 1, AI: 1, NU: 5},
+		HekiRadarChart{EntryID: 2, AI: 3, NU: 3},
+		HekiRadarChart{EntryID: 3, AI: 5, NU: 1},
+	)
+	return charts
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	charts := newRepo()
+	axes := newAxes()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, charts, axes) }
+
+	var all HekiRadarChartsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/heki_radar_chart", nil), http.StatusOK, &all)
+	if len(all.HekiRadarCharts) != 3 {
+		t.Fatalf("charts = %+v", all.HekiRadarCharts)
+	}
+
+	var some HekiRadarChartsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/heki_radar_chart?entry_id=3", nil), http.StatusOK, &some)
+	if len(some.HekiRadarCharts) != 1 || some.HekiRadarCharts[0].AI != 5 {
+		t.Fatalf("charts = %+v", some.HekiRadarCharts)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/heki_radar_chart?entry_id=x", nil), http.StatusBadRequest, nil)
+}
+
+func TestPost(t *testing.T) {
+	apitest.Setup(t)
+	charts := newRepo()
+	axes := newAxes()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, charts, axes) }
+	body := HekiRadarChartsJson{HekiRadarCharts: []HekiRadarChart{{EntryID: 4, AI: 2, NU: 4}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPost, "/api/v1/heki_radar_chart", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/heki_radar_chart", body), http.StatusOK, nil)
+	if _, err := charts.Get(context.Background(), 4); err != nil {
+		t.Fatal(err)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/heki_radar_chart", HekiRadarChartsJson{}), http.StatusUnprocessableEntity, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/heki_radar_chart", HekiRadarChartsJson{HekiRadarCharts: []HekiRadarChart{{EntryID: 5}}}), http.StatusUnprocessableEntity, nil)
+	// 軸の範囲の外
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/heki_radar_chart", HekiRadarChartsJson{HekiRadarCharts: []HekiRadarChart{{EntryID: 5, AI: 11, NU: 1}}}), http.StatusUnprocessableEntity, nil)
+}
+
+func TestPut(t *testing.T) {
+	apitest.Setup(t)
+	charts := newRepo()
+	axes := newAxes()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, charts, axes) }
+	body := HekiRadarChartsJson{HekiRadarCharts: []HekiRadarChart{{EntryID: 2, AI: 4, NU: 2}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPut, "/api/v1/heki_radar_chart", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPut, "/api/v1/heki_radar_chart", body), http.StatusOK, nil)
+	got, err := charts.Get(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AI != 4 || got.NU != 2 {
+		t.Fatalf("chart = %+v", got)
+	}
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPut, "/api/v1/heki_radar_chart", HekiRadarChartsJson{HekiRadarCharts: []HekiRadarChart{{EntryID: 2, AI: 4, NU: -1}}}), http.StatusUnprocessableEntity, nil)
+}
+
+// TestPutWithoutRows はradar_valueに行がないentryへのPUTで値を登録することを確認する
+func TestPutWithoutRows(t *testing.T) {
+	apitest.Setup(t)
+	db := dbtest.Open(t)
+	dbtest.Exec(t, db, `INSERT INTO source (id, name, url, type) VALUES (1, 'source', 'https://example.com', 'web')`)
+	dbtest.Exec(t, db, `INSERT INTO entry (id, source_id, name, image, content) VALUES (1, 1, 'first', '', ''), (2, 1, 'second', '', '')`)
+	dbtest.Exec(t, db, `INSERT INTO radar_value (entry_id, axis_id, value) SELECT 1, id, 10 FROM radar_axis`)
+	charts := &store.HekiRadarCharts{DB: db}
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, charts, &store.RadarAxes{DB: db}) }
+
+	body := HekiRadarChartsJson{HekiRadarCharts: []HekiRadarChart{{EntryID: 1, AI: 20, NU: 30}, {EntryID: 2, AI: 40, NU: 50}}}
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPut, "/api/v1/heki_radar_chart", body), http.StatusOK, nil)
+	rows, err := charts.List(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []HekiRadarChart{{EntryID: 1, AI: 20, NU: 30}, {EntryID: 2, AI: 40, NU: 50}}
+	if len(rows) != len(want) || rows[0] != want[0] || rows[1] != want[1] {
+		t.Fatalf("rows = %+v, want %+v", rows, want)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	apitest.Setup(t)
+	charts := newRepo()
+	axes := newAxes()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, charts, axes) }
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/heki_radar_chart", IDs{}), http.StatusUnprocessableEntity, nil)
+	// editorは一括削除できない
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/heki_radar_chart", IDs{IDs: []int64{1, 2}}), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/heki_radar_chart", IDs{IDs: []int64{1}}), http.StatusOK, nil)
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodDelete, "/api/v1/heki_radar_chart", IDs{IDs: []int64{2, 3}}), http.StatusOK, nil)
+	rows, _ := charts.List(context.Background(), nil)
+	if len(rows) != 0 {
+		t.Fatalf("rows = %+v, want none", rows)
+	}
+}
@@ -0,0 +1,223 @@
+package svg
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+
+	"maguro-alternative/varcel-go/pkg/auth"
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/radar"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// 重ねて描けるentryの上限
+const maxEntries = 8
+
+// Sources は描くのに使うリポジトリ。テストではメモリの実装を渡す
+type Sources struct {
+	Axes    store.RadarAxisRepository
+	Charts  store.RadarChartRepository
+	Entries store.EntryRepository
+}
+
+func Handler(w http.ResponseWriter, r *http.Request) {
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
+	if err != nil {
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer db.Close()
+	serve(w, r, Sources{Axes: &store.RadarAxes{DB: db}, Charts: &store.RadarCharts{DB: db}, Entries: &store.Entries{DB: db}})
+}
+
+// serve はリポジトリを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, sources Sources) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	// 権限の確認
+	if !rbac.Authorize(w, r, "heki_radar_chart", rbac.Read) {
+		return
+	}
+	// クエリパラメータから描くentryと描き方を取得
+	ids, err := params.Int64s(r.URL.Query()["entry_id"])
+	if err == nil && len(ids) == 0 {
+		err = errors.New("entry_id is required")
+	}
+	if err == nil && len(ids) > maxEntries {
+		err = fmt.Errorf("at most %d entry_id can be overlaid", maxEntries)
+	}
+	if err != nil {
+		logging.Error(r.Context(), "validation", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	opts := radar.Options{Size: radar.DefaultSize, Rings: radar.DefaultRings, Theme: radar.Themes[radar.DefaultTheme]}
+	if v := r.URL.Query()["theme"]; len(v) > 0 {
+		theme, ok := radar.Themes[v[0]]
+		if !ok {
+			err = fmt.Errorf("invalid theme %q: want light or dark", v[0])
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		opts.Theme = theme
+	}
+	if v := r.URL.Query()["palette"]; len(v) > 0 {
+		opts.Theme.Palette, err = radar.ParsePalette(v[0])
+		if err != nil {
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if v := r.URL.Query()["size"]; len(v) > 0 {
+		opts.Size, err = strconv.Atoi(v[0])
+		if err != nil || opts.Size < radar.MinSize || opts.Size > radar.MaxSize {
+			err = fmt.Errorf("invalid size %q: want %d-%d", v[0], radar.MinSize, radar.MaxSize)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if v := r.URL.Query()["rings"]; len(v) > 0 {
+		opts.Rings, err = strconv.Atoi(v[0])
+		if err != nil || opts.Rings < 1 || opts.Rings > radar.MaxRings {
+			err = fmt.Errorf("invalid rings %q: want 1-%d", v[0], radar.MaxRings)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	lang := "ja"
+	if v := r.URL.Query()["lang"]; len(v) > 0 {
+		lang = v[0]
+		if lang != "ja" && lang != "en" {
+			err = fmt.Errorf("invalid lang %q: want ja or en", lang)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	format := "svg"
+	if v := r.URL.Query()["format"]; len(v) > 0 {
+		format = v[0]
+		if format != "svg" && format != "png" {
+			err = fmt.Errorf("invalid format %q: want svg or png", format)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	axes, err := sources.Axes.List(r.Context(), nil)
+	if err != nil {
+		logging.Error(r.Context(), "select", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	charts, err := sources.Charts.List(r.Context(), ids)
+	if err != nil {
+		logging.Error(r.Context(), "select", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	byEntry := make(map[int64]model.RadarChart, len(charts))
+	for _, c := range charts {
+		byEntry[c.EntryID] = c
+	}
+	entries, err := sources.Entries.List(r.Context(), ids)
+	if err != nil {
+		logging.Error(r.Context(), "select", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	names := make(map[int64]string, len(entries))
+	for _, e := range entries {
+		names[e.ID] = e.Name
+	}
+
+	// 軸はradar_axisの表示する順。指定された順に重ねる。凡例と色もこの順
+	chart := radar.Chart{}
+	for _, a := range axes {
+		label := a.LabelJa
+		if lang == "en" {
+			label = a.LabelEn
+		}
+		chart.Axes = append(chart.Axes, radar.Axis{Label: label, Min: float64(a.Min), Max: float64(a.Max)})
+	}
+	for _, id := range ids {
+		c, ok := byEntry[id]
+		if !ok {
+			http.Error(w, fmt.Sprintf("radar chart for entry %d not found", id), http.StatusNotFound)
+			return
+		}
+		name := names[id]
+		if name == "" {
+			name = fmt.Sprintf("entry %d", id)
+		}
+		series := radar.Series{Label: name}
+		for _, a := range axes {
+			// 値のない軸は最小値として描く
+			v, ok := c.Values[a.Key]
+			if !ok {
+				v = a.Min
+			}
+			series.Values = append(series.Values, float64(v))
+		}
+		chart.Series = append(chart.Series, series)
+	}
+	chart.Title = chart.Series[0].Label
+	for _, s := range chart.Series[1:] {
+		chart.Title += " / " + s.Label
+	}
+
+	var body []byte
+	contentType := "image/svg+xml"
+	if format == "png" {
+		contentType = "image/png"
+		body, err = radar.PNG(chart, opts)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	} else {
+		body = radar.SVG(chart, opts)
+	}
+	// 値や名前が変わると画像も変わるため、内容のハッシュをETagにする
+	h := fnv.New64a()
+	h.Write(body)
+	etag := fmt.Sprintf(`"%x"`, h.Sum64())
+	w.Header().Set("ETag", etag)
+	// 認証したリクエストの結果は共有のキャッシュに置かせない
+	if auth.FromContext(r.Context()) != nil {
+		w.Header().Set("Cache-Control", "private, max-age=300")
+	} else {
+		w.Header().Set("Cache-Control", "public, max-age=300")
+	}
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	_, err = w.Write(body)
+	if err != nil {
+		logging.Error(r.Context(), "write", err)
+	}
+}
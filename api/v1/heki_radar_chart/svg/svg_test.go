@@ -0,0 +1,125 @@
+package svg
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// newSources は3つの軸と、2件のentryのレーダーチャート、チャートのない1件を作る
+func newSources() Sources {
+	axes := store.NewMemoryRadarAxes()
+	axes.Seed(
+		model.RadarAxis{ID: 1, Key: "ai", LabelJa: "愛", LabelEn: "ai", Min: 0, Max: 100, DisplayOrder: 1},
+		model.RadarAxis{ID: 2, Key: "nu", LabelJa: "ぬ", LabelEn: "nu", Min: 0, Max: 100, DisplayOrder: 2},
+		model.RadarAxis{ID: 3, Key: "moe", LabelJa: "萌え", LabelEn: "moe", Min: 1, Max: 5, DisplayOrder: 3},
+	)
+	charts := store.NewMemoryRadarCharts()
+	charts.Seed(
+		model.RadarChart{EntryID: 1, Values: map[string]int64{"ai": 80, "nu": 20, "moe": 3}},
+		model.RadarChart{EntryID: 2, Values: map[string]int64{"ai": 30, "nu": 90}},
+	)
+	entries := store.NewMemoryEntries(nil)
+	entries.Seed(
+		model.Entry{ID: 1, SourceID: 1, Name: "<a&b>"},
+		model.Entry{ID: 2, SourceID: 1, Name: "b"},
+		model.Entry{ID: 3, SourceID: 1, Name: "c"},
+	)
+	return Sources{Axes: axes, Charts: charts, Entries: entries}
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	sources := newSources()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, sources) }
+
+	w := apitest.Do(t, h, "", http.MethodGet, "/api/v1/heki_radar_chart/svg?entry_id=1", nil)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "image/svg+xml" {
+		t.Fatalf("status = %d, Content-Type = %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	body := w.Body.String()
+	for _, want := range []string{"<svg ", "<title>&lt;a&amp;b&gt;</title>", ">愛</text>", ">ぬ</text>", ">萌え</text>", `fill="#ffffff"`} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("body does not contain %q:\n%s", want, body)
+		}
+	}
+	if w.Header().Get("Cache-Control") != "public, max-age=300" || w.Header().Get("ETag") == "" {
+		t.Fatalf("headers = %v", w.Header())
+	}
+
+	// 同じ内容はETagで304を返す
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/heki_radar_chart/svg?entry_id=1", nil)
+	r.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	cached := httptest.NewRecorder()
+	h(cached, r)
+	if cached.Code != http.StatusNotModified || cached.Body.Len() != 0 {
+		t.Fatalf("status = %d", cached.Code)
+	}
+
+	// 英語の軸の名前
+	en := apitest.Do(t, h, "", http.MethodGet, "/api/v1/heki_radar_chart/svg?entry_id=1&lang=en", nil).Body.String()
+	if !strings.Contains(en, ">moe</text>") {
+		t.Fatalf("body does not contain the english label:\n%s", en)
+	}
+
+	// 重ねると凡例に名前と指定した色が出る
+	overlay := apitest.Do(t, h, "", http.MethodGet, "/api/v1/heki_radar_chart/svg?entry_id=2&entry_id=1&theme=dark&palette=112233,445566", nil).Body.String()
+	for _, want := range []string{">b</text>", ">&lt;a&amp;b&gt;</text>", `fill="#0d1117"`, `stroke="#112233"`, `stroke="#445566"`} {
+		if !strings.Contains(overlay, want) {
+			t.Fatalf("overlay does not contain %q:\n%s", want, overlay)
+		}
+	}
+
+	w = apitest.Do(t, h, "", http.MethodGet, "/api/v1/heki_radar_chart/svg?entry_id=1&format=png&size=200", nil)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "image/png" {
+		t.Fatalf("status = %d, Content-Type = %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	img, err := png.Decode(bytes.NewReader(w.Body.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != 200 || b.Dy() != 200 {
+		t.Fatalf("bounds = %v", b)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/heki_radar_chart/svg?entry_id=3", nil), http.StatusNotFound, nil)
+	for _, target := range []string{
+		"/api/v1/heki_radar_chart/svg",
+		"/api/v1/heki_radar_chart/svg?entry_id=x",
+		"/api/v1/heki_radar_chart/svg?entry_id=1&entry_id=2&entry_id=3&entry_id=4&entry_id=5&entry_id=6&entry_id=7&entry_id=8&entry_id=9",
+		"/api/v1/heki_radar_chart/svg?entry_id=1&theme=neon",
+		"/api/v1/heki_radar_chart/svg?entry_id=1&palette=red",
+		"/api/v1/heki_radar_chart/svg?entry_id=1&size=50",
+		"/api/v1/heki_radar_chart/svg?entry_id=1&rings=0",
+		"/api/v1/heki_radar_chart/svg?entry_id=1&format=gif",
+		"/api/v1/heki_radar_chart/svg?entry_id=1&lang=fr",
+	} {
+		apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, target, nil), http.StatusBadRequest, nil)
+	}
+}
+
+func TestPrivateCache(t *testing.T) {
+	apitest.Setup(t)
+	sources := newSources()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, sources) }
+
+	w := apitest.Do(t, h, "viewer", http.MethodGet, "/api/v1/heki_radar_chart/svg?entry_id=1", nil)
+	if got := w.Header().Get("Cache-Control"); got != "private, max-age=300" {
+		t.Fatalf("Cache-Control = %q", got)
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	apitest.Setup(t)
+	sources := newSources()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, sources) }
+
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodPost, "/api/v1/heki_radar_chart/svg?entry_id=1", nil), http.StatusMethodNotAllowed, nil)
+}
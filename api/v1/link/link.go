@@ -1,34 +1,23 @@
 package link
 
 import (
-	"encoding/json"
-	"log"
-	"fmt"
 	"net/http"
 
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
 	validation "github.com/go-ozzo/ozzo-validation"
-)
 
-type Link struct {
-	ID       int64  `db:"id"`
-	EntryID  int64  `db:"entry_id"`
-	Type     string `db:"type"`
-	URL      string `db:"url"`
-	Nsfw     bool   `db:"nsfw"`
-	Darkness bool   `db:"darkness"`
-}
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/jsonapi"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
+)
 
-func (l *Link) Validate() error {
-	return validation.ValidateStruct(l,
-		validation.Field(&l.EntryID, validation.Required),
-		validation.Field(&l.Type, validation.Required),
-		validation.Field(&l.URL, validation.Required),
-		validation.Field(&l.Nsfw, validation.In(false, true)),
-		validation.Field(&l.Darkness, validation.In(false, true)),
-	)
-}
+// Link はlinkテーブルの行。RPCと共通の型を使う
+type Link = model.Link
 
 type LinksJson struct {
 	Links []Link `json:"links"`
@@ -51,253 +40,174 @@ func (i *IDs) Validate() error {
 }
 
 func Handler(w http.ResponseWriter, r *http.Request) {
-	db, err := sqlx.Open("postgres", "")
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
 	if err != nil {
-		log.Printf("sql.Open error %s", err)
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 	defer db.Close()
+	// RPCと同じstoreで読み書きする
+	serve(w, r, &store.Links{DB: db})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, links store.LinkRepository) {
 	switch r.Method {
 	case http.MethodGet:
-		var linksJson LinksJson
-		query := `
-			SELECT
-				entry_id,
-				type,
-				url,
-				nsfw,
-				darkness
-			FROM
-				link
-			WHERE
-				id IN (?)
-		`
-		queryIDs, ok := r.URL.Query()["id"]
-		if !ok {
-			query = `
-				SELECT
-					entry_id,
-					type,
-					url,
-					nsfw,
-					darkness
-				FROM
-					link
-			`
-			err := db.SelectContext(r.Context(), &linksJson.Links, query)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// レスポンスボディをJSONに変換
-			err = json.NewEncoder(w).Encode(&linksJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			return
-		} else if len(queryIDs) == 1 {
-			query = `
-				SELECT
-					entry_id,
-					type,
-					url,
-					nsfw,
-					darkness
-				FROM
-					link
-				WHERE
-					id = $1
-			`
-			err := db.SelectContext(r.Context(), &linksJson.Links, query, queryIDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("select error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// レスポンスボディをJSONに変換
-			err = json.NewEncoder(w).Encode(&linksJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+		// 権限の確認
+		if !rbac.Authorize(w, r, "link", rbac.Read) {
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, queryIDs)
+		var linksJson LinksJson
+		// クエリパラメータからidを取得
+		queryIDs := r.URL.Query()["id"]
+		ids, err := params.Int64s(queryIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("in query error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(queryIDs), query)
-		err = db.SelectContext(r.Context(), &linksJson.Links, query, args...)
+		// idが指定されていない場合は全件取得
+		var total int
+		linksJson.Links, total, err = jsonapi.List(r, links, ids)
 		if err != nil {
-			log.Printf(fmt.Sprintf("select error: %v", err))
+			logging.Error(r.Context(), "select", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// レスポンスボディをJSONに変換
-		err = json.NewEncoder(w).Encode(&linksJson)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, jsonapi.WithTotal(&linksJson, total))
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPost:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "link", rbac.Create) {
+			return
+		}
 		var linksJson LinksJson
-		query := `
-			INSERT INTO link (
-				entry_id,
-				type,
-				url,
-				nsfw,
-				darkness
-			) VALUES (
-				:entry_id,
-				:type,
-				:url,
-				:nsfw,
-				:darkness
-			)
-		`
-		// リクエストボディをJSONに変換
-		err := json.NewDecoder(r.Body).Decode(&linksJson)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &linksJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		// JSONのバリデーション
+		// リクエストボディのバリデーション
 		err = linksJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
 		for _, link := range linksJson.Links {
-			// リンクのバリデーション
 			err = link.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("validation error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 				return
 			}
-			_, err = db.NamedExecContext(r.Context(), query, link)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
 		}
-		// レスポンスボディをJSONに変換
-		err = json.NewEncoder(w).Encode(&linksJson)
+		// 登録
+		linksJson.Links, err = links.Create(r.Context(), linksJson.Links)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "insert", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &linksJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	case http.MethodPut:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "link", rbac.Update) {
+			return
+		}
 		var linksJson LinksJson
-		query := `
-			UPDATE
-				link
-			SET
-				entry_id = :entry_id,
-				type = :type,
-				url = :url,
-				nsfw = :nsfw,
-				darkness = :darkness
-			WHERE
-				id = :id
-		`
-		// リクエストボディをJSONに変換
-		err := json.NewDecoder(r.Body).Decode(&linksJson)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &linksJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// JSONのバリデーション
+		// リクエストボディのバリデーション
 		err = linksJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
 		}
 		for _, link := range linksJson.Links {
-			// リンクのバリデーション
 			err = link.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("validation error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
 			}
-			_, err = db.NamedExecContext(r.Context(), query, link)
-			if err != nil {
-				log.Printf(fmt.Sprintf("update error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
 		}
-		// レスポンスボディをJSONに変換
-		err = json.NewEncoder(w).Encode(&linksJson)
+		// 更新
+		err = links.Update(r.Context(), linksJson.Links)
+		if err != nil {
+			logging.Error(r.Context(), "update", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &linksJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodDelete:
+		// 権限の確認。ボディを読む前に削除の権限を確認する
+		if !rbac.Authorize(w, r, "link", rbac.Delete) {
+			return
+		}
 		var delIDs IDs
-		query := `
-			DELETE FROM
-				link
-			WHERE
-				id IN (?)
-		`
-		// リクエストボディをJSONに変換
-		err := json.NewDecoder(r.Body).Decode(&delIDs)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &delIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// JSONのバリデーション
+		// リクエストボディのバリデーション
 		err = delIDs.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-		}
-		if len(delIDs.IDs) == 0 {
-			return
-		} else if len(delIDs.IDs) == 1 {
-			query = `
-				DELETE FROM
-					link
-				WHERE
-					id = $1
-			`
-			_, err = db.ExecContext(r.Context(), query, delIDs.IDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("delete error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// レスポンスボディをJSONに変換
-			err = json.NewEncoder(w).Encode(&delIDs)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, delIDs.IDs)
-		if err != nil {
-			log.Printf(fmt.Sprintf("in query error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+		// 2件以上の削除は一括削除の権限も確認する
+		if len(delIDs.IDs) > 1 && !rbac.Authorize(w, r, "link", rbac.BulkDelete) {
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(delIDs.IDs), query)
-		_, err = db.ExecContext(r.Context(), query, args...)
+		// 削除
+		err = links.Delete(r.Context(), delIDs.IDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("delete error: %v", err))
+			logging.Error(r.Context(), "delete", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// レスポンスボディをJSONに変換
-		err = json.NewEncoder(w).Encode(&delIDs)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &delIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
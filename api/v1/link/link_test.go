@@ -0,0 +1,92 @@
+package link
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+func newRepo() *store.Memory[model.Link, store.LinkSearch] {
+	links := store.NewMemoryLinks()
+	links.Seed(
+		Link{ID: 1, EntryID: 1, Type: "pixiv", URL: "https://example.com/1"},
+		Link{ID: 2, EntryID: 1, Type: "x", URL: "https://example.com/2", Nsfw: true},
+		Link{ID: 3, EntryID: 2, Type: "pixiv", URL: "https://example.com/3"},
+	)
+	return links
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	links := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, links) }
+
+	var all LinksJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/link", nil), http.StatusOK, &all)
+	if len(all.Links) != 3 {
+		t.Fatalf("links = %+v", all.Links)
+	}
+
+	var some LinksJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/link?id=2", nil), http.StatusOK, &some)
+	if len(some.Links) != 1 || !some.Links[0].Nsfw {
+		t.Fatalf("links = %+v", some.Links)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/link?id=x", nil), http.StatusBadRequest, nil)
+}
+
+func TestPost(t *testing.T) {
+	apitest.Setup(t)
+	links := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, links) }
+	body := LinksJson{Links: []Link{{EntryID: 2, Type: "x", URL: "https://example.com/4", Darkness: true}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPost, "/api/v1/link", body), http.StatusForbidden, nil)
+
+	var created LinksJson
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/link", body), http.StatusOK, &created)
+	if len(created.Links) != 1 || created.Links[0].ID != 4 {
+		t.Fatalf("links = %+v", created.Links)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/link", LinksJson{}), http.StatusUnprocessableEntity, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/link", LinksJson{Links: []Link{{EntryID: 2}}}), http.StatusUnprocessableEntity, nil)
+}
+
+func TestPut(t *testing.T) {
+	apitest.Setup(t)
+	links := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, links) }
+	body := LinksJson{Links: []Link{{ID: 3, EntryID: 2, Type: "pixiv", URL: "https://example.com/3b"}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPut, "/api/v1/link", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPut, "/api/v1/link", body), http.StatusOK, nil)
+	got, err := links.Get(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.URL != "https://example.com/3b" {
+		t.Fatalf("url = %q", got.URL)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	apitest.Setup(t)
+	links := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, links) }
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/link", IDs{}), http.StatusUnprocessableEntity, nil)
+	// editorは一括削除できない
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/link", IDs{IDs: []int64{1, 2}}), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/link", IDs{IDs: []int64{1}}), http.StatusOK, nil)
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodDelete, "/api/v1/link", IDs{IDs: []int64{2, 3}}), http.StatusOK, nil)
+	rows, _ := links.List(context.Background(), nil)
+	if len(rows) != 0 {
+		t.Fatalf("rows = %+v, want none", rows)
+	}
+}
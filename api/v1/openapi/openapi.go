@@ -0,0 +1,138 @@
+package openapi
+
+//go:generate go run ../../../cmd/openapi -out ../../../docs/openapi.json
+
+import (
+	"net/http"
+	"sync"
+
+	"maguro-alternative/varcel-go/api/v1/bwh"
+	"maguro-alternative/varcel-go/api/v1/bwh/metrics"
+	"maguro-alternative/varcel-go/api/v1/bwh/stats"
+	"maguro-alternative/varcel-go/api/v1/entry"
+	"maguro-alternative/varcel-go/api/v1/entry/random"
+	"maguro-alternative/varcel-go/api/v1/entry/similar"
+	entrytag "maguro-alternative/varcel-go/api/v1/entry_tag"
+	"maguro-alternative/varcel-go/api/v1/entry_tag/recommend"
+	"maguro-alternative/varcel-go/api/v1/eyescolor"
+	eyescolortype "maguro-alternative/varcel-go/api/v1/eyescolor_type"
+	"maguro-alternative/varcel-go/api/v1/haircolor"
+	haircolortype "maguro-alternative/varcel-go/api/v1/haircolor_type"
+	"maguro-alternative/varcel-go/api/v1/hairlength"
+	hairlengthtype "maguro-alternative/varcel-go/api/v1/hairlength_type"
+	"maguro-alternative/varcel-go/api/v1/hairstyle"
+	hairstyletype "maguro-alternative/varcel-go/api/v1/hairstyle_type"
+	hekiradarchart "maguro-alternative/varcel-go/api/v1/heki_radar_chart"
+	"maguro-alternative/varcel-go/api/v1/link"
+	"maguro-alternative/varcel-go/api/v1/personality"
+	personalitytype "maguro-alternative/varcel-go/api/v1/personality_type"
+	radaraxis "maguro-alternative/varcel-go/api/v1/radar_axis"
+	radarchart "maguro-alternative/varcel-go/api/v1/radar_chart"
+	"maguro-alternative/varcel-go/pkg/catalog"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	spec "maguro-alternative/varcel-go/pkg/openapi"
+)
+
+// types はカタログのリソース名とハンドラが送受信する型の対応
+var types = map[string][2]interface{}{
+	"entry":            {entry.EntriesJson{}, entry.IDs{}},
+	"entry_tag":        {entrytag.EntryTagsJson{}, entrytag.IDs{}},
+	"link":             {link.LinksJson{}, link.IDs{}},
+	"bwh":              {bwh.BWHsJson{}, bwh.IDs{}},
+	"haircolor":        {haircolor.HairColorsJson{}, haircolor.IDs{}},
+	"haircolor_type":   {haircolortype.HairColorTypesJson{}, haircolortype.IDs{}},
+	"hairstyle":        {hairstyle.HairStylesJson{}, hairstyle.IDs{}},
+	"hairstyle_type":   {hairstyletype.HairStyleTypesJson{}, hairstyletype.IDs{}},
+	"hairlength":       {hairlength.HairLengthsJson{}, hairlength.IDs{}},
+	"hairlength_type":  {hairlengthtype.HairLengthTypesJson{}, hairlengthtype.IDs{}},
+	"eyescolor":        {eyescolor.EyeColorsJson{}, eyescolor.IDs{}},
+	"eyescolor_type":   {eyescolortype.EyeColorTypesJson{}, eyescolortype.IDs{}},
+	"personality":      {personality.PersonalitiesJson{}, personality.IDs{}},
+	"personality_type": {personalitytype.PersonalityTypesJson{}, personalitytype.IDs{}},
+	"heki_radar_chart": {hekiradarchart.HekiRadarChartsJson{}, hekiradarchart.IDs{}},
+	"radar_axis":       {radaraxis.RadarAxesJson{}, radaraxis.IDs{}},
+	"radar_chart":      {radarchart.RadarChartsJson{}, radarchart.IDs{}},
+}
+
+// endpoints はリソースの下の読み込み専用のURLとGETで返す型の対応
+var endpoints = map[string]map[string]interface{}{
+	"entry": {
+		"similar": similar.SimilarJson{},
+		"random":  random.RandomJson{},
+	},
+	"entry_tag": {
+		"recommend": recommend.RecommendJson{},
+	},
+	"bwh": {
+		"stats":   stats.StatsJson{},
+		"metrics": metrics.MetricsJson{},
+	},
+	"heki_radar_chart": {
+		"svg": spec.Media{Types: []string{"image/svg+xml", "image/png"}},
+	},
+}
+
+var (
+	once   sync.Once
+	doc    []byte
+	docErr error
+)
+
+// Resources はカタログの全てのリソースに型を対応付けて返す
+func Resources() []spec.Resource {
+	resources := make([]spec.Resource, 0, len(catalog.Resources))
+	for _, res := range catalog.Resources {
+		t, ok := types[res.Name]
+		if !ok {
+			continue
+		}
+		resources = append(resources, spec.Resource{Resource: res, Collection: t[0], IDs: t[1], Responses: endpoints[res.Name]})
+	}
+	return resources
+}
+
+// Document はOpenAPIドキュメントを返す。生成は初回のみ行う
+func Document() ([]byte, error) {
+	once.Do(func() {
+		doc, docErr = spec.Generate(Resources())
+	})
+	return doc, docErr
+}
+
+// Types はOpenAPIに型が登録されているリソース名と、リソースの下のURL(entry/similar)の一覧
+func Types() map[string]bool {
+	names := make(map[string]bool, len(types))
+	for name := range types {
+		names[name] = true
+	}
+	for name, eps := range endpoints {
+		for ep := range eps {
+			names[name+"/"+ep] = true
+		}
+	}
+	return names
+}
+
+func Handler(w http.ResponseWriter, r *http.Request) {
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	b, err := Document()
+	if err != nil {
+		logging.Error(r.Context(), "openapi", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	_, err = w.Write(b)
+	if err != nil {
+		logging.Error(r.Context(), "write", err)
+	}
+}
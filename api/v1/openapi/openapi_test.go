@@ -0,0 +1,70 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestDocumentUpToDate はハンドラの型から生成したドキュメントがdocs/openapi.jsonと一致するかを確認する
+// 失敗した場合は go generate ./api/v1/openapi で更新する
+func TestDocumentUpToDate(t *testing.T) {
+	doc, err := Document()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join("..", "..", "..", "docs", "openapi.json")
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(current, doc) {
+		return
+	}
+
+	// どのパスとスキーマがずれているかを表示する
+	var got, want struct {
+		Paths      map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(doc, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(current, &want); err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+	diff(t, "path", got.Paths, want.Paths)
+	diff(t, "schema", got.Components.Schemas, want.Components.Schemas)
+	t.Errorf("%s is out of date; run go generate ./api/v1/openapi", path)
+}
+
+func diff(t *testing.T, kind string, got, want map[string]json.RawMessage) {
+	t.Helper()
+	for name, g := range got {
+		w, ok := want[name]
+		switch {
+		case !ok:
+			t.Errorf("%s %s is missing from docs/openapi.json", kind, name)
+		case !bytes.Equal(compact(t, g), compact(t, w)):
+			t.Errorf("%s %s differs from docs/openapi.json", kind, name)
+		}
+	}
+	for name := range want {
+		if _, ok := got[name]; !ok {
+			t.Errorf("%s %s is no longer generated", kind, name)
+		}
+	}
+}
+
+func compact(t *testing.T, raw json.RawMessage) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -1,19 +1,24 @@
 package personality
 
 import (
-	"encoding/json"
-	"log"
-	"fmt"
 	"net/http"
 
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
 	validation "github.com/go-ozzo/ozzo-validation"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/jsonapi"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
 )
 
 type Personality struct {
-	EntryID int64 `db:"entry_id"`
-	TypeID  int64 `db:"type_id"`
+	EntryID int64 `db:"entry_id" json:"entry_id"`
+	TypeID  int64 `db:"type_id" json:"type_id"`
 }
 
 func (p *Personality) Validate() error {
@@ -43,228 +48,197 @@ func (i *IDs) Validate() error {
 	)
 }
 
+// attribute はstoreで扱う形に変換する
+func (p Personality) attribute() model.Attribute {
+	return model.Attribute{EntryID: p.EntryID, ValueID: p.TypeID}
+}
+
+func toAttributes(rows []Personality) []model.Attribute {
+	attrs := make([]model.Attribute, len(rows))
+	for i, row := range rows {
+		attrs[i] = row.attribute()
+	}
+	return attrs
+}
+
+func fromAttributes(attrs []model.Attribute) []Personality {
+	rows := make([]Personality, len(attrs))
+	for i, attr := range attrs {
+		rows[i] = Personality{EntryID: attr.EntryID, TypeID: attr.ValueID}
+	}
+	return rows
+}
+
 func Handler(w http.ResponseWriter, r *http.Request) {
-	db, err := sqlx.Open("postgres", "")
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
 	if err != nil {
-		log.Printf("sql.Open error %s", err)
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 	defer db.Close()
+	// 属性テーブル共通のstoreで読み書きする
+	serve(w, r, &store.Attributes{DB: db, Table: model.PersonalityTable})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, attributes store.AttributeRepository) {
 	switch r.Method {
 	case http.MethodGet:
-		var personalitiesJson PersonalitiesJson
-		query := `
-			SELECT
-				entry_id,
-				type_id
-			FROM
-				personality
-			WHERE
-				entry_id IN (?)
-		`
-		queryIDs, ok := r.URL.Query()["entry_id"]
-		if !ok {
-			query = `
-				SELECT
-					entry_id,
-					type_id
-				FROM
-					personality
-			`
-			err := db.SelectContext(r.Context(), &personalitiesJson.Personalities, query)
-			if err != nil {
-				log.Printf(fmt.Sprintf("select error: %v", err))
-				http.Error(w, err.Error(), http.StatusBadRequest)
-			}
-			// json encode
-			err = json.NewEncoder(w).Encode(&personalitiesJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			return
-		} else if len(queryIDs) == 1 {
-			query = `
-				SELECT
-					entry_id,
-					type_id
-				FROM
-					personality
-				WHERE
-					entry_id = $1
-			`
-			err := db.SelectContext(r.Context(), &personalitiesJson.Personalities, query, queryIDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("select error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// json encode
-			err = json.NewEncoder(w).Encode(&personalitiesJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+		// 権限の確認
+		if !rbac.Authorize(w, r, "personality", rbac.Read) {
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, queryIDs)
+		var personalitiesJson PersonalitiesJson
+		// クエリパラメータからentry_idを取得
+		queryIDs := r.URL.Query()["entry_id"]
+		ids, err := params.Int64s(queryIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("in query error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(queryIDs), query)
-		err = db.SelectContext(r.Context(), &personalitiesJson.Personalities, query, args...)
+		// entry_idが指定されていない場合は全件取得
+		attrs, total, err := jsonapi.List(r, attributes, ids)
+		personalitiesJson.Personalities = fromAttributes(attrs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("select error: %v", err))
+			logging.Error(r.Context(), "select", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// json encode
-		err = json.NewEncoder(w).Encode(&personalitiesJson)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, jsonapi.WithTotal(&personalitiesJson, total))
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPost:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "personality", rbac.Create) {
+			return
+		}
 		var personalitiesJson PersonalitiesJson
-		query := `
-			INSERT INTO personality (
-				entry_id,
-				type_id
-			) VALUES (
-				:entry_id,
-				:type_id
-			)
-		`
-		// json decode
-		err := json.NewDecoder(r.Body).Decode(&personalitiesJson)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &personalitiesJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// validation
+		// リクエストボディのバリデーション
 		err = personalitiesJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
 		}
 		for _, personality := range personalitiesJson.Personalities {
-			// validation
 			err = personality.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("validation error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-			}
-			_, err = db.NamedExecContext(r.Context(), query, personality)
-			if err != nil {
-				log.Printf(fmt.Sprintf("insert error: %v", err))
-				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
 			}
 		}
-		// json encode
-		err = json.NewEncoder(w).Encode(&personalitiesJson)
+		// 登録
+		attrs, err := attributes.Create(r.Context(), toAttributes(personalitiesJson.Personalities))
+		personalitiesJson.Personalities = fromAttributes(attrs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			logging.Error(r.Context(), "insert", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &personalitiesJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodPut:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "personality", rbac.Update) {
+			return
+		}
 		var personalitiesJson PersonalitiesJson
-		query := `
-			UPDATE
-				personality
-			SET
-				type_id = :type_id
-			WHERE
-				entry_id = :entry_id
-		`
-		err := json.NewDecoder(r.Body).Decode(&personalitiesJson)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &personalitiesJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// validation
+		// リクエストボディのバリデーション
 		err = personalitiesJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
 		}
 		for _, personality := range personalitiesJson.Personalities {
-			// validation
 			err = personality.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("validation error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-			}
-			_, err = db.NamedExecContext(r.Context(), query, personality)
-			if err != nil {
-				log.Printf(fmt.Sprintf("update error: %v", err))
-				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
 			}
 		}
-		// json encode
-		err = json.NewEncoder(w).Encode(&personalitiesJson)
+		// 更新
+		err = attributes.Update(r.Context(), toAttributes(personalitiesJson.Personalities))
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			logging.Error(r.Context(), "update", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &personalitiesJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	case http.MethodDelete:
+		// 権限の確認。ボディを読む前に削除の権限を確認する
+		if !rbac.Authorize(w, r, "personality", rbac.Delete) {
+			return
+		}
 		var delIDs IDs
-		query := `
-			DELETE FROM
-				personality
-			WHERE
-				entry_id IN (?)
-		`
-		err := json.NewDecoder(r.Body).Decode(&delIDs)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &delIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		// validation
+		// リクエストボディのバリデーション
 		err = delIDs.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("validation error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-		}
-		if len(delIDs.IDs) == 0 {
-			return
-		} else if len(delIDs.IDs) == 1 {
-			query = `
-				DELETE FROM
-					personality
-				WHERE
-					entry_id = $1
-			`
-			_, err = db.ExecContext(r.Context(), query, delIDs.IDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("delete error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			// json encode
-			err = json.NewEncoder(w).Encode(&delIDs)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, delIDs.IDs)
-		if err != nil {
-			log.Printf(fmt.Sprintf("in query error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+		// 2件以上の削除は一括削除の権限も確認する
+		if len(delIDs.IDs) > 1 && !rbac.Authorize(w, r, "personality", rbac.BulkDelete) {
+			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(delIDs.IDs), query)
-		_, err = db.ExecContext(r.Context(), query, args...)
+		// 削除
+		err = attributes.Delete(r.Context(), delIDs.IDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("delete error: %v", err))
+			logging.Error(r.Context(), "delete", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		// json encode
-		err = json.NewEncoder(w).Encode(&delIDs)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &delIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
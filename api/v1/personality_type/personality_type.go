@@ -1,19 +1,24 @@
 package personalitytype
 
 import (
-	"encoding/json"
-	"log"
-	"fmt"
 	"net/http"
 
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
 	validation "github.com/go-ozzo/ozzo-validation"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/jsonapi"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
 )
 
 type PersonalityType struct {
-	ID   int64  `db:"id"`
-	Type string `db:"type"`
+	ID   int64  `db:"id" json:"id"`
+	Type string `db:"type" json:"type"`
 }
 
 func (p *PersonalityType) Validate() error {
@@ -42,246 +47,195 @@ func (i *IDs) Validate() error {
 	)
 }
 
+// value はstoreで扱う形に変換する
+func (p PersonalityType) value() model.TypeValue {
+	return model.TypeValue{ID: p.ID, Value: p.Type}
+}
+
+func toValues(rows []PersonalityType) []model.TypeValue {
+	values := make([]model.TypeValue, len(rows))
+	for i, row := range rows {
+		values[i] = row.value()
+	}
+	return values
+}
+
+func fromValues(values []model.TypeValue) []PersonalityType {
+	rows := make([]PersonalityType, len(values))
+	for i, value := range values {
+		rows[i] = PersonalityType{ID: value.ID, Type: value.Value}
+	}
+	return rows
+}
+
 func Handler(w http.ResponseWriter, r *http.Request) {
-	db, err := sqlx.Open("postgres", "")
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
 	if err != nil {
-		log.Printf("sql.Open error %s", err)
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 	defer db.Close()
+	// RPCと同じstoreで読み書きする
+	serve(w, r, &store.Types{DB: db, Table: model.PersonalityTypeTable})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, types store.TypeRepository) {
 	switch r.Method {
 	case http.MethodGet:
-		var personalityTypesJson PersonalityTypesJson
-		query := `
-			SELECT
-				id,
-				type
-			FROM
-				personality_type
-			WHERE
-				id IN (?)
-		`
-		queryIDs, ok := r.URL.Query()["id"]
-		if !ok {
-			query = `
-				SELECT
-					id,
-					type
-				FROM
-					personality_type
-			`
-			err := db.SelectContext(r.Context(), &personalityTypesJson.PersonalityTypes, query)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			// jsonを返す
-			err = json.NewEncoder(w).Encode(&personalityTypesJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			return
-		} else if len(queryIDs) == 1 {
-			query = `
-				SELECT
-					id,
-					type
-				FROM
-					personality_type
-				WHERE
-					id = $1
-			`
-			err := db.SelectContext(r.Context(), &personalityTypesJson.PersonalityTypes, query, queryIDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			// jsonを返す
-			err = json.NewEncoder(w).Encode(&personalityTypesJson)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
+		// 権限の確認
+		if !rbac.Authorize(w, r, "personality_type", rbac.Read) {
 			return
 		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, queryIDs)
+		var personalityTypesJson PersonalityTypesJson
+		// クエリパラメータからidを取得
+		queryIDs := r.URL.Query()["id"]
+		ids, err := params.Int64s(queryIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(queryIDs), query)
-		err = db.SelectContext(r.Context(), &personalityTypesJson.PersonalityTypes, query, args...)
+		// idが指定されていない場合は全件取得
+		values, total, err := jsonapi.List(r, types, ids)
+		personalityTypesJson.PersonalityTypes = fromValues(values)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
+			logging.Error(r.Context(), "select", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		// jsonを返す
-		err = json.NewEncoder(w).Encode(&personalityTypesJson)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, jsonapi.WithTotal(&personalityTypesJson, total))
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	case http.MethodPost:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "personality_type", rbac.Create) {
+			return
+		}
 		var personalityTypesJson PersonalityTypesJson
-		query := `
-			INSERT INTO personality_type (
-				type
-			) VALUES (
-				:type
-			)
-		`
-		err := json.NewDecoder(r.Body).Decode(&personalityTypesJson)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &personalityTypesJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = personalityTypesJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("json validate error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
 		for _, personalityType := range personalityTypesJson.PersonalityTypes {
-			// jsonバリデーション
 			err = personalityType.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("json validate error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 				return
 			}
-			_, err = db.NamedExecContext(r.Context(), query, personalityType)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
 		}
-		// jsonを返す
-		err = json.NewEncoder(w).Encode(&personalityTypesJson)
+		// 登録
+		values, err := types.Create(r.Context(), toValues(personalityTypesJson.PersonalityTypes))
+		personalityTypesJson.PersonalityTypes = fromValues(values)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "insert", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &personalityTypesJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	case http.MethodPut:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "personality_type", rbac.Update) {
+			return
+		}
 		var personalityTypesJson PersonalityTypesJson
-		query := `
-			UPDATE
-				personality_type
-			SET
-				type = :type
-			WHERE
-				id = :id
-		`
-		err := json.NewDecoder(r.Body).Decode(&personalityTypesJson)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &personalityTypesJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = personalityTypesJson.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("json validate error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
 		for _, personalityType := range personalityTypesJson.PersonalityTypes {
-			// jsonバリデーション
 			err = personalityType.Validate()
 			if err != nil {
-				log.Printf(fmt.Sprintf("json validate error: %v", err))
+				logging.Error(r.Context(), "validation", err)
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 				return
 			}
-			_, err = db.NamedExecContext(r.Context(), query, personalityType)
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
 		}
-		// jsonを返す
-		err = json.NewEncoder(w).Encode(&personalityTypesJson)
+		// 更新
+		err = types.Update(r.Context(), toValues(personalityTypesJson.PersonalityTypes))
+		if err != nil {
+			logging.Error(r.Context(), "update", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &personalityTypesJson)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	case http.MethodDelete:
+		// 権限の確認。ボディを読む前に削除の権限を確認する
+		if !rbac.Authorize(w, r, "personality_type", rbac.Delete) {
+			return
+		}
 		var delIDs IDs
-		query := `
-			DELETE FROM
-				personality_type
-			WHERE
-				id IN (?)
-		`
-		err := json.NewDecoder(r.Body).Decode(&delIDs)
+		// リクエストボディを読み込む
+		err := response.Decode(r, &delIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json decode error: %v body:%v", err, r.Body))
+			logging.Error(r.Context(), "decode", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		// jsonバリデーション
+		// リクエストボディのバリデーション
 		err = delIDs.Validate()
 		if err != nil {
-			log.Printf(fmt.Sprintf("json validate error: %v", err))
+			logging.Error(r.Context(), "validation", err)
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
-		if len(delIDs.IDs) == 0 {
-			return
-		} else if len(delIDs.IDs) == 1 {
-			query = `
-				DELETE FROM
-					personality_type
-				WHERE
-					id = $1
-			`
-			_, err = db.ExecContext(r.Context(), query, delIDs.IDs[0])
-			if err != nil {
-				log.Printf(fmt.Sprintf("db error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			// jsonを返す
-			err = json.NewEncoder(w).Encode(&delIDs)
-			if err != nil {
-				log.Printf(fmt.Sprintf("json encode error: %v", err))
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			return
-		}
-		// idの数だけ置換文字を作成
-		query, args, err := sqlx.In(query, delIDs.IDs)
-		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+		// 2件以上の削除は一括削除の権限も確認する
+		if len(delIDs.IDs) > 1 && !rbac.Authorize(w, r, "personality_type", rbac.BulkDelete) {
 			return
 		}
-		// Postgresの場合は置換文字を$1, $2, ...とする必要がある
-		query = sqlx.Rebind(len(delIDs.IDs), query)
-		_, err = db.ExecContext(r.Context(), query, args...)
+		// 削除
+		err = types.Delete(r.Context(), delIDs.IDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("db error: %v", err))
+			logging.Error(r.Context(), "delete", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		// jsonを返す
-		err = json.NewEncoder(w).Encode(&delIDs)
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &delIDs)
 		if err != nil {
-			log.Printf(fmt.Sprintf("json encode error: %v", err))
+			logging.Error(r.Context(), "encode", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -0,0 +1,212 @@
+package radaraxis
+
+import (
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// RadarAxis はradar_axisテーブルの行
+type RadarAxis = model.RadarAxis
+
+type RadarAxesJson struct {
+	RadarAxes []RadarAxis `json:"radar_axes"`
+}
+
+func (a *RadarAxesJson) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.RadarAxes, validation.Required),
+	)
+}
+
+type IDs struct {
+	IDs []int64 `json:"ids"`
+}
+
+func (i *IDs) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.IDs, validation.Required),
+	)
+}
+
+func Handler(w http.ResponseWriter, r *http.Request) {
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
+	if err != nil {
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer db.Close()
+	serve(w, r, &store.RadarAxes{DB: db})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+func serve(w http.ResponseWriter, r *http.Request, axes store.RadarAxisRepository) {
+	switch r.Method {
+	case http.MethodGet:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "radar_axis", rbac.Read) {
+			return
+		}
+		var radarAxesJson RadarAxesJson
+		// クエリパラメータからidを取得
+		queryIDs := r.URL.Query()["id"]
+		ids, err := params.Int64s(queryIDs)
+		if err != nil {
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// idが指定されていない場合は全件取得(表示する順)
+		radarAxesJson.RadarAxes, err = axes.List(r.Context(), ids)
+		if err != nil {
+			logging.Error(r.Context(), "select", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &radarAxesJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPost:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "radar_axis", rbac.Create) {
+			return
+		}
+		var radarAxesJson RadarAxesJson
+		// リクエストボディを読み込む
+		err := response.Decode(r, &radarAxesJson)
+		if err != nil {
+			logging.Error(r.Context(), "decode", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// リクエストボディのバリデーション
+		err = radarAxesJson.Validate()
+		if err != nil {
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		for _, axis := range radarAxesJson.RadarAxes {
+			err = axis.Validate()
+			if err != nil {
+				logging.Error(r.Context(), "validation", err)
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			}
+		}
+		// 登録
+		radarAxesJson.RadarAxes, err = axes.Create(r.Context(), radarAxesJson.RadarAxes)
+		if err != nil {
+			logging.Error(r.Context(), "insert", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &radarAxesJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPut:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "radar_axis", rbac.Update) {
+			return
+		}
+		var radarAxesJson RadarAxesJson
+		// リクエストボディを読み込む
+		err := response.Decode(r, &radarAxesJson)
+		if err != nil {
+			logging.Error(r.Context(), "decode", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// リクエストボディのバリデーション
+		err = radarAxesJson.Validate()
+		if err != nil {
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		for _, axis := range radarAxesJson.RadarAxes {
+			err = axis.Validate()
+			if err != nil {
+				logging.Error(r.Context(), "validation", err)
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			}
+		}
+		// 更新
+		err = axes.Update(r.Context(), radarAxesJson.RadarAxes)
+		if err != nil {
+			logging.Error(r.Context(), "update", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &radarAxesJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodDelete:
+		// 権限の確認。ボディを読む前に削除の権限を確認する
+		if !rbac.Authorize(w, r, "radar_axis", rbac.Delete) {
+			return
+		}
+		var delIDs IDs
+		// リクエストボディを読み込む
+		err := response.Decode(r, &delIDs)
+		if err != nil {
+			logging.Error(r.Context(), "decode", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// リクエストボディのバリデーション
+		err = delIDs.Validate()
+		if err != nil {
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		// 2件以上の削除は一括削除の権限も確認する
+		if len(delIDs.IDs) > 1 && !rbac.Authorize(w, r, "radar_axis", rbac.BulkDelete) {
+			return
+		}
+		// 削除。軸の値(radar_value)も削除される
+		err = axes.Delete(r.Context(), delIDs.IDs)
+		if err != nil {
+			logging.Error(r.Context(), "delete", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &delIDs)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
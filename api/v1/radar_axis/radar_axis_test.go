@@ -0,0 +1,98 @@
+package radaraxis
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+func newRepo() *store.Memory[model.RadarAxis, store.RadarAxisSearch] {
+	axes := store.NewMemoryRadarAxes()
+	axes.Seed(
+		RadarAxis{ID: 1, Key: "ai", LabelJa: "ai", LabelEn: "ai", Min: 0, Max: 100, DisplayOrder: 2},
+		RadarAxis{ID: 2, Key: "nu", LabelJa: "nu", LabelEn: "nu", Min: 0, Max: 100, DisplayOrder: 1},
+	)
+	return axes
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	axes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, axes) }
+
+	// 表示する順に返す
+	var all RadarAxesJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/radar_axis", nil), http.StatusOK, &all)
+	if len(all.RadarAxes) != 2 || all.RadarAxes[0].Key != "nu" {
+		t.Fatalf("RadarAxes = %+v", all.RadarAxes)
+	}
+
+	var some RadarAxesJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/radar_axis?id=1", nil), http.StatusOK, &some)
+	if len(some.RadarAxes) != 1 || some.RadarAxes[0].Key != "ai" {
+		t.Fatalf("RadarAxes = %+v", some.RadarAxes)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/radar_axis?id=x", nil), http.StatusBadRequest, nil)
+}
+
+func TestPost(t *testing.T) {
+	apitest.Setup(t)
+	axes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, axes) }
+	body := RadarAxesJson{RadarAxes: []RadarAxis{{Key: "moe", LabelJa: "萌え", LabelEn: "moe", Min: 1, Max: 5, DisplayOrder: 3}}}
+
+	// 軸はadminだけが書き込める
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/radar_axis", body), http.StatusForbidden, nil)
+
+	var created RadarAxesJson
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodPost, "/api/v1/radar_axis", body), http.StatusOK, &created)
+	if len(created.RadarAxes) != 1 || created.RadarAxes[0].ID != 3 {
+		t.Fatalf("RadarAxes = %+v", created.RadarAxes)
+	}
+
+	for _, axis := range []RadarAxis{
+		{},
+		{Key: "Moe", LabelJa: "萌え", LabelEn: "moe", Max: 5},
+		{Key: "moe", LabelEn: "moe", Max: 5},
+		{Key: "moe", LabelJa: "萌え", LabelEn: "moe", Min: 5, Max: 5},
+	} {
+		apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodPost, "/api/v1/radar_axis", RadarAxesJson{RadarAxes: []RadarAxis{axis}}), http.StatusUnprocessableEntity, nil)
+	}
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodPost, "/api/v1/radar_axis", RadarAxesJson{}), http.StatusUnprocessableEntity, nil)
+}
+
+func TestPut(t *testing.T) {
+	apitest.Setup(t)
+	axes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, axes) }
+	body := RadarAxesJson{RadarAxes: []RadarAxis{{ID: 2, Key: "nu", LabelJa: "ぬ", LabelEn: "nu", Min: 0, Max: 10, DisplayOrder: 1}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPut, "/api/v1/radar_axis", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodPut, "/api/v1/radar_axis", body), http.StatusOK, nil)
+	got, err := axes.Get(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.LabelJa != "ぬ" || got.Max != 10 {
+		t.Fatalf("axis = %+v", got)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	apitest.Setup(t)
+	axes := newRepo()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, axes) }
+
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodDelete, "/api/v1/radar_axis", IDs{}), http.StatusUnprocessableEntity, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/radar_axis", IDs{IDs: []int64{1}}), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodDelete, "/api/v1/radar_axis", IDs{IDs: []int64{1}}), http.StatusOK, nil)
+	rows, _ := axes.List(context.Background(), nil)
+	if len(rows) != 1 || rows[0].ID != 2 {
+		t.Fatalf("rows = %+v", rows)
+	}
+}
@@ -0,0 +1,242 @@
+package radarchart
+
+import (
+	"fmt"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/middleware"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/params"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/response"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// RadarChart はentry1件の軸ごとの値(radar_valueテーブルの行をまとめたもの)
+type RadarChart = model.RadarChart
+
+type RadarChartsJson struct {
+	RadarCharts []RadarChart `json:"radar_charts"`
+}
+
+func (c *RadarChartsJson) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.RadarCharts, validation.Required),
+	)
+}
+
+type IDs struct {
+	IDs []int64 `json:"ids"`
+}
+
+func (i *IDs) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.IDs, validation.Required),
+	)
+}
+
+func Handler(w http.ResponseWriter, r *http.Request) {
+	middleware.Wrap(handle).ServeHTTP(w, r)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	db, err := database.Open(r.Context())
+	if err != nil {
+		logging.Error(r.Context(), "sql_open", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer db.Close()
+	serve(w, r, &store.RadarCharts{DB: db}, &store.RadarAxes{DB: db})
+}
+
+// serve はstoreを受け取ってリクエストを処理する。テストではメモリの実装を渡す
+// 値は登録・更新の前にradar_axisの範囲で確認する
+func serve(w http.ResponseWriter, r *http.Request, charts store.RadarChartRepository, axes store.RadarAxisRepository) {
+	switch r.Method {
+	case http.MethodGet:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "radar_chart", rbac.Read) {
+			return
+		}
+		var radarChartsJson RadarChartsJson
+		// クエリパラメータからentry_idを取得
+		queryIDs := r.URL.Query()["entry_id"]
+		ids, err := params.Int64s(queryIDs)
+		if err != nil {
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// entry_idが指定されていない場合は全件取得
+		radarChartsJson.RadarCharts, err = charts.List(r.Context(), ids)
+		if err != nil {
+			logging.Error(r.Context(), "select", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &radarChartsJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPost:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "radar_chart", rbac.Create) {
+			return
+		}
+		var radarChartsJson RadarChartsJson
+		// リクエストボディを読み込む
+		err := response.Decode(r, &radarChartsJson)
+		if err != nil {
+			logging.Error(r.Context(), "decode", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// リクエストボディのバリデーション
+		err = radarChartsJson.Validate()
+		if err != nil {
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		for _, chart := range radarChartsJson.RadarCharts {
+			err = chart.Validate()
+			if err != nil {
+				logging.Error(r.Context(), "validation", err)
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			}
+		}
+		// 軸の範囲の確認
+		if !checkValues(w, r, axes, radarChartsJson.RadarCharts) {
+			return
+		}
+		// 登録
+		radarChartsJson.RadarCharts, err = charts.Create(r.Context(), radarChartsJson.RadarCharts)
+		if err != nil {
+			logging.Error(r.Context(), "insert", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &radarChartsJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPut:
+		// 権限の確認
+		if !rbac.Authorize(w, r, "radar_chart", rbac.Update) {
+			return
+		}
+		var radarChartsJson RadarChartsJson
+		// リクエストボディを読み込む
+		err := response.Decode(r, &radarChartsJson)
+		if err != nil {
+			logging.Error(r.Context(), "decode", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// リクエストボディのバリデーション
+		err = radarChartsJson.Validate()
+		if err != nil {
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		for _, chart := range radarChartsJson.RadarCharts {
+			err = chart.Validate()
+			if err != nil {
+				logging.Error(r.Context(), "validation", err)
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			}
+		}
+		// 軸の範囲の確認
+		if !checkValues(w, r, axes, radarChartsJson.RadarCharts) {
+			return
+		}
+		// 更新。entryの値を置き換え、指定のない軸の値は削除する
+		err = charts.Update(r.Context(), radarChartsJson.RadarCharts)
+		if err != nil {
+			logging.Error(r.Context(), "update", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &radarChartsJson)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodDelete:
+		// 権限の確認。ボディを読む前に削除の権限を確認する
+		if !rbac.Authorize(w, r, "radar_chart", rbac.Delete) {
+			return
+		}
+		var delIDs IDs
+		// リクエストボディを読み込む
+		err := response.Decode(r, &delIDs)
+		if err != nil {
+			logging.Error(r.Context(), "decode", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// リクエストボディのバリデーション
+		err = delIDs.Validate()
+		if err != nil {
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		// 2件以上の削除は一括削除の権限も確認する
+		if len(delIDs.IDs) > 1 && !rbac.Authorize(w, r, "radar_chart", rbac.BulkDelete) {
+			return
+		}
+		// 削除。entryの全ての軸の値を削除する
+		err = charts.Delete(r.Context(), delIDs.IDs)
+		if err != nil {
+			logging.Error(r.Context(), "delete", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// レスポンスボディに書き込む
+		err = response.Write(w, r, &delIDs)
+		if err != nil {
+			logging.Error(r.Context(), "encode", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// checkValues は値のキーと範囲をradar_axisで確認する。範囲の外の場合は422を書き込んでfalseを返す
+func checkValues(w http.ResponseWriter, r *http.Request, axes store.RadarAxisRepository, charts []RadarChart) bool {
+	all, err := axes.List(r.Context(), nil)
+	if err != nil {
+		logging.Error(r.Context(), "select", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	for _, chart := range charts {
+		err = model.CheckRadarValues(all, chart.Values)
+		if err != nil {
+			err = fmt.Errorf("entry %d: %w", chart.EntryID, err)
+			logging.Error(r.Context(), "validation", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package radarchart
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/apitest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// newAxes はaiとnu(0〜100)とmoe(1〜5)の軸を作る
+func newAxes() *store.Memory[model.RadarAxis, store.RadarAxisSearch] {
+	axes := store.NewMemoryRadarAxes()
+	axes.Seed(
+		model.RadarAxis{ID: 1, Key: "ai", LabelJa: "ai", LabelEn: "ai", Min: 0, Max: 100, DisplayOrder: 1},
+		model.RadarAxis{ID: 2, Key: "nu", LabelJa: "nu", LabelEn: "nu", Min: 0, Max: 100, DisplayOrder: 2},
+		model.RadarAxis{ID: 3, Key: "moe", LabelJa: "萌え", LabelEn: "moe", Min: 1, Max: 5, DisplayOrder: 3},
+	)
+	return axes
+}
+
+func newRepo() *store.Memory[model.RadarChart, store.RadarChartSearch] {
+	charts := store.NewMemoryRadarCharts()
+	charts.Seed(
+		RadarChart{EntryID: 1, Values: map[string]int64{"ai": 10, "nu": 50}},
+		RadarChart{EntryID: 2, Values: map[string]int64{"ai": 30, "nu": 30, "moe": 4}},
+		RadarChart{EntryID: 3, Values: map[string]int64{"moe": 1}},
+	)
+	return charts
+}
+
+func TestGet(t *testing.T) {
+	apitest.Setup(t)
+	charts, axes := newRepo(), newAxes()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, charts, axes) }
+
+	var all RadarChartsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/radar_chart", nil), http.StatusOK, &all)
+	if len(all.RadarCharts) != 3 {
+		t.Fatalf("charts = %+v", all.RadarCharts)
+	}
+
+	var some RadarChartsJson
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/radar_chart?entry_id=2", nil), http.StatusOK, &some)
+	if len(some.RadarCharts) != 1 || some.RadarCharts[0].Values["moe"] != 4 {
+		t.Fatalf("charts = %+v", some.RadarCharts)
+	}
+
+	apitest.Decode(t, apitest.Do(t, h, "", http.MethodGet, "/api/v1/radar_chart?entry_id=x", nil), http.StatusBadRequest, nil)
+}
+
+func TestPost(t *testing.T) {
+	apitest.Setup(t)
+	charts, axes := newRepo(), newAxes()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, charts, axes) }
+	body := RadarChartsJson{RadarCharts: []RadarChart{{EntryID: 4, Values: map[string]int64{"ai": 0, "moe": 5}}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPost, "/api/v1/radar_chart", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/radar_chart", body), http.StatusOK, nil)
+	got, err := charts.Get(context.Background(), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Values) != 2 || got.Values["moe"] != 5 {
+		t.Fatalf("chart = %+v", got)
+	}
+
+	for _, chart := range []RadarChart{
+		{EntryID: 5},
+		{Values: map[string]int64{"ai": 1}},
+		// 軸の範囲の外とないキー
+		{EntryID: 5, Values: map[string]int64{"moe": 0}},
+		{EntryID: 5, Values: map[string]int64{"ai": 101}},
+		{EntryID: 5, Values: map[string]int64{"tsun": 1}},
+	} {
+		apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/radar_chart", RadarChartsJson{RadarCharts: []RadarChart{chart}}), http.StatusUnprocessableEntity, nil)
+	}
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPost, "/api/v1/radar_chart", RadarChartsJson{}), http.StatusUnprocessableEntity, nil)
+}
+
+func TestPut(t *testing.T) {
+	apitest.Setup(t)
+	charts, axes := newRepo(), newAxes()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, charts, axes) }
+	body := RadarChartsJson{RadarCharts: []RadarChart{{EntryID: 2, Values: map[string]int64{"ai": 40, "nu": 20}}}}
+
+	apitest.Decode(t, apitest.Do(t, h, "viewer", http.MethodPut, "/api/v1/radar_chart", body), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPut, "/api/v1/radar_chart", body), http.StatusOK, nil)
+	got, err := charts.Get(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 指定のない軸の値はなくなる
+	if len(got.Values) != 2 || got.Values["ai"] != 40 {
+		t.Fatalf("chart = %+v", got)
+	}
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodPut, "/api/v1/radar_chart", RadarChartsJson{RadarCharts: []RadarChart{{EntryID: 2, Values: map[string]int64{"moe": 6}}}}), http.StatusUnprocessableEntity, nil)
+}
+
+func TestDelete(t *testing.T) {
+	apitest.Setup(t)
+	charts, axes := newRepo(), newAxes()
+	h := func(w http.ResponseWriter, r *http.Request) { serve(w, r, charts, axes) }
+
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/radar_chart", IDs{}), http.StatusUnprocessableEntity, nil)
+	// editorは一括削除できない
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/radar_chart", IDs{IDs: []int64{1, 2}}), http.StatusForbidden, nil)
+	apitest.Decode(t, apitest.Do(t, h, "editor", http.MethodDelete, "/api/v1/radar_chart", IDs{IDs: []int64{1}}), http.StatusOK, nil)
+	apitest.Decode(t, apitest.Do(t, h, "admin", http.MethodDelete, "/api/v1/radar_chart", IDs{IDs: []int64{2, 3}}), http.StatusOK, nil)
+	rows, _ := charts.List(context.Background(), nil)
+	if len(rows) != 0 {
+		t.Fatalf("rows = %+v, want none", rows)
+	}
+}
@@ -0,0 +1,106 @@
+// apikey は書き込み用のAPIキーを発行・失効・一覧表示する
+//
+//	go run ./cmd/apikey mint -name ci -scopes "entry:write *_type:write" -role editor -expires 720h
+//	go run ./cmd/apikey revoke -id 3
+//	go run ./cmd/apikey list
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/auth"
+	"maguro-alternative/varcel-go/pkg/database"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	ctx := context.Background()
+	db, err := database.Open(ctx)
+	if err != nil {
+		log.Fatalf("db open error: %v", err)
+	}
+	defer db.Close()
+	keys := &auth.Keys{DB: db}
+
+	switch os.Args[1] {
+	case "mint":
+		mint(ctx, keys, os.Args[2:])
+	case "revoke":
+		revoke(ctx, keys, os.Args[2:])
+	case "list":
+		list(ctx, keys)
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: apikey mint|revoke|list [flags]")
+	os.Exit(2)
+}
+
+func mint(ctx context.Context, keys *auth.Keys, args []string) {
+	fs := flag.NewFlagSet("mint", flag.ExitOnError)
+	name := fs.String("name", "", "キーの用途(必須)")
+	scopes := fs.String("scopes", "", `空白区切りのスコープ。例: "entry:write *_type:write"`)
+	role := fs.String("role", "editor", "権限表で使うロール(viewer, editor, admin)")
+	expires := fs.Duration("expires", 0, "有効期間。0の場合は無期限")
+	fs.Parse(args)
+	if *name == "" || strings.TrimSpace(*scopes) == "" {
+		log.Fatal("-name and -scopes are required")
+	}
+	var expiresAt *time.Time
+	if *expires > 0 {
+		t := time.Now().Add(*expires)
+		expiresAt = &t
+	}
+	token, key, err := keys.Mint(ctx, *name, strings.Fields(*scopes), *role, expiresAt)
+	if err != nil {
+		log.Fatalf("mint error: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "minted key %d (%s). the key is shown only once:\n", key.ID, key.Name)
+	fmt.Println(token)
+}
+
+func revoke(ctx context.Context, keys *auth.Keys, args []string) {
+	fs := flag.NewFlagSet("revoke", flag.ExitOnError)
+	id := fs.Int64("id", 0, "失効させるキーのid(必須)")
+	fs.Parse(args)
+	if *id == 0 {
+		log.Fatal("-id is required")
+	}
+	err := keys.Revoke(ctx, *id)
+	if err != nil {
+		log.Fatalf("revoke error: %v", err)
+	}
+	fmt.Printf("revoked key %d\n", *id)
+}
+
+func list(ctx context.Context, keys *auth.Keys) {
+	all, err := keys.List(ctx)
+	if err != nil {
+		log.Fatalf("list error: %v", err)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tPREFIX\tSCOPES\tROLE\tEXPIRES\tLAST USED\tREVOKED")
+	for _, k := range all {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", k.ID, k.Name, k.Prefix, k.Scopes, k.Role, format(k.ExpiresAt), format(k.LastUsedAt), format(k.RevokedAt))
+	}
+	tw.Flush()
+}
+
+func format(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,40 @@
+// cooccurrence はentry_tagからタグの共起の統計(tag_cooccurrence)を作り直す
+// /api/v1/entry_tag/recommend はこの統計を使うため、タグを大きく変更した後や定期的に実行する
+//
+//	go run ./cmd/cooccurrence
+//	go run ./cmd/cooccurrence -min-count 3
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/cooccurrence"
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+func main() {
+	minCount := flag.Int64("min-count", cooccurrence.DefaultMinCount, "統計に残す組の最小の共起数")
+	flag.Parse()
+	if *minCount < 1 {
+		log.Fatal("-min-count must be at least 1")
+	}
+
+	ctx := context.Background()
+	db, err := database.Open(ctx)
+	if err != nil {
+		log.Fatalf("db open error: %v", err)
+	}
+	defer db.Close()
+
+	start := time.Now()
+	n, err := cooccurrence.Refresh(ctx, &store.EntryTags{DB: db}, &store.TagCooccurrences{DB: db}, *minCount)
+	if err != nil {
+		log.Fatalf("refresh error: %v", err)
+	}
+	fmt.Printf("refreshed tag_cooccurrence: %d rows in %s\n", n, time.Since(start).Round(time.Millisecond))
+}
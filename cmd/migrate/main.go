@@ -0,0 +1,49 @@
+// migrate は同梱しているマイグレーションをDBに適用する
+//
+//	PGHOST=... PGUSER=... go run ./cmd/migrate
+//	DATABASE_URL=sqlite:./local.db go run ./cmd/migrate
+//	go run ./cmd/migrate -status
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"maguro-alternative/varcel-go/pkg/database"
+)
+
+func main() {
+	status := flag.Bool("status", false, "適用済みのバージョンを表示して終了する")
+	flag.Parse()
+
+	ctx := context.Background()
+	db, err := database.Open(ctx)
+	if err != nil {
+		log.Fatalf("db open error: %v", err)
+	}
+	defer db.Close()
+
+	expected, err := database.ExpectedVersion()
+	if err != nil {
+		log.Fatalf("read migrations error: %v", err)
+	}
+	if *status {
+		applied, err := db.AppliedVersion(ctx)
+		if err != nil {
+			log.Fatalf("applied version error: %v", err)
+		}
+		fmt.Printf("applied: %d expected: %d\n", applied, expected)
+		return
+	}
+
+	done, err := db.Migrate(ctx)
+	for _, m := range done {
+		fmt.Printf("applied %04d_%s\n", m.Version, m.Name)
+	}
+	if err != nil {
+		log.Fatalf("migrate error: %v", err)
+	}
+	fmt.Printf("schema is at version %d\n", expected)
+}
@@ -0,0 +1,158 @@
+// openapi はハンドラの型からOpenAPIドキュメントを生成する
+//
+//	go run ./cmd/openapi            docs/openapi.json を更新する
+//	go run ./cmd/openapi -check     ハンドラとドキュメントがずれていれば失敗する
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"maguro-alternative/varcel-go/api/v1/openapi"
+	"maguro-alternative/varcel-go/pkg/catalog"
+)
+
+// ハンドラがフィルターとして読むクエリパラメータ
+var queryParam = regexp.MustCompile(`Query\(\)\["(\w+)"\]`)
+
+// v1以下でリソースではないディレクトリ
+var nonResources = map[string]bool{
+	"openapi": true,
+}
+
+func main() {
+	out := flag.String("out", "docs/openapi.json", "出力するファイル")
+	check := flag.Bool("check", false, "ファイルを更新せずに差分があれば失敗する")
+	root := flag.String("root", ".", "リポジトリのルート")
+	flag.Parse()
+
+	doc, err := openapi.Document()
+	if err != nil {
+		fail("generate error: %v", err)
+	}
+	if !*check {
+		if err := os.WriteFile(*out, doc, 0o644); err != nil {
+			fail("write error: %v", err)
+		}
+		return
+	}
+
+	var problems []string
+	problems = append(problems, checkHandlers(*root)...)
+	current, err := os.ReadFile(*out)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("read %s: %v", *out, err))
+	} else if !bytes.Equal(current, doc) {
+		problems = append(problems, fmt.Sprintf("%s is out of date; run go generate ./api/v1/openapi", *out))
+	}
+	for _, p := range problems {
+		fmt.Fprintln(os.Stderr, p)
+	}
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+}
+
+// checkHandlers はapi/v1以下のハンドラとカタログ・OpenAPIの型が対応しているかを確認する
+func checkHandlers(root string) []string {
+	var problems []string
+	types := openapi.Types()
+	dirs, err := filepath.Glob(filepath.Join(root, "api", "v1", "*"))
+	if err != nil {
+		return []string{err.Error()}
+	}
+	handlers := map[string]bool{}
+	for _, dir := range dirs {
+		name := filepath.Base(dir)
+		if !nonResources[name] {
+			handlers[name] = true
+		}
+	}
+	for _, res := range catalog.Resources {
+		if !handlers[res.Name] {
+			problems = append(problems, fmt.Sprintf("%s: in catalog but api/v1/%s does not exist", res.Name, res.Name))
+			continue
+		}
+		delete(handlers, res.Name)
+		if !types[res.Name] {
+			problems = append(problems, fmt.Sprintf("%s: no request/response types registered in api/v1/openapi", res.Name))
+		}
+		src, err := os.ReadFile(filepath.Join(root, res.Source))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", res.Name, err))
+			continue
+		}
+		problems = append(problems, checkFilters(res.Name, res.Filters, src)...)
+		problems = append(problems, checkEndpoints(root, res, types)...)
+	}
+	for name := range handlers {
+		problems = append(problems, fmt.Sprintf("%s: handler exists but is missing from the catalog", name))
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// checkEndpoints はリソースのディレクトリの下のハンドラとカタログのEndpointが対応しているかを確認する
+func checkEndpoints(root string, res catalog.Resource, types map[string]bool) []string {
+	var problems []string
+	dirs, err := filepath.Glob(filepath.Join(root, "api", "v1", res.Name, "*"))
+	if err != nil {
+		return []string{err.Error()}
+	}
+	handlers := map[string]bool{}
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			handlers[filepath.Base(dir)] = true
+		}
+	}
+	for _, ep := range res.Endpoints {
+		name := res.Name + "/" + ep.Name
+		if !handlers[ep.Name] {
+			problems = append(problems, fmt.Sprintf("%s: in catalog but api/v1/%s does not exist", name, name))
+			continue
+		}
+		delete(handlers, ep.Name)
+		if !types[name] {
+			problems = append(problems, fmt.Sprintf("%s: no response type registered in api/v1/openapi", name))
+		}
+		src, err := os.ReadFile(filepath.Join(root, ep.Source))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		problems = append(problems, checkFilters(name, ep.Params, src)...)
+	}
+	for ep := range handlers {
+		problems = append(problems, fmt.Sprintf("%s/%s: handler exists but is missing from the catalog", res.Name, ep))
+	}
+	return problems
+}
+
+// checkFilters はハンドラが読むクエリパラメータとカタログのフィルターを比較する
+func checkFilters(name string, filters []catalog.Filter, src []byte) []string {
+	var problems []string
+	used := map[string]bool{}
+	for _, m := range queryParam.FindAllSubmatch(src, -1) {
+		used[string(m[1])] = true
+	}
+	for _, f := range filters {
+		if !used[f.Name] {
+			problems = append(problems, fmt.Sprintf("%s: filter %q is documented but not read by the handler", name, f.Name))
+		}
+		delete(used, f.Name)
+	}
+	for param := range used {
+		problems = append(problems, fmt.Sprintf("%s: handler reads %q but it is not documented", name, param))
+	}
+	return problems
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,218 @@
+// seed はデモや負荷試験、UIの開発用に架空のキャラクターを登録する
+// 同じ-seedと同じ参照テーブルからは同じデータになる。*_typeテーブルが空の場合は正規の語彙(pkg/vocabulary)を登録する
+//
+//	go run ./cmd/seed -n 200 -seed 42
+//	DATABASE_URL=sqlite:./local.db go run ./cmd/seed -n 50
+//	go run ./cmd/seed -n 3 -dry-run
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/seed"
+	"maguro-alternative/varcel-go/pkg/store"
+	"maguro-alternative/varcel-go/pkg/vocabulary"
+)
+
+func main() {
+	n := flag.Int("n", 100, "登録するキャラクターの数")
+	seedValue := flag.Uint64("seed", 1, "乱数のシード")
+	dryRun := flag.Bool("dry-run", false, "DBに接続せず、既定の参照テーブルで作成した行をJSONで出力する")
+	flag.Parse()
+
+	if *dryRun {
+		printCharacters(*n, *seedValue)
+		return
+	}
+
+	ctx := context.Background()
+	db, err := database.Open(ctx)
+	if err != nil {
+		log.Fatalf("db open error: %v", err)
+	}
+	defer db.Close()
+
+	sources, err := ensureSources(ctx, db)
+	if err != nil {
+		log.Fatalf("source error: %v", err)
+	}
+	types, err := ensureTypes(ctx, db)
+	if err != nil {
+		log.Fatalf("type error: %v", err)
+	}
+	tags, err := ensureTags(ctx, db)
+	if err != nil {
+		log.Fatalf("tag error: %v", err)
+	}
+	g, err := seed.New(*seedValue, sources, types)
+	if err != nil {
+		log.Fatalf("seed error: %v", err)
+	}
+	for i := 0; i < *n; i++ {
+		if err := insert(ctx, db, g.Next(), tags); err != nil {
+			log.Fatalf("insert error: %v", err)
+		}
+		if (i+1)%100 == 0 {
+			fmt.Fprintf(os.Stderr, "%d/%d\n", i+1, *n)
+		}
+	}
+	fmt.Printf("seeded %d characters (seed %d)\n", *n, *seedValue)
+}
+
+// printCharacters は語彙の順に1から振ったidを*_typeのidとして作成した行を出力する
+func printCharacters(n int, seedValue uint64) {
+	sources := make([]int64, len(seed.Sources))
+	for i := range sources {
+		sources[i] = int64(i + 1)
+	}
+	g, err := seed.New(seedValue, sources, seed.Types{
+		HairColors:    defaultValues("haircolor_type"),
+		EyeColors:     defaultValues("eyecolor_type"),
+		HairStyles:    defaultValues("hairstyle_type"),
+		HairLengths:   defaultValues("hairlength_type"),
+		Personalities: defaultValues("personality_type"),
+	})
+	if err != nil {
+		log.Fatalf("seed error: %v", err)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	for i := 0; i < n; i++ {
+		c := g.Next()
+		c.SetEntryID(int64(i + 1))
+		if err := enc.Encode(c); err != nil {
+			log.Fatalf("encode error: %v", err)
+		}
+	}
+}
+
+func defaultValues(table string) []model.TypeValue {
+	t, _ := vocabulary.Lookup(table)
+	values := make([]model.TypeValue, len(t.Terms))
+	for i, term := range t.Terms {
+		values[i] = model.TypeValue{ID: int64(i + 1), Value: term.Ja}
+	}
+	return values
+}
+
+// ensureSources はsourceのidを返す。空の場合は既定の出典を登録する
+func ensureSources(ctx context.Context, db *database.DB) ([]int64, error) {
+	var ids []int64
+	if err := db.SelectContext(ctx, &ids, `SELECT id FROM source ORDER BY id`); err != nil {
+		return nil, err
+	}
+	if len(ids) > 0 {
+		return ids, nil
+	}
+	query := db.Rebind(`INSERT INTO source (name, url, type) VALUES (?, ?, ?)`)
+	for _, s := range seed.Sources {
+		id, err := db.InsertID(ctx, query, s.Name, s.URL, s.Type)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ensureTypes は*_typeテーブルの行を返す。空のテーブルには語彙を登録する
+func ensureTypes(ctx context.Context, db *database.DB) (seed.Types, error) {
+	var types seed.Types
+	tables := []struct {
+		table model.TypeTable
+		dest  *[]model.TypeValue
+	}{
+		{model.HairColorTypeTable, &types.HairColors},
+		{model.EyeColorTypeTable, &types.EyeColors},
+		{model.HairStyleTypeTable, &types.HairStyles},
+		{model.HairLengthTypeTable, &types.HairLengths},
+		{model.PersonalityTypeTable, &types.Personalities},
+	}
+	for _, t := range tables {
+		s := &store.Types{DB: db, Table: t.table}
+		values, err := s.List(ctx, nil)
+		if err != nil {
+			return types, err
+		}
+		if len(values) == 0 {
+			v, _ := vocabulary.Lookup(t.table.Name)
+			if _, err := vocabulary.Sync(ctx, db, v, vocabulary.Options{}); err != nil {
+				return types, err
+			}
+			if values, err = s.List(ctx, nil); err != nil {
+				return types, err
+			}
+		}
+		*t.dest = values
+	}
+	return types, nil
+}
+
+// ensureTags は生成に使うタグを登録し、名前からidを引けるようにする
+func ensureTags(ctx context.Context, db *database.DB) (map[string]int64, error) {
+	query := db.Rebind(`INSERT INTO tag (name) VALUES (?)` + db.Dialect.DoNothing([]string{"name"}))
+	for _, name := range seed.Tags() {
+		if _, err := db.ExecContext(ctx, query, name); err != nil {
+			return nil, err
+		}
+	}
+	rows := []struct {
+		ID   int64  `db:"id"`
+		Name string `db:"name"`
+	}{}
+	if err := db.SelectContext(ctx, &rows, `SELECT id, name FROM tag`); err != nil {
+		return nil, err
+	}
+	tags := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		tags[row.Name] = row.ID
+	}
+	return tags, nil
+}
+
+// insert は1人分の行をハンドラと同じstoreで登録する
+func insert(ctx context.Context, db *database.DB, c seed.Character, tags map[string]int64) error {
+	entries, err := (&store.Entries{DB: db}).Create(ctx, []model.Entry{c.Entry})
+	if err != nil {
+		return err
+	}
+	c.SetEntryID(entries[0].ID)
+	if _, err := (&store.BWHs{DB: db}).Create(ctx, []model.BWH{c.BWH}); err != nil {
+		return err
+	}
+	attributes := []struct {
+		table model.AttributeTable
+		rows  []model.Attribute
+	}{
+		{model.HairColorTable, []model.Attribute{c.HairColor}},
+		{model.EyeColorTable, []model.Attribute{c.EyeColor}},
+		{model.HairStyleTable, []model.Attribute{c.HairStyle}},
+		{model.HairLengthTable, []model.Attribute{c.HairLength}},
+		{model.PersonalityTable, c.Personalities},
+	}
+	for _, a := range attributes {
+		if _, err := (&store.Attributes{DB: db, Table: a.table}).Create(ctx, a.rows); err != nil {
+			return err
+		}
+	}
+	if _, err := (&store.HekiRadarCharts{DB: db}).Create(ctx, []model.HekiRadarChart{c.Chart}); err != nil {
+		return err
+	}
+	if len(c.Links) > 0 {
+		if _, err := (&store.Links{DB: db}).Create(ctx, c.Links); err != nil {
+			return err
+		}
+	}
+	entryTags := make([]model.EntryTag, 0, len(c.Tags))
+	for _, name := range c.Tags {
+		entryTags = append(entryTags, model.EntryTag{EntryID: c.Entry.ID, TagID: tags[name]})
+	}
+	_, err = (&store.EntryTags{DB: db}).Create(ctx, entryTags)
+	return err
+}
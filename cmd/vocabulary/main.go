@@ -0,0 +1,72 @@
+// vocabulary は*_typeテーブルをpkg/vocabularyの正規の語彙と同期する
+// 語彙にない値を登録し、既存の行に英語とローマ字を付ける。語彙にない行は報告するだけで削除しない
+//
+//	go run ./cmd/vocabulary -dry-run
+//	go run ./cmd/vocabulary
+//	go run ./cmd/vocabulary -table hairstyle_type -prune
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/vocabulary"
+)
+
+func main() {
+	table := flag.String("table", "", "同期する*_typeテーブル。省略した場合は全てのテーブル")
+	dryRun := flag.Bool("dry-run", false, "DBを書き換えずに結果だけを表示する")
+	prune := flag.Bool("prune", false, "語彙にない行のうち、どのentryからも参照されていない行を削除する")
+	flag.Parse()
+
+	tables := vocabulary.Tables
+	if *table != "" {
+		t, ok := vocabulary.Lookup(*table)
+		if !ok {
+			log.Fatalf("unknown table: %s", *table)
+		}
+		tables = []vocabulary.Table{t}
+	}
+
+	ctx := context.Background()
+	db, err := database.Open(ctx)
+	if err != nil {
+		log.Fatalf("db open error: %v", err)
+	}
+	defer db.Close()
+
+	fmt.Printf("vocabulary version %d\n", vocabulary.Version)
+	if *dryRun {
+		fmt.Println("dry run: no changes are written")
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tADDED\tLABELED\tEXTRA\tPRUNED")
+	var results []vocabulary.Result
+	for _, t := range tables {
+		result, err := vocabulary.Sync(ctx, db, t, vocabulary.Options{DryRun: *dryRun, Prune: *prune})
+		if err != nil {
+			log.Fatalf("%s: sync error: %v", t.Type.Name, err)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", result.Table, len(result.Added), len(result.Labeled), len(result.Extras), len(result.Pruned))
+		results = append(results, result)
+	}
+	w.Flush()
+
+	// 語彙にない行は表記揺れの可能性があるため、参照数と一緒に表示する
+	for _, result := range results {
+		for _, term := range result.Added {
+			fmt.Printf("%s: added %s (%s, %s)\n", result.Table, term.Ja, term.En, term.Romaji)
+		}
+		for _, extra := range result.Extras {
+			fmt.Printf("%s: extra id=%d %q referenced by %d entries\n", result.Table, extra.ID, extra.Value, extra.References)
+		}
+		for _, extra := range result.Pruned {
+			fmt.Printf("%s: pruned id=%d %q\n", result.Table, extra.ID, extra.Value)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Package apitest はDBなしでハンドラを動かすテストの共通処理
+package apitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/auth"
+	"maguro-alternative/varcel-go/pkg/rbac"
+)
+
+// editable はeditorが書き込めるリソース。0003_permission.sqlと0007_radar_axis.sqlの初期値と同じ
+var editable = []string{
+	"entry", "entry_tag", "link", "bwh", "haircolor", "eyescolor",
+	"hairstyle", "hairlength", "personality", "heki_radar_chart",
+	"radar_chart",
+}
+
+// Matrix はrole_permissionの初期値と同じ権限表
+func Matrix() rbac.Matrix {
+	m := rbac.Matrix{
+		{Role: rbac.Viewer, Resource: "*", Action: string(rbac.Read)},
+		{Role: rbac.Editor, Resource: "*", Action: string(rbac.Read)},
+		{Role: rbac.Admin, Resource: "*", Action: "*"},
+	}
+	for _, res := range editable {
+		for _, action := range []rbac.Action{rbac.Create, rbac.Update, rbac.Delete} {
+			m = append(m, rbac.Rule{Role: rbac.Editor, Resource: res, Action: string(action)})
+		}
+	}
+	return m
+}
+
+// Setup は権限表を固定し、テストの終了時に戻す
+func Setup(t *testing.T) {
+	t.Helper()
+	rbac.Use(Matrix())
+	t.Cleanup(func() { rbac.Use(nil) })
+}
+
+// Do はハンドラにリクエストを渡してレスポンスを返す
+// roleが空の場合は認証していないリクエストとして扱う。bodyはJSONにして送る
+func Do(t *testing.T, h http.HandlerFunc, role, method, target string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := httptest.NewRequest(method, target, &buf)
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	if role != "" {
+		p := &auth.Principal{Name: "test", Scopes: []string{"*"}, Role: role}
+		r = r.WithContext(auth.NewContext(r.Context(), p))
+	}
+	w := httptest.NewRecorder()
+	h(w, r)
+	return w
+}
+
+// Decode はステータスコードを確認してレスポンスボディを読み込む
+func Decode(t *testing.T, w *httptest.ResponseRecorder, status int, v interface{}) {
+	t.Helper()
+	if w.Code != status {
+		t.Fatalf("status = %d, want %d: %s", w.Code, status, w.Body.String())
+	}
+	if v == nil {
+		return
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("decode: %v: %s", err, w.Body.String())
+	}
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+var (
+	// ErrMissingKey はAuthorizationヘッダーがない場合のエラー
+	ErrMissingKey = errors.New("api key is required")
+	// ErrInvalidKey は存在しない、失効した、期限切れのキーのエラー
+	ErrInvalidKey = errors.New("invalid api key")
+)
+
+// ScopeError はキーに必要なスコープがない場合のエラー
+type ScopeError struct {
+	Scope string
+}
+
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("api key does not have scope %q", e.Scope)
+}
+
+// WriteScope はリソースへの書き込みに必要なスコープ
+func WriteScope(resource string) string {
+	return resource + ":write"
+}
+
+// ReadScope はリソースの読み込みに必要なスコープ
+// REQUIRE_API_KEY_FOR_READS=true の場合だけ要求する
+func ReadScope(resource string) string {
+	return resource + ":read"
+}
+
+// ReadsRequireKey は読み込みにもAPIキーを要求するかを返す。既定では読み込みは公開する
+func ReadsRequireKey() bool {
+	return os.Getenv("REQUIRE_API_KEY_FOR_READS") == "true"
+}
+
+// Principal は認証されたAPIキー
+type Principal struct {
+	KeyID  int64
+	Name   string
+	Scopes []string
+	// Role はrbacの権限表で操作を判定するロール
+	Role string
+}
+
+// Allows はスコープを持っているかを判定する
+// キーのスコープはpath.Matchのパターンとして扱うため、"*:write"や"*_type:write"も指定できる
+func (p *Principal) Allows(scope string) bool {
+	for _, pattern := range p.Scopes {
+		if ok, _ := path.Match(pattern, scope); ok {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext は認証済みのキーを返す。認証していない場合はnil
+func FromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(contextKey{}).(*Principal)
+	return p
+}
+
+// BearerToken はAuthorizationヘッダーからトークンを取り出す
+func BearerToken(authorization string) (string, bool) {
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
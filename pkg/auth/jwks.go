@@ -0,0 +1,371 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/logging"
+)
+
+const (
+	// 鍵セットを読み直す間隔の既定値。Cache-Controlのmax-ageがあればそちらを使う
+	defaultJWKSTTL = time.Hour
+	// 知らないkidが来た時に読み直す間隔の下限。不正なトークンで提供元に負荷をかけないため
+	minJWKSRefresh = 30 * time.Second
+	// 読み直し1回(ディスカバリと鍵セットの取得)の時間の上限
+	jwksFetchTimeout = 10 * time.Second
+)
+
+// ErrUnknownKey はkidに対応する鍵が鍵セットにない場合のエラー
+var ErrUnknownKey = errors.New("signing key not found in jwks")
+
+// JWKS はJWTの検証に使う公開鍵のセット
+// URLかファイルから読み込み、期限が切れるか知らないkidが来た時に読み直す
+// 読み直しに失敗した場合は前回の鍵を使い続ける
+// 読み直しはロックの外で行い、同時に必要になった場合は1回の取得を待ち合わせる
+type JWKS struct {
+	// URL はjwks_uri。空でFileも空の場合はIssuerのディスカバリから求める
+	URL string
+	// File はオフラインで使う鍵セットのファイル。更新日時が変わると読み直す
+	File string
+	// Issuer はディスカバリ(/.well-known/openid-configuration)に使う
+	Issuer string
+	// TTL は読み直す間隔。0の場合はdefaultJWKSTTL
+	TTL    time.Duration
+	Client *http.Client
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	expiresAt   time.Time
+	modTime     time.Time
+	refreshedAt time.Time
+	// inflight は実行中の読み直し。終わるとnilに戻す
+	inflight *refreshCall
+}
+
+// refreshCall は読み直し1回分。doneを閉じた後にerrを読む
+type refreshCall struct {
+	done chan struct{}
+	err  error
+}
+
+// jwk は鍵セットの鍵1つ分。RSAとEC(P-256)の署名用の鍵だけを使う
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// Key はkidの公開鍵を返す
+// kidを指定していないトークンは、鍵が1つだけの場合にその鍵で検証する
+func (s *JWKS) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	stale := s.stale()
+	s.mu.Unlock()
+	if stale {
+		if err := s.refresh(ctx); err != nil {
+			if !s.loaded() {
+				return nil, err
+			}
+			logging.Error(ctx, "jwks", err)
+		}
+	}
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	// 鍵のローテーションで新しいkidが使われ始めた可能性があるので読み直す
+	s.mu.Lock()
+	recent := time.Since(s.refreshedAt) < minJWKSRefresh
+	s.mu.Unlock()
+	if recent {
+		return nil, ErrUnknownKey
+	}
+	if err := s.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+func (s *JWKS) loaded() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.keys != nil
+}
+
+func (s *JWKS) lookup(kid string) (crypto.PublicKey, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// stale はmuを持って呼ぶ
+func (s *JWKS) stale() bool {
+	if s.keys == nil {
+		return true
+	}
+	if s.File != "" {
+		info, err := os.Stat(s.File)
+		return err != nil || !info.ModTime().Equal(s.modTime)
+	}
+	return !time.Now().Before(s.expiresAt)
+}
+
+// refresh は鍵セットを読み直す。他のリクエストが読み直している場合はその結果を待つ
+func (s *JWKS) refresh(ctx context.Context) error {
+	s.mu.Lock()
+	if c := s.inflight; c != nil {
+		s.mu.Unlock()
+		select {
+		case <-c.done:
+			return c.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	c := &refreshCall{done: make(chan struct{})}
+	s.inflight = c
+	s.refreshedAt = time.Now()
+	s.mu.Unlock()
+
+	// 待ち合わせている他のリクエストがあるため、最初に呼んだリクエストのctxでは取得しない
+	// そのリクエストが切断されても読み直しは続ける
+	fetchCtx, cancel := context.WithTimeout(context.Background(), jwksFetchTimeout)
+	defer cancel()
+	keys, ttl, modTime, err := s.load(fetchCtx)
+	s.mu.Lock()
+	if err == nil {
+		s.keys = keys
+		s.expiresAt = time.Now().Add(ttl)
+		s.modTime = modTime
+	}
+	s.inflight = nil
+	s.mu.Unlock()
+	c.err = err
+	close(c.done)
+	return err
+}
+
+// load は鍵セットを読み込み、次に読み直すまでの間隔とファイルの更新日時を返す
+// 同時に呼ばれるのはrefreshの1つだけ
+func (s *JWKS) load(ctx context.Context) (map[string]crypto.PublicKey, time.Duration, time.Time, error) {
+	var (
+		data    []byte
+		modTime time.Time
+		ttl     = s.TTL
+		err     error
+	)
+	if ttl <= 0 {
+		ttl = defaultJWKSTTL
+	}
+	if s.File != "" {
+		info, err := os.Stat(s.File)
+		if err != nil {
+			return nil, 0, modTime, err
+		}
+		data, err = os.ReadFile(s.File)
+		if err != nil {
+			return nil, 0, modTime, err
+		}
+		modTime = info.ModTime()
+	} else {
+		var maxAge time.Duration
+		data, maxAge, err = s.fetch(ctx)
+		if err != nil {
+			return nil, 0, modTime, err
+		}
+		if maxAge > 0 {
+			ttl = maxAge
+		}
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, 0, modTime, err
+	}
+	return keys, ttl, modTime, nil
+}
+
+// fetch は鍵セットを取得し、Cache-Controlのmax-ageを返す
+func (s *JWKS) fetch(ctx context.Context) ([]byte, time.Duration, error) {
+	s.mu.Lock()
+	url := s.URL
+	s.mu.Unlock()
+	if url == "" {
+		u, err := s.discover(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		s.mu.Lock()
+		s.URL = u
+		s.mu.Unlock()
+		url = u
+	}
+	res, err := s.get(ctx, url)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, maxAge(res.Header.Get("Cache-Control")), nil
+}
+
+// discover はIssuerのOpenID Connectのディスカバリからjwks_uriを求める
+func (s *JWKS) discover(ctx context.Context) (string, error) {
+	if s.Issuer == "" {
+		return "", errors.New("jwks: url, file or issuer is required")
+	}
+	res, err := s.get(ctx, strings.TrimSuffix(s.Issuer, "/")+"/.well-known/openid-configuration")
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	var config struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(&config); err != nil {
+		return "", err
+	}
+	if config.JWKSURI == "" {
+		return "", errors.New("jwks: discovery document has no jwks_uri")
+	}
+	return config.JWKSURI, nil
+}
+
+func (s *JWKS) get(ctx context.Context, url string) (*http.Response, error) {
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("jwks: GET %s: %s", url, res.Status)
+	}
+	return res, nil
+}
+
+func maxAge(cacheControl string) time.Duration {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		v, ok := strings.CutPrefix(strings.TrimSpace(directive), "max-age=")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return 0
+		}
+		return time.Duration(n) * time.Second
+	}
+	return 0
+}
+
+// parseJWKS は署名に使える鍵をkidごとに取り出す。対応していない鍵は無視する
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("jwks: %w", err)
+	}
+	keys := map[string]crypto.PublicKey{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwks: no usable signing keys")
+	}
+	return keys, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("jwks: invalid rsa exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("jwks: unsupported curve %s", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		if !key.Curve.IsOnCurve(x, y) {
+			return nil, errors.New("jwks: point is not on curve")
+		}
+		return key, nil
+	}
+	return nil, fmt.Errorf("jwks: unsupported key type %s", k.Kty)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("jwks: empty key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
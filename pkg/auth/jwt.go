@@ -0,0 +1,346 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidToken は署名、発行者、対象者、有効期限のいずれかが正しくないJWTのエラー
+var ErrInvalidToken = errors.New("invalid bearer token")
+
+// 時刻のずれを許容する幅
+const defaultLeeway = time.Minute
+
+// 権限の強い順。複数のロールに対応する場合は強い方を使う
+var rolePriority = []string{"admin", "editor", "viewer"}
+
+// Verifier はOIDCのプロバイダが発行したJWT(RS256, ES256)を検証する
+type Verifier struct {
+	Issuer   string
+	Audience string
+	Keys     *JWKS
+	// RolesClaim はロールを持つクレーム。"realm_access.roles"のようにドットで入れ子を指定できる
+	RolesClaim string
+	// RoleMap はクレームの値からロールへの対応。対応がない値は無視する
+	RoleMap map[string]string
+	// DefaultRole はロールのクレームにRoleMapに対応する値がない場合のロール
+	DefaultRole string
+	Leeway      time.Duration
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Claims は検証に使う登録済みのクレームと、ロールを取り出すための全てのクレーム
+type Claims struct {
+	Issuer    string
+	Subject   string
+	Audience  []string
+	ExpiresAt time.Time
+	NotBefore time.Time
+	Raw       map[string]interface{}
+}
+
+// IsJWT はトークンがJWS Compact Serializationの形かを判定する
+func IsJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// Verify はトークンの署名とクレームを検証し、ロールを割り当てたPrincipalを返す
+// 失敗の理由はErrInvalidTokenに包んで返す。レスポンスには理由を含めないこと
+func (v *Verifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	claims, err := v.verify(ctx, token)
+	if err != nil {
+		var invalid *invalidTokenError
+		if errors.As(err, &invalid) || errors.Is(err, ErrUnknownKey) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+		}
+		// 鍵セットを取得できない場合はサーバーのエラーとして扱う
+		return nil, err
+	}
+	return &Principal{
+		Name: claims.Subject,
+		// スコープは確認せず、ロールの権限表で操作を判定する
+		Scopes: []string{"*"},
+		Role:   v.role(claims),
+	}, nil
+}
+
+type invalidTokenError struct {
+	reason string
+}
+
+func (e *invalidTokenError) Error() string {
+	return e.reason
+}
+
+func invalid(format string, args ...interface{}) error {
+	return &invalidTokenError{reason: fmt.Sprintf(format, args...)}
+}
+
+func (v *Verifier) verify(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, invalid("malformed token")
+	}
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, invalid("malformed header: %v", err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalid("malformed signature: %v", err)
+	}
+	key, err := v.Keys.Key(ctx, h.Kid)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	// algは鍵の種類と一致するものだけを受け付ける(noneやHS256への差し替えを防ぐ)
+	switch h.Alg {
+	case "RS256":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, invalid("alg %s does not match key %q", h.Alg, h.Kid)
+		}
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+			return nil, invalid("bad signature")
+		}
+	case "ES256":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, invalid("alg %s does not match key %q", h.Alg, h.Kid)
+		}
+		if len(sig) != 64 {
+			return nil, invalid("bad signature")
+		}
+		r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+		if !ecdsa.Verify(pub, digest[:], r, s) {
+			return nil, invalid("bad signature")
+		}
+	default:
+		return nil, invalid("unsupported alg %q", h.Alg)
+	}
+	var raw map[string]interface{}
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, invalid("malformed claims: %v", err)
+	}
+	claims, err := parseClaims(raw)
+	if err != nil {
+		return nil, err
+	}
+	return claims, v.validate(claims)
+}
+
+func (v *Verifier) validate(c *Claims) error {
+	leeway := v.Leeway
+	if leeway <= 0 {
+		leeway = defaultLeeway
+	}
+	now := time.Now()
+	if c.Issuer != v.Issuer {
+		return invalid("unexpected issuer %q", c.Issuer)
+	}
+	// 対象者を確認しないと、同じプロバイダが他のアプリに発行したトークンも通ってしまう
+	if v.Audience == "" || !contains(c.Audience, v.Audience) {
+		return invalid("token is not for audience %q", v.Audience)
+	}
+	if c.ExpiresAt.IsZero() {
+		return invalid("exp is required")
+	}
+	if !now.Before(c.ExpiresAt.Add(leeway)) {
+		return invalid("token expired at %s", c.ExpiresAt.Format(time.RFC3339))
+	}
+	if !c.NotBefore.IsZero() && now.Add(leeway).Before(c.NotBefore) {
+		return invalid("token is not valid before %s", c.NotBefore.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// role はロールのクレームの値のうち、RoleMapに対応があるものから権限の最も強いロールを求める
+// 対応がない値は使わない。プロバイダ側で自由に付けられる値でadminなどになれないようにするため
+func (v *Verifier) role(c *Claims) string {
+	var mapped []string
+	roles := map[string]bool{}
+	for _, value := range claimStrings(lookupClaim(c.Raw, v.RolesClaim)) {
+		if role, ok := v.RoleMap[value]; ok {
+			mapped = append(mapped, role)
+			roles[role] = true
+		}
+	}
+	for _, role := range rolePriority {
+		if roles[role] {
+			return role
+		}
+	}
+	// 既定のロール以外は権限表に行があれば使える。その場合は最初の値を使う
+	if len(mapped) > 0 {
+		return mapped[0]
+	}
+	return v.DefaultRole
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func parseClaims(raw map[string]interface{}) (*Claims, error) {
+	c := &Claims{Raw: raw}
+	var ok bool
+	if v, exists := raw["iss"]; exists {
+		if c.Issuer, ok = v.(string); !ok {
+			return nil, invalid("iss must be a string")
+		}
+	}
+	if v, exists := raw["sub"]; exists {
+		if c.Subject, ok = v.(string); !ok {
+			return nil, invalid("sub must be a string")
+		}
+	}
+	// audは文字列と配列のどちらでもよい
+	switch aud := raw["aud"].(type) {
+	case nil:
+	case string:
+		c.Audience = []string{aud}
+	case []interface{}:
+		c.Audience = claimStrings(aud)
+	default:
+		return nil, invalid("aud must be a string or an array")
+	}
+	for name, dst := range map[string]*time.Time{"exp": &c.ExpiresAt, "nbf": &c.NotBefore} {
+		v, exists := raw[name]
+		if !exists {
+			continue
+		}
+		n, ok := v.(float64)
+		if !ok {
+			return nil, invalid("%s must be a number", name)
+		}
+		*dst = time.Unix(int64(n), 0)
+	}
+	return c, nil
+}
+
+// lookupClaim はドット区切りのパスでクレームを取り出す
+func lookupClaim(raw map[string]interface{}, name string) interface{} {
+	var cur interface{} = raw
+	for _, key := range strings.Split(name, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		cur = m[key]
+	}
+	return cur
+}
+
+// claimStrings は文字列、文字列の配列、空白区切りの文字列(scope)を文字列の配列にする
+func claimStrings(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	verifierOnce sync.Once
+	verifier     *Verifier
+	verifierErr  error
+)
+
+// OIDC は環境変数から作成したVerifierを返す。OIDC_ISSUERがない場合はnil
+// OIDC_ISSUERを設定してOIDC_AUDIENCEを設定していない場合はエラーを返す
+//
+//	OIDC_ISSUER       発行者(issの値)
+//	OIDC_AUDIENCE     対象者(audに含まれる値)。OIDC_ISSUERを設定した場合は必須
+//	OIDC_JWKS_URL     鍵セットのURL。空の場合はディスカバリで求める
+//	OIDC_JWKS_FILE    鍵セットのファイル。オフラインのテストなどで使う
+//	OIDC_JWKS_TTL     鍵セットを読み直す間隔(例: 1h)
+//	OIDC_ROLES_CLAIM  ロールのクレーム。既定は"roles"
+//	OIDC_ROLE_MAP     クレームの値とロールの対応(例: "app-admins=admin,app-editors=editor")。対応がない値は無視する
+//	OIDC_DEFAULT_ROLE 対応する値がない場合のロール。既定は"viewer"
+func OIDC() (*Verifier, error) {
+	verifierOnce.Do(func() {
+		verifier, verifierErr = newVerifier()
+	})
+	return verifier, verifierErr
+}
+
+func newVerifier() (*Verifier, error) {
+	issuer := os.Getenv("OIDC_ISSUER")
+	if issuer == "" {
+		return nil, nil
+	}
+	audience := os.Getenv("OIDC_AUDIENCE")
+	if audience == "" {
+		return nil, errors.New("auth: OIDC_AUDIENCE is required when OIDC_ISSUER is set")
+	}
+	ttl, _ := time.ParseDuration(os.Getenv("OIDC_JWKS_TTL"))
+	return &Verifier{
+		Issuer:   issuer,
+		Audience: audience,
+		Keys: &JWKS{
+			URL:    os.Getenv("OIDC_JWKS_URL"),
+			File:   os.Getenv("OIDC_JWKS_FILE"),
+			Issuer: issuer,
+			TTL:    ttl,
+		},
+		RolesClaim:  envOr("OIDC_ROLES_CLAIM", "roles"),
+		RoleMap:     parseRoleMap(os.Getenv("OIDC_ROLE_MAP")),
+		DefaultRole: envOr("OIDC_DEFAULT_ROLE", "viewer"),
+	}, nil
+}
+
+func parseRoleMap(s string) map[string]string {
+	m := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		value, role, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if ok && value != "" && role != "" {
+			m[value] = role
+		}
+	}
+	return m
+}
+
+func envOr(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,313 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testIssuer = "https://issuer.example.com"
+
+// testKeys はテスト用の鍵と、その公開鍵を返す鍵セットのサーバー
+type testKeys struct {
+	rsa     *rsa.PrivateKey
+	ec      *ecdsa.PrivateKey
+	rotated *rsa.PrivateKey
+	// published はサーバーが返すkid
+	published atomic.Value
+	fetches   atomic.Int32
+	server    *httptest.Server
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	t.Helper()
+	k := &testKeys{}
+	var err error
+	if k.rsa, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		t.Fatal(err)
+	}
+	if k.rotated, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		t.Fatal(err)
+	}
+	if k.ec, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+	k.published.Store([]string{"rsa", "ec"})
+	k.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		k.fetches.Add(1)
+		// 同時の読み直しが待ち合わせることを確かめるため、少し待ってから返す
+		time.Sleep(20 * time.Millisecond)
+		var set struct {
+			Keys []map[string]string `json:"keys"`
+		}
+		for _, kid := range k.published.Load().([]string) {
+			switch kid {
+			case "rsa":
+				set.Keys = append(set.Keys, rsaJWK(kid, &k.rsa.PublicKey))
+			case "rotated":
+				set.Keys = append(set.Keys, rsaJWK(kid, &k.rotated.PublicKey))
+			case "ec":
+				set.Keys = append(set.Keys, map[string]string{"kty": "EC", "kid": kid, "crv": "P-256", "x": b64(k.ec.X.FillBytes(make([]byte, 32))), "y": b64(k.ec.Y.FillBytes(make([]byte, 32)))})
+			}
+		}
+		json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(k.server.Close)
+	return k
+}
+
+func rsaJWK(kid string, pub *rsa.PublicKey) map[string]string {
+	return map[string]string{"kty": "RSA", "kid": kid, "use": "sig", "n": b64(pub.N.Bytes()), "e": b64(big.NewInt(int64(pub.E)).Bytes())}
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (k *testKeys) verifier() *Verifier {
+	return &Verifier{
+		Issuer:      testIssuer,
+		Audience:    "vercel-go",
+		Keys:        &JWKS{URL: k.server.URL, Client: k.server.Client()},
+		RolesClaim:  "realm_access.roles",
+		RoleMap:     map[string]string{"app-admins": "admin", "app-editors": "editor", "app-auditors": "auditor"},
+		DefaultRole: "viewer",
+	}
+}
+
+// sign はalgとkidをヘッダーに入れ、keyで署名したトークンを作る
+func sign(t *testing.T, key crypto.Signer, alg, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	h, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	c, _ := json.Marshal(claims)
+	input := b64(h) + "." + b64(c)
+	digest := sha256.Sum256([]byte(input))
+	var sig []byte
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		if sig, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:]); err != nil {
+			t.Fatal(err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return input + "." + b64(sig)
+}
+
+// claims は有効なクレームにchangesを上書きする。nilの値はクレームを消す
+func claims(changes map[string]interface{}) map[string]interface{} {
+	c := map[string]interface{}{
+		"iss": testIssuer,
+		"sub": "user-1",
+		"aud": "vercel-go",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for name, v := range changes {
+		if v == nil {
+			delete(c, name)
+			continue
+		}
+		c[name] = v
+	}
+	return c
+}
+
+func TestVerify(t *testing.T) {
+	k := newTestKeys(t)
+	v := k.verifier()
+	now := time.Now()
+	for _, tt := range []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"rs256", sign(t, k.rsa, "RS256", "rsa", claims(nil)), true},
+		{"es256", sign(t, k.ec, "ES256", "ec", claims(nil)), true},
+		{"alg does not match rsa key", sign(t, k.ec, "ES256", "rsa", claims(nil)), false},
+		{"alg does not match ec key", sign(t, k.rsa, "RS256", "ec", claims(nil)), false},
+		{"unsupported alg", sign(t, k.rsa, "HS256", "rsa", claims(nil)), false},
+		{"bad signature", sign(t, k.rotated, "RS256", "rsa", claims(nil)), false},
+		{"audience in array", sign(t, k.rsa, "RS256", "rsa", claims(map[string]interface{}{"aud": []string{"other", "vercel-go"}})), true},
+		{"wrong audience", sign(t, k.rsa, "RS256", "rsa", claims(map[string]interface{}{"aud": "other"})), false},
+		{"missing audience", sign(t, k.rsa, "RS256", "rsa", claims(map[string]interface{}{"aud": nil})), false},
+		{"wrong issuer", sign(t, k.rsa, "RS256", "rsa", claims(map[string]interface{}{"iss": "https://evil.example.com"})), false},
+		{"missing exp", sign(t, k.rsa, "RS256", "rsa", claims(map[string]interface{}{"exp": nil})), false},
+		{"expired within leeway", sign(t, k.rsa, "RS256", "rsa", claims(map[string]interface{}{"exp": now.Add(-30 * time.Second).Unix()})), true},
+		{"expired", sign(t, k.rsa, "RS256", "rsa", claims(map[string]interface{}{"exp": now.Add(-2 * time.Minute).Unix()})), false},
+		{"nbf within leeway", sign(t, k.rsa, "RS256", "rsa", claims(map[string]interface{}{"nbf": now.Add(30 * time.Second).Unix()})), true},
+		{"not yet valid", sign(t, k.rsa, "RS256", "rsa", claims(map[string]interface{}{"nbf": now.Add(2 * time.Minute).Unix()})), false},
+		{"malformed", "a.b.c", false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := v.Verify(context.Background(), tt.token)
+			if !tt.ok {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Fatalf("err = %v, want ErrInvalidToken", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Name != "user-1" || p.Role != "viewer" {
+				t.Fatalf("principal = %+v", p)
+			}
+		})
+	}
+}
+
+func TestVerifyRole(t *testing.T) {
+	k := newTestKeys(t)
+	v := k.verifier()
+	for _, tt := range []struct {
+		name  string
+		roles interface{}
+		want  string
+	}{
+		{"strongest mapped role", []string{"app-editors", "app-admins"}, "admin"},
+		{"unmapped values are ignored", []string{"admin", "app-editors"}, "editor"},
+		{"unmapped role name", []string{"admin"}, "viewer"},
+		{"custom role", []string{"app-auditors"}, "auditor"},
+		{"no roles claim", nil, "viewer"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := claims(nil)
+			if tt.roles != nil {
+				c["realm_access"] = map[string]interface{}{"roles": tt.roles}
+			}
+			p, err := v.Verify(context.Background(), sign(t, k.rsa, "RS256", "rsa", c))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Role != tt.want {
+				t.Fatalf("role = %q, want %q", p.Role, tt.want)
+			}
+		})
+	}
+}
+
+func TestJWKSUnknownKid(t *testing.T) {
+	k := newTestKeys(t)
+	v := k.verifier()
+	ctx := context.Background()
+	token := sign(t, k.rotated, "RS256", "rotated", claims(nil))
+
+	if _, err := v.Verify(ctx, token); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("err = %v, want ErrInvalidToken", err)
+	}
+	if n := k.fetches.Load(); n != 1 {
+		t.Fatalf("fetches = %d, want 1", n)
+	}
+
+	// 直前に読み直した場合は知らないkidでも読み直さない
+	k.published.Store([]string{"rsa", "rotated"})
+	if _, err := v.Verify(ctx, token); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("err = %v, want ErrInvalidToken", err)
+	}
+	if n := k.fetches.Load(); n != 1 {
+		t.Fatalf("fetches = %d, want 1", n)
+	}
+
+	// 間隔が空いていれば読み直して新しい鍵で検証する
+	v.Keys.mu.Lock()
+	v.Keys.refreshedAt = time.Now().Add(-minJWKSRefresh)
+	v.Keys.mu.Unlock()
+	if _, err := v.Verify(ctx, token); err != nil {
+		t.Fatal(err)
+	}
+	if n := k.fetches.Load(); n != 2 {
+		t.Fatalf("fetches = %d, want 2", n)
+	}
+}
+
+func TestJWKSConcurrentRefresh(t *testing.T) {
+	k := newTestKeys(t)
+	keys := &JWKS{URL: k.server.URL, Client: k.server.Client()}
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := keys.Key(context.Background(), "rsa")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 同時に読み直しが必要になっても取得は1回だけ
+	if n := k.fetches.Load(); n != 1 {
+		t.Fatalf("fetches = %d, want 1", n)
+	}
+}
+
+func TestVerifyRequiresAudience(t *testing.T) {
+	k := newTestKeys(t)
+	v := k.verifier()
+	v.Audience = ""
+	for _, c := range []map[string]interface{}{claims(nil), claims(map[string]interface{}{"aud": nil})} {
+		if _, err := v.Verify(context.Background(), sign(t, k.rsa, "RS256", "rsa", c)); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("err = %v, want ErrInvalidToken", err)
+		}
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	for _, name := range []string{"OIDC_ISSUER", "OIDC_AUDIENCE", "OIDC_JWKS_URL", "OIDC_JWKS_FILE", "OIDC_JWKS_TTL"} {
+		t.Setenv(name, "")
+	}
+	if v, err := newVerifier(); v != nil || err != nil {
+		t.Fatalf("without issuer: %+v, %v", v, err)
+	}
+	// 対象者がない場合はJWTを受け付けない
+	t.Setenv("OIDC_ISSUER", testIssuer)
+	if v, err := newVerifier(); v != nil || err == nil {
+		t.Fatalf("without audience: %+v, %v", v, err)
+	}
+	t.Setenv("OIDC_AUDIENCE", "vercel-go")
+	v, err := newVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Issuer != testIssuer || v.Audience != "vercel-go" || v.Keys.Issuer != testIssuer {
+		t.Fatalf("verifier = %+v", v)
+	}
+}
+
+func TestJWKSRefreshOutlivesCaller(t *testing.T) {
+	k := newTestKeys(t)
+	keys := &JWKS{URL: k.server.URL, Client: k.server.Client()}
+	// 読み直しは呼んだリクエストのctxを使わないため、キャンセルされていても取得できる
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := keys.Key(ctx, "rsa"); err != nil {
+		t.Fatal(err)
+	}
+	if n := k.fetches.Load(); n != 1 {
+		t.Fatalf("fetches = %d, want 1", n)
+	}
+}
@@ -0,0 +1,261 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/database"
+)
+
+// tokenPrefix はAPIキーの先頭に付ける文字列。ログやリポジトリに漏れた時に見つけやすくする
+const tokenPrefix = "vgk_"
+
+// prefixBytes はprefixの乱数のバイト数。prefixはUNIQUEのため、発行数が増えても衝突しない長さにする
+// 以前の4バイトのprefixのキーもそのまま使える
+const prefixBytes = 12
+
+// lastUsedInterval は最終使用日時を書き込む間隔。リクエストごとにUPDATEしないように間引く
+const lastUsedInterval = time.Minute
+
+// ErrNotFound は失効させるキーが見つからない場合のエラー
+var ErrNotFound = errors.New("api key not found")
+
+// APIKey はapi_keyテーブルの行
+type APIKey struct {
+	ID         int64      `db:"id" json:"id"`
+	Name       string     `db:"name" json:"name"`
+	Prefix     string     `db:"prefix" json:"prefix"`
+	Hash       string     `db:"hash" json:"-"`
+	Scopes     string     `db:"scopes" json:"scopes"`
+	Role       string     `db:"role" json:"role"`
+	ExpiresAt  *time.Time `db:"expires_at" json:"expires_at"`
+	LastUsedAt *time.Time `db:"last_used_at" json:"last_used_at"`
+	RevokedAt  *time.Time `db:"revoked_at" json:"revoked_at"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+}
+
+// generate は vgk_<prefix>_<secret> の形のキーを作成する
+// prefixはキーの検索に使い、キー全体のハッシュで照合する
+func generate() (token, prefix string, err error) {
+	b := make([]byte, prefixBytes+32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	prefix = hex.EncodeToString(b[:prefixBytes])
+	return tokenPrefix + prefix + "_" + hex.EncodeToString(b[prefixBytes:]), prefix, nil
+}
+
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// parse はキーからprefixを取り出す
+func parse(token string) (string, bool) {
+	rest, ok := strings.CutPrefix(token, tokenPrefix)
+	if !ok {
+		return "", false
+	}
+	prefix, _, ok := strings.Cut(rest, "_")
+	return prefix, ok && prefix != ""
+}
+
+// Keys はapi_keyテーブルの読み書き
+type Keys struct {
+	DB *database.DB
+}
+
+// Mint はroleのキーを発行する。キーそのものは保存しないため、返したtokenは再表示できない
+func (k *Keys) Mint(ctx context.Context, name string, scopes []string, role string, expiresAt *time.Time) (string, APIKey, error) {
+	token, prefix, err := generate()
+	if err != nil {
+		return "", APIKey{}, err
+	}
+	key := APIKey{
+		Name:      name,
+		Prefix:    prefix,
+		Hash:      hash(token),
+		Scopes:    strings.Join(scopes, " "),
+		Role:      role,
+		ExpiresAt: expiresAt,
+		// RETURNINGを使えないDBもあるため、作成日時はこちらで決める
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	query := `
+		INSERT INTO api_key (
+			name,
+			prefix,
+			hash,
+			scopes,
+			role,
+			expires_at,
+			created_at
+		) VALUES (
+			?,
+			?,
+			?,
+			?,
+			?,
+			?,
+			?
+		)
+	`
+	key.ID, err = k.DB.InsertID(ctx, k.DB.Rebind(query), key.Name, key.Prefix, key.Hash, key.Scopes, key.Role, key.ExpiresAt, key.CreatedAt)
+	if err != nil {
+		return "", APIKey{}, err
+	}
+	return token, key, nil
+}
+
+// Revoke はキーを失効させる
+func (k *Keys) Revoke(ctx context.Context, id int64) error {
+	query := `
+		UPDATE
+			api_key
+		SET
+			revoked_at = CURRENT_TIMESTAMP
+		WHERE
+			id = ?
+			AND revoked_at IS NULL
+	`
+	res, err := k.DB.ExecContext(ctx, k.DB.Rebind(query), id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (k *Keys) List(ctx context.Context) ([]APIKey, error) {
+	query := `
+		SELECT
+			id,
+			name,
+			prefix,
+			hash,
+			scopes,
+			role,
+			expires_at,
+			last_used_at,
+			revoked_at,
+			created_at
+		FROM
+			api_key
+		ORDER BY
+			id
+	`
+	keys := []APIKey{}
+	err := k.DB.SelectContext(ctx, &keys, query)
+	return keys, err
+}
+
+// Authenticate はキーを照合し、最終使用日時を記録する
+// 最終使用日時はlastUsedIntervalより前の場合だけ書き込む
+func (k *Keys) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	prefix, ok := parse(token)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	query := `
+		SELECT
+			id,
+			name,
+			prefix,
+			hash,
+			scopes,
+			role,
+			expires_at,
+			last_used_at,
+			revoked_at,
+			created_at
+		FROM
+			api_key
+		WHERE
+			prefix = ?
+	`
+	var key APIKey
+	err := k.DB.GetContext(ctx, &key, k.DB.Rebind(query), prefix)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hash(token)), []byte(key.Hash)) != 1 {
+		return nil, ErrInvalidKey
+	}
+	if key.RevokedAt != nil {
+		return nil, fmt.Errorf("%w: revoked", ErrInvalidKey)
+	}
+	if key.ExpiresAt != nil && !time.Now().Before(*key.ExpiresAt) {
+		return nil, fmt.Errorf("%w: expired", ErrInvalidKey)
+	}
+	now := time.Now().UTC()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedInterval {
+		_, err = k.DB.ExecContext(ctx, k.DB.Rebind(`UPDATE api_key SET last_used_at = ? WHERE id = ?`), now, key.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Principal{KeyID: key.ID, Name: key.Name, Scopes: strings.Fields(key.Scopes), Role: key.Role}, nil
+}
+
+// Authenticate はAuthorizationヘッダーのAPIキーかJWTを認証する。スコープは確認しない
+func Authenticate(ctx context.Context, authorization string) (*Principal, error) {
+	token, ok := BearerToken(authorization)
+	if !ok {
+		return nil, ErrMissingKey
+	}
+	return authenticate(ctx, token)
+}
+
+// Authorize はAuthorizationヘッダーのAPIキーかJWTを認証し、scopeを持っているかを確認する
+func Authorize(ctx context.Context, authorization, scope string) (*Principal, error) {
+	p, err := Authenticate(ctx, authorization)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Allows(scope) {
+		return p, &ScopeError{Scope: scope}
+	}
+	return p, nil
+}
+
+// authenticate はトークンの形式でAPIキーとOIDCのJWTを振り分ける
+// 形式が違うトークンはDBに接続せずに拒否する
+func authenticate(ctx context.Context, token string) (*Principal, error) {
+	if _, ok := parse(token); ok {
+		db, err := database.Open(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer db.Close()
+		return (&Keys{DB: db}).Authenticate(ctx, token)
+	}
+	if !IsJWT(token) {
+		return nil, ErrInvalidKey
+	}
+	v, err := OIDC()
+	if err != nil {
+		// 設定が正しくない場合はJWTを受け付けない。理由はログにだけ残す
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if v == nil {
+		return nil, ErrInvalidKey
+	}
+	return v.Verify(ctx, token)
+}
@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/dbtest"
+)
+
+func TestMint(t *testing.T) {
+	keys := &Keys{DB: dbtest.Open(t)}
+	ctx := context.Background()
+	prefixes := map[string]bool{}
+	for i := 0; i < 20; i++ {
+		token, key, err := keys.Mint(ctx, "ci", []string{"entry:write", "tag:read"}, "editor", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		prefix, ok := parse(token)
+		if !ok || prefix != key.Prefix || len(prefix) != 2*prefixBytes {
+			t.Fatalf("token = %q, prefix = %q", token, key.Prefix)
+		}
+		if prefixes[prefix] {
+			t.Fatalf("duplicate prefix %q", prefix)
+		}
+		prefixes[prefix] = true
+		// キーそのものは保存しない
+		if key.Hash != hash(token) || strings.Contains(key.Hash, prefix) {
+			t.Fatalf("hash = %q", key.Hash)
+		}
+	}
+	list, err := keys.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 20 || list[0].Scopes != "entry:write tag:read" || list[0].Role != "editor" {
+		t.Fatalf("list = %+v", list)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	db := dbtest.Open(t)
+	keys := &Keys{DB: db}
+	ctx := context.Background()
+	token, key, err := keys.Mint(ctx, "ci", []string{"entry:write"}, "editor", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := keys.Authenticate(ctx, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.KeyID != key.ID || p.Name != "ci" || p.Role != "editor" || !p.Allows("entry:write") || p.Allows("tag:write") {
+		t.Fatalf("principal = %+v", p)
+	}
+
+	for _, bad := range []string{
+		"",
+		"vgk_",
+		"not-a-key",
+		// prefixが一致してもキー全体が違えば拒否する
+		token[:len(token)-1] + "0",
+		tokenPrefix + strings.Repeat("0", 2*prefixBytes) + "_00",
+	} {
+		if bad == token {
+			continue
+		}
+		if _, err := keys.Authenticate(ctx, bad); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Authenticate(%q) = %v, want ErrInvalidKey", bad, err)
+		}
+	}
+}
+
+func TestAuthenticateLastUsed(t *testing.T) {
+	db := dbtest.Open(t)
+	keys := &Keys{DB: db}
+	ctx := context.Background()
+	token, key, err := keys.Mint(ctx, "ci", nil, "viewer", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lastUsed := func() time.Time {
+		t.Helper()
+		list, err := keys.List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if list[0].LastUsedAt == nil {
+			t.Fatal("last_used_at is null")
+		}
+		return *list[0].LastUsedAt
+	}
+
+	if _, err := keys.Authenticate(ctx, token); err != nil {
+		t.Fatal(err)
+	}
+	first := lastUsed()
+	// 間隔の中では書き込まない
+	old := first.Add(-lastUsedInterval / 2)
+	dbtest.Exec(t, db, `UPDATE api_key SET last_used_at = ? WHERE id = ?`, old, key.ID)
+	if _, err := keys.Authenticate(ctx, token); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastUsed(); !got.Equal(old) {
+		t.Fatalf("last_used_at = %v, want %v", got, old)
+	}
+	// 間隔を過ぎていれば書き込む
+	old = first.Add(-2 * lastUsedInterval)
+	dbtest.Exec(t, db, `UPDATE api_key SET last_used_at = ? WHERE id = ?`, old, key.ID)
+	if _, err := keys.Authenticate(ctx, token); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastUsed(); !got.After(old) {
+		t.Fatalf("last_used_at = %v, want after %v", got, old)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	keys := &Keys{DB: dbtest.Open(t)}
+	ctx := context.Background()
+	token, key, err := keys.Mint(ctx, "ci", nil, "viewer", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := keys.Revoke(ctx, key.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keys.Authenticate(ctx, token); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("err = %v, want ErrInvalidKey", err)
+	}
+	// 失効済みと存在しないキーは見つからない
+	if err := keys.Revoke(ctx, key.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+	if err := keys.Revoke(ctx, key.ID+1); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	keys := &Keys{DB: dbtest.Open(t)}
+	ctx := context.Background()
+	past := time.Now().UTC().Add(-time.Minute)
+	future := time.Now().UTC().Add(time.Hour)
+	expired, _, err := keys.Mint(ctx, "expired", nil, "viewer", &past)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid, _, err := keys.Mint(ctx, "valid", nil, "viewer", &future)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keys.Authenticate(ctx, expired); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("err = %v, want ErrInvalidKey", err)
+	}
+	if _, err := keys.Authenticate(ctx, valid); err != nil {
+		t.Fatal(err)
+	}
+}
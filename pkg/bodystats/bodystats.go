@@ -0,0 +1,216 @@
+// Package bodystats はbwhの計測値の統計(平均、中央値、パーセンタイル、ヒストグラム)と、
+// entryごとの指標(ウエスト・ヒップ比、BMI、全体の中でのパーセンタイル順位)を計算する
+package bodystats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+// ヒストグラムの区間の数の既定値と上限
+const (
+	DefaultBins = 10
+	MaxBins     = 50
+)
+
+// DefaultPercentiles は既定で返すパーセンタイル
+var DefaultPercentiles = []float64{5, 25, 75, 95}
+
+// 指定できるパーセンタイルの数の上限
+const maxPercentiles = 20
+
+// measure はbwhの計測値1つ。値がない場合はfalse
+type measure struct {
+	name  string
+	value func(model.BWH) (int64, bool)
+}
+
+// measures は統計を取る計測値。身長と体重は登録されていない行がある
+var measures = []measure{
+	{"bust", func(b model.BWH) (int64, bool) { return b.Bust, true }},
+	{"waist", func(b model.BWH) (int64, bool) { return b.Waist, true }},
+	{"hip", func(b model.BWH) (int64, bool) { return b.Hip, true }},
+	{"height", func(b model.BWH) (int64, bool) { return deref(b.Height) }},
+	{"weight", func(b model.BWH) (int64, bool) { return deref(b.Weight) }},
+}
+
+func deref(v *int64) (int64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	return *v, true
+}
+
+// Bin はヒストグラムの区間1つ。Fromを含み、Toを含まない
+type Bin struct {
+	From  int64 `json:"from"`
+	To    int64 `json:"to"`
+	Count int   `json:"count"`
+}
+
+// Summary は1つの計測値の統計
+type Summary struct {
+	Count  int     `json:"count"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	// StdDev は母標準偏差
+	StdDev float64 `json:"stddev"`
+	Min    int64   `json:"min"`
+	Max    int64   `json:"max"`
+	// Percentiles は"p25"のようなキーとパーセンタイル。線形補間で求める
+	Percentiles map[string]float64 `json:"percentiles"`
+	Histogram   []Bin              `json:"histogram"`
+}
+
+// Stats は計測値ごとの統計。値が1つもない計測値はnull
+type Stats struct {
+	// Count はbwhの行数
+	Count  int      `json:"count"`
+	Bust   *Summary `json:"bust"`
+	Waist  *Summary `json:"waist"`
+	Hip    *Summary `json:"hip"`
+	Height *Summary `json:"height"`
+	Weight *Summary `json:"weight"`
+}
+
+// ParsePercentiles はカンマ区切りのパーセンタイル(0〜100)を読む
+func ParsePercentiles(s string) ([]float64, error) {
+	var ps []float64
+	for _, v := range strings.Split(s, ",") {
+		p, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid percentile %q: want 0-100", v)
+		}
+		ps = append(ps, p)
+	}
+	if len(ps) > maxPercentiles {
+		return nil, fmt.Errorf("at most %d percentiles can be requested", maxPercentiles)
+	}
+	return ps, nil
+}
+
+// Options は統計の取り方
+type Options struct {
+	Percentiles []float64
+	Bins        int
+}
+
+// scale はヒストグラムの区間の始まりと幅
+type scale struct {
+	start, width int64
+	bins         int
+}
+
+// Calculator は行の集まりの統計を計算する
+// ヒストグラムの区間は全体の値から決めるため、グループごとの統計を同じ区間で比べられる
+type Calculator struct {
+	percentiles []float64
+	scales      []scale
+}
+
+// NewCalculator はallの値の範囲をo.Bins個以下の整数の幅の区間に分ける
+func NewCalculator(all []model.BWH, o Options) *Calculator {
+	if o.Bins <= 0 {
+		o.Bins = DefaultBins
+	}
+	if o.Percentiles == nil {
+		o.Percentiles = DefaultPercentiles
+	}
+	c := &Calculator{percentiles: o.Percentiles}
+	for _, m := range measures {
+		values := collect(all, m)
+		if len(values) == 0 {
+			c.scales = append(c.scales, scale{})
+			continue
+		}
+		lo, hi := values[0], values[len(values)-1]
+		// 最大値を含むように+1する
+		width := (hi - lo + int64(o.Bins)) / int64(o.Bins)
+		c.scales = append(c.scales, scale{start: lo, width: width, bins: int((hi-lo)/width) + 1})
+	}
+	return c
+}
+
+// collect は計測値を昇順で返す
+func collect(rows []model.BWH, m measure) []int64 {
+	values := make([]int64, 0, len(rows))
+	for _, b := range rows {
+		if v, ok := m.value(b); ok {
+			values = append(values, v)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values
+}
+
+// Stats はrowsの統計を返す
+func (c *Calculator) Stats(rows []model.BWH) Stats {
+	s := Stats{Count: len(rows)}
+	fields := []**Summary{&s.Bust, &s.Waist, &s.Hip, &s.Height, &s.Weight}
+	for i, m := range measures {
+		values := collect(rows, m)
+		if len(values) == 0 {
+			continue
+		}
+		*fields[i] = c.summary(values, c.scales[i])
+	}
+	return s
+}
+
+func (c *Calculator) summary(values []int64, sc scale) *Summary {
+	var sum float64
+	for _, v := range values {
+		sum += float64(v)
+	}
+	mean := sum / float64(len(values))
+	var squares float64
+	for _, v := range values {
+		squares += (float64(v) - mean) * (float64(v) - mean)
+	}
+	s := &Summary{
+		Count:       len(values),
+		Mean:        round(mean, 2),
+		Median:      round(percentile(values, 50), 2),
+		StdDev:      round(math.Sqrt(squares/float64(len(values))), 2),
+		Min:         values[0],
+		Max:         values[len(values)-1],
+		Percentiles: make(map[string]float64, len(c.percentiles)),
+		Histogram:   make([]Bin, sc.bins),
+	}
+	for _, p := range c.percentiles {
+		s.Percentiles["p"+strconv.FormatFloat(p, 'f', -1, 64)] = round(percentile(values, p), 2)
+	}
+	for i := range s.Histogram {
+		from := sc.start + int64(i)*sc.width
+		s.Histogram[i] = Bin{From: from, To: from + sc.width}
+	}
+	for _, v := range values {
+		if sc.bins == 0 {
+			break
+		}
+		// NewCalculatorに渡していない行の値は端の区間に数える
+		i := min(max((v-sc.start)/sc.width, 0), int64(sc.bins-1))
+		s.Histogram[i].Count++
+	}
+	return s
+}
+
+// percentile は昇順の値のpパーセンタイルを、隣り合う値の線形補間で求める
+func percentile(sorted []int64, p float64) float64 {
+	pos := p / 100 * float64(len(sorted)-1)
+	i := int(math.Floor(pos))
+	if i+1 >= len(sorted) {
+		return float64(sorted[len(sorted)-1])
+	}
+	return float64(sorted[i]) + (pos-float64(i))*float64(sorted[i+1]-sorted[i])
+}
+
+func round(v float64, digits int) float64 {
+	p := math.Pow(10, float64(digits))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,136 @@
+package bodystats
+
+import (
+	"fmt"
+	"sort"
+
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+// Metrics はentry1件の計測値から計算する指標
+type Metrics struct {
+	EntryID int64 `json:"entry_id"`
+	// WaistHipRatio はウエスト÷ヒップ
+	WaistHipRatio *float64 `json:"waist_hip_ratio"`
+	// BMI は体重(kg)÷身長(m)の2乗。身長と体重の両方がある場合だけ計算する
+	BMI *float64 `json:"bmi"`
+	// PercentileRanks は計測値と指標ごとの全体の中での順位(0〜100)。値が大きいほど大きい
+	// 同じ値は半分ずつ数える。値がない計測値と指標は含めない
+	PercentileRanks map[string]float64 `json:"percentile_ranks"`
+}
+
+// metric は計測値か、計測値から計算する指標
+type metric struct {
+	name  string
+	value func(model.BWH) (float64, bool)
+}
+
+// metrics はパーセンタイル順位を求める計測値と指標。並べ替えにも使う
+var metrics = func() []metric {
+	var ms []metric
+	for _, m := range measures {
+		value := m.value
+		ms = append(ms, metric{m.name, func(b model.BWH) (float64, bool) {
+			v, ok := value(b)
+			return float64(v), ok
+		}})
+	}
+	return append(ms, metric{"waist_hip_ratio", waistHipRatio}, metric{"bmi", bmi})
+}()
+
+func waistHipRatio(b model.BWH) (float64, bool) {
+	if b.Hip <= 0 {
+		return 0, false
+	}
+	return float64(b.Waist) / float64(b.Hip), true
+}
+
+func bmi(b model.BWH) (float64, bool) {
+	if b.Height == nil || b.Weight == nil || *b.Height <= 0 {
+		return 0, false
+	}
+	m := float64(*b.Height) / 100
+	return float64(*b.Weight) / (m * m), true
+}
+
+// Ranker は全体の値と比べてentryの指標を計算する
+type Ranker struct {
+	// sorted は指標ごとの全体の値。metricsと同じ順で昇順
+	sorted [][]float64
+}
+
+// NewRanker はallを全体としてパーセンタイル順位を求めるRankerを返す
+func NewRanker(all []model.BWH) *Ranker {
+	r := &Ranker{}
+	for _, m := range metrics {
+		values := make([]float64, 0, len(all))
+		for _, b := range all {
+			if v, ok := m.value(b); ok {
+				values = append(values, v)
+			}
+		}
+		sort.Float64s(values)
+		r.sorted = append(r.sorted, values)
+	}
+	return r
+}
+
+// Metrics はbの指標を返す
+func (r *Ranker) Metrics(b model.BWH) Metrics {
+	m := Metrics{EntryID: b.EntryID, PercentileRanks: map[string]float64{}}
+	if v, ok := waistHipRatio(b); ok {
+		v = round(v, 3)
+		m.WaistHipRatio = &v
+	}
+	if v, ok := bmi(b); ok {
+		v = round(v, 1)
+		m.BMI = &v
+	}
+	for i, metric := range metrics {
+		v, ok := metric.value(b)
+		values := r.sorted[i]
+		if !ok || len(values) == 0 {
+			continue
+		}
+		below := sort.SearchFloat64s(values, v)
+		equal := sort.Search(len(values), func(j int) bool { return values[j] > v }) - below
+		m.PercentileRanks[metric.name] = round((float64(below)+float64(equal)/2)/float64(len(values))*100, 1)
+	}
+	return m
+}
+
+// Names は計測値と指標の名前
+func Names() []string {
+	names := make([]string, len(metrics))
+	for i, m := range metrics {
+		names[i] = m.name
+	}
+	return names
+}
+
+// Sort はrowsを指標の値で並べ替える。値がない行は順序によらず最後にし、同じ値はentry_id順にする
+func Sort(rows []model.BWH, name string, desc bool) error {
+	var value func(model.BWH) (float64, bool)
+	for _, m := range metrics {
+		if m.name == name {
+			value = m.value
+		}
+	}
+	if value == nil {
+		return fmt.Errorf("unknown metric %q", name)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, aok := value(rows[i])
+		b, bok := value(rows[j])
+		switch {
+		case aok != bok:
+			return aok
+		case !aok || a == b:
+			return rows[i].EntryID < rows[j].EntryID
+		case desc:
+			return a > b
+		}
+		return a < b
+	})
+	return nil
+}
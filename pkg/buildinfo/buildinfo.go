@@ -0,0 +1,38 @@
+package buildinfo
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+)
+
+// Info はビルド時の情報
+type Info struct {
+	Commit    string `json:"commit"`
+	Modified  bool   `json:"modified"`
+	GoVersion string `json:"go_version"`
+}
+
+// Read はバイナリに埋め込まれたVCS情報を読み取る
+// Vercelのビルドでは埋め込まれないため、VERCEL_GIT_COMMIT_SHAで補う
+func Read() Info {
+	info := Info{
+		Commit:    os.Getenv("VERCEL_GIT_COMMIT_SHA"),
+		GoVersion: runtime.Version(),
+	}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = s.Value
+			}
+		case "vcs.modified":
+			info.Modified = s.Value == "true"
+		}
+	}
+	return info
+}
@@ -0,0 +1,340 @@
+package catalog
+
+// Filter はGETで指定できるクエリパラメータ
+type Filter struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Repeatable  bool   `json:"repeatable"`
+	// Type はJSON Schemaの型(integer, number, string, boolean)。空の場合はint64のid
+	Type     string `json:"type,omitempty"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// Endpoint はリソースの下にある読み込み専用のURL(/api/v1/entry/similar など)。GETだけを受け付ける
+// 権限とスコープは親のリソースの読み込みとして扱う
+type Endpoint struct {
+	Name        string   `json:"name"`
+	Path        string   `json:"url"`
+	Description string   `json:"description"`
+	Params      []Filter `json:"params"`
+	// Source はハンドラのソースファイル
+	Source string `json:"-"`
+}
+
+// Resource は /api/v1 以下のリソース1つ分
+type Resource struct {
+	Name          string   `json:"name"`
+	Path          string   `json:"url"`
+	Table         string   `json:"table"`
+	Description   string   `json:"description"`
+	Methods       []string `json:"methods"`
+	Filters       []Filter `json:"filters"`
+	CollectionKey string   `json:"collection_key"`
+	// Key はPUTとDELETEで行を特定するカラム
+	Key       string     `json:"key"`
+	Endpoints []Endpoint `json:"endpoints,omitempty"`
+	// Source はハンドラのソースファイル
+	Source string `json:"-"`
+}
+
+var crud = []string{"GET", "POST", "PUT", "DELETE"}
+
+func byID(name string) []Filter {
+	return []Filter{{Name: "id", Description: name + "のID。複数指定した場合はいずれかに一致するものを返す", Repeatable: true}}
+}
+
+func byEntryID() []Filter {
+	return []Filter{{Name: "entry_id", Description: "entryのID。複数指定した場合はいずれかに一致するものを返す", Repeatable: true}}
+}
+
+// Resources は全てのv1リソースの一覧
+var Resources = []Resource{
+	{
+		Name:          "entry",
+		Path:          "/api/v1/entry",
+		Table:         "entry",
+		Description:   "キャラクター",
+		Methods:       crud,
+		Filters:       byID("entry"),
+		CollectionKey: "entries",
+		Key:           "id",
+		Source:        "api/v1/entry/entry.go",
+		Endpoints: []Endpoint{
+			{
+				Name:        "similar",
+				Path:        "/api/v1/entry/similar",
+				Description: "似ているキャラクター。髪・目・性格・タグ・スリーサイズ・癖レーダーチャートの類似度の重み付き平均が高い順に返す",
+				Params: []Filter{
+					{Name: "id", Description: "基準にするentryのID", Required: true},
+					{Name: "k", Description: "返す件数(1〜100)。既定は10", Type: "integer"},
+					{Name: "weights", Description: "属性ごとの重み。例: tags:5,bwh:0。指定のない属性は既定の重みで、0の属性は比べない。属性はhair_color, hair_style, hair_length, eye_color, personality, tags, bwh, heki_radar_chart", Type: "string"},
+				},
+				Source: "api/v1/entry/similar/similar.go",
+			},
+			{
+				Name:        "random",
+				Path:        "/api/v1/entry/random",
+				Description: "条件に一致するキャラクターを1件選ぶ。同じシードでは同じキャラクターを返し、dailyではタイムゾーンの日付ごとに「今日のキャラクター」を返す",
+				Params: []Filter{
+					{Name: "query", Description: "名前か説明に含まれる文字列", Type: "string"},
+					{Name: "source_id", Description: "作品のID"},
+					{Name: "tag_id", Description: "タグのID"},
+					{Name: "haircolor_id", Description: "髪色(haircolor_type)のID"},
+					{Name: "eyecolor_id", Description: "目の色(eyecolor_type)のID"},
+					{Name: "hairstyle_id", Description: "髪型(hairstyle_type)のID"},
+					{Name: "hairlength_id", Description: "髪の長さ(hairlength_type)のID"},
+					{Name: "personality_id", Description: "性格(personality_type)のID"},
+					{Name: "exclude_nsfw", Description: "trueの場合は成人向けのリンクがあるキャラクターを除く", Type: "boolean"},
+					{Name: "seed", Description: "選び方を決めるシード。省略した場合は選んだシードをレスポンスで返す", Type: "string"},
+					{Name: "daily", Description: "trueの場合はtzの日付をシードにし、日付が変わるまでキャッシュできる", Type: "boolean"},
+					{Name: "tz", Description: "dailyの日付を決めるタイムゾーン(IANA)。既定はUTC", Type: "string"},
+				},
+				Source: "api/v1/entry/random/random.go",
+			},
+		},
+	},
+	{
+		Name:          "entry_tag",
+		Path:          "/api/v1/entry_tag",
+		Table:         "entry_tag",
+		Description:   "キャラクターとタグの紐付け",
+		Methods:       crud,
+		Filters:       byID("entry_tag"),
+		CollectionKey: "entry_tags",
+		Key:           "id",
+		Source:        "api/v1/entry_tag/entry_tag.go",
+		Endpoints: []Endpoint{
+			{
+				Name:        "recommend",
+				Path:        "/api/v1/entry_tag/recommend",
+				Description: "一緒に付きやすいタグと、それらのタグが付いたキャラクター。統計はcmd/cooccurrenceで作り直す",
+				Params: []Filter{
+					{Name: "entry_id", Description: "基準にするentryのID。そのentryのタグを基準にし、結果からは除く"},
+					{Name: "tag_id", Description: "基準にするタグのID。entry_idを指定しない場合に使う", Repeatable: true},
+					{Name: "limit", Description: "タグとentryそれぞれの件数(1〜100)。既定は10", Type: "integer"},
+					{Name: "metric", Description: "関連の強さの指標。pmi(既定)かlift", Type: "string"},
+				},
+				Source: "api/v1/entry_tag/recommend/recommend.go",
+			},
+		},
+	},
+	{
+		Name:          "link",
+		Path:          "/api/v1/link",
+		Table:         "link",
+		Description:   "キャラクターに関連するリンク",
+		Methods:       crud,
+		Filters:       byID("link"),
+		CollectionKey: "links",
+		Key:           "id",
+		Source:        "api/v1/link/link.go",
+	},
+	{
+		Name:          "bwh",
+		Path:          "/api/v1/bwh",
+		Table:         "bwh",
+		Description:   "スリーサイズと身長・体重",
+		Methods:       crud,
+		Filters:       byEntryID(),
+		CollectionKey: "bwhs",
+		Key:           "entry_id",
+		Source:        "api/v1/bwh/bwh.go",
+		Endpoints: []Endpoint{
+			{
+				Name:        "stats",
+				Path:        "/api/v1/bwh/stats",
+				Description: "バスト・ウエスト・ヒップ・身長・体重の統計(件数、平均、中央値、標準偏差、最小、最大、パーセンタイル、ヒストグラム)。身長と体重は値のある行だけを数え、値が1つもない場合はnull。ヒストグラムの区間は全体の値から決め、グループでも同じ区間を使う",
+				Params: []Filter{
+					{Name: "source_id", Description: "作品のID。複数指定した場合はいずれかの作品のentryを対象にする", Repeatable: true},
+					{Name: "tag_id", Description: "タグのID。複数指定した場合はいずれかのタグが付いたentryを対象にする", Repeatable: true},
+					{Name: "group_by", Description: "sourceかtag。作品かタグごとの統計もgroupsで返す。タグの場合、entryは付いているタグそれぞれのグループに入る", Type: "string"},
+					{Name: "bins", Description: "ヒストグラムの区間の数の上限(1〜50)。区間の幅は整数。既定は10", Type: "integer"},
+					{Name: "percentiles", Description: "返すパーセンタイル(0〜100)。カンマ区切りで最大20個。既定は5,25,75,95", Type: "string"},
+				},
+				Source: "api/v1/bwh/stats/stats.go",
+			},
+			{
+				Name:        "metrics",
+				Path:        "/api/v1/bwh/metrics",
+				Description: "entryごとのウエスト・ヒップ比、BMI(身長と体重の両方がある場合)と、計測値と指標ごとの全件の中でのパーセンタイル順位",
+				Params: []Filter{
+					{Name: "entry_id", Description: "entryのID。指定しない場合は全件を返す", Repeatable: true},
+					{Name: "sort", Description: "並べ替える計測値か指標。bust, waist, hip, height, weight, waist_hip_ratio, bmi。値のないentryは最後", Type: "string"},
+					{Name: "order", Description: "desc(既定)かasc", Type: "string"},
+					{Name: "limit", Description: "返す件数(1〜1000)。既定は全件", Type: "integer"},
+				},
+				Source: "api/v1/bwh/metrics/metrics.go",
+			},
+		},
+	},
+	{
+		Name:          "haircolor",
+		Path:          "/api/v1/haircolor",
+		Table:         "haircolor",
+		Description:   "髪色",
+		Methods:       crud,
+		Filters:       byEntryID(),
+		CollectionKey: "haircolors",
+		Key:           "entry_id",
+		Source:        "api/v1/haircolor/haircolor.go",
+	},
+	{
+		Name:          "haircolor_type",
+		Path:          "/api/v1/haircolor_type",
+		Table:         "haircolor_type",
+		Description:   "髪色の種類",
+		Methods:       crud,
+		Filters:       byID("haircolor_type"),
+		CollectionKey: "haircolor_types",
+		Key:           "id",
+		Source:        "api/v1/haircolor_type/haircolor_type.go",
+	},
+	{
+		Name:          "hairstyle",
+		Path:          "/api/v1/hairstyle",
+		Table:         "hairstyle",
+		Description:   "髪型",
+		Methods:       crud,
+		Filters:       byEntryID(),
+		CollectionKey: "hair_styles",
+		Key:           "entry_id",
+		Source:        "api/v1/hairstyle/hairstyle.go",
+	},
+	{
+		Name:          "hairstyle_type",
+		Path:          "/api/v1/hairstyle_type",
+		Table:         "hairstyle_type",
+		Description:   "髪型の種類",
+		Methods:       crud,
+		Filters:       byID("hairstyle_type"),
+		CollectionKey: "hairstyle_types",
+		Key:           "id",
+		Source:        "api/v1/hairstyle_type/hairstyle_type.go",
+	},
+	{
+		Name:          "hairlength",
+		Path:          "/api/v1/hairlength",
+		Table:         "hairlength",
+		Description:   "髪の長さ",
+		Methods:       crud,
+		Filters:       byEntryID(),
+		CollectionKey: "hairlengths",
+		Key:           "entry_id",
+		Source:        "api/v1/hairlength/hairlength.go",
+	},
+	{
+		Name:          "hairlength_type",
+		Path:          "/api/v1/hairlength_type",
+		Table:         "hairlength_type",
+		Description:   "髪の長さの種類",
+		Methods:       crud,
+		Filters:       byID("hairlength_type"),
+		CollectionKey: "hairlength_types",
+		Key:           "id",
+		Source:        "api/v1/hairlength_type/hairlength_type.go",
+	},
+	{
+		Name:          "eyescolor",
+		Path:          "/api/v1/eyescolor",
+		Table:         "eyecolor",
+		Description:   "目の色",
+		Methods:       crud,
+		Filters:       byEntryID(),
+		CollectionKey: "eyecolors",
+		Key:           "entry_id",
+		Source:        "api/v1/eyescolor/eyescolor.go",
+	},
+	{
+		Name:          "eyescolor_type",
+		Path:          "/api/v1/eyescolor_type",
+		Table:         "eyecolor_type",
+		Description:   "目の色の種類",
+		Methods:       crud,
+		Filters:       byID("eyescolor_type"),
+		CollectionKey: "eyecolor_types",
+		Key:           "id",
+		Source:        "api/v1/eyescolor_type/eyescolor_type.go",
+	},
+	{
+		Name:          "personality",
+		Path:          "/api/v1/personality",
+		Table:         "personality",
+		Description:   "性格",
+		Methods:       crud,
+		Filters:       byEntryID(),
+		CollectionKey: "personalities",
+		Key:           "entry_id",
+		Source:        "api/v1/personality/personality.go",
+	},
+	{
+		Name:          "personality_type",
+		Path:          "/api/v1/personality_type",
+		Table:         "personality_type",
+		Description:   "性格の種類",
+		Methods:       crud,
+		Filters:       byID("personality_type"),
+		CollectionKey: "personality_types",
+		Key:           "id",
+		Source:        "api/v1/personality_type/personality_type.go",
+	},
+	{
+		Name:          "heki_radar_chart",
+		Path:          "/api/v1/heki_radar_chart",
+		Table:         "heki_radar_chart",
+		Description:   "癖レーダーチャート。radar_chartのaiとnuの軸を以前の形で読み書きする互換のビュー",
+		Methods:       crud,
+		Filters:       byEntryID(),
+		CollectionKey: "heki_radar_charts",
+		Key:           "entry_id",
+		Source:        "api/v1/heki_radar_chart/heki_radar_chart.go",
+		Endpoints: []Endpoint{
+			{
+				Name:        "svg",
+				Path:        "/api/v1/heki_radar_chart/svg",
+				Description: "レーダーチャートの画像(SVG、format=pngでPNG)。radar_axisの全ての軸を表示する順に描き、entry_idを複数指定すると重ねて描く。PNGには文字と凡例を描かない。内容のハッシュをETagにし、5分間キャッシュできる",
+				Params: []Filter{
+					{Name: "entry_id", Description: "描くentryのID。最大8件で、指定した順に色を割り当てる", Repeatable: true, Required: true},
+					{Name: "theme", Description: "light(既定)かdark", Type: "string"},
+					{Name: "palette", Description: "系列の色。カンマ区切りのrrggbb。例: e91e63,2196f3", Type: "string"},
+					{Name: "size", Description: "一辺の大きさ(120〜1024px)。既定は400", Type: "integer"},
+					{Name: "rings", Description: "目盛りの輪の数(1〜10)。既定は4", Type: "integer"},
+					{Name: "format", Description: "svg(既定)かpng", Type: "string"},
+					{Name: "lang", Description: "軸の名前の言語。ja(既定)かen", Type: "string"},
+				},
+				Source: "api/v1/heki_radar_chart/svg/svg.go",
+			},
+		},
+	},
+	{
+		Name:          "radar_axis",
+		Path:          "/api/v1/radar_axis",
+		Table:         "radar_axis",
+		Description:   "レーダーチャートの軸。値の範囲(min〜max)と表示する順を持つ。キーがaiとnuの軸はheki_radar_chartが使う。軸を削除するとその軸の値も削除する",
+		Methods:       crud,
+		Filters:       byID("radar_axis"),
+		CollectionKey: "radar_axes",
+		Key:           "id",
+		Source:        "api/v1/radar_axis/radar_axis.go",
+	},
+	{
+		Name:          "radar_chart",
+		Path:          "/api/v1/radar_chart",
+		Table:         "radar_value",
+		Description:   "entryごとのレーダーチャートの値。valuesは軸のキーと値で、値は軸の範囲にある必要がある。PUTはentryの値を全て置き換える",
+		Methods:       crud,
+		Filters:       byEntryID(),
+		CollectionKey: "radar_charts",
+		Key:           "entry_id",
+		Source:        "api/v1/radar_chart/radar_chart.go",
+	},
+}
+
+// Lookup は名前からリソースを探す
+func Lookup(name string) (Resource, bool) {
+	for _, r := range Resources {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Resource{}, false
+}
@@ -0,0 +1,208 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec はリクエスト・レスポンスのボディのエンコード方式
+// どの方式でも構造体のjsonタグをフィールド名として使う
+type Codec interface {
+	// MediaType はContent-Typeに使うメディアタイプ
+	MediaType() string
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+var (
+	JSON        Codec = jsonCodec{}
+	MessagePack Codec = msgpackCodec{}
+	CBOR        Codec = newCBOR()
+)
+
+// MediaTypes は対応しているメディアタイプ。先頭がデフォルト
+var MediaTypes = []string{"application/json", "application/msgpack", "application/cbor"}
+
+// codecs はメディアタイプと方式の対応。x-付きの古い名前も受け付ける
+var codecs = map[string]Codec{
+	"application/json":      JSON,
+	"application/msgpack":   MessagePack,
+	"application/x-msgpack": MessagePack,
+	"application/cbor":      CBOR,
+}
+
+// Lookup はメディアタイプに対応する方式を返す
+func Lookup(mediaType string) (Codec, bool) {
+	c, ok := codecs[strings.ToLower(mediaType)]
+	return c, ok
+}
+
+// ForRequest はContent-Typeからリクエストボディの方式を返す
+// Content-Typeがない場合はJSONとして扱う
+func ForRequest(r *http.Request) (Codec, bool) {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return JSON, true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+	return Lookup(mediaType)
+}
+
+// ForResponse はAcceptからレスポンスの方式を返す
+// 対応している方式がない場合はJSONを返す
+func ForResponse(r *http.Request) Codec {
+	c, ok := Negotiate(r)
+	if !ok {
+		return JSON
+	}
+	return c
+}
+
+// Negotiate はAcceptのqの大きいものから対応している方式を選ぶ
+// Acceptがない場合と*/*、application/*はJSONとして扱い、対応している方式がない場合はfalseを返す
+func Negotiate(r *http.Request) (Codec, bool) {
+	type candidate struct {
+		codec Codec
+		q     float64
+	}
+	var candidates []candidate
+	present := false
+	for _, accept := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			present = true
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil {
+				continue
+			}
+			c, ok := Lookup(mediaType)
+			if mediaType == "*/*" || mediaType == "application/*" {
+				c, ok = JSON, true
+			}
+			if !ok {
+				continue
+			}
+			q := 1.0
+			if s, ok := params["q"]; ok {
+				q, err = strconv.ParseFloat(s, 64)
+				if err != nil || q <= 0 {
+					continue
+				}
+			}
+			candidates = append(candidates, candidate{c, q})
+		}
+	}
+	if !present {
+		return JSON, true
+	}
+	if len(candidates) == 0 {
+		return nil, false
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	return candidates[0].codec, true
+}
+
+// Unmarshal はContent-Typeに従ってリクエストボディをデコードする
+func Unmarshal(r *http.Request, data []byte, v interface{}) error {
+	c, ok := ForRequest(r)
+	if !ok {
+		return &UnsupportedError{ContentType: r.Header.Get("Content-Type")}
+	}
+	return c.Unmarshal(data, v)
+}
+
+// UnsupportedError は対応していないContent-Typeのエラー
+type UnsupportedError struct {
+	ContentType string
+}
+
+func (e *UnsupportedError) Error() string {
+	return "unsupported content type: " + e.ContentType
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) MediaType() string {
+	return "application/json"
+}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) MediaType() string {
+	return "application/msgpack"
+}
+
+func (msgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
+
+type cborCodec struct {
+	enc cbor.EncMode
+	dec cbor.DecMode
+}
+
+// newCBOR は時刻をRFC3339の文字列(タグ0)でエンコードし、mapを文字列キーでデコードする設定を作る
+func newCBOR() cborCodec {
+	enc, err := cbor.EncOptions{
+		Time:    cbor.TimeRFC3339Nano,
+		TimeTag: cbor.EncTagRequired,
+	}.EncMode()
+	if err != nil {
+		panic(err)
+	}
+	dec, err := cbor.DecOptions{
+		DefaultMapType: reflect.TypeOf(map[string]interface{}(nil)),
+	}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	return cborCodec{enc: enc, dec: dec}
+}
+
+func (cborCodec) MediaType() string {
+	return "application/cbor"
+}
+
+func (c cborCodec) Marshal(v interface{}) ([]byte, error) {
+	return c.enc.Marshal(v)
+}
+
+func (c cborCodec) Unmarshal(data []byte, v interface{}) error {
+	return c.dec.Unmarshal(data, v)
+}
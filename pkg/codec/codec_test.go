@@ -0,0 +1,163 @@
+package codec
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept []string
+		want   Codec
+		ok     bool
+	}{
+		{"no accept", nil, JSON, true},
+		{"json", []string{"application/json"}, JSON, true},
+		{"msgpack", []string{"application/msgpack"}, MessagePack, true},
+		{"x-msgpack", []string{"application/x-msgpack"}, MessagePack, true},
+		{"cbor", []string{"application/cbor"}, CBOR, true},
+		{"case insensitive", []string{"Application/CBOR"}, CBOR, true},
+		{"wildcard", []string{"*/*"}, JSON, true},
+		{"application wildcard", []string{"application/*"}, JSON, true},
+		{"first of equal q", []string{"application/cbor, application/msgpack"}, CBOR, true},
+		{"highest q", []string{"application/json;q=0.5, application/msgpack;q=0.9, application/cbor;q=0.7"}, MessagePack, true},
+		{"default q is 1", []string{"application/msgpack;q=0.8, application/cbor"}, CBOR, true},
+		{"wildcard below explicit", []string{"*/*;q=0.1, application/cbor"}, CBOR, true},
+		{"multiple headers", []string{"text/html", "application/msgpack"}, MessagePack, true},
+		{"unsupported skipped", []string{"text/html, application/cbor;q=0.2"}, CBOR, true},
+		{"invalid q skipped", []string{"application/cbor;q=x, application/msgpack;q=0.1"}, MessagePack, true},
+		// 対応している方式がない場合は406にする
+		{"unsupported", []string{"text/html"}, nil, false},
+		{"q zero", []string{"application/json;q=0"}, nil, false},
+		{"malformed", []string{"application/"}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			for _, a := range tt.accept {
+				r.Header.Add("Accept", a)
+			}
+			got, ok := Negotiate(r)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Negotiate = %v, %v; want %v, %v", got, ok, tt.want, tt.ok)
+			}
+			// ForResponseは対応している方式がない場合もJSONを返す
+			want := tt.want
+			if !tt.ok {
+				want = JSON
+			}
+			if got := ForResponse(r); got != want {
+				t.Errorf("ForResponse = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestForRequest(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        Codec
+		ok          bool
+	}{
+		{"", JSON, true},
+		{"application/json", JSON, true},
+		{"application/json; charset=utf-8", JSON, true},
+		{"application/msgpack", MessagePack, true},
+		{"application/x-msgpack", MessagePack, true},
+		{"application/cbor", CBOR, true},
+		{"text/plain", nil, false},
+		{"not a media type", nil, false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/", nil)
+		if tt.contentType != "" {
+			r.Header.Set("Content-Type", tt.contentType)
+		}
+		got, ok := ForRequest(r)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ForRequest(%q) = %v, %v; want %v, %v", tt.contentType, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Set("Content-Type", "text/plain")
+	var v map[string]interface{}
+	var unsupported *UnsupportedError
+	if err := Unmarshal(r, []byte("{}"), &v); !errors.As(err, &unsupported) || unsupported.ContentType != "text/plain" {
+		t.Errorf("Unmarshal error = %v", err)
+	}
+}
+
+// row はハンドラのレスポンスと同じくjsonタグだけを持つ
+type row struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Tags      []string  `json:"tags"`
+	Nsfw      bool      `json:"nsfw"`
+	Ratio     float64   `json:"ratio"`
+	Parent    *int64    `json:"parent_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type rowsJson struct {
+	Rows []row `json:"rows"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	parent := int64(7)
+	in := rowsJson{Rows: []row{
+		{ID: 1, Name: "一号", Tags: []string{"眼鏡"}, Nsfw: true, Ratio: 0.5, Parent: &parent, CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)},
+		{ID: 2, Name: "", Tags: []string{}, CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}}
+	for _, c := range []Codec{JSON, MessagePack, CBOR} {
+		t.Run(c.MediaType(), func(t *testing.T) {
+			b, err := c.Marshal(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var out rowsJson
+			if err := c.Unmarshal(b, &out); err != nil {
+				t.Fatal(err)
+			}
+			if len(out.Rows) != len(in.Rows) {
+				t.Fatalf("rows = %+v", out.Rows)
+			}
+			for i := range in.Rows {
+				want, got := in.Rows[i], out.Rows[i]
+				if !got.CreatedAt.Equal(want.CreatedAt) {
+					t.Errorf("created_at = %v, want %v", got.CreatedAt, want.CreatedAt)
+				}
+				got.CreatedAt, want.CreatedAt = time.Time{}, time.Time{}
+				if len(got.Tags) == 0 && len(want.Tags) == 0 {
+					got.Tags, want.Tags = nil, nil
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("row = %+v, want %+v", got, want)
+				}
+			}
+
+			// フィールド名はjsonタグを使う
+			var generic map[string]interface{}
+			if err := c.Unmarshal(b, &generic); err != nil {
+				t.Fatal(err)
+			}
+			rows, _ := generic["rows"].([]interface{})
+			if len(rows) != 2 {
+				t.Fatalf("generic = %v", generic)
+			}
+			first, _ := rows[0].(map[string]interface{})
+			for _, key := range []string{"id", "name", "tags", "nsfw", "ratio", "parent_id", "created_at"} {
+				if _, ok := first[key]; !ok {
+					t.Errorf("key %q is missing: %v", key, first)
+				}
+			}
+			if len(first) != 7 {
+				t.Errorf("keys = %v", first)
+			}
+		})
+	}
+}
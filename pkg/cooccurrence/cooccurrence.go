@@ -0,0 +1,81 @@
+// Package cooccurrence はentry_tagからタグの共起の統計を作り、関連するタグとentryを推薦する
+package cooccurrence
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// DefaultMinCount は統計に残す組の最小の共起数の既定値
+// 1回しか一緒に付いていない組はPMIが大きく出やすく、推薦の質を下げる
+const DefaultMinCount = 2
+
+// Compute はentry_tagの行から共起の統計を作る
+// PMIは log(N・n(a,b) / (n(a)・n(b)))、リフトはその真数。Nはタグが1つ以上付いたentryの数
+// n(a,b)がminCount未満の組は除く。tag_id = other_tag_idの行は常に残す
+func Compute(entryTags []model.EntryTag, minCount int64) []model.TagCooccurrence {
+	byEntry := map[int64][]int64{}
+	seen := map[[2]int64]bool{}
+	for _, et := range entryTags {
+		if seen[[2]int64{et.EntryID, et.TagID}] {
+			continue
+		}
+		seen[[2]int64{et.EntryID, et.TagID}] = true
+		byEntry[et.EntryID] = append(byEntry[et.EntryID], et.TagID)
+	}
+	n := float64(len(byEntry))
+	counts := map[int64]int64{}
+	pairs := map[[2]int64]int64{}
+	for _, tags := range byEntry {
+		for _, a := range tags {
+			counts[a]++
+			for _, b := range tags {
+				if a != b {
+					pairs[[2]int64{a, b}]++
+				}
+			}
+		}
+	}
+	for tag, c := range counts {
+		pairs[[2]int64{tag, tag}] = c
+	}
+
+	rows := make([]model.TagCooccurrence, 0, len(pairs))
+	for pair, c := range pairs {
+		if pair[0] != pair[1] && c < minCount {
+			continue
+		}
+		lift := n * float64(c) / (float64(counts[pair[0]]) * float64(counts[pair[1]]))
+		rows = append(rows, model.TagCooccurrence{
+			TagID:      pair[0],
+			OtherTagID: pair[1],
+			Count:      c,
+			PMI:        math.Log(lift),
+			Lift:       lift,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].TagID != rows[j].TagID {
+			return rows[i].TagID < rows[j].TagID
+		}
+		return rows[i].OtherTagID < rows[j].OtherTagID
+	})
+	return rows
+}
+
+// Refresh はentry_tagの全ての行から統計を作り直し、登録した行数を返す
+func Refresh(ctx context.Context, entryTags store.EntryTagRepository, stats store.TagCooccurrenceRepository, minCount int64) (int, error) {
+	rows, err := entryTags.List(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	computed := Compute(rows, minCount)
+	if err := stats.Replace(ctx, computed); err != nil {
+		return 0, err
+	}
+	return len(computed), nil
+}
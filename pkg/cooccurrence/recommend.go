@@ -0,0 +1,172 @@
+package cooccurrence
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// 関連の強さに使う指標
+const (
+	PMI  = "pmi"
+	Lift = "lift"
+)
+
+// 1つのタグから候補のentryを読む件数の上限
+const entriesPerTag = 1000
+
+// Sources は推薦に使うリポジトリ。テストではメモリの実装を渡す
+type Sources struct {
+	Entries   store.EntryRepository
+	EntryTags store.EntryTagRepository
+	Stats     store.TagCooccurrenceRepository
+}
+
+// NewSources はDBのリポジトリを返す
+func NewSources(db *database.DB) Sources {
+	return Sources{
+		Entries:   &store.Entries{DB: db},
+		EntryTags: &store.EntryTags{DB: db},
+		Stats:     &store.TagCooccurrences{DB: db},
+	}
+}
+
+// RelatedTag は推薦するタグ
+type RelatedTag struct {
+	ID    int64   `json:"id"`
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+	// Count は基準のタグそれぞれと一緒に付いているentryの数の合計
+	Count int64 `json:"count"`
+}
+
+// RelatedEntry は推薦するentry
+type RelatedEntry struct {
+	Entry model.Entry `json:"entry"`
+	Score float64     `json:"score"`
+	// TagIDs はスコアに寄与したタグ
+	TagIDs []int64 `json:"tag_ids"`
+}
+
+// association は行の関連の強さ。負の相関(一緒に付きにくい)は0にする
+func association(row model.TagCooccurrence, metric string) float64 {
+	if metric == Lift {
+		return math.Max(row.Lift-1, 0)
+	}
+	return math.Max(row.PMI, 0)
+}
+
+// Recommend はtagIDsと一緒に付きやすいタグと、それらのタグが付いたentryを最大limit件ずつ返す
+// タグのスコアは基準のタグそれぞれとの関連の強さの合計
+// entryのスコアは付いているタグのスコアの合計。基準のタグ自身はtag_id = other_tag_idの行(珍しいタグほど大きい)を使う
+// excludeのentry(推薦元)は含めない
+func (s Sources) Recommend(ctx context.Context, tagIDs []int64, exclude int64, metric string, limit int) ([]RelatedTag, []RelatedEntry, error) {
+	rows, err := s.Stats.Related(ctx, tagIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	base := map[int64]bool{}
+	for _, id := range tagIDs {
+		base[id] = true
+	}
+	weights := map[int64]float64{}
+	related := map[int64]*RelatedTag{}
+	for _, row := range rows {
+		a := association(row, metric)
+		if a <= 0 {
+			continue
+		}
+		weights[row.OtherTagID] += a
+		if base[row.OtherTagID] {
+			continue
+		}
+		t, ok := related[row.OtherTagID]
+		if !ok {
+			t = &RelatedTag{ID: row.OtherTagID, Name: row.OtherName}
+			related[row.OtherTagID] = t
+		}
+		t.Score += a
+		t.Count += row.Count
+	}
+	tags := make([]RelatedTag, 0, len(related))
+	for _, t := range related {
+		tags = append(tags, *t)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Score != tags[j].Score {
+			return tags[i].Score > tags[j].Score
+		}
+		return tags[i].ID < tags[j].ID
+	})
+	if len(tags) > limit {
+		tags = tags[:limit]
+	}
+
+	// 候補のentryは基準のタグと推薦するタグのいずれかが付いたentry
+	candidates := append([]int64{}, tagIDs...)
+	for _, t := range tags {
+		candidates = append(candidates, t.ID)
+	}
+	scores := map[int64]*RelatedEntry{}
+	for _, tagID := range candidates {
+		w := weights[tagID]
+		if w <= 0 {
+			continue
+		}
+		id := tagID
+		entryTags, err := s.EntryTags.Search(ctx, store.EntryTagSearch{TagID: &id, Page: store.Page{Limit: entriesPerTag}})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, et := range entryTags {
+			if et.EntryID == exclude {
+				continue
+			}
+			e, ok := scores[et.EntryID]
+			if !ok {
+				e = &RelatedEntry{Entry: model.Entry{ID: et.EntryID}}
+				scores[et.EntryID] = e
+			}
+			e.Score += w
+			e.TagIDs = append(e.TagIDs, tagID)
+		}
+	}
+	entries := make([]RelatedEntry, 0, len(scores))
+	for _, e := range scores {
+		entries = append(entries, *e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].Entry.ID < entries[j].Entry.ID
+	})
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	if len(entries) == 0 {
+		return tags, entries, nil
+	}
+
+	ids := make([]int64, len(entries))
+	for i, e := range entries {
+		ids[i] = e.Entry.ID
+	}
+	found, err := s.Entries.List(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[int64]model.Entry, len(found))
+	for _, e := range found {
+		byID[e.ID] = e
+	}
+	for i := range entries {
+		entries[i].Entry = byID[entries[i].Entry.ID]
+		sort.Slice(entries[i].TagIDs, func(a, b int) bool { return entries[i].TagIDs[a] < entries[i].TagIDs[b] })
+	}
+	return tags, entries, nil
+}
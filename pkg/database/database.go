@@ -0,0 +1,185 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/timing"
+)
+
+// DB はクエリの実行時間をリクエストに記録し、スパンを作成するsqlx.DBのラッパー
+type DB struct {
+	*sqlx.DB
+	Dialect Dialect
+}
+
+// Open はDATABASE_URL(ない場合は環境変数 PGHOST, PGUSER, ...)の接続情報で接続する
+// 接続にかかった時間はServer-Timingのdb-connectとして記録する
+func Open(ctx context.Context) (*DB, error) {
+	dialect, dsn, err := FromEnv()
+	if err != nil {
+		return nil, err
+	}
+	db, err := sqlx.Open(dialect.Driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	d := time.Since(start)
+	if req := logging.FromContext(ctx); req != nil {
+		req.AddDBTime(d)
+	}
+	if rec := timing.FromContext(ctx); rec != nil {
+		rec.Add("db-connect", "", d)
+	}
+	return &DB{DB: db, Dialect: dialect}, nil
+}
+
+func (db *DB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, done := db.observe(ctx, query, positional(args))
+	err := db.DB.SelectContext(ctx, dest, query, args...)
+	done(sliceLen(dest), err)
+	return err
+}
+
+func (db *DB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, done := db.observe(ctx, query, positional(args))
+	err := db.DB.GetContext(ctx, dest, query, args...)
+	rows := int64(1)
+	if err != nil {
+		rows = 0
+	}
+	done(rows, err)
+	return err
+}
+
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, done := db.observe(ctx, query, positional(args))
+	res, err := db.DB.ExecContext(ctx, query, args...)
+	done(rowsAffected(res), err)
+	return res, err
+}
+
+func (db *DB) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	ctx, done := db.observe(ctx, query, named(query, arg))
+	res, err := db.DB.NamedExecContext(ctx, query, arg)
+	done(rowsAffected(res), err)
+	return res, err
+}
+
+// observe はクエリ1回分の計測を開始し、終了時に呼ぶ関数を返す
+// bindsはデバッグモードの時だけ呼ばれる
+func (db *DB) observe(ctx context.Context, query string, binds func() int) (context.Context, func(rows int64, err error)) {
+	start := time.Now()
+	ctx, span := startSpan(ctx, db.Dialect, query)
+	return ctx, func(rows int64, err error) {
+		d := time.Since(start)
+		if req := logging.FromContext(ctx); req != nil {
+			req.AddDBTime(d)
+		}
+		if rec := timing.FromContext(ctx); rec != nil {
+			q := timing.Query{SQL: Shape(query), Rows: rows}
+			if rec.Debug {
+				q.Binds = binds()
+			}
+			if err != nil {
+				q.Error = err.Error()
+			}
+			rec.AddQuery(span.name, q, d)
+		}
+		span.end(rows, err)
+	}
+}
+
+func positional(args []interface{}) func() int {
+	return func() int {
+		return len(args)
+	}
+}
+
+func named(query string, arg interface{}) func() int {
+	return func() int {
+		_, args, err := sqlx.Named(query, arg)
+		if err != nil {
+			return 0
+		}
+		return len(args)
+	}
+}
+
+func sliceLen(dest interface{}) int64 {
+	v := reflect.ValueOf(dest)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return 0
+	}
+	return int64(v.Len())
+}
+
+func rowsAffected(res sql.Result) int64 {
+	if res == nil {
+		return 0
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// InsertID はINSERTを実行し、採番したidを返す。queryにはRETURNINGを付けない
+// RETURNINGを使えないDB(MySQL)ではLastInsertIdで代用する
+func (db *DB) InsertID(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	if db.Dialect.Returning {
+		var id int64
+		err := db.GetContext(ctx, &id, query+` RETURNING id`, args...)
+		return id, err
+	}
+	res, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// SelectMapsContext は列名をキーにしたmapとして全ての行を取得する
+func (db *DB) SelectMapsContext(ctx context.Context, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	ctx, done := db.observe(ctx, query, positional(args))
+	var results []map[string]interface{}
+	rows, err := db.DB.QueryxContext(ctx, query, args...)
+	if err != nil {
+		done(0, err)
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		m := map[string]interface{}{}
+		if err = rows.MapScan(m); err != nil {
+			break
+		}
+		// MySQLは文字列を[]byteで返すため、JSONでbase64にならないよう文字列にする
+		for k, v := range m {
+			if b, ok := v.([]byte); ok {
+				m[k] = string(b)
+			}
+		}
+		results = append(results, m)
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	done(int64(len(results)), err)
+	return results, err
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Postgresはmigrations直下、MySQLとSQLiteはサブディレクトリに同じバージョンのファイルを置く
+//
+//go:embed migrations/*.sql migrations/mysql/*.sql migrations/sqlite/*.sql
+var migrationFiles embed.FS
+
+// Migration は migrations/NNNN_name.sql の1ファイル分
+type Migration struct {
+	Version int64
+	Name    string
+	SQL     string
+}
+
+// Migrations はDialectのマイグレーションをバージョン順に返す
+func Migrations(d Dialect) ([]Migration, error) {
+	entries, err := migrationFiles.ReadDir(d.migrations)
+	if err != nil {
+		return nil, err
+	}
+	migrations := make([]Migration, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		prefix, name, ok := strings.Cut(strings.TrimSuffix(e.Name(), ".sql"), "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name: %s", e.Name())
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version: %s", e.Name())
+		}
+		b, err := migrationFiles.ReadFile(path.Join(d.migrations, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, Migration{Version: version, Name: name, SQL: string(b)})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// ExpectedVersion はこのビルドが前提とするスキーマのバージョン
+func ExpectedVersion() (int64, error) {
+	d, _, err := FromEnv()
+	if err != nil {
+		return 0, err
+	}
+	migrations, err := Migrations(d)
+	if err != nil {
+		return 0, err
+	}
+	if len(migrations) == 0 {
+		return 0, nil
+	}
+	return migrations[len(migrations)-1].Version, nil
+}
+
+// AppliedVersion はDBに適用済みのスキーマのバージョン
+// 一度もマイグレーションしていない場合は0を返す
+func (db *DB) AppliedVersion(ctx context.Context) (int64, error) {
+	var exists bool
+	err := db.GetContext(ctx, &exists, db.Rebind(db.Dialect.tableExists), "schema_migrations")
+	if err != nil || !exists {
+		return 0, err
+	}
+	var version int64
+	err = db.GetContext(ctx, &version, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`)
+	return version, err
+}
+
+// Migrate は未適用のマイグレーションを1ファイルずつトランザクションで適用する
+func (db *DB) Migrate(ctx context.Context) ([]Migration, error) {
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at `+db.Dialect.timestamp+` NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := db.AppliedVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := Migrations(db.Dialect)
+	if err != nil {
+		return nil, err
+	}
+	var done []Migration
+	for _, m := range migrations {
+		if m.Version <= applied {
+			continue
+		}
+		tx, err := db.BeginTxx(ctx, nil)
+		if err != nil {
+			return done, err
+		}
+		if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+			tx.Rollback()
+			return done, fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		if _, err := tx.ExecContext(ctx, db.Rebind(`INSERT INTO schema_migrations (version, name) VALUES (?, ?)`), m.Version, m.Name); err != nil {
+			tx.Rollback()
+			return done, err
+		}
+		if err := tx.Commit(); err != nil {
+			return done, err
+		}
+		done = append(done, m)
+	}
+	return done, nil
+}
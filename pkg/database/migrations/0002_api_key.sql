@@ -0,0 +1,13 @@
+-- 書き込み用のAPIキー。キーそのものは保存せず、SHA-256のハッシュだけを持つ
+-- scopesは空白区切りのスコープ(例: "entry:write *_type:write")
+CREATE TABLE IF NOT EXISTS api_key (
+    id           BIGSERIAL PRIMARY KEY,
+    name         TEXT NOT NULL,
+    prefix       TEXT NOT NULL UNIQUE,
+    hash         TEXT NOT NULL,
+    scopes       TEXT NOT NULL DEFAULT '',
+    expires_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at   TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
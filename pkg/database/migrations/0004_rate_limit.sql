@@ -0,0 +1,10 @@
+-- レート制限のトークンバケット。サーバーレスのインスタンス間で共有するためにDBに置く
+-- keyは "<read|write>:<key|sub|ip>:<識別子>"
+-- UNLOGGEDにしてWALを書かない。クラッシュ時に空になっても満タンのバケットに戻るだけ
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_bucket (
+    key        TEXT PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    allowed    BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
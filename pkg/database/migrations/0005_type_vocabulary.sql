@@ -0,0 +1,7 @@
+-- *_typeテーブルに正規の語彙(pkg/vocabulary)の英語とローマ字を持たせる
+-- 語彙にない既存の行はNULLのまま
+ALTER TABLE haircolor_type ADD COLUMN IF NOT EXISTS label_en TEXT, ADD COLUMN IF NOT EXISTS romaji TEXT;
+ALTER TABLE eyecolor_type ADD COLUMN IF NOT EXISTS label_en TEXT, ADD COLUMN IF NOT EXISTS romaji TEXT;
+ALTER TABLE hairstyle_type ADD COLUMN IF NOT EXISTS label_en TEXT, ADD COLUMN IF NOT EXISTS romaji TEXT;
+ALTER TABLE hairlength_type ADD COLUMN IF NOT EXISTS label_en TEXT, ADD COLUMN IF NOT EXISTS romaji TEXT;
+ALTER TABLE personality_type ADD COLUMN IF NOT EXISTS label_en TEXT, ADD COLUMN IF NOT EXISTS romaji TEXT;
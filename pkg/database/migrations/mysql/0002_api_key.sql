@@ -0,0 +1,13 @@
+-- 書き込み用のAPIキー。キーそのものは保存せず、SHA-256のハッシュだけを持つ
+-- scopesは空白区切りのスコープ(例: "entry:write *_type:write")
+CREATE TABLE IF NOT EXISTS api_key (
+    id           BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name         TEXT NOT NULL,
+    prefix       VARCHAR(64) NOT NULL UNIQUE,
+    hash         TEXT NOT NULL,
+    scopes       VARCHAR(1024) NOT NULL DEFAULT '',
+    expires_at   DATETIME(6),
+    last_used_at DATETIME(6),
+    revoked_at   DATETIME(6),
+    created_at   DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+);
@@ -0,0 +1,8 @@
+-- レート制限のトークンバケット
+-- keyは "<read|write>:<key|sub|ip>:<識別子>"
+CREATE TABLE IF NOT EXISTS rate_limit_bucket (
+    `key`      VARCHAR(255) PRIMARY KEY,
+    tokens     DOUBLE NOT NULL,
+    allowed    BOOLEAN NOT NULL,
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+);
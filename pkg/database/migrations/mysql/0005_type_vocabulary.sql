@@ -0,0 +1,6 @@
+-- *_typeテーブルに正規の語彙(pkg/vocabulary)の英語とローマ字を持たせる
+ALTER TABLE haircolor_type ADD COLUMN label_en VARCHAR(255) NULL, ADD COLUMN romaji VARCHAR(255) NULL;
+ALTER TABLE eyecolor_type ADD COLUMN label_en VARCHAR(255) NULL, ADD COLUMN romaji VARCHAR(255) NULL;
+ALTER TABLE hairstyle_type ADD COLUMN label_en VARCHAR(255) NULL, ADD COLUMN romaji VARCHAR(255) NULL;
+ALTER TABLE hairlength_type ADD COLUMN label_en VARCHAR(255) NULL, ADD COLUMN romaji VARCHAR(255) NULL;
+ALTER TABLE personality_type ADD COLUMN label_en VARCHAR(255) NULL, ADD COLUMN romaji VARCHAR(255) NULL;
@@ -0,0 +1,10 @@
+-- タグの共起の統計。cmd/cooccurrenceでentry_tagから作り直す
+-- 両方の向きの行と、tag_id = other_tag_idの行(そのタグが付いたentryの数)を持つ
+CREATE TABLE IF NOT EXISTS tag_cooccurrence (
+    tag_id       BIGINT NOT NULL REFERENCES tag (id) ON DELETE CASCADE,
+    other_tag_id BIGINT NOT NULL REFERENCES tag (id) ON DELETE CASCADE,
+    pair_count   BIGINT NOT NULL,
+    pmi          DOUBLE NOT NULL,
+    lift         DOUBLE NOT NULL,
+    PRIMARY KEY (tag_id, other_tag_id)
+);
@@ -0,0 +1,48 @@
+-- レーダーチャートの軸。軸を増やすときは行を追加するだけでよい
+CREATE TABLE IF NOT EXISTS radar_axis (
+    id            BIGINT AUTO_INCREMENT PRIMARY KEY,
+    `key`         VARCHAR(64) NOT NULL UNIQUE,
+    label_ja      VARCHAR(255) NOT NULL,
+    label_en      VARCHAR(255) NOT NULL,
+    min_value     BIGINT NOT NULL DEFAULT 0,
+    max_value     BIGINT NOT NULL DEFAULT 100,
+    display_order BIGINT NOT NULL DEFAULT 0,
+    CHECK (min_value < max_value)
+);
+
+-- entryの軸ごとの値。範囲の確認はAPIで行う
+CREATE TABLE IF NOT EXISTS radar_value (
+    entry_id BIGINT NOT NULL REFERENCES entry (id) ON DELETE CASCADE,
+    axis_id  BIGINT NOT NULL REFERENCES radar_axis (id) ON DELETE CASCADE,
+    value    BIGINT NOT NULL,
+    PRIMARY KEY (entry_id, axis_id)
+);
+
+INSERT INTO radar_axis (`key`, label_ja, label_en, min_value, max_value, display_order) VALUES
+    ('ai', 'ai', 'ai', 0, 100, 1),
+    ('nu', 'nu', 'nu', 0, 100, 2);
+
+-- heki_radar_chartの値を移し、同じ形のビューに置き換える
+INSERT INTO radar_value (entry_id, axis_id, value)
+SELECT h.entry_id, a.id, h.ai FROM heki_radar_chart h JOIN radar_axis a ON a.`key` = 'ai'
+UNION ALL
+SELECT h.entry_id, a.id, h.nu FROM heki_radar_chart h JOIN radar_axis a ON a.`key` = 'nu';
+
+DROP TABLE heki_radar_chart;
+
+-- aiとnuの両方の値があるentryだけを返す
+CREATE VIEW heki_radar_chart AS
+SELECT
+    ai.entry_id,
+    ai.value AS ai,
+    nu.value AS nu
+FROM
+    radar_value ai
+    JOIN radar_axis ai_axis ON ai_axis.id = ai.axis_id AND ai_axis.`key` = 'ai'
+    JOIN radar_value nu ON nu.entry_id = ai.entry_id
+    JOIN radar_axis nu_axis ON nu_axis.id = nu.axis_id AND nu_axis.`key` = 'nu';
+
+INSERT INTO role_permission (role, resource, action) VALUES
+    ('editor', 'radar_chart', 'create'),
+    ('editor', 'radar_chart', 'update'),
+    ('editor', 'radar_chart', 'delete');
@@ -0,0 +1,103 @@
+-- SQLite用。Postgresのmigrations/0001_init.sqlと同じテーブル
+CREATE TABLE IF NOT EXISTS source (
+    id   INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    url  TEXT NOT NULL,
+    type TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS entry (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    source_id  BIGINT NOT NULL REFERENCES source (id),
+    name       TEXT NOT NULL,
+    image      TEXT NOT NULL,
+    content    TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS tag (
+    id   INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS entry_tag (
+    id       INTEGER PRIMARY KEY AUTOINCREMENT,
+    entry_id BIGINT NOT NULL REFERENCES entry (id) ON DELETE CASCADE,
+    tag_id   BIGINT NOT NULL REFERENCES tag (id) ON DELETE CASCADE,
+    UNIQUE (entry_id, tag_id)
+);
+
+CREATE TABLE IF NOT EXISTS link (
+    id       INTEGER PRIMARY KEY AUTOINCREMENT,
+    entry_id BIGINT NOT NULL REFERENCES entry (id) ON DELETE CASCADE,
+    type     TEXT NOT NULL,
+    url      TEXT NOT NULL,
+    nsfw     BOOLEAN NOT NULL DEFAULT false,
+    darkness BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE TABLE IF NOT EXISTS bwh (
+    entry_id BIGINT PRIMARY KEY REFERENCES entry (id) ON DELETE CASCADE,
+    bust     BIGINT NOT NULL,
+    waist    BIGINT NOT NULL,
+    hip      BIGINT NOT NULL,
+    height   BIGINT,
+    weight   BIGINT
+);
+
+CREATE TABLE IF NOT EXISTS haircolor_type (
+    id    INTEGER PRIMARY KEY AUTOINCREMENT,
+    color TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS haircolor (
+    entry_id BIGINT PRIMARY KEY REFERENCES entry (id) ON DELETE CASCADE,
+    color_id BIGINT NOT NULL REFERENCES haircolor_type (id)
+);
+
+CREATE TABLE IF NOT EXISTS eyecolor_type (
+    id    INTEGER PRIMARY KEY AUTOINCREMENT,
+    color TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS eyecolor (
+    entry_id BIGINT PRIMARY KEY REFERENCES entry (id) ON DELETE CASCADE,
+    color_id BIGINT NOT NULL REFERENCES eyecolor_type (id)
+);
+
+CREATE TABLE IF NOT EXISTS hairstyle_type (
+    id    INTEGER PRIMARY KEY AUTOINCREMENT,
+    style TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS hairstyle (
+    entry_id BIGINT PRIMARY KEY REFERENCES entry (id) ON DELETE CASCADE,
+    style_id BIGINT NOT NULL REFERENCES hairstyle_type (id)
+);
+
+CREATE TABLE IF NOT EXISTS hairlength_type (
+    id     INTEGER PRIMARY KEY AUTOINCREMENT,
+    length TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS hairlength (
+    entry_id           BIGINT PRIMARY KEY REFERENCES entry (id) ON DELETE CASCADE,
+    hairlength_type_id BIGINT NOT NULL REFERENCES hairlength_type (id)
+);
+
+CREATE TABLE IF NOT EXISTS personality_type (
+    id   INTEGER PRIMARY KEY AUTOINCREMENT,
+    type TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS personality (
+    entry_id BIGINT NOT NULL REFERENCES entry (id) ON DELETE CASCADE,
+    type_id  BIGINT NOT NULL REFERENCES personality_type (id),
+    PRIMARY KEY (entry_id, type_id)
+);
+
+CREATE TABLE IF NOT EXISTS heki_radar_chart (
+    entry_id BIGINT PRIMARY KEY REFERENCES entry (id) ON DELETE CASCADE,
+    ai       BIGINT NOT NULL,
+    nu       BIGINT NOT NULL
+);
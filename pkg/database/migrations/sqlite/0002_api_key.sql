@@ -0,0 +1,13 @@
+-- 書き込み用のAPIキー。キーそのものは保存せず、SHA-256のハッシュだけを持つ
+-- scopesは空白区切りのスコープ(例: "entry:write *_type:write")
+CREATE TABLE IF NOT EXISTS api_key (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    name         TEXT NOT NULL,
+    prefix       TEXT NOT NULL UNIQUE,
+    hash         TEXT NOT NULL,
+    scopes       TEXT NOT NULL DEFAULT '',
+    expires_at   TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at   TIMESTAMP,
+    created_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,49 @@
+-- APIキーのロール(viewer, editor, admin)
+ALTER TABLE api_key ADD COLUMN role TEXT NOT NULL DEFAULT 'editor';
+
+-- ロールごとに許可する操作の表。resourceとactionはpath.Matchのパターン
+-- 行を書き換えるとデプロイなしで反映される(各インスタンスのキャッシュが切れた時点)
+CREATE TABLE IF NOT EXISTS role_permission (
+    role     TEXT NOT NULL,
+    resource TEXT NOT NULL,
+    action   TEXT NOT NULL,
+    PRIMARY KEY (role, resource, action)
+);
+
+-- viewerは読み込みのみ、editorはentryと属性の行を変更できる
+-- 参照テーブル(*_type, source, tag)の変更と一括削除はadminのみ
+INSERT INTO role_permission (role, resource, action) VALUES
+    ('viewer', '*', 'read'),
+    ('editor', '*', 'read'),
+    ('editor', 'entry', 'create'),
+    ('editor', 'entry', 'update'),
+    ('editor', 'entry', 'delete'),
+    ('editor', 'entry_tag', 'create'),
+    ('editor', 'entry_tag', 'update'),
+    ('editor', 'entry_tag', 'delete'),
+    ('editor', 'link', 'create'),
+    ('editor', 'link', 'update'),
+    ('editor', 'link', 'delete'),
+    ('editor', 'bwh', 'create'),
+    ('editor', 'bwh', 'update'),
+    ('editor', 'bwh', 'delete'),
+    ('editor', 'haircolor', 'create'),
+    ('editor', 'haircolor', 'update'),
+    ('editor', 'haircolor', 'delete'),
+    ('editor', 'eyescolor', 'create'),
+    ('editor', 'eyescolor', 'update'),
+    ('editor', 'eyescolor', 'delete'),
+    ('editor', 'hairstyle', 'create'),
+    ('editor', 'hairstyle', 'update'),
+    ('editor', 'hairstyle', 'delete'),
+    ('editor', 'hairlength', 'create'),
+    ('editor', 'hairlength', 'update'),
+    ('editor', 'hairlength', 'delete'),
+    ('editor', 'personality', 'create'),
+    ('editor', 'personality', 'update'),
+    ('editor', 'personality', 'delete'),
+    ('editor', 'heki_radar_chart', 'create'),
+    ('editor', 'heki_radar_chart', 'update'),
+    ('editor', 'heki_radar_chart', 'delete'),
+    ('admin', '*', '*')
+ON CONFLICT DO NOTHING;
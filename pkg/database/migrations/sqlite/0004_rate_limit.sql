@@ -0,0 +1,8 @@
+-- レート制限のトークンバケット
+-- keyは "<read|write>:<key|sub|ip>:<識別子>"
+CREATE TABLE IF NOT EXISTS rate_limit_bucket (
+    key        TEXT PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    allowed    BOOLEAN NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,12 @@
+-- *_typeテーブルに正規の語彙(pkg/vocabulary)の英語とローマ字を持たせる
+-- SQLiteのADD COLUMNは1列ずつ
+ALTER TABLE haircolor_type ADD COLUMN label_en TEXT;
+ALTER TABLE haircolor_type ADD COLUMN romaji TEXT;
+ALTER TABLE eyecolor_type ADD COLUMN label_en TEXT;
+ALTER TABLE eyecolor_type ADD COLUMN romaji TEXT;
+ALTER TABLE hairstyle_type ADD COLUMN label_en TEXT;
+ALTER TABLE hairstyle_type ADD COLUMN romaji TEXT;
+ALTER TABLE hairlength_type ADD COLUMN label_en TEXT;
+ALTER TABLE hairlength_type ADD COLUMN romaji TEXT;
+ALTER TABLE personality_type ADD COLUMN label_en TEXT;
+ALTER TABLE personality_type ADD COLUMN romaji TEXT;
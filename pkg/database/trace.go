@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/tracing"
+)
+
+var (
+	spaces = regexp.MustCompile(`\s+`)
+	// IN ($1, $2, $3) のような可変長のプレースホルダー
+	placeholderList = regexp.MustCompile(`\(\s*(\$\d+|\?)(\s*,\s*(\$\d+|\?))+\s*\)`)
+	tableName       = regexp.MustCompile(`(?i)\b(?:FROM|INTO|UPDATE)\s+([A-Za-z_][A-Za-z0-9_.]*)`)
+)
+
+// Shape はクエリの空白とIN句のプレースホルダー数を正規化する
+// 件数の違うクエリが同じ形になるので、トレースやログで集計できる
+func Shape(query string) string {
+	s := strings.TrimSpace(spaces.ReplaceAllString(query, " "))
+	return placeholderList.ReplaceAllString(s, "(...)")
+}
+
+// querySpan はクエリ1回分のスパン
+type querySpan struct {
+	trace.Span
+	// nameは"SELECT entry"のような操作とテーブル名
+	name string
+}
+
+func startSpan(ctx context.Context, d Dialect, query string) (context.Context, *querySpan) {
+	shape := Shape(query)
+	op, table := operation(shape)
+	name := op
+	if table != "" {
+		name += " " + table
+	}
+	ctx, span := tracing.Tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			d.system,
+			semconv.DBQueryText(shape),
+			semconv.DBOperationName(op),
+			semconv.DBCollectionName(table),
+		),
+	)
+	return ctx, &querySpan{Span: span, name: name}
+}
+
+func (span *querySpan) end(rows int64, err error) {
+	span.SetAttributes(attribute.Int64("db.rows", rows))
+	if err != nil {
+		// ドライバのエラーは行の値を含むため、ログと同じく値を取り除く
+		msg := logging.Sanitize(err)
+		span.RecordError(errors.New(msg))
+		span.SetStatus(codes.Error, msg)
+	}
+	span.End()
+}
+
+func operation(shape string) (string, string) {
+	op, _, _ := strings.Cut(shape, " ")
+	var table string
+	if m := tableName.FindStringSubmatch(shape); m != nil {
+		table = m[1]
+	}
+	return strings.ToUpper(op), table
+}
@@ -0,0 +1,36 @@
+// Package dbtest はテストごとに一時ファイルのSQLiteを用意する
+// DBに接続するコード(認証、レート制限、GraphQL)をPostgresなしでテストするために使う
+package dbtest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/database"
+)
+
+// Open はマイグレーションを適用したSQLiteに接続する
+// DATABASE_URLも設定するため、テスト中にdatabase.Openを呼ぶコードも同じDBに接続する
+func Open(t *testing.T) *database.DB {
+	t.Helper()
+	t.Setenv("DATABASE_URL", "sqlite:"+filepath.Join(t.TempDir(), "test.db"))
+	ctx := context.Background()
+	db, err := database.Open(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Migrate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// Exec はテストデータを登録する。クエリは?で書く
+func Exec(t *testing.T, db *database.DB, query string, args ...interface{}) {
+	t.Helper()
+	if _, err := db.ExecContext(context.Background(), db.Rebind(query), args...); err != nil {
+		t.Fatal(err)
+	}
+}
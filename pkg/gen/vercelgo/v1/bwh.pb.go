@@ -0,0 +1,838 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: vercelgo/v1/bwh.proto
+
+package vercelgov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// BWH はbwhテーブルの行
+type BWH struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       int64                  `protobuf:"varint,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	Bust          int64                  `protobuf:"varint,2,opt,name=bust,proto3" json:"bust,omitempty"`
+	Waist         int64                  `protobuf:"varint,3,opt,name=waist,proto3" json:"waist,omitempty"`
+	Hip           int64                  `protobuf:"varint,4,opt,name=hip,proto3" json:"hip,omitempty"`
+	Height        *int64                 `protobuf:"varint,5,opt,name=height,proto3,oneof" json:"height,omitempty"`
+	Weight        *int64                 `protobuf:"varint,6,opt,name=weight,proto3,oneof" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BWH) Reset() {
+	*x = BWH{}
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BWH) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BWH) ProtoMessage() {}
+
+func (x *BWH) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BWH.ProtoReflect.Descriptor instead.
+func (*BWH) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_bwh_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BWH) GetEntryId() int64 {
+	if x != nil {
+		return x.EntryId
+	}
+	return 0
+}
+
+func (x *BWH) GetBust() int64 {
+	if x != nil {
+		return x.Bust
+	}
+	return 0
+}
+
+func (x *BWH) GetWaist() int64 {
+	if x != nil {
+		return x.Waist
+	}
+	return 0
+}
+
+func (x *BWH) GetHip() int64 {
+	if x != nil {
+		return x.Hip
+	}
+	return 0
+}
+
+func (x *BWH) GetHeight() int64 {
+	if x != nil && x.Height != nil {
+		return *x.Height
+	}
+	return 0
+}
+
+func (x *BWH) GetWeight() int64 {
+	if x != nil && x.Weight != nil {
+		return *x.Weight
+	}
+	return 0
+}
+
+type BWHServiceGetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       int64                  `protobuf:"varint,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BWHServiceGetRequest) Reset() {
+	*x = BWHServiceGetRequest{}
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BWHServiceGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BWHServiceGetRequest) ProtoMessage() {}
+
+func (x *BWHServiceGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BWHServiceGetRequest.ProtoReflect.Descriptor instead.
+func (*BWHServiceGetRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_bwh_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BWHServiceGetRequest) GetEntryId() int64 {
+	if x != nil {
+		return x.EntryId
+	}
+	return 0
+}
+
+type BWHServiceGetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bwh           *BWH                   `protobuf:"bytes,1,opt,name=bwh,proto3" json:"bwh,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BWHServiceGetResponse) Reset() {
+	*x = BWHServiceGetResponse{}
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BWHServiceGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BWHServiceGetResponse) ProtoMessage() {}
+
+func (x *BWHServiceGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BWHServiceGetResponse.ProtoReflect.Descriptor instead.
+func (*BWHServiceGetResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_bwh_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BWHServiceGetResponse) GetBwh() *BWH {
+	if x != nil {
+		return x.Bwh
+	}
+	return nil
+}
+
+// BWHServiceListRequest のentry_idsが空の場合は全件を返す
+type BWHServiceListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryIds      []int64                `protobuf:"varint,1,rep,packed,name=entry_ids,json=entryIds,proto3" json:"entry_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BWHServiceListRequest) Reset() {
+	*x = BWHServiceListRequest{}
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BWHServiceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BWHServiceListRequest) ProtoMessage() {}
+
+func (x *BWHServiceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BWHServiceListRequest.ProtoReflect.Descriptor instead.
+func (*BWHServiceListRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_bwh_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BWHServiceListRequest) GetEntryIds() []int64 {
+	if x != nil {
+		return x.EntryIds
+	}
+	return nil
+}
+
+type BWHServiceListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bwhs          []*BWH                 `protobuf:"bytes,1,rep,name=bwhs,proto3" json:"bwhs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BWHServiceListResponse) Reset() {
+	*x = BWHServiceListResponse{}
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BWHServiceListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BWHServiceListResponse) ProtoMessage() {}
+
+func (x *BWHServiceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BWHServiceListResponse.ProtoReflect.Descriptor instead.
+func (*BWHServiceListResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_bwh_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BWHServiceListResponse) GetBwhs() []*BWH {
+	if x != nil {
+		return x.Bwhs
+	}
+	return nil
+}
+
+// BWHServiceSearchRequest はバスト・ウエスト・ヒップの範囲(両端を含む)で検索する
+type BWHServiceSearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinBust       *int64                 `protobuf:"varint,1,opt,name=min_bust,json=minBust,proto3,oneof" json:"min_bust,omitempty"`
+	MaxBust       *int64                 `protobuf:"varint,2,opt,name=max_bust,json=maxBust,proto3,oneof" json:"max_bust,omitempty"`
+	MinWaist      *int64                 `protobuf:"varint,3,opt,name=min_waist,json=minWaist,proto3,oneof" json:"min_waist,omitempty"`
+	MaxWaist      *int64                 `protobuf:"varint,4,opt,name=max_waist,json=maxWaist,proto3,oneof" json:"max_waist,omitempty"`
+	MinHip        *int64                 `protobuf:"varint,5,opt,name=min_hip,json=minHip,proto3,oneof" json:"min_hip,omitempty"`
+	MaxHip        *int64                 `protobuf:"varint,6,opt,name=max_hip,json=maxHip,proto3,oneof" json:"max_hip,omitempty"`
+	Limit         int32                  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,8,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BWHServiceSearchRequest) Reset() {
+	*x = BWHServiceSearchRequest{}
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BWHServiceSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BWHServiceSearchRequest) ProtoMessage() {}
+
+func (x *BWHServiceSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BWHServiceSearchRequest.ProtoReflect.Descriptor instead.
+func (*BWHServiceSearchRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_bwh_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BWHServiceSearchRequest) GetMinBust() int64 {
+	if x != nil && x.MinBust != nil {
+		return *x.MinBust
+	}
+	return 0
+}
+
+func (x *BWHServiceSearchRequest) GetMaxBust() int64 {
+	if x != nil && x.MaxBust != nil {
+		return *x.MaxBust
+	}
+	return 0
+}
+
+func (x *BWHServiceSearchRequest) GetMinWaist() int64 {
+	if x != nil && x.MinWaist != nil {
+		return *x.MinWaist
+	}
+	return 0
+}
+
+func (x *BWHServiceSearchRequest) GetMaxWaist() int64 {
+	if x != nil && x.MaxWaist != nil {
+		return *x.MaxWaist
+	}
+	return 0
+}
+
+func (x *BWHServiceSearchRequest) GetMinHip() int64 {
+	if x != nil && x.MinHip != nil {
+		return *x.MinHip
+	}
+	return 0
+}
+
+func (x *BWHServiceSearchRequest) GetMaxHip() int64 {
+	if x != nil && x.MaxHip != nil {
+		return *x.MaxHip
+	}
+	return 0
+}
+
+func (x *BWHServiceSearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *BWHServiceSearchRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type BWHServiceSearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bwhs          []*BWH                 `protobuf:"bytes,1,rep,name=bwhs,proto3" json:"bwhs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BWHServiceSearchResponse) Reset() {
+	*x = BWHServiceSearchResponse{}
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BWHServiceSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BWHServiceSearchResponse) ProtoMessage() {}
+
+func (x *BWHServiceSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BWHServiceSearchResponse.ProtoReflect.Descriptor instead.
+func (*BWHServiceSearchResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_bwh_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BWHServiceSearchResponse) GetBwhs() []*BWH {
+	if x != nil {
+		return x.Bwhs
+	}
+	return nil
+}
+
+type BWHServiceCreateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bwhs          []*BWH                 `protobuf:"bytes,1,rep,name=bwhs,proto3" json:"bwhs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BWHServiceCreateRequest) Reset() {
+	*x = BWHServiceCreateRequest{}
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BWHServiceCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BWHServiceCreateRequest) ProtoMessage() {}
+
+func (x *BWHServiceCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BWHServiceCreateRequest.ProtoReflect.Descriptor instead.
+func (*BWHServiceCreateRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_bwh_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BWHServiceCreateRequest) GetBwhs() []*BWH {
+	if x != nil {
+		return x.Bwhs
+	}
+	return nil
+}
+
+type BWHServiceCreateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bwhs          []*BWH                 `protobuf:"bytes,1,rep,name=bwhs,proto3" json:"bwhs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BWHServiceCreateResponse) Reset() {
+	*x = BWHServiceCreateResponse{}
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BWHServiceCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BWHServiceCreateResponse) ProtoMessage() {}
+
+func (x *BWHServiceCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BWHServiceCreateResponse.ProtoReflect.Descriptor instead.
+func (*BWHServiceCreateResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_bwh_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BWHServiceCreateResponse) GetBwhs() []*BWH {
+	if x != nil {
+		return x.Bwhs
+	}
+	return nil
+}
+
+type BWHServiceUpdateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bwhs          []*BWH                 `protobuf:"bytes,1,rep,name=bwhs,proto3" json:"bwhs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BWHServiceUpdateRequest) Reset() {
+	*x = BWHServiceUpdateRequest{}
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BWHServiceUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BWHServiceUpdateRequest) ProtoMessage() {}
+
+func (x *BWHServiceUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BWHServiceUpdateRequest.ProtoReflect.Descriptor instead.
+func (*BWHServiceUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_bwh_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BWHServiceUpdateRequest) GetBwhs() []*BWH {
+	if x != nil {
+		return x.Bwhs
+	}
+	return nil
+}
+
+type BWHServiceUpdateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bwhs          []*BWH                 `protobuf:"bytes,1,rep,name=bwhs,proto3" json:"bwhs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BWHServiceUpdateResponse) Reset() {
+	*x = BWHServiceUpdateResponse{}
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BWHServiceUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BWHServiceUpdateResponse) ProtoMessage() {}
+
+func (x *BWHServiceUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BWHServiceUpdateResponse.ProtoReflect.Descriptor instead.
+func (*BWHServiceUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_bwh_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BWHServiceUpdateResponse) GetBwhs() []*BWH {
+	if x != nil {
+		return x.Bwhs
+	}
+	return nil
+}
+
+type BWHServiceDeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryIds      []int64                `protobuf:"varint,1,rep,packed,name=entry_ids,json=entryIds,proto3" json:"entry_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BWHServiceDeleteRequest) Reset() {
+	*x = BWHServiceDeleteRequest{}
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BWHServiceDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BWHServiceDeleteRequest) ProtoMessage() {}
+
+func (x *BWHServiceDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BWHServiceDeleteRequest.ProtoReflect.Descriptor instead.
+func (*BWHServiceDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_bwh_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BWHServiceDeleteRequest) GetEntryIds() []int64 {
+	if x != nil {
+		return x.EntryIds
+	}
+	return nil
+}
+
+type BWHServiceDeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryIds      []int64                `protobuf:"varint,1,rep,packed,name=entry_ids,json=entryIds,proto3" json:"entry_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BWHServiceDeleteResponse) Reset() {
+	*x = BWHServiceDeleteResponse{}
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BWHServiceDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BWHServiceDeleteResponse) ProtoMessage() {}
+
+func (x *BWHServiceDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_bwh_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BWHServiceDeleteResponse.ProtoReflect.Descriptor instead.
+func (*BWHServiceDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_bwh_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BWHServiceDeleteResponse) GetEntryIds() []int64 {
+	if x != nil {
+		return x.EntryIds
+	}
+	return nil
+}
+
+var File_vercelgo_v1_bwh_proto protoreflect.FileDescriptor
+
+const file_vercelgo_v1_bwh_proto_rawDesc = "" +
+	"\n" +
+	"\x15vercelgo/v1/bwh.proto\x12\vvercelgo.v1\"\xac\x01\n" +
+	"\x03BWH\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\x03R\aentryId\x12\x12\n" +
+	"\x04bust\x18\x02 \x01(\x03R\x04bust\x12\x14\n" +
+	"\x05waist\x18\x03 \x01(\x03R\x05waist\x12\x10\n" +
+	"\x03hip\x18\x04 \x01(\x03R\x03hip\x12\x1b\n" +
+	"\x06height\x18\x05 \x01(\x03H\x00R\x06height\x88\x01\x01\x12\x1b\n" +
+	"\x06weight\x18\x06 \x01(\x03H\x01R\x06weight\x88\x01\x01B\t\n" +
+	"\a_heightB\t\n" +
+	"\a_weight\"1\n" +
+	"\x14BWHServiceGetRequest\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\x03R\aentryId\";\n" +
+	"\x15BWHServiceGetResponse\x12\"\n" +
+	"\x03bwh\x18\x01 \x01(\v2\x10.vercelgo.v1.BWHR\x03bwh\"4\n" +
+	"\x15BWHServiceListRequest\x12\x1b\n" +
+	"\tentry_ids\x18\x01 \x03(\x03R\bentryIds\">\n" +
+	"\x16BWHServiceListResponse\x12$\n" +
+	"\x04bwhs\x18\x01 \x03(\v2\x10.vercelgo.v1.BWHR\x04bwhs\"\xd5\x02\n" +
+	"\x17BWHServiceSearchRequest\x12\x1e\n" +
+	"\bmin_bust\x18\x01 \x01(\x03H\x00R\aminBust\x88\x01\x01\x12\x1e\n" +
+	"\bmax_bust\x18\x02 \x01(\x03H\x01R\amaxBust\x88\x01\x01\x12 \n" +
+	"\tmin_waist\x18\x03 \x01(\x03H\x02R\bminWaist\x88\x01\x01\x12 \n" +
+	"\tmax_waist\x18\x04 \x01(\x03H\x03R\bmaxWaist\x88\x01\x01\x12\x1c\n" +
+	"\amin_hip\x18\x05 \x01(\x03H\x04R\x06minHip\x88\x01\x01\x12\x1c\n" +
+	"\amax_hip\x18\x06 \x01(\x03H\x05R\x06maxHip\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\b \x01(\x05R\x06offsetB\v\n" +
+	"\t_min_bustB\v\n" +
+	"\t_max_bustB\f\n" +
+	"\n" +
+	"_min_waistB\f\n" +
+	"\n" +
+	"_max_waistB\n" +
+	"\n" +
+	"\b_min_hipB\n" +
+	"\n" +
+	"\b_max_hip\"@\n" +
+	"\x18BWHServiceSearchResponse\x12$\n" +
+	"\x04bwhs\x18\x01 \x03(\v2\x10.vercelgo.v1.BWHR\x04bwhs\"?\n" +
+	"\x17BWHServiceCreateRequest\x12$\n" +
+	"\x04bwhs\x18\x01 \x03(\v2\x10.vercelgo.v1.BWHR\x04bwhs\"@\n" +
+	"\x18BWHServiceCreateResponse\x12$\n" +
+	"\x04bwhs\x18\x01 \x03(\v2\x10.vercelgo.v1.BWHR\x04bwhs\"?\n" +
+	"\x17BWHServiceUpdateRequest\x12$\n" +
+	"\x04bwhs\x18\x01 \x03(\v2\x10.vercelgo.v1.BWHR\x04bwhs\"@\n" +
+	"\x18BWHServiceUpdateResponse\x12$\n" +
+	"\x04bwhs\x18\x01 \x03(\v2\x10.vercelgo.v1.BWHR\x04bwhs\"6\n" +
+	"\x17BWHServiceDeleteRequest\x12\x1b\n" +
+	"\tentry_ids\x18\x01 \x03(\x03R\bentryIds\"7\n" +
+	"\x18BWHServiceDeleteResponse\x12\x1b\n" +
+	"\tentry_ids\x18\x01 \x03(\x03R\bentryIds2\x96\x04\n" +
+	"\n" +
+	"BWHService\x12Q\n" +
+	"\x03Get\x12!.vercelgo.v1.BWHServiceGetRequest\x1a\".vercelgo.v1.BWHServiceGetResponse\"\x03\x90\x02\x01\x12T\n" +
+	"\x04List\x12\".vercelgo.v1.BWHServiceListRequest\x1a#.vercelgo.v1.BWHServiceListResponse\"\x03\x90\x02\x01\x12Z\n" +
+	"\x06Search\x12$.vercelgo.v1.BWHServiceSearchRequest\x1a%.vercelgo.v1.BWHServiceSearchResponse\"\x03\x90\x02\x01\x12U\n" +
+	"\x06Create\x12$.vercelgo.v1.BWHServiceCreateRequest\x1a%.vercelgo.v1.BWHServiceCreateResponse\x12U\n" +
+	"\x06Update\x12$.vercelgo.v1.BWHServiceUpdateRequest\x1a%.vercelgo.v1.BWHServiceUpdateResponse\x12U\n" +
+	"\x06Delete\x12$.vercelgo.v1.BWHServiceDeleteRequest\x1a%.vercelgo.v1.BWHServiceDeleteResponseB=Z;maguro-alternative/varcel-go/pkg/gen/vercelgo/v1;vercelgov1b\x06proto3"
+
+var (
+	file_vercelgo_v1_bwh_proto_rawDescOnce sync.Once
+	file_vercelgo_v1_bwh_proto_rawDescData []byte
+)
+
+func file_vercelgo_v1_bwh_proto_rawDescGZIP() []byte {
+	file_vercelgo_v1_bwh_proto_rawDescOnce.Do(func() {
+		file_vercelgo_v1_bwh_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_vercelgo_v1_bwh_proto_rawDesc), len(file_vercelgo_v1_bwh_proto_rawDesc)))
+	})
+	return file_vercelgo_v1_bwh_proto_rawDescData
+}
+
+var file_vercelgo_v1_bwh_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_vercelgo_v1_bwh_proto_goTypes = []any{
+	(*BWH)(nil),                      // 0: vercelgo.v1.BWH
+	(*BWHServiceGetRequest)(nil),     // 1: vercelgo.v1.BWHServiceGetRequest
+	(*BWHServiceGetResponse)(nil),    // 2: vercelgo.v1.BWHServiceGetResponse
+	(*BWHServiceListRequest)(nil),    // 3: vercelgo.v1.BWHServiceListRequest
+	(*BWHServiceListResponse)(nil),   // 4: vercelgo.v1.BWHServiceListResponse
+	(*BWHServiceSearchRequest)(nil),  // 5: vercelgo.v1.BWHServiceSearchRequest
+	(*BWHServiceSearchResponse)(nil), // 6: vercelgo.v1.BWHServiceSearchResponse
+	(*BWHServiceCreateRequest)(nil),  // 7: vercelgo.v1.BWHServiceCreateRequest
+	(*BWHServiceCreateResponse)(nil), // 8: vercelgo.v1.BWHServiceCreateResponse
+	(*BWHServiceUpdateRequest)(nil),  // 9: vercelgo.v1.BWHServiceUpdateRequest
+	(*BWHServiceUpdateResponse)(nil), // 10: vercelgo.v1.BWHServiceUpdateResponse
+	(*BWHServiceDeleteRequest)(nil),  // 11: vercelgo.v1.BWHServiceDeleteRequest
+	(*BWHServiceDeleteResponse)(nil), // 12: vercelgo.v1.BWHServiceDeleteResponse
+}
+var file_vercelgo_v1_bwh_proto_depIdxs = []int32{
+	0,  // 0: vercelgo.v1.BWHServiceGetResponse.bwh:type_name -> vercelgo.v1.BWH
+	0,  // 1: vercelgo.v1.BWHServiceListResponse.bwhs:type_name -> vercelgo.v1.BWH
+	0,  // 2: vercelgo.v1.BWHServiceSearchResponse.bwhs:type_name -> vercelgo.v1.BWH
+	0,  // 3: vercelgo.v1.BWHServiceCreateRequest.bwhs:type_name -> vercelgo.v1.BWH
+	0,  // 4: vercelgo.v1.BWHServiceCreateResponse.bwhs:type_name -> vercelgo.v1.BWH
+	0,  // 5: vercelgo.v1.BWHServiceUpdateRequest.bwhs:type_name -> vercelgo.v1.BWH
+	0,  // 6: vercelgo.v1.BWHServiceUpdateResponse.bwhs:type_name -> vercelgo.v1.BWH
+	1,  // 7: vercelgo.v1.BWHService.Get:input_type -> vercelgo.v1.BWHServiceGetRequest
+	3,  // 8: vercelgo.v1.BWHService.List:input_type -> vercelgo.v1.BWHServiceListRequest
+	5,  // 9: vercelgo.v1.BWHService.Search:input_type -> vercelgo.v1.BWHServiceSearchRequest
+	7,  // 10: vercelgo.v1.BWHService.Create:input_type -> vercelgo.v1.BWHServiceCreateRequest
+	9,  // 11: vercelgo.v1.BWHService.Update:input_type -> vercelgo.v1.BWHServiceUpdateRequest
+	11, // 12: vercelgo.v1.BWHService.Delete:input_type -> vercelgo.v1.BWHServiceDeleteRequest
+	2,  // 13: vercelgo.v1.BWHService.Get:output_type -> vercelgo.v1.BWHServiceGetResponse
+	4,  // 14: vercelgo.v1.BWHService.List:output_type -> vercelgo.v1.BWHServiceListResponse
+	6,  // 15: vercelgo.v1.BWHService.Search:output_type -> vercelgo.v1.BWHServiceSearchResponse
+	8,  // 16: vercelgo.v1.BWHService.Create:output_type -> vercelgo.v1.BWHServiceCreateResponse
+	10, // 17: vercelgo.v1.BWHService.Update:output_type -> vercelgo.v1.BWHServiceUpdateResponse
+	12, // 18: vercelgo.v1.BWHService.Delete:output_type -> vercelgo.v1.BWHServiceDeleteResponse
+	13, // [13:19] is the sub-list for method output_type
+	7,  // [7:13] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_vercelgo_v1_bwh_proto_init() }
+func file_vercelgo_v1_bwh_proto_init() {
+	if File_vercelgo_v1_bwh_proto != nil {
+		return
+	}
+	file_vercelgo_v1_bwh_proto_msgTypes[0].OneofWrappers = []any{}
+	file_vercelgo_v1_bwh_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_vercelgo_v1_bwh_proto_rawDesc), len(file_vercelgo_v1_bwh_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_vercelgo_v1_bwh_proto_goTypes,
+		DependencyIndexes: file_vercelgo_v1_bwh_proto_depIdxs,
+		MessageInfos:      file_vercelgo_v1_bwh_proto_msgTypes,
+	}.Build()
+	File_vercelgo_v1_bwh_proto = out.File
+	file_vercelgo_v1_bwh_proto_goTypes = nil
+	file_vercelgo_v1_bwh_proto_depIdxs = nil
+}
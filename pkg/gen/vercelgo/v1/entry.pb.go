@@ -0,0 +1,805 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: vercelgo/v1/entry.proto
+
+package vercelgov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Entry はentryテーブルの行
+type Entry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SourceId      int64                  `protobuf:"varint,2,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Image         string                 `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Entry) Reset() {
+	*x = Entry{}
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entry) ProtoMessage() {}
+
+func (x *Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entry.ProtoReflect.Descriptor instead.
+func (*Entry) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Entry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Entry) GetSourceId() int64 {
+	if x != nil {
+		return x.SourceId
+	}
+	return 0
+}
+
+func (x *Entry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Entry) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Entry) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Entry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type EntryServiceGetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryServiceGetRequest) Reset() {
+	*x = EntryServiceGetRequest{}
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryServiceGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryServiceGetRequest) ProtoMessage() {}
+
+func (x *EntryServiceGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryServiceGetRequest.ProtoReflect.Descriptor instead.
+func (*EntryServiceGetRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EntryServiceGetRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type EntryServiceGetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *Entry                 `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryServiceGetResponse) Reset() {
+	*x = EntryServiceGetResponse{}
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryServiceGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryServiceGetResponse) ProtoMessage() {}
+
+func (x *EntryServiceGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryServiceGetResponse.ProtoReflect.Descriptor instead.
+func (*EntryServiceGetResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EntryServiceGetResponse) GetEntry() *Entry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+// EntryServiceListRequest のidsが空の場合は全件を返す
+type EntryServiceListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryServiceListRequest) Reset() {
+	*x = EntryServiceListRequest{}
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryServiceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryServiceListRequest) ProtoMessage() {}
+
+func (x *EntryServiceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryServiceListRequest.ProtoReflect.Descriptor instead.
+func (*EntryServiceListRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EntryServiceListRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type EntryServiceListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*Entry               `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryServiceListResponse) Reset() {
+	*x = EntryServiceListResponse{}
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryServiceListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryServiceListResponse) ProtoMessage() {}
+
+func (x *EntryServiceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryServiceListResponse.ProtoReflect.Descriptor instead.
+func (*EntryServiceListResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EntryServiceListResponse) GetEntries() []*Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// EntryServiceSearchRequest の条件は指定されたもの全てを満たすものを返す
+type EntryServiceSearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// query はnameとcontentの部分一致
+	Query         string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	SourceId      *int64 `protobuf:"varint,2,opt,name=source_id,json=sourceId,proto3,oneof" json:"source_id,omitempty"`
+	TagId         *int64 `protobuf:"varint,3,opt,name=tag_id,json=tagId,proto3,oneof" json:"tag_id,omitempty"`
+	Limit         int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryServiceSearchRequest) Reset() {
+	*x = EntryServiceSearchRequest{}
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryServiceSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryServiceSearchRequest) ProtoMessage() {}
+
+func (x *EntryServiceSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryServiceSearchRequest.ProtoReflect.Descriptor instead.
+func (*EntryServiceSearchRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EntryServiceSearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *EntryServiceSearchRequest) GetSourceId() int64 {
+	if x != nil && x.SourceId != nil {
+		return *x.SourceId
+	}
+	return 0
+}
+
+func (x *EntryServiceSearchRequest) GetTagId() int64 {
+	if x != nil && x.TagId != nil {
+		return *x.TagId
+	}
+	return 0
+}
+
+func (x *EntryServiceSearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *EntryServiceSearchRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type EntryServiceSearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*Entry               `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryServiceSearchResponse) Reset() {
+	*x = EntryServiceSearchResponse{}
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryServiceSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryServiceSearchResponse) ProtoMessage() {}
+
+func (x *EntryServiceSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryServiceSearchResponse.ProtoReflect.Descriptor instead.
+func (*EntryServiceSearchResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EntryServiceSearchResponse) GetEntries() []*Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type EntryServiceCreateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*Entry               `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryServiceCreateRequest) Reset() {
+	*x = EntryServiceCreateRequest{}
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryServiceCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryServiceCreateRequest) ProtoMessage() {}
+
+func (x *EntryServiceCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryServiceCreateRequest.ProtoReflect.Descriptor instead.
+func (*EntryServiceCreateRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EntryServiceCreateRequest) GetEntries() []*Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type EntryServiceCreateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*Entry               `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryServiceCreateResponse) Reset() {
+	*x = EntryServiceCreateResponse{}
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryServiceCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryServiceCreateResponse) ProtoMessage() {}
+
+func (x *EntryServiceCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryServiceCreateResponse.ProtoReflect.Descriptor instead.
+func (*EntryServiceCreateResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EntryServiceCreateResponse) GetEntries() []*Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type EntryServiceUpdateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*Entry               `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryServiceUpdateRequest) Reset() {
+	*x = EntryServiceUpdateRequest{}
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryServiceUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryServiceUpdateRequest) ProtoMessage() {}
+
+func (x *EntryServiceUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryServiceUpdateRequest.ProtoReflect.Descriptor instead.
+func (*EntryServiceUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EntryServiceUpdateRequest) GetEntries() []*Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type EntryServiceUpdateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*Entry               `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryServiceUpdateResponse) Reset() {
+	*x = EntryServiceUpdateResponse{}
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryServiceUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryServiceUpdateResponse) ProtoMessage() {}
+
+func (x *EntryServiceUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryServiceUpdateResponse.ProtoReflect.Descriptor instead.
+func (*EntryServiceUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EntryServiceUpdateResponse) GetEntries() []*Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type EntryServiceDeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryServiceDeleteRequest) Reset() {
+	*x = EntryServiceDeleteRequest{}
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryServiceDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryServiceDeleteRequest) ProtoMessage() {}
+
+func (x *EntryServiceDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryServiceDeleteRequest.ProtoReflect.Descriptor instead.
+func (*EntryServiceDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EntryServiceDeleteRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type EntryServiceDeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryServiceDeleteResponse) Reset() {
+	*x = EntryServiceDeleteResponse{}
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryServiceDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryServiceDeleteResponse) ProtoMessage() {}
+
+func (x *EntryServiceDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryServiceDeleteResponse.ProtoReflect.Descriptor instead.
+func (*EntryServiceDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EntryServiceDeleteResponse) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+var File_vercelgo_v1_entry_proto protoreflect.FileDescriptor
+
+const file_vercelgo_v1_entry_proto_rawDesc = "" +
+	"\n" +
+	"\x17vercelgo/v1/entry.proto\x12\vvercelgo.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb3\x01\n" +
+	"\x05Entry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\tsource_id\x18\x02 \x01(\x03R\bsourceId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05image\x18\x04 \x01(\tR\x05image\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"(\n" +
+	"\x16EntryServiceGetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"C\n" +
+	"\x17EntryServiceGetResponse\x12(\n" +
+	"\x05entry\x18\x01 \x01(\v2\x12.vercelgo.v1.EntryR\x05entry\"+\n" +
+	"\x17EntryServiceListRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"H\n" +
+	"\x18EntryServiceListResponse\x12,\n" +
+	"\aentries\x18\x01 \x03(\v2\x12.vercelgo.v1.EntryR\aentries\"\xb6\x01\n" +
+	"\x19EntryServiceSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12 \n" +
+	"\tsource_id\x18\x02 \x01(\x03H\x00R\bsourceId\x88\x01\x01\x12\x1a\n" +
+	"\x06tag_id\x18\x03 \x01(\x03H\x01R\x05tagId\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offsetB\f\n" +
+	"\n" +
+	"_source_idB\t\n" +
+	"\a_tag_id\"J\n" +
+	"\x1aEntryServiceSearchResponse\x12,\n" +
+	"\aentries\x18\x01 \x03(\v2\x12.vercelgo.v1.EntryR\aentries\"I\n" +
+	"\x19EntryServiceCreateRequest\x12,\n" +
+	"\aentries\x18\x01 \x03(\v2\x12.vercelgo.v1.EntryR\aentries\"J\n" +
+	"\x1aEntryServiceCreateResponse\x12,\n" +
+	"\aentries\x18\x01 \x03(\v2\x12.vercelgo.v1.EntryR\aentries\"I\n" +
+	"\x19EntryServiceUpdateRequest\x12,\n" +
+	"\aentries\x18\x01 \x03(\v2\x12.vercelgo.v1.EntryR\aentries\"J\n" +
+	"\x1aEntryServiceUpdateResponse\x12,\n" +
+	"\aentries\x18\x01 \x03(\v2\x12.vercelgo.v1.EntryR\aentries\"-\n" +
+	"\x19EntryServiceDeleteRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\".\n" +
+	"\x1aEntryServiceDeleteResponse\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids2\xb0\x04\n" +
+	"\fEntryService\x12U\n" +
+	"\x03Get\x12#.vercelgo.v1.EntryServiceGetRequest\x1a$.vercelgo.v1.EntryServiceGetResponse\"\x03\x90\x02\x01\x12X\n" +
+	"\x04List\x12$.vercelgo.v1.EntryServiceListRequest\x1a%.vercelgo.v1.EntryServiceListResponse\"\x03\x90\x02\x01\x12^\n" +
+	"\x06Search\x12&.vercelgo.v1.EntryServiceSearchRequest\x1a'.vercelgo.v1.EntryServiceSearchResponse\"\x03\x90\x02\x01\x12Y\n" +
+	"\x06Create\x12&.vercelgo.v1.EntryServiceCreateRequest\x1a'.vercelgo.v1.EntryServiceCreateResponse\x12Y\n" +
+	"\x06Update\x12&.vercelgo.v1.EntryServiceUpdateRequest\x1a'.vercelgo.v1.EntryServiceUpdateResponse\x12Y\n" +
+	"\x06Delete\x12&.vercelgo.v1.EntryServiceDeleteRequest\x1a'.vercelgo.v1.EntryServiceDeleteResponseB=Z;maguro-alternative/varcel-go/pkg/gen/vercelgo/v1;vercelgov1b\x06proto3"
+
+var (
+	file_vercelgo_v1_entry_proto_rawDescOnce sync.Once
+	file_vercelgo_v1_entry_proto_rawDescData []byte
+)
+
+func file_vercelgo_v1_entry_proto_rawDescGZIP() []byte {
+	file_vercelgo_v1_entry_proto_rawDescOnce.Do(func() {
+		file_vercelgo_v1_entry_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_vercelgo_v1_entry_proto_rawDesc), len(file_vercelgo_v1_entry_proto_rawDesc)))
+	})
+	return file_vercelgo_v1_entry_proto_rawDescData
+}
+
+var file_vercelgo_v1_entry_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_vercelgo_v1_entry_proto_goTypes = []any{
+	(*Entry)(nil),                      // 0: vercelgo.v1.Entry
+	(*EntryServiceGetRequest)(nil),     // 1: vercelgo.v1.EntryServiceGetRequest
+	(*EntryServiceGetResponse)(nil),    // 2: vercelgo.v1.EntryServiceGetResponse
+	(*EntryServiceListRequest)(nil),    // 3: vercelgo.v1.EntryServiceListRequest
+	(*EntryServiceListResponse)(nil),   // 4: vercelgo.v1.EntryServiceListResponse
+	(*EntryServiceSearchRequest)(nil),  // 5: vercelgo.v1.EntryServiceSearchRequest
+	(*EntryServiceSearchResponse)(nil), // 6: vercelgo.v1.EntryServiceSearchResponse
+	(*EntryServiceCreateRequest)(nil),  // 7: vercelgo.v1.EntryServiceCreateRequest
+	(*EntryServiceCreateResponse)(nil), // 8: vercelgo.v1.EntryServiceCreateResponse
+	(*EntryServiceUpdateRequest)(nil),  // 9: vercelgo.v1.EntryServiceUpdateRequest
+	(*EntryServiceUpdateResponse)(nil), // 10: vercelgo.v1.EntryServiceUpdateResponse
+	(*EntryServiceDeleteRequest)(nil),  // 11: vercelgo.v1.EntryServiceDeleteRequest
+	(*EntryServiceDeleteResponse)(nil), // 12: vercelgo.v1.EntryServiceDeleteResponse
+	(*timestamppb.Timestamp)(nil),      // 13: google.protobuf.Timestamp
+}
+var file_vercelgo_v1_entry_proto_depIdxs = []int32{
+	13, // 0: vercelgo.v1.Entry.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 1: vercelgo.v1.EntryServiceGetResponse.entry:type_name -> vercelgo.v1.Entry
+	0,  // 2: vercelgo.v1.EntryServiceListResponse.entries:type_name -> vercelgo.v1.Entry
+	0,  // 3: vercelgo.v1.EntryServiceSearchResponse.entries:type_name -> vercelgo.v1.Entry
+	0,  // 4: vercelgo.v1.EntryServiceCreateRequest.entries:type_name -> vercelgo.v1.Entry
+	0,  // 5: vercelgo.v1.EntryServiceCreateResponse.entries:type_name -> vercelgo.v1.Entry
+	0,  // 6: vercelgo.v1.EntryServiceUpdateRequest.entries:type_name -> vercelgo.v1.Entry
+	0,  // 7: vercelgo.v1.EntryServiceUpdateResponse.entries:type_name -> vercelgo.v1.Entry
+	1,  // 8: vercelgo.v1.EntryService.Get:input_type -> vercelgo.v1.EntryServiceGetRequest
+	3,  // 9: vercelgo.v1.EntryService.List:input_type -> vercelgo.v1.EntryServiceListRequest
+	5,  // 10: vercelgo.v1.EntryService.Search:input_type -> vercelgo.v1.EntryServiceSearchRequest
+	7,  // 11: vercelgo.v1.EntryService.Create:input_type -> vercelgo.v1.EntryServiceCreateRequest
+	9,  // 12: vercelgo.v1.EntryService.Update:input_type -> vercelgo.v1.EntryServiceUpdateRequest
+	11, // 13: vercelgo.v1.EntryService.Delete:input_type -> vercelgo.v1.EntryServiceDeleteRequest
+	2,  // 14: vercelgo.v1.EntryService.Get:output_type -> vercelgo.v1.EntryServiceGetResponse
+	4,  // 15: vercelgo.v1.EntryService.List:output_type -> vercelgo.v1.EntryServiceListResponse
+	6,  // 16: vercelgo.v1.EntryService.Search:output_type -> vercelgo.v1.EntryServiceSearchResponse
+	8,  // 17: vercelgo.v1.EntryService.Create:output_type -> vercelgo.v1.EntryServiceCreateResponse
+	10, // 18: vercelgo.v1.EntryService.Update:output_type -> vercelgo.v1.EntryServiceUpdateResponse
+	12, // 19: vercelgo.v1.EntryService.Delete:output_type -> vercelgo.v1.EntryServiceDeleteResponse
+	14, // [14:20] is the sub-list for method output_type
+	8,  // [8:14] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_vercelgo_v1_entry_proto_init() }
+func file_vercelgo_v1_entry_proto_init() {
+	if File_vercelgo_v1_entry_proto != nil {
+		return
+	}
+	file_vercelgo_v1_entry_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_vercelgo_v1_entry_proto_rawDesc), len(file_vercelgo_v1_entry_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_vercelgo_v1_entry_proto_goTypes,
+		DependencyIndexes: file_vercelgo_v1_entry_proto_depIdxs,
+		MessageInfos:      file_vercelgo_v1_entry_proto_msgTypes,
+	}.Build()
+	File_vercelgo_v1_entry_proto = out.File
+	file_vercelgo_v1_entry_proto_goTypes = nil
+	file_vercelgo_v1_entry_proto_depIdxs = nil
+}
@@ -0,0 +1,768 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: vercelgo/v1/entry_tag.proto
+
+package vercelgov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EntryTag はentry_tagテーブルの行
+type EntryTag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EntryId       int64                  `protobuf:"varint,2,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	TagId         int64                  `protobuf:"varint,3,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryTag) Reset() {
+	*x = EntryTag{}
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryTag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryTag) ProtoMessage() {}
+
+func (x *EntryTag) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryTag.ProtoReflect.Descriptor instead.
+func (*EntryTag) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_tag_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EntryTag) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *EntryTag) GetEntryId() int64 {
+	if x != nil {
+		return x.EntryId
+	}
+	return 0
+}
+
+func (x *EntryTag) GetTagId() int64 {
+	if x != nil {
+		return x.TagId
+	}
+	return 0
+}
+
+type EntryTagServiceGetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryTagServiceGetRequest) Reset() {
+	*x = EntryTagServiceGetRequest{}
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryTagServiceGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryTagServiceGetRequest) ProtoMessage() {}
+
+func (x *EntryTagServiceGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryTagServiceGetRequest.ProtoReflect.Descriptor instead.
+func (*EntryTagServiceGetRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_tag_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EntryTagServiceGetRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type EntryTagServiceGetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryTag      *EntryTag              `protobuf:"bytes,1,opt,name=entry_tag,json=entryTag,proto3" json:"entry_tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryTagServiceGetResponse) Reset() {
+	*x = EntryTagServiceGetResponse{}
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryTagServiceGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryTagServiceGetResponse) ProtoMessage() {}
+
+func (x *EntryTagServiceGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryTagServiceGetResponse.ProtoReflect.Descriptor instead.
+func (*EntryTagServiceGetResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_tag_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EntryTagServiceGetResponse) GetEntryTag() *EntryTag {
+	if x != nil {
+		return x.EntryTag
+	}
+	return nil
+}
+
+// EntryTagServiceListRequest のidsが空の場合は全件を返す
+type EntryTagServiceListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryTagServiceListRequest) Reset() {
+	*x = EntryTagServiceListRequest{}
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryTagServiceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryTagServiceListRequest) ProtoMessage() {}
+
+func (x *EntryTagServiceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryTagServiceListRequest.ProtoReflect.Descriptor instead.
+func (*EntryTagServiceListRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_tag_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EntryTagServiceListRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type EntryTagServiceListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryTags     []*EntryTag            `protobuf:"bytes,1,rep,name=entry_tags,json=entryTags,proto3" json:"entry_tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryTagServiceListResponse) Reset() {
+	*x = EntryTagServiceListResponse{}
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryTagServiceListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryTagServiceListResponse) ProtoMessage() {}
+
+func (x *EntryTagServiceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryTagServiceListResponse.ProtoReflect.Descriptor instead.
+func (*EntryTagServiceListResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_tag_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EntryTagServiceListResponse) GetEntryTags() []*EntryTag {
+	if x != nil {
+		return x.EntryTags
+	}
+	return nil
+}
+
+type EntryTagServiceSearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       *int64                 `protobuf:"varint,1,opt,name=entry_id,json=entryId,proto3,oneof" json:"entry_id,omitempty"`
+	TagId         *int64                 `protobuf:"varint,2,opt,name=tag_id,json=tagId,proto3,oneof" json:"tag_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryTagServiceSearchRequest) Reset() {
+	*x = EntryTagServiceSearchRequest{}
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryTagServiceSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryTagServiceSearchRequest) ProtoMessage() {}
+
+func (x *EntryTagServiceSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryTagServiceSearchRequest.ProtoReflect.Descriptor instead.
+func (*EntryTagServiceSearchRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_tag_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EntryTagServiceSearchRequest) GetEntryId() int64 {
+	if x != nil && x.EntryId != nil {
+		return *x.EntryId
+	}
+	return 0
+}
+
+func (x *EntryTagServiceSearchRequest) GetTagId() int64 {
+	if x != nil && x.TagId != nil {
+		return *x.TagId
+	}
+	return 0
+}
+
+func (x *EntryTagServiceSearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *EntryTagServiceSearchRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type EntryTagServiceSearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryTags     []*EntryTag            `protobuf:"bytes,1,rep,name=entry_tags,json=entryTags,proto3" json:"entry_tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryTagServiceSearchResponse) Reset() {
+	*x = EntryTagServiceSearchResponse{}
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryTagServiceSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryTagServiceSearchResponse) ProtoMessage() {}
+
+func (x *EntryTagServiceSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryTagServiceSearchResponse.ProtoReflect.Descriptor instead.
+func (*EntryTagServiceSearchResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_tag_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EntryTagServiceSearchResponse) GetEntryTags() []*EntryTag {
+	if x != nil {
+		return x.EntryTags
+	}
+	return nil
+}
+
+type EntryTagServiceCreateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryTags     []*EntryTag            `protobuf:"bytes,1,rep,name=entry_tags,json=entryTags,proto3" json:"entry_tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryTagServiceCreateRequest) Reset() {
+	*x = EntryTagServiceCreateRequest{}
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryTagServiceCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryTagServiceCreateRequest) ProtoMessage() {}
+
+func (x *EntryTagServiceCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryTagServiceCreateRequest.ProtoReflect.Descriptor instead.
+func (*EntryTagServiceCreateRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_tag_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EntryTagServiceCreateRequest) GetEntryTags() []*EntryTag {
+	if x != nil {
+		return x.EntryTags
+	}
+	return nil
+}
+
+type EntryTagServiceCreateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryTags     []*EntryTag            `protobuf:"bytes,1,rep,name=entry_tags,json=entryTags,proto3" json:"entry_tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryTagServiceCreateResponse) Reset() {
+	*x = EntryTagServiceCreateResponse{}
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryTagServiceCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryTagServiceCreateResponse) ProtoMessage() {}
+
+func (x *EntryTagServiceCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryTagServiceCreateResponse.ProtoReflect.Descriptor instead.
+func (*EntryTagServiceCreateResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_tag_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EntryTagServiceCreateResponse) GetEntryTags() []*EntryTag {
+	if x != nil {
+		return x.EntryTags
+	}
+	return nil
+}
+
+type EntryTagServiceUpdateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryTags     []*EntryTag            `protobuf:"bytes,1,rep,name=entry_tags,json=entryTags,proto3" json:"entry_tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryTagServiceUpdateRequest) Reset() {
+	*x = EntryTagServiceUpdateRequest{}
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryTagServiceUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryTagServiceUpdateRequest) ProtoMessage() {}
+
+func (x *EntryTagServiceUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryTagServiceUpdateRequest.ProtoReflect.Descriptor instead.
+func (*EntryTagServiceUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_tag_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EntryTagServiceUpdateRequest) GetEntryTags() []*EntryTag {
+	if x != nil {
+		return x.EntryTags
+	}
+	return nil
+}
+
+type EntryTagServiceUpdateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryTags     []*EntryTag            `protobuf:"bytes,1,rep,name=entry_tags,json=entryTags,proto3" json:"entry_tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryTagServiceUpdateResponse) Reset() {
+	*x = EntryTagServiceUpdateResponse{}
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryTagServiceUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryTagServiceUpdateResponse) ProtoMessage() {}
+
+func (x *EntryTagServiceUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryTagServiceUpdateResponse.ProtoReflect.Descriptor instead.
+func (*EntryTagServiceUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_tag_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EntryTagServiceUpdateResponse) GetEntryTags() []*EntryTag {
+	if x != nil {
+		return x.EntryTags
+	}
+	return nil
+}
+
+type EntryTagServiceDeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryTagServiceDeleteRequest) Reset() {
+	*x = EntryTagServiceDeleteRequest{}
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryTagServiceDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryTagServiceDeleteRequest) ProtoMessage() {}
+
+func (x *EntryTagServiceDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryTagServiceDeleteRequest.ProtoReflect.Descriptor instead.
+func (*EntryTagServiceDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_tag_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EntryTagServiceDeleteRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type EntryTagServiceDeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryTagServiceDeleteResponse) Reset() {
+	*x = EntryTagServiceDeleteResponse{}
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryTagServiceDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryTagServiceDeleteResponse) ProtoMessage() {}
+
+func (x *EntryTagServiceDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_entry_tag_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryTagServiceDeleteResponse.ProtoReflect.Descriptor instead.
+func (*EntryTagServiceDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_entry_tag_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EntryTagServiceDeleteResponse) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+var File_vercelgo_v1_entry_tag_proto protoreflect.FileDescriptor
+
+const file_vercelgo_v1_entry_tag_proto_rawDesc = "" +
+	"\n" +
+	"\x1bvercelgo/v1/entry_tag.proto\x12\vvercelgo.v1\"L\n" +
+	"\bEntryTag\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bentry_id\x18\x02 \x01(\x03R\aentryId\x12\x15\n" +
+	"\x06tag_id\x18\x03 \x01(\x03R\x05tagId\"+\n" +
+	"\x19EntryTagServiceGetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"P\n" +
+	"\x1aEntryTagServiceGetResponse\x122\n" +
+	"\tentry_tag\x18\x01 \x01(\v2\x15.vercelgo.v1.EntryTagR\bentryTag\".\n" +
+	"\x1aEntryTagServiceListRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"S\n" +
+	"\x1bEntryTagServiceListResponse\x124\n" +
+	"\n" +
+	"entry_tags\x18\x01 \x03(\v2\x15.vercelgo.v1.EntryTagR\tentryTags\"\xa0\x01\n" +
+	"\x1cEntryTagServiceSearchRequest\x12\x1e\n" +
+	"\bentry_id\x18\x01 \x01(\x03H\x00R\aentryId\x88\x01\x01\x12\x1a\n" +
+	"\x06tag_id\x18\x02 \x01(\x03H\x01R\x05tagId\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offsetB\v\n" +
+	"\t_entry_idB\t\n" +
+	"\a_tag_id\"U\n" +
+	"\x1dEntryTagServiceSearchResponse\x124\n" +
+	"\n" +
+	"entry_tags\x18\x01 \x03(\v2\x15.vercelgo.v1.EntryTagR\tentryTags\"T\n" +
+	"\x1cEntryTagServiceCreateRequest\x124\n" +
+	"\n" +
+	"entry_tags\x18\x01 \x03(\v2\x15.vercelgo.v1.EntryTagR\tentryTags\"U\n" +
+	"\x1dEntryTagServiceCreateResponse\x124\n" +
+	"\n" +
+	"entry_tags\x18\x01 \x03(\v2\x15.vercelgo.v1.EntryTagR\tentryTags\"T\n" +
+	"\x1cEntryTagServiceUpdateRequest\x124\n" +
+	"\n" +
+	"entry_tags\x18\x01 \x03(\v2\x15.vercelgo.v1.EntryTagR\tentryTags\"U\n" +
+	"\x1dEntryTagServiceUpdateResponse\x124\n" +
+	"\n" +
+	"entry_tags\x18\x01 \x03(\v2\x15.vercelgo.v1.EntryTagR\tentryTags\"0\n" +
+	"\x1cEntryTagServiceDeleteRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"1\n" +
+	"\x1dEntryTagServiceDeleteResponse\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids2\xd7\x04\n" +
+	"\x0fEntryTagService\x12[\n" +
+	"\x03Get\x12&.vercelgo.v1.EntryTagServiceGetRequest\x1a'.vercelgo.v1.EntryTagServiceGetResponse\"\x03\x90\x02\x01\x12^\n" +
+	"\x04List\x12'.vercelgo.v1.EntryTagServiceListRequest\x1a(.vercelgo.v1.EntryTagServiceListResponse\"\x03\x90\x02\x01\x12d\n" +
+	"\x06Search\x12).vercelgo.v1.EntryTagServiceSearchRequest\x1a*.vercelgo.v1.EntryTagServiceSearchResponse\"\x03\x90\x02\x01\x12_\n" +
+	"\x06Create\x12).vercelgo.v1.EntryTagServiceCreateRequest\x1a*.vercelgo.v1.EntryTagServiceCreateResponse\x12_\n" +
+	"\x06Update\x12).vercelgo.v1.EntryTagServiceUpdateRequest\x1a*.vercelgo.v1.EntryTagServiceUpdateResponse\x12_\n" +
+	"\x06Delete\x12).vercelgo.v1.EntryTagServiceDeleteRequest\x1a*.vercelgo.v1.EntryTagServiceDeleteResponseB=Z;maguro-alternative/varcel-go/pkg/gen/vercelgo/v1;vercelgov1b\x06proto3"
+
+var (
+	file_vercelgo_v1_entry_tag_proto_rawDescOnce sync.Once
+	file_vercelgo_v1_entry_tag_proto_rawDescData []byte
+)
+
+func file_vercelgo_v1_entry_tag_proto_rawDescGZIP() []byte {
+	file_vercelgo_v1_entry_tag_proto_rawDescOnce.Do(func() {
+		file_vercelgo_v1_entry_tag_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_vercelgo_v1_entry_tag_proto_rawDesc), len(file_vercelgo_v1_entry_tag_proto_rawDesc)))
+	})
+	return file_vercelgo_v1_entry_tag_proto_rawDescData
+}
+
+var file_vercelgo_v1_entry_tag_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_vercelgo_v1_entry_tag_proto_goTypes = []any{
+	(*EntryTag)(nil),                      // 0: vercelgo.v1.EntryTag
+	(*EntryTagServiceGetRequest)(nil),     // 1: vercelgo.v1.EntryTagServiceGetRequest
+	(*EntryTagServiceGetResponse)(nil),    // 2: vercelgo.v1.EntryTagServiceGetResponse
+	(*EntryTagServiceListRequest)(nil),    // 3: vercelgo.v1.EntryTagServiceListRequest
+	(*EntryTagServiceListResponse)(nil),   // 4: vercelgo.v1.EntryTagServiceListResponse
+	(*EntryTagServiceSearchRequest)(nil),  // 5: vercelgo.v1.EntryTagServiceSearchRequest
+	(*EntryTagServiceSearchResponse)(nil), // 6: vercelgo.v1.EntryTagServiceSearchResponse
+	(*EntryTagServiceCreateRequest)(nil),  // 7: vercelgo.v1.EntryTagServiceCreateRequest
+	(*EntryTagServiceCreateResponse)(nil), // 8: vercelgo.v1.EntryTagServiceCreateResponse
+	(*EntryTagServiceUpdateRequest)(nil),  // 9: vercelgo.v1.EntryTagServiceUpdateRequest
+	(*EntryTagServiceUpdateResponse)(nil), // 10: vercelgo.v1.EntryTagServiceUpdateResponse
+	(*EntryTagServiceDeleteRequest)(nil),  // 11: vercelgo.v1.EntryTagServiceDeleteRequest
+	(*EntryTagServiceDeleteResponse)(nil), // 12: vercelgo.v1.EntryTagServiceDeleteResponse
+}
+var file_vercelgo_v1_entry_tag_proto_depIdxs = []int32{
+	0,  // 0: vercelgo.v1.EntryTagServiceGetResponse.entry_tag:type_name -> vercelgo.v1.EntryTag
+	0,  // 1: vercelgo.v1.EntryTagServiceListResponse.entry_tags:type_name -> vercelgo.v1.EntryTag
+	0,  // 2: vercelgo.v1.EntryTagServiceSearchResponse.entry_tags:type_name -> vercelgo.v1.EntryTag
+	0,  // 3: vercelgo.v1.EntryTagServiceCreateRequest.entry_tags:type_name -> vercelgo.v1.EntryTag
+	0,  // 4: vercelgo.v1.EntryTagServiceCreateResponse.entry_tags:type_name -> vercelgo.v1.EntryTag
+	0,  // 5: vercelgo.v1.EntryTagServiceUpdateRequest.entry_tags:type_name -> vercelgo.v1.EntryTag
+	0,  // 6: vercelgo.v1.EntryTagServiceUpdateResponse.entry_tags:type_name -> vercelgo.v1.EntryTag
+	1,  // 7: vercelgo.v1.EntryTagService.Get:input_type -> vercelgo.v1.EntryTagServiceGetRequest
+	3,  // 8: vercelgo.v1.EntryTagService.List:input_type -> vercelgo.v1.EntryTagServiceListRequest
+	5,  // 9: vercelgo.v1.EntryTagService.Search:input_type -> vercelgo.v1.EntryTagServiceSearchRequest
+	7,  // 10: vercelgo.v1.EntryTagService.Create:input_type -> vercelgo.v1.EntryTagServiceCreateRequest
+	9,  // 11: vercelgo.v1.EntryTagService.Update:input_type -> vercelgo.v1.EntryTagServiceUpdateRequest
+	11, // 12: vercelgo.v1.EntryTagService.Delete:input_type -> vercelgo.v1.EntryTagServiceDeleteRequest
+	2,  // 13: vercelgo.v1.EntryTagService.Get:output_type -> vercelgo.v1.EntryTagServiceGetResponse
+	4,  // 14: vercelgo.v1.EntryTagService.List:output_type -> vercelgo.v1.EntryTagServiceListResponse
+	6,  // 15: vercelgo.v1.EntryTagService.Search:output_type -> vercelgo.v1.EntryTagServiceSearchResponse
+	8,  // 16: vercelgo.v1.EntryTagService.Create:output_type -> vercelgo.v1.EntryTagServiceCreateResponse
+	10, // 17: vercelgo.v1.EntryTagService.Update:output_type -> vercelgo.v1.EntryTagServiceUpdateResponse
+	12, // 18: vercelgo.v1.EntryTagService.Delete:output_type -> vercelgo.v1.EntryTagServiceDeleteResponse
+	13, // [13:19] is the sub-list for method output_type
+	7,  // [7:13] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_vercelgo_v1_entry_tag_proto_init() }
+func file_vercelgo_v1_entry_tag_proto_init() {
+	if File_vercelgo_v1_entry_tag_proto != nil {
+		return
+	}
+	file_vercelgo_v1_entry_tag_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_vercelgo_v1_entry_tag_proto_rawDesc), len(file_vercelgo_v1_entry_tag_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_vercelgo_v1_entry_tag_proto_goTypes,
+		DependencyIndexes: file_vercelgo_v1_entry_tag_proto_depIdxs,
+		MessageInfos:      file_vercelgo_v1_entry_tag_proto_msgTypes,
+	}.Build()
+	File_vercelgo_v1_entry_tag_proto = out.File
+	file_vercelgo_v1_entry_tag_proto_goTypes = nil
+	file_vercelgo_v1_entry_tag_proto_depIdxs = nil
+}
@@ -0,0 +1,809 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: vercelgo/v1/link.proto
+
+package vercelgov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Link はlinkテーブルの行
+type Link struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EntryId       int64                  `protobuf:"varint,2,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	Nsfw          bool                   `protobuf:"varint,5,opt,name=nsfw,proto3" json:"nsfw,omitempty"`
+	Darkness      bool                   `protobuf:"varint,6,opt,name=darkness,proto3" json:"darkness,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Link) Reset() {
+	*x = Link{}
+	mi := &file_vercelgo_v1_link_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Link) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Link) ProtoMessage() {}
+
+func (x *Link) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_link_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Link.ProtoReflect.Descriptor instead.
+func (*Link) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_link_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Link) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Link) GetEntryId() int64 {
+	if x != nil {
+		return x.EntryId
+	}
+	return 0
+}
+
+func (x *Link) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Link) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Link) GetNsfw() bool {
+	if x != nil {
+		return x.Nsfw
+	}
+	return false
+}
+
+func (x *Link) GetDarkness() bool {
+	if x != nil {
+		return x.Darkness
+	}
+	return false
+}
+
+type LinkServiceGetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkServiceGetRequest) Reset() {
+	*x = LinkServiceGetRequest{}
+	mi := &file_vercelgo_v1_link_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkServiceGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkServiceGetRequest) ProtoMessage() {}
+
+func (x *LinkServiceGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_link_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkServiceGetRequest.ProtoReflect.Descriptor instead.
+func (*LinkServiceGetRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_link_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LinkServiceGetRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type LinkServiceGetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Link          *Link                  `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkServiceGetResponse) Reset() {
+	*x = LinkServiceGetResponse{}
+	mi := &file_vercelgo_v1_link_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkServiceGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkServiceGetResponse) ProtoMessage() {}
+
+func (x *LinkServiceGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_link_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkServiceGetResponse.ProtoReflect.Descriptor instead.
+func (*LinkServiceGetResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_link_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LinkServiceGetResponse) GetLink() *Link {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+// LinkServiceListRequest のidsが空の場合は全件を返す
+type LinkServiceListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkServiceListRequest) Reset() {
+	*x = LinkServiceListRequest{}
+	mi := &file_vercelgo_v1_link_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkServiceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkServiceListRequest) ProtoMessage() {}
+
+func (x *LinkServiceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_link_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkServiceListRequest.ProtoReflect.Descriptor instead.
+func (*LinkServiceListRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_link_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LinkServiceListRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type LinkServiceListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*Link                `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkServiceListResponse) Reset() {
+	*x = LinkServiceListResponse{}
+	mi := &file_vercelgo_v1_link_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkServiceListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkServiceListResponse) ProtoMessage() {}
+
+func (x *LinkServiceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_link_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkServiceListResponse.ProtoReflect.Descriptor instead.
+func (*LinkServiceListResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_link_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LinkServiceListResponse) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type LinkServiceSearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       *int64                 `protobuf:"varint,1,opt,name=entry_id,json=entryId,proto3,oneof" json:"entry_id,omitempty"`
+	Type          *string                `protobuf:"bytes,2,opt,name=type,proto3,oneof" json:"type,omitempty"`
+	Nsfw          *bool                  `protobuf:"varint,3,opt,name=nsfw,proto3,oneof" json:"nsfw,omitempty"`
+	Darkness      *bool                  `protobuf:"varint,4,opt,name=darkness,proto3,oneof" json:"darkness,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkServiceSearchRequest) Reset() {
+	*x = LinkServiceSearchRequest{}
+	mi := &file_vercelgo_v1_link_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkServiceSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkServiceSearchRequest) ProtoMessage() {}
+
+func (x *LinkServiceSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_link_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkServiceSearchRequest.ProtoReflect.Descriptor instead.
+func (*LinkServiceSearchRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_link_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LinkServiceSearchRequest) GetEntryId() int64 {
+	if x != nil && x.EntryId != nil {
+		return *x.EntryId
+	}
+	return 0
+}
+
+func (x *LinkServiceSearchRequest) GetType() string {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ""
+}
+
+func (x *LinkServiceSearchRequest) GetNsfw() bool {
+	if x != nil && x.Nsfw != nil {
+		return *x.Nsfw
+	}
+	return false
+}
+
+func (x *LinkServiceSearchRequest) GetDarkness() bool {
+	if x != nil && x.Darkness != nil {
+		return *x.Darkness
+	}
+	return false
+}
+
+func (x *LinkServiceSearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *LinkServiceSearchRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type LinkServiceSearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*Link                `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkServiceSearchResponse) Reset() {
+	*x = LinkServiceSearchResponse{}
+	mi := &file_vercelgo_v1_link_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkServiceSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkServiceSearchResponse) ProtoMessage() {}
+
+func (x *LinkServiceSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_link_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkServiceSearchResponse.ProtoReflect.Descriptor instead.
+func (*LinkServiceSearchResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_link_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LinkServiceSearchResponse) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type LinkServiceCreateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*Link                `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkServiceCreateRequest) Reset() {
+	*x = LinkServiceCreateRequest{}
+	mi := &file_vercelgo_v1_link_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkServiceCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkServiceCreateRequest) ProtoMessage() {}
+
+func (x *LinkServiceCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_link_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkServiceCreateRequest.ProtoReflect.Descriptor instead.
+func (*LinkServiceCreateRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_link_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LinkServiceCreateRequest) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type LinkServiceCreateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*Link                `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkServiceCreateResponse) Reset() {
+	*x = LinkServiceCreateResponse{}
+	mi := &file_vercelgo_v1_link_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkServiceCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkServiceCreateResponse) ProtoMessage() {}
+
+func (x *LinkServiceCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_link_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkServiceCreateResponse.ProtoReflect.Descriptor instead.
+func (*LinkServiceCreateResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_link_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LinkServiceCreateResponse) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type LinkServiceUpdateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*Link                `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkServiceUpdateRequest) Reset() {
+	*x = LinkServiceUpdateRequest{}
+	mi := &file_vercelgo_v1_link_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkServiceUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkServiceUpdateRequest) ProtoMessage() {}
+
+func (x *LinkServiceUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_link_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkServiceUpdateRequest.ProtoReflect.Descriptor instead.
+func (*LinkServiceUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_link_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LinkServiceUpdateRequest) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type LinkServiceUpdateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*Link                `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkServiceUpdateResponse) Reset() {
+	*x = LinkServiceUpdateResponse{}
+	mi := &file_vercelgo_v1_link_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkServiceUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkServiceUpdateResponse) ProtoMessage() {}
+
+func (x *LinkServiceUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_link_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkServiceUpdateResponse.ProtoReflect.Descriptor instead.
+func (*LinkServiceUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_link_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LinkServiceUpdateResponse) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type LinkServiceDeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkServiceDeleteRequest) Reset() {
+	*x = LinkServiceDeleteRequest{}
+	mi := &file_vercelgo_v1_link_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkServiceDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkServiceDeleteRequest) ProtoMessage() {}
+
+func (x *LinkServiceDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_link_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkServiceDeleteRequest.ProtoReflect.Descriptor instead.
+func (*LinkServiceDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_link_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LinkServiceDeleteRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type LinkServiceDeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkServiceDeleteResponse) Reset() {
+	*x = LinkServiceDeleteResponse{}
+	mi := &file_vercelgo_v1_link_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkServiceDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkServiceDeleteResponse) ProtoMessage() {}
+
+func (x *LinkServiceDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_link_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkServiceDeleteResponse.ProtoReflect.Descriptor instead.
+func (*LinkServiceDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_link_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LinkServiceDeleteResponse) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+var File_vercelgo_v1_link_proto protoreflect.FileDescriptor
+
+const file_vercelgo_v1_link_proto_rawDesc = "" +
+	"\n" +
+	"\x16vercelgo/v1/link.proto\x12\vvercelgo.v1\"\x87\x01\n" +
+	"\x04Link\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bentry_id\x18\x02 \x01(\x03R\aentryId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x12\n" +
+	"\x04nsfw\x18\x05 \x01(\bR\x04nsfw\x12\x1a\n" +
+	"\bdarkness\x18\x06 \x01(\bR\bdarkness\"'\n" +
+	"\x15LinkServiceGetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"?\n" +
+	"\x16LinkServiceGetResponse\x12%\n" +
+	"\x04link\x18\x01 \x01(\v2\x11.vercelgo.v1.LinkR\x04link\"*\n" +
+	"\x16LinkServiceListRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"B\n" +
+	"\x17LinkServiceListResponse\x12'\n" +
+	"\x05links\x18\x01 \x03(\v2\x11.vercelgo.v1.LinkR\x05links\"\xe7\x01\n" +
+	"\x18LinkServiceSearchRequest\x12\x1e\n" +
+	"\bentry_id\x18\x01 \x01(\x03H\x00R\aentryId\x88\x01\x01\x12\x17\n" +
+	"\x04type\x18\x02 \x01(\tH\x01R\x04type\x88\x01\x01\x12\x17\n" +
+	"\x04nsfw\x18\x03 \x01(\bH\x02R\x04nsfw\x88\x01\x01\x12\x1f\n" +
+	"\bdarkness\x18\x04 \x01(\bH\x03R\bdarkness\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x06 \x01(\x05R\x06offsetB\v\n" +
+	"\t_entry_idB\a\n" +
+	"\x05_typeB\a\n" +
+	"\x05_nsfwB\v\n" +
+	"\t_darkness\"D\n" +
+	"\x19LinkServiceSearchResponse\x12'\n" +
+	"\x05links\x18\x01 \x03(\v2\x11.vercelgo.v1.LinkR\x05links\"C\n" +
+	"\x18LinkServiceCreateRequest\x12'\n" +
+	"\x05links\x18\x01 \x03(\v2\x11.vercelgo.v1.LinkR\x05links\"D\n" +
+	"\x19LinkServiceCreateResponse\x12'\n" +
+	"\x05links\x18\x01 \x03(\v2\x11.vercelgo.v1.LinkR\x05links\"C\n" +
+	"\x18LinkServiceUpdateRequest\x12'\n" +
+	"\x05links\x18\x01 \x03(\v2\x11.vercelgo.v1.LinkR\x05links\"D\n" +
+	"\x19LinkServiceUpdateResponse\x12'\n" +
+	"\x05links\x18\x01 \x03(\v2\x11.vercelgo.v1.LinkR\x05links\",\n" +
+	"\x18LinkServiceDeleteRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"-\n" +
+	"\x19LinkServiceDeleteResponse\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids2\xa3\x04\n" +
+	"\vLinkService\x12S\n" +
+	"\x03Get\x12\".vercelgo.v1.LinkServiceGetRequest\x1a#.vercelgo.v1.LinkServiceGetResponse\"\x03\x90\x02\x01\x12V\n" +
+	"\x04List\x12#.vercelgo.v1.LinkServiceListRequest\x1a$.vercelgo.v1.LinkServiceListResponse\"\x03\x90\x02\x01\x12\\\n" +
+	"\x06Search\x12%.vercelgo.v1.LinkServiceSearchRequest\x1a&.vercelgo.v1.LinkServiceSearchResponse\"\x03\x90\x02\x01\x12W\n" +
+	"\x06Create\x12%.vercelgo.v1.LinkServiceCreateRequest\x1a&.vercelgo.v1.LinkServiceCreateResponse\x12W\n" +
+	"\x06Update\x12%.vercelgo.v1.LinkServiceUpdateRequest\x1a&.vercelgo.v1.LinkServiceUpdateResponse\x12W\n" +
+	"\x06Delete\x12%.vercelgo.v1.LinkServiceDeleteRequest\x1a&.vercelgo.v1.LinkServiceDeleteResponseB=Z;maguro-alternative/varcel-go/pkg/gen/vercelgo/v1;vercelgov1b\x06proto3"
+
+var (
+	file_vercelgo_v1_link_proto_rawDescOnce sync.Once
+	file_vercelgo_v1_link_proto_rawDescData []byte
+)
+
+func file_vercelgo_v1_link_proto_rawDescGZIP() []byte {
+	file_vercelgo_v1_link_proto_rawDescOnce.Do(func() {
+		file_vercelgo_v1_link_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_vercelgo_v1_link_proto_rawDesc), len(file_vercelgo_v1_link_proto_rawDesc)))
+	})
+	return file_vercelgo_v1_link_proto_rawDescData
+}
+
+var file_vercelgo_v1_link_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_vercelgo_v1_link_proto_goTypes = []any{
+	(*Link)(nil),                      // 0: vercelgo.v1.Link
+	(*LinkServiceGetRequest)(nil),     // 1: vercelgo.v1.LinkServiceGetRequest
+	(*LinkServiceGetResponse)(nil),    // 2: vercelgo.v1.LinkServiceGetResponse
+	(*LinkServiceListRequest)(nil),    // 3: vercelgo.v1.LinkServiceListRequest
+	(*LinkServiceListResponse)(nil),   // 4: vercelgo.v1.LinkServiceListResponse
+	(*LinkServiceSearchRequest)(nil),  // 5: vercelgo.v1.LinkServiceSearchRequest
+	(*LinkServiceSearchResponse)(nil), // 6: vercelgo.v1.LinkServiceSearchResponse
+	(*LinkServiceCreateRequest)(nil),  // 7: vercelgo.v1.LinkServiceCreateRequest
+	(*LinkServiceCreateResponse)(nil), // 8: vercelgo.v1.LinkServiceCreateResponse
+	(*LinkServiceUpdateRequest)(nil),  // 9: vercelgo.v1.LinkServiceUpdateRequest
+	(*LinkServiceUpdateResponse)(nil), // 10: vercelgo.v1.LinkServiceUpdateResponse
+	(*LinkServiceDeleteRequest)(nil),  // 11: vercelgo.v1.LinkServiceDeleteRequest
+	(*LinkServiceDeleteResponse)(nil), // 12: vercelgo.v1.LinkServiceDeleteResponse
+}
+var file_vercelgo_v1_link_proto_depIdxs = []int32{
+	0,  // 0: vercelgo.v1.LinkServiceGetResponse.link:type_name -> vercelgo.v1.Link
+	0,  // 1: vercelgo.v1.LinkServiceListResponse.links:type_name -> vercelgo.v1.Link
+	0,  // 2: vercelgo.v1.LinkServiceSearchResponse.links:type_name -> vercelgo.v1.Link
+	0,  // 3: vercelgo.v1.LinkServiceCreateRequest.links:type_name -> vercelgo.v1.Link
+	0,  // 4: vercelgo.v1.LinkServiceCreateResponse.links:type_name -> vercelgo.v1.Link
+	0,  // 5: vercelgo.v1.LinkServiceUpdateRequest.links:type_name -> vercelgo.v1.Link
+	0,  // 6: vercelgo.v1.LinkServiceUpdateResponse.links:type_name -> vercelgo.v1.Link
+	1,  // 7: vercelgo.v1.LinkService.Get:input_type -> vercelgo.v1.LinkServiceGetRequest
+	3,  // 8: vercelgo.v1.LinkService.List:input_type -> vercelgo.v1.LinkServiceListRequest
+	5,  // 9: vercelgo.v1.LinkService.Search:input_type -> vercelgo.v1.LinkServiceSearchRequest
+	7,  // 10: vercelgo.v1.LinkService.Create:input_type -> vercelgo.v1.LinkServiceCreateRequest
+	9,  // 11: vercelgo.v1.LinkService.Update:input_type -> vercelgo.v1.LinkServiceUpdateRequest
+	11, // 12: vercelgo.v1.LinkService.Delete:input_type -> vercelgo.v1.LinkServiceDeleteRequest
+	2,  // 13: vercelgo.v1.LinkService.Get:output_type -> vercelgo.v1.LinkServiceGetResponse
+	4,  // 14: vercelgo.v1.LinkService.List:output_type -> vercelgo.v1.LinkServiceListResponse
+	6,  // 15: vercelgo.v1.LinkService.Search:output_type -> vercelgo.v1.LinkServiceSearchResponse
+	8,  // 16: vercelgo.v1.LinkService.Create:output_type -> vercelgo.v1.LinkServiceCreateResponse
+	10, // 17: vercelgo.v1.LinkService.Update:output_type -> vercelgo.v1.LinkServiceUpdateResponse
+	12, // 18: vercelgo.v1.LinkService.Delete:output_type -> vercelgo.v1.LinkServiceDeleteResponse
+	13, // [13:19] is the sub-list for method output_type
+	7,  // [7:13] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_vercelgo_v1_link_proto_init() }
+func file_vercelgo_v1_link_proto_init() {
+	if File_vercelgo_v1_link_proto != nil {
+		return
+	}
+	file_vercelgo_v1_link_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_vercelgo_v1_link_proto_rawDesc), len(file_vercelgo_v1_link_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_vercelgo_v1_link_proto_goTypes,
+		DependencyIndexes: file_vercelgo_v1_link_proto_depIdxs,
+		MessageInfos:      file_vercelgo_v1_link_proto_msgTypes,
+	}.Build()
+	File_vercelgo_v1_link_proto = out.File
+	file_vercelgo_v1_link_proto_goTypes = nil
+	file_vercelgo_v1_link_proto_depIdxs = nil
+}
@@ -0,0 +1,872 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: vercelgo/v1/type.proto
+
+package vercelgov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TypeTable は*_typeテーブルの種類
+type TypeTable int32
+
+const (
+	TypeTable_TYPE_TABLE_UNSPECIFIED TypeTable = 0
+	TypeTable_TYPE_TABLE_HAIRCOLOR   TypeTable = 1
+	TypeTable_TYPE_TABLE_EYECOLOR    TypeTable = 2
+	TypeTable_TYPE_TABLE_HAIRSTYLE   TypeTable = 3
+	TypeTable_TYPE_TABLE_HAIRLENGTH  TypeTable = 4
+	TypeTable_TYPE_TABLE_PERSONALITY TypeTable = 5
+)
+
+// Enum value maps for TypeTable.
+var (
+	TypeTable_name = map[int32]string{
+		0: "TYPE_TABLE_UNSPECIFIED",
+		1: "TYPE_TABLE_HAIRCOLOR",
+		2: "TYPE_TABLE_EYECOLOR",
+		3: "TYPE_TABLE_HAIRSTYLE",
+		4: "TYPE_TABLE_HAIRLENGTH",
+		5: "TYPE_TABLE_PERSONALITY",
+	}
+	TypeTable_value = map[string]int32{
+		"TYPE_TABLE_UNSPECIFIED": 0,
+		"TYPE_TABLE_HAIRCOLOR":   1,
+		"TYPE_TABLE_EYECOLOR":    2,
+		"TYPE_TABLE_HAIRSTYLE":   3,
+		"TYPE_TABLE_HAIRLENGTH":  4,
+		"TYPE_TABLE_PERSONALITY": 5,
+	}
+)
+
+func (x TypeTable) Enum() *TypeTable {
+	p := new(TypeTable)
+	*p = x
+	return p
+}
+
+func (x TypeTable) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TypeTable) Descriptor() protoreflect.EnumDescriptor {
+	return file_vercelgo_v1_type_proto_enumTypes[0].Descriptor()
+}
+
+func (TypeTable) Type() protoreflect.EnumType {
+	return &file_vercelgo_v1_type_proto_enumTypes[0]
+}
+
+func (x TypeTable) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TypeTable.Descriptor instead.
+func (TypeTable) EnumDescriptor() ([]byte, []int) {
+	return file_vercelgo_v1_type_proto_rawDescGZIP(), []int{0}
+}
+
+// TypeValue は*_typeテーブルの行
+// valueはテーブルごとの列(color, style, length, type)の値
+type TypeValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeValue) Reset() {
+	*x = TypeValue{}
+	mi := &file_vercelgo_v1_type_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeValue) ProtoMessage() {}
+
+func (x *TypeValue) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_type_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeValue.ProtoReflect.Descriptor instead.
+func (*TypeValue) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_type_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TypeValue) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TypeValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type TypeServiceGetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Table         TypeTable              `protobuf:"varint,1,opt,name=table,proto3,enum=vercelgo.v1.TypeTable" json:"table,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeServiceGetRequest) Reset() {
+	*x = TypeServiceGetRequest{}
+	mi := &file_vercelgo_v1_type_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeServiceGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeServiceGetRequest) ProtoMessage() {}
+
+func (x *TypeServiceGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_type_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeServiceGetRequest.ProtoReflect.Descriptor instead.
+func (*TypeServiceGetRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_type_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TypeServiceGetRequest) GetTable() TypeTable {
+	if x != nil {
+		return x.Table
+	}
+	return TypeTable_TYPE_TABLE_UNSPECIFIED
+}
+
+func (x *TypeServiceGetRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type TypeServiceGetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          *TypeValue             `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeServiceGetResponse) Reset() {
+	*x = TypeServiceGetResponse{}
+	mi := &file_vercelgo_v1_type_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeServiceGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeServiceGetResponse) ProtoMessage() {}
+
+func (x *TypeServiceGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_type_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeServiceGetResponse.ProtoReflect.Descriptor instead.
+func (*TypeServiceGetResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_type_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TypeServiceGetResponse) GetType() *TypeValue {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+// TypeServiceListRequest のidsが空の場合は全件を返す
+type TypeServiceListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Table         TypeTable              `protobuf:"varint,1,opt,name=table,proto3,enum=vercelgo.v1.TypeTable" json:"table,omitempty"`
+	Ids           []int64                `protobuf:"varint,2,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeServiceListRequest) Reset() {
+	*x = TypeServiceListRequest{}
+	mi := &file_vercelgo_v1_type_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeServiceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeServiceListRequest) ProtoMessage() {}
+
+func (x *TypeServiceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_type_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeServiceListRequest.ProtoReflect.Descriptor instead.
+func (*TypeServiceListRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_type_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TypeServiceListRequest) GetTable() TypeTable {
+	if x != nil {
+		return x.Table
+	}
+	return TypeTable_TYPE_TABLE_UNSPECIFIED
+}
+
+func (x *TypeServiceListRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type TypeServiceListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []*TypeValue           `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeServiceListResponse) Reset() {
+	*x = TypeServiceListResponse{}
+	mi := &file_vercelgo_v1_type_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeServiceListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeServiceListResponse) ProtoMessage() {}
+
+func (x *TypeServiceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_type_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeServiceListResponse.ProtoReflect.Descriptor instead.
+func (*TypeServiceListResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_type_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TypeServiceListResponse) GetTypes() []*TypeValue {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type TypeServiceSearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Table TypeTable              `protobuf:"varint,1,opt,name=table,proto3,enum=vercelgo.v1.TypeTable" json:"table,omitempty"`
+	// query はvalueの部分一致
+	Query         string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeServiceSearchRequest) Reset() {
+	*x = TypeServiceSearchRequest{}
+	mi := &file_vercelgo_v1_type_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeServiceSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeServiceSearchRequest) ProtoMessage() {}
+
+func (x *TypeServiceSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_type_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeServiceSearchRequest.ProtoReflect.Descriptor instead.
+func (*TypeServiceSearchRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_type_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TypeServiceSearchRequest) GetTable() TypeTable {
+	if x != nil {
+		return x.Table
+	}
+	return TypeTable_TYPE_TABLE_UNSPECIFIED
+}
+
+func (x *TypeServiceSearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *TypeServiceSearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *TypeServiceSearchRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type TypeServiceSearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []*TypeValue           `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeServiceSearchResponse) Reset() {
+	*x = TypeServiceSearchResponse{}
+	mi := &file_vercelgo_v1_type_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeServiceSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeServiceSearchResponse) ProtoMessage() {}
+
+func (x *TypeServiceSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_type_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeServiceSearchResponse.ProtoReflect.Descriptor instead.
+func (*TypeServiceSearchResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_type_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TypeServiceSearchResponse) GetTypes() []*TypeValue {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type TypeServiceCreateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Table         TypeTable              `protobuf:"varint,1,opt,name=table,proto3,enum=vercelgo.v1.TypeTable" json:"table,omitempty"`
+	Types         []*TypeValue           `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeServiceCreateRequest) Reset() {
+	*x = TypeServiceCreateRequest{}
+	mi := &file_vercelgo_v1_type_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeServiceCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeServiceCreateRequest) ProtoMessage() {}
+
+func (x *TypeServiceCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_type_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeServiceCreateRequest.ProtoReflect.Descriptor instead.
+func (*TypeServiceCreateRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_type_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TypeServiceCreateRequest) GetTable() TypeTable {
+	if x != nil {
+		return x.Table
+	}
+	return TypeTable_TYPE_TABLE_UNSPECIFIED
+}
+
+func (x *TypeServiceCreateRequest) GetTypes() []*TypeValue {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type TypeServiceCreateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []*TypeValue           `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeServiceCreateResponse) Reset() {
+	*x = TypeServiceCreateResponse{}
+	mi := &file_vercelgo_v1_type_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeServiceCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeServiceCreateResponse) ProtoMessage() {}
+
+func (x *TypeServiceCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_type_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeServiceCreateResponse.ProtoReflect.Descriptor instead.
+func (*TypeServiceCreateResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_type_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TypeServiceCreateResponse) GetTypes() []*TypeValue {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type TypeServiceUpdateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Table         TypeTable              `protobuf:"varint,1,opt,name=table,proto3,enum=vercelgo.v1.TypeTable" json:"table,omitempty"`
+	Types         []*TypeValue           `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeServiceUpdateRequest) Reset() {
+	*x = TypeServiceUpdateRequest{}
+	mi := &file_vercelgo_v1_type_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeServiceUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeServiceUpdateRequest) ProtoMessage() {}
+
+func (x *TypeServiceUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_type_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeServiceUpdateRequest.ProtoReflect.Descriptor instead.
+func (*TypeServiceUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_type_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TypeServiceUpdateRequest) GetTable() TypeTable {
+	if x != nil {
+		return x.Table
+	}
+	return TypeTable_TYPE_TABLE_UNSPECIFIED
+}
+
+func (x *TypeServiceUpdateRequest) GetTypes() []*TypeValue {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type TypeServiceUpdateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []*TypeValue           `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeServiceUpdateResponse) Reset() {
+	*x = TypeServiceUpdateResponse{}
+	mi := &file_vercelgo_v1_type_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeServiceUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeServiceUpdateResponse) ProtoMessage() {}
+
+func (x *TypeServiceUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_type_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeServiceUpdateResponse.ProtoReflect.Descriptor instead.
+func (*TypeServiceUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_type_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TypeServiceUpdateResponse) GetTypes() []*TypeValue {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type TypeServiceDeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Table         TypeTable              `protobuf:"varint,1,opt,name=table,proto3,enum=vercelgo.v1.TypeTable" json:"table,omitempty"`
+	Ids           []int64                `protobuf:"varint,2,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeServiceDeleteRequest) Reset() {
+	*x = TypeServiceDeleteRequest{}
+	mi := &file_vercelgo_v1_type_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeServiceDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeServiceDeleteRequest) ProtoMessage() {}
+
+func (x *TypeServiceDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_type_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeServiceDeleteRequest.ProtoReflect.Descriptor instead.
+func (*TypeServiceDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_type_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TypeServiceDeleteRequest) GetTable() TypeTable {
+	if x != nil {
+		return x.Table
+	}
+	return TypeTable_TYPE_TABLE_UNSPECIFIED
+}
+
+func (x *TypeServiceDeleteRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type TypeServiceDeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeServiceDeleteResponse) Reset() {
+	*x = TypeServiceDeleteResponse{}
+	mi := &file_vercelgo_v1_type_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeServiceDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeServiceDeleteResponse) ProtoMessage() {}
+
+func (x *TypeServiceDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vercelgo_v1_type_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeServiceDeleteResponse.ProtoReflect.Descriptor instead.
+func (*TypeServiceDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_vercelgo_v1_type_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TypeServiceDeleteResponse) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+var File_vercelgo_v1_type_proto protoreflect.FileDescriptor
+
+const file_vercelgo_v1_type_proto_rawDesc = "" +
+	"\n" +
+	"\x16vercelgo/v1/type.proto\x12\vvercelgo.v1\"1\n" +
+	"\tTypeValue\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"U\n" +
+	"\x15TypeServiceGetRequest\x12,\n" +
+	"\x05table\x18\x01 \x01(\x0e2\x16.vercelgo.v1.TypeTableR\x05table\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\"D\n" +
+	"\x16TypeServiceGetResponse\x12*\n" +
+	"\x04type\x18\x01 \x01(\v2\x16.vercelgo.v1.TypeValueR\x04type\"X\n" +
+	"\x16TypeServiceListRequest\x12,\n" +
+	"\x05table\x18\x01 \x01(\x0e2\x16.vercelgo.v1.TypeTableR\x05table\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\x03R\x03ids\"G\n" +
+	"\x17TypeServiceListResponse\x12,\n" +
+	"\x05types\x18\x01 \x03(\v2\x16.vercelgo.v1.TypeValueR\x05types\"\x8c\x01\n" +
+	"\x18TypeServiceSearchRequest\x12,\n" +
+	"\x05table\x18\x01 \x01(\x0e2\x16.vercelgo.v1.TypeTableR\x05table\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"I\n" +
+	"\x19TypeServiceSearchResponse\x12,\n" +
+	"\x05types\x18\x01 \x03(\v2\x16.vercelgo.v1.TypeValueR\x05types\"v\n" +
+	"\x18TypeServiceCreateRequest\x12,\n" +
+	"\x05table\x18\x01 \x01(\x0e2\x16.vercelgo.v1.TypeTableR\x05table\x12,\n" +
+	"\x05types\x18\x02 \x03(\v2\x16.vercelgo.v1.TypeValueR\x05types\"I\n" +
+	"\x19TypeServiceCreateResponse\x12,\n" +
+	"\x05types\x18\x01 \x03(\v2\x16.vercelgo.v1.TypeValueR\x05types\"v\n" +
+	"\x18TypeServiceUpdateRequest\x12,\n" +
+	"\x05table\x18\x01 \x01(\x0e2\x16.vercelgo.v1.TypeTableR\x05table\x12,\n" +
+	"\x05types\x18\x02 \x03(\v2\x16.vercelgo.v1.TypeValueR\x05types\"I\n" +
+	"\x19TypeServiceUpdateResponse\x12,\n" +
+	"\x05types\x18\x01 \x03(\v2\x16.vercelgo.v1.TypeValueR\x05types\"Z\n" +
+	"\x18TypeServiceDeleteRequest\x12,\n" +
+	"\x05table\x18\x01 \x01(\x0e2\x16.vercelgo.v1.TypeTableR\x05table\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\x03R\x03ids\"-\n" +
+	"\x19TypeServiceDeleteResponse\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids*\xab\x01\n" +
+	"\tTypeTable\x12\x1a\n" +
+	"\x16TYPE_TABLE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14TYPE_TABLE_HAIRCOLOR\x10\x01\x12\x17\n" +
+	"\x13TYPE_TABLE_EYECOLOR\x10\x02\x12\x18\n" +
+	"\x14TYPE_TABLE_HAIRSTYLE\x10\x03\x12\x19\n" +
+	"\x15TYPE_TABLE_HAIRLENGTH\x10\x04\x12\x1a\n" +
+	"\x16TYPE_TABLE_PERSONALITY\x10\x052\xa3\x04\n" +
+	"\vTypeService\x12S\n" +
+	"\x03Get\x12\".vercelgo.v1.TypeServiceGetRequest\x1a#.vercelgo.v1.TypeServiceGetResponse\"\x03\x90\x02\x01\x12V\n" +
+	"\x04List\x12#.vercelgo.v1.TypeServiceListRequest\x1a$.vercelgo.v1.TypeServiceListResponse\"\x03\x90\x02\x01\x12\\\n" +
+	"\x06Search\x12%.vercelgo.v1.TypeServiceSearchRequest\x1a&.vercelgo.v1.TypeServiceSearchResponse\"\x03\x90\x02\x01\x12W\n" +
+	"\x06Create\x12%.vercelgo.v1.TypeServiceCreateRequest\x1a&.vercelgo.v1.TypeServiceCreateResponse\x12W\n" +
+	"\x06Update\x12%.vercelgo.v1.TypeServiceUpdateRequest\x1a&.vercelgo.v1.TypeServiceUpdateResponse\x12W\n" +
+	"\x06Delete\x12%.vercelgo.v1.TypeServiceDeleteRequest\x1a&.vercelgo.v1.TypeServiceDeleteResponseB=Z;maguro-alternative/varcel-go/pkg/gen/vercelgo/v1;vercelgov1b\x06proto3"
+
+var (
+	file_vercelgo_v1_type_proto_rawDescOnce sync.Once
+	file_vercelgo_v1_type_proto_rawDescData []byte
+)
+
+func file_vercelgo_v1_type_proto_rawDescGZIP() []byte {
+	file_vercelgo_v1_type_proto_rawDescOnce.Do(func() {
+		file_vercelgo_v1_type_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_vercelgo_v1_type_proto_rawDesc), len(file_vercelgo_v1_type_proto_rawDesc)))
+	})
+	return file_vercelgo_v1_type_proto_rawDescData
+}
+
+var file_vercelgo_v1_type_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_vercelgo_v1_type_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_vercelgo_v1_type_proto_goTypes = []any{
+	(TypeTable)(0),                    // 0: vercelgo.v1.TypeTable
+	(*TypeValue)(nil),                 // 1: vercelgo.v1.TypeValue
+	(*TypeServiceGetRequest)(nil),     // 2: vercelgo.v1.TypeServiceGetRequest
+	(*TypeServiceGetResponse)(nil),    // 3: vercelgo.v1.TypeServiceGetResponse
+	(*TypeServiceListRequest)(nil),    // 4: vercelgo.v1.TypeServiceListRequest
+	(*TypeServiceListResponse)(nil),   // 5: vercelgo.v1.TypeServiceListResponse
+	(*TypeServiceSearchRequest)(nil),  // 6: vercelgo.v1.TypeServiceSearchRequest
+	(*TypeServiceSearchResponse)(nil), // 7: vercelgo.v1.TypeServiceSearchResponse
+	(*TypeServiceCreateRequest)(nil),  // 8: vercelgo.v1.TypeServiceCreateRequest
+	(*TypeServiceCreateResponse)(nil), // 9: vercelgo.v1.TypeServiceCreateResponse
+	(*TypeServiceUpdateRequest)(nil),  // 10: vercelgo.v1.TypeServiceUpdateRequest
+	(*TypeServiceUpdateResponse)(nil), // 11: vercelgo.v1.TypeServiceUpdateResponse
+	(*TypeServiceDeleteRequest)(nil),  // 12: vercelgo.v1.TypeServiceDeleteRequest
+	(*TypeServiceDeleteResponse)(nil), // 13: vercelgo.v1.TypeServiceDeleteResponse
+}
+var file_vercelgo_v1_type_proto_depIdxs = []int32{
+	0,  // 0: vercelgo.v1.TypeServiceGetRequest.table:type_name -> vercelgo.v1.TypeTable
+	1,  // 1: vercelgo.v1.TypeServiceGetResponse.type:type_name -> vercelgo.v1.TypeValue
+	0,  // 2: vercelgo.v1.TypeServiceListRequest.table:type_name -> vercelgo.v1.TypeTable
+	1,  // 3: vercelgo.v1.TypeServiceListResponse.types:type_name -> vercelgo.v1.TypeValue
+	0,  // 4: vercelgo.v1.TypeServiceSearchRequest.table:type_name -> vercelgo.v1.TypeTable
+	1,  // 5: vercelgo.v1.TypeServiceSearchResponse.types:type_name -> vercelgo.v1.TypeValue
+	0,  // 6: vercelgo.v1.TypeServiceCreateRequest.table:type_name -> vercelgo.v1.TypeTable
+	1,  // 7: vercelgo.v1.TypeServiceCreateRequest.types:type_name -> vercelgo.v1.TypeValue
+	1,  // 8: vercelgo.v1.TypeServiceCreateResponse.types:type_name -> vercelgo.v1.TypeValue
+	0,  // 9: vercelgo.v1.TypeServiceUpdateRequest.table:type_name -> vercelgo.v1.TypeTable
+	1,  // 10: vercelgo.v1.TypeServiceUpdateRequest.types:type_name -> vercelgo.v1.TypeValue
+	1,  // 11: vercelgo.v1.TypeServiceUpdateResponse.types:type_name -> vercelgo.v1.TypeValue
+	0,  // 12: vercelgo.v1.TypeServiceDeleteRequest.table:type_name -> vercelgo.v1.TypeTable
+	2,  // 13: vercelgo.v1.TypeService.Get:input_type -> vercelgo.v1.TypeServiceGetRequest
+	4,  // 14: vercelgo.v1.TypeService.List:input_type -> vercelgo.v1.TypeServiceListRequest
+	6,  // 15: vercelgo.v1.TypeService.Search:input_type -> vercelgo.v1.TypeServiceSearchRequest
+	8,  // 16: vercelgo.v1.TypeService.Create:input_type -> vercelgo.v1.TypeServiceCreateRequest
+	10, // 17: vercelgo.v1.TypeService.Update:input_type -> vercelgo.v1.TypeServiceUpdateRequest
+	12, // 18: vercelgo.v1.TypeService.Delete:input_type -> vercelgo.v1.TypeServiceDeleteRequest
+	3,  // 19: vercelgo.v1.TypeService.Get:output_type -> vercelgo.v1.TypeServiceGetResponse
+	5,  // 20: vercelgo.v1.TypeService.List:output_type -> vercelgo.v1.TypeServiceListResponse
+	7,  // 21: vercelgo.v1.TypeService.Search:output_type -> vercelgo.v1.TypeServiceSearchResponse
+	9,  // 22: vercelgo.v1.TypeService.Create:output_type -> vercelgo.v1.TypeServiceCreateResponse
+	11, // 23: vercelgo.v1.TypeService.Update:output_type -> vercelgo.v1.TypeServiceUpdateResponse
+	13, // 24: vercelgo.v1.TypeService.Delete:output_type -> vercelgo.v1.TypeServiceDeleteResponse
+	19, // [19:25] is the sub-list for method output_type
+	13, // [13:19] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_vercelgo_v1_type_proto_init() }
+func file_vercelgo_v1_type_proto_init() {
+	if File_vercelgo_v1_type_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_vercelgo_v1_type_proto_rawDesc), len(file_vercelgo_v1_type_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_vercelgo_v1_type_proto_goTypes,
+		DependencyIndexes: file_vercelgo_v1_type_proto_depIdxs,
+		EnumInfos:         file_vercelgo_v1_type_proto_enumTypes,
+		MessageInfos:      file_vercelgo_v1_type_proto_msgTypes,
+	}.Build()
+	File_vercelgo_v1_type_proto = out.File
+	file_vercelgo_v1_type_proto_goTypes = nil
+	file_vercelgo_v1_type_proto_depIdxs = nil
+}
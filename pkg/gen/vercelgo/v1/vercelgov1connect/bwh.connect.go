@@ -0,0 +1,254 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: vercelgo/v1/bwh.proto
+
+package vercelgov1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "maguro-alternative/varcel-go/pkg/gen/vercelgo/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// BWHServiceName is the fully-qualified name of the BWHService service.
+	BWHServiceName = "vercelgo.v1.BWHService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// BWHServiceGetProcedure is the fully-qualified name of the BWHService's Get RPC.
+	BWHServiceGetProcedure = "/vercelgo.v1.BWHService/Get"
+	// BWHServiceListProcedure is the fully-qualified name of the BWHService's List RPC.
+	BWHServiceListProcedure = "/vercelgo.v1.BWHService/List"
+	// BWHServiceSearchProcedure is the fully-qualified name of the BWHService's Search RPC.
+	BWHServiceSearchProcedure = "/vercelgo.v1.BWHService/Search"
+	// BWHServiceCreateProcedure is the fully-qualified name of the BWHService's Create RPC.
+	BWHServiceCreateProcedure = "/vercelgo.v1.BWHService/Create"
+	// BWHServiceUpdateProcedure is the fully-qualified name of the BWHService's Update RPC.
+	BWHServiceUpdateProcedure = "/vercelgo.v1.BWHService/Update"
+	// BWHServiceDeleteProcedure is the fully-qualified name of the BWHService's Delete RPC.
+	BWHServiceDeleteProcedure = "/vercelgo.v1.BWHService/Delete"
+)
+
+// BWHServiceClient is a client for the vercelgo.v1.BWHService service.
+type BWHServiceClient interface {
+	Get(context.Context, *connect.Request[v1.BWHServiceGetRequest]) (*connect.Response[v1.BWHServiceGetResponse], error)
+	List(context.Context, *connect.Request[v1.BWHServiceListRequest]) (*connect.Response[v1.BWHServiceListResponse], error)
+	Search(context.Context, *connect.Request[v1.BWHServiceSearchRequest]) (*connect.Response[v1.BWHServiceSearchResponse], error)
+	Create(context.Context, *connect.Request[v1.BWHServiceCreateRequest]) (*connect.Response[v1.BWHServiceCreateResponse], error)
+	Update(context.Context, *connect.Request[v1.BWHServiceUpdateRequest]) (*connect.Response[v1.BWHServiceUpdateResponse], error)
+	Delete(context.Context, *connect.Request[v1.BWHServiceDeleteRequest]) (*connect.Response[v1.BWHServiceDeleteResponse], error)
+}
+
+// NewBWHServiceClient constructs a client for the vercelgo.v1.BWHService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewBWHServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) BWHServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	bWHServiceMethods := v1.File_vercelgo_v1_bwh_proto.Services().ByName("BWHService").Methods()
+	return &bWHServiceClient{
+		get: connect.NewClient[v1.BWHServiceGetRequest, v1.BWHServiceGetResponse](
+			httpClient,
+			baseURL+BWHServiceGetProcedure,
+			connect.WithSchema(bWHServiceMethods.ByName("Get")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		list: connect.NewClient[v1.BWHServiceListRequest, v1.BWHServiceListResponse](
+			httpClient,
+			baseURL+BWHServiceListProcedure,
+			connect.WithSchema(bWHServiceMethods.ByName("List")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		search: connect.NewClient[v1.BWHServiceSearchRequest, v1.BWHServiceSearchResponse](
+			httpClient,
+			baseURL+BWHServiceSearchProcedure,
+			connect.WithSchema(bWHServiceMethods.ByName("Search")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		create: connect.NewClient[v1.BWHServiceCreateRequest, v1.BWHServiceCreateResponse](
+			httpClient,
+			baseURL+BWHServiceCreateProcedure,
+			connect.WithSchema(bWHServiceMethods.ByName("Create")),
+			connect.WithClientOptions(opts...),
+		),
+		update: connect.NewClient[v1.BWHServiceUpdateRequest, v1.BWHServiceUpdateResponse](
+			httpClient,
+			baseURL+BWHServiceUpdateProcedure,
+			connect.WithSchema(bWHServiceMethods.ByName("Update")),
+			connect.WithClientOptions(opts...),
+		),
+		delete: connect.NewClient[v1.BWHServiceDeleteRequest, v1.BWHServiceDeleteResponse](
+			httpClient,
+			baseURL+BWHServiceDeleteProcedure,
+			connect.WithSchema(bWHServiceMethods.ByName("Delete")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// bWHServiceClient implements BWHServiceClient.
+type bWHServiceClient struct {
+	get    *connect.Client[v1.BWHServiceGetRequest, v1.BWHServiceGetResponse]
+	list   *connect.Client[v1.BWHServiceListRequest, v1.BWHServiceListResponse]
+	search *connect.Client[v1.BWHServiceSearchRequest, v1.BWHServiceSearchResponse]
+	create *connect.Client[v1.BWHServiceCreateRequest, v1.BWHServiceCreateResponse]
+	update *connect.Client[v1.BWHServiceUpdateRequest, v1.BWHServiceUpdateResponse]
+	delete *connect.Client[v1.BWHServiceDeleteRequest, v1.BWHServiceDeleteResponse]
+}
+
+// Get calls vercelgo.v1.BWHService.Get.
+func (c *bWHServiceClient) Get(ctx context.Context, req *connect.Request[v1.BWHServiceGetRequest]) (*connect.Response[v1.BWHServiceGetResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// List calls vercelgo.v1.BWHService.List.
+func (c *bWHServiceClient) List(ctx context.Context, req *connect.Request[v1.BWHServiceListRequest]) (*connect.Response[v1.BWHServiceListResponse], error) {
+	return c.list.CallUnary(ctx, req)
+}
+
+// Search calls vercelgo.v1.BWHService.Search.
+func (c *bWHServiceClient) Search(ctx context.Context, req *connect.Request[v1.BWHServiceSearchRequest]) (*connect.Response[v1.BWHServiceSearchResponse], error) {
+	return c.search.CallUnary(ctx, req)
+}
+
+// Create calls vercelgo.v1.BWHService.Create.
+func (c *bWHServiceClient) Create(ctx context.Context, req *connect.Request[v1.BWHServiceCreateRequest]) (*connect.Response[v1.BWHServiceCreateResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Update calls vercelgo.v1.BWHService.Update.
+func (c *bWHServiceClient) Update(ctx context.Context, req *connect.Request[v1.BWHServiceUpdateRequest]) (*connect.Response[v1.BWHServiceUpdateResponse], error) {
+	return c.update.CallUnary(ctx, req)
+}
+
+// Delete calls vercelgo.v1.BWHService.Delete.
+func (c *bWHServiceClient) Delete(ctx context.Context, req *connect.Request[v1.BWHServiceDeleteRequest]) (*connect.Response[v1.BWHServiceDeleteResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// BWHServiceHandler is an implementation of the vercelgo.v1.BWHService service.
+type BWHServiceHandler interface {
+	Get(context.Context, *connect.Request[v1.BWHServiceGetRequest]) (*connect.Response[v1.BWHServiceGetResponse], error)
+	List(context.Context, *connect.Request[v1.BWHServiceListRequest]) (*connect.Response[v1.BWHServiceListResponse], error)
+	Search(context.Context, *connect.Request[v1.BWHServiceSearchRequest]) (*connect.Response[v1.BWHServiceSearchResponse], error)
+	Create(context.Context, *connect.Request[v1.BWHServiceCreateRequest]) (*connect.Response[v1.BWHServiceCreateResponse], error)
+	Update(context.Context, *connect.Request[v1.BWHServiceUpdateRequest]) (*connect.Response[v1.BWHServiceUpdateResponse], error)
+	Delete(context.Context, *connect.Request[v1.BWHServiceDeleteRequest]) (*connect.Response[v1.BWHServiceDeleteResponse], error)
+}
+
+// NewBWHServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewBWHServiceHandler(svc BWHServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	bWHServiceMethods := v1.File_vercelgo_v1_bwh_proto.Services().ByName("BWHService").Methods()
+	bWHServiceGetHandler := connect.NewUnaryHandler(
+		BWHServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(bWHServiceMethods.ByName("Get")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	bWHServiceListHandler := connect.NewUnaryHandler(
+		BWHServiceListProcedure,
+		svc.List,
+		connect.WithSchema(bWHServiceMethods.ByName("List")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	bWHServiceSearchHandler := connect.NewUnaryHandler(
+		BWHServiceSearchProcedure,
+		svc.Search,
+		connect.WithSchema(bWHServiceMethods.ByName("Search")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	bWHServiceCreateHandler := connect.NewUnaryHandler(
+		BWHServiceCreateProcedure,
+		svc.Create,
+		connect.WithSchema(bWHServiceMethods.ByName("Create")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bWHServiceUpdateHandler := connect.NewUnaryHandler(
+		BWHServiceUpdateProcedure,
+		svc.Update,
+		connect.WithSchema(bWHServiceMethods.ByName("Update")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bWHServiceDeleteHandler := connect.NewUnaryHandler(
+		BWHServiceDeleteProcedure,
+		svc.Delete,
+		connect.WithSchema(bWHServiceMethods.ByName("Delete")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/vercelgo.v1.BWHService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case BWHServiceGetProcedure:
+			bWHServiceGetHandler.ServeHTTP(w, r)
+		case BWHServiceListProcedure:
+			bWHServiceListHandler.ServeHTTP(w, r)
+		case BWHServiceSearchProcedure:
+			bWHServiceSearchHandler.ServeHTTP(w, r)
+		case BWHServiceCreateProcedure:
+			bWHServiceCreateHandler.ServeHTTP(w, r)
+		case BWHServiceUpdateProcedure:
+			bWHServiceUpdateHandler.ServeHTTP(w, r)
+		case BWHServiceDeleteProcedure:
+			bWHServiceDeleteHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedBWHServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedBWHServiceHandler struct{}
+
+func (UnimplementedBWHServiceHandler) Get(context.Context, *connect.Request[v1.BWHServiceGetRequest]) (*connect.Response[v1.BWHServiceGetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.BWHService.Get is not implemented"))
+}
+
+func (UnimplementedBWHServiceHandler) List(context.Context, *connect.Request[v1.BWHServiceListRequest]) (*connect.Response[v1.BWHServiceListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.BWHService.List is not implemented"))
+}
+
+func (UnimplementedBWHServiceHandler) Search(context.Context, *connect.Request[v1.BWHServiceSearchRequest]) (*connect.Response[v1.BWHServiceSearchResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.BWHService.Search is not implemented"))
+}
+
+func (UnimplementedBWHServiceHandler) Create(context.Context, *connect.Request[v1.BWHServiceCreateRequest]) (*connect.Response[v1.BWHServiceCreateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.BWHService.Create is not implemented"))
+}
+
+func (UnimplementedBWHServiceHandler) Update(context.Context, *connect.Request[v1.BWHServiceUpdateRequest]) (*connect.Response[v1.BWHServiceUpdateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.BWHService.Update is not implemented"))
+}
+
+func (UnimplementedBWHServiceHandler) Delete(context.Context, *connect.Request[v1.BWHServiceDeleteRequest]) (*connect.Response[v1.BWHServiceDeleteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.BWHService.Delete is not implemented"))
+}
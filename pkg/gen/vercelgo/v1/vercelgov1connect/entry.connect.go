@@ -0,0 +1,254 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: vercelgo/v1/entry.proto
+
+package vercelgov1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "maguro-alternative/varcel-go/pkg/gen/vercelgo/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// EntryServiceName is the fully-qualified name of the EntryService service.
+	EntryServiceName = "vercelgo.v1.EntryService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// EntryServiceGetProcedure is the fully-qualified name of the EntryService's Get RPC.
+	EntryServiceGetProcedure = "/vercelgo.v1.EntryService/Get"
+	// EntryServiceListProcedure is the fully-qualified name of the EntryService's List RPC.
+	EntryServiceListProcedure = "/vercelgo.v1.EntryService/List"
+	// EntryServiceSearchProcedure is the fully-qualified name of the EntryService's Search RPC.
+	EntryServiceSearchProcedure = "/vercelgo.v1.EntryService/Search"
+	// EntryServiceCreateProcedure is the fully-qualified name of the EntryService's Create RPC.
+	EntryServiceCreateProcedure = "/vercelgo.v1.EntryService/Create"
+	// EntryServiceUpdateProcedure is the fully-qualified name of the EntryService's Update RPC.
+	EntryServiceUpdateProcedure = "/vercelgo.v1.EntryService/Update"
+	// EntryServiceDeleteProcedure is the fully-qualified name of the EntryService's Delete RPC.
+	EntryServiceDeleteProcedure = "/vercelgo.v1.EntryService/Delete"
+)
+
+// EntryServiceClient is a client for the vercelgo.v1.EntryService service.
+type EntryServiceClient interface {
+	Get(context.Context, *connect.Request[v1.EntryServiceGetRequest]) (*connect.Response[v1.EntryServiceGetResponse], error)
+	List(context.Context, *connect.Request[v1.EntryServiceListRequest]) (*connect.Response[v1.EntryServiceListResponse], error)
+	Search(context.Context, *connect.Request[v1.EntryServiceSearchRequest]) (*connect.Response[v1.EntryServiceSearchResponse], error)
+	Create(context.Context, *connect.Request[v1.EntryServiceCreateRequest]) (*connect.Response[v1.EntryServiceCreateResponse], error)
+	Update(context.Context, *connect.Request[v1.EntryServiceUpdateRequest]) (*connect.Response[v1.EntryServiceUpdateResponse], error)
+	Delete(context.Context, *connect.Request[v1.EntryServiceDeleteRequest]) (*connect.Response[v1.EntryServiceDeleteResponse], error)
+}
+
+// NewEntryServiceClient constructs a client for the vercelgo.v1.EntryService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewEntryServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) EntryServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	entryServiceMethods := v1.File_vercelgo_v1_entry_proto.Services().ByName("EntryService").Methods()
+	return &entryServiceClient{
+		get: connect.NewClient[v1.EntryServiceGetRequest, v1.EntryServiceGetResponse](
+			httpClient,
+			baseURL+EntryServiceGetProcedure,
+			connect.WithSchema(entryServiceMethods.ByName("Get")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		list: connect.NewClient[v1.EntryServiceListRequest, v1.EntryServiceListResponse](
+			httpClient,
+			baseURL+EntryServiceListProcedure,
+			connect.WithSchema(entryServiceMethods.ByName("List")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		search: connect.NewClient[v1.EntryServiceSearchRequest, v1.EntryServiceSearchResponse](
+			httpClient,
+			baseURL+EntryServiceSearchProcedure,
+			connect.WithSchema(entryServiceMethods.ByName("Search")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		create: connect.NewClient[v1.EntryServiceCreateRequest, v1.EntryServiceCreateResponse](
+			httpClient,
+			baseURL+EntryServiceCreateProcedure,
+			connect.WithSchema(entryServiceMethods.ByName("Create")),
+			connect.WithClientOptions(opts...),
+		),
+		update: connect.NewClient[v1.EntryServiceUpdateRequest, v1.EntryServiceUpdateResponse](
+			httpClient,
+			baseURL+EntryServiceUpdateProcedure,
+			connect.WithSchema(entryServiceMethods.ByName("Update")),
+			connect.WithClientOptions(opts...),
+		),
+		delete: connect.NewClient[v1.EntryServiceDeleteRequest, v1.EntryServiceDeleteResponse](
+			httpClient,
+			baseURL+EntryServiceDeleteProcedure,
+			connect.WithSchema(entryServiceMethods.ByName("Delete")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// entryServiceClient implements EntryServiceClient.
+type entryServiceClient struct {
+	get    *connect.Client[v1.EntryServiceGetRequest, v1.EntryServiceGetResponse]
+	list   *connect.Client[v1.EntryServiceListRequest, v1.EntryServiceListResponse]
+	search *connect.Client[v1.EntryServiceSearchRequest, v1.EntryServiceSearchResponse]
+	create *connect.Client[v1.EntryServiceCreateRequest, v1.EntryServiceCreateResponse]
+	update *connect.Client[v1.EntryServiceUpdateRequest, v1.EntryServiceUpdateResponse]
+	delete *connect.Client[v1.EntryServiceDeleteRequest, v1.EntryServiceDeleteResponse]
+}
+
+// Get calls vercelgo.v1.EntryService.Get.
+func (c *entryServiceClient) Get(ctx context.Context, req *connect.Request[v1.EntryServiceGetRequest]) (*connect.Response[v1.EntryServiceGetResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// List calls vercelgo.v1.EntryService.List.
+func (c *entryServiceClient) List(ctx context.Context, req *connect.Request[v1.EntryServiceListRequest]) (*connect.Response[v1.EntryServiceListResponse], error) {
+	return c.list.CallUnary(ctx, req)
+}
+
+// Search calls vercelgo.v1.EntryService.Search.
+func (c *entryServiceClient) Search(ctx context.Context, req *connect.Request[v1.EntryServiceSearchRequest]) (*connect.Response[v1.EntryServiceSearchResponse], error) {
+	return c.search.CallUnary(ctx, req)
+}
+
+// Create calls vercelgo.v1.EntryService.Create.
+func (c *entryServiceClient) Create(ctx context.Context, req *connect.Request[v1.EntryServiceCreateRequest]) (*connect.Response[v1.EntryServiceCreateResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Update calls vercelgo.v1.EntryService.Update.
+func (c *entryServiceClient) Update(ctx context.Context, req *connect.Request[v1.EntryServiceUpdateRequest]) (*connect.Response[v1.EntryServiceUpdateResponse], error) {
+	return c.update.CallUnary(ctx, req)
+}
+
+// Delete calls vercelgo.v1.EntryService.Delete.
+func (c *entryServiceClient) Delete(ctx context.Context, req *connect.Request[v1.EntryServiceDeleteRequest]) (*connect.Response[v1.EntryServiceDeleteResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// EntryServiceHandler is an implementation of the vercelgo.v1.EntryService service.
+type EntryServiceHandler interface {
+	Get(context.Context, *connect.Request[v1.EntryServiceGetRequest]) (*connect.Response[v1.EntryServiceGetResponse], error)
+	List(context.Context, *connect.Request[v1.EntryServiceListRequest]) (*connect.Response[v1.EntryServiceListResponse], error)
+	Search(context.Context, *connect.Request[v1.EntryServiceSearchRequest]) (*connect.Response[v1.EntryServiceSearchResponse], error)
+	Create(context.Context, *connect.Request[v1.EntryServiceCreateRequest]) (*connect.Response[v1.EntryServiceCreateResponse], error)
+	Update(context.Context, *connect.Request[v1.EntryServiceUpdateRequest]) (*connect.Response[v1.EntryServiceUpdateResponse], error)
+	Delete(context.Context, *connect.Request[v1.EntryServiceDeleteRequest]) (*connect.Response[v1.EntryServiceDeleteResponse], error)
+}
+
+// NewEntryServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewEntryServiceHandler(svc EntryServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	entryServiceMethods := v1.File_vercelgo_v1_entry_proto.Services().ByName("EntryService").Methods()
+	entryServiceGetHandler := connect.NewUnaryHandler(
+		EntryServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(entryServiceMethods.ByName("Get")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	entryServiceListHandler := connect.NewUnaryHandler(
+		EntryServiceListProcedure,
+		svc.List,
+		connect.WithSchema(entryServiceMethods.ByName("List")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	entryServiceSearchHandler := connect.NewUnaryHandler(
+		EntryServiceSearchProcedure,
+		svc.Search,
+		connect.WithSchema(entryServiceMethods.ByName("Search")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	entryServiceCreateHandler := connect.NewUnaryHandler(
+		EntryServiceCreateProcedure,
+		svc.Create,
+		connect.WithSchema(entryServiceMethods.ByName("Create")),
+		connect.WithHandlerOptions(opts...),
+	)
+	entryServiceUpdateHandler := connect.NewUnaryHandler(
+		EntryServiceUpdateProcedure,
+		svc.Update,
+		connect.WithSchema(entryServiceMethods.ByName("Update")),
+		connect.WithHandlerOptions(opts...),
+	)
+	entryServiceDeleteHandler := connect.NewUnaryHandler(
+		EntryServiceDeleteProcedure,
+		svc.Delete,
+		connect.WithSchema(entryServiceMethods.ByName("Delete")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/vercelgo.v1.EntryService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case EntryServiceGetProcedure:
+			entryServiceGetHandler.ServeHTTP(w, r)
+		case EntryServiceListProcedure:
+			entryServiceListHandler.ServeHTTP(w, r)
+		case EntryServiceSearchProcedure:
+			entryServiceSearchHandler.ServeHTTP(w, r)
+		case EntryServiceCreateProcedure:
+			entryServiceCreateHandler.ServeHTTP(w, r)
+		case EntryServiceUpdateProcedure:
+			entryServiceUpdateHandler.ServeHTTP(w, r)
+		case EntryServiceDeleteProcedure:
+			entryServiceDeleteHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedEntryServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedEntryServiceHandler struct{}
+
+func (UnimplementedEntryServiceHandler) Get(context.Context, *connect.Request[v1.EntryServiceGetRequest]) (*connect.Response[v1.EntryServiceGetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.EntryService.Get is not implemented"))
+}
+
+func (UnimplementedEntryServiceHandler) List(context.Context, *connect.Request[v1.EntryServiceListRequest]) (*connect.Response[v1.EntryServiceListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.EntryService.List is not implemented"))
+}
+
+func (UnimplementedEntryServiceHandler) Search(context.Context, *connect.Request[v1.EntryServiceSearchRequest]) (*connect.Response[v1.EntryServiceSearchResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.EntryService.Search is not implemented"))
+}
+
+func (UnimplementedEntryServiceHandler) Create(context.Context, *connect.Request[v1.EntryServiceCreateRequest]) (*connect.Response[v1.EntryServiceCreateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.EntryService.Create is not implemented"))
+}
+
+func (UnimplementedEntryServiceHandler) Update(context.Context, *connect.Request[v1.EntryServiceUpdateRequest]) (*connect.Response[v1.EntryServiceUpdateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.EntryService.Update is not implemented"))
+}
+
+func (UnimplementedEntryServiceHandler) Delete(context.Context, *connect.Request[v1.EntryServiceDeleteRequest]) (*connect.Response[v1.EntryServiceDeleteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.EntryService.Delete is not implemented"))
+}
@@ -0,0 +1,254 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: vercelgo/v1/entry_tag.proto
+
+package vercelgov1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "maguro-alternative/varcel-go/pkg/gen/vercelgo/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// EntryTagServiceName is the fully-qualified name of the EntryTagService service.
+	EntryTagServiceName = "vercelgo.v1.EntryTagService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// EntryTagServiceGetProcedure is the fully-qualified name of the EntryTagService's Get RPC.
+	EntryTagServiceGetProcedure = "/vercelgo.v1.EntryTagService/Get"
+	// EntryTagServiceListProcedure is the fully-qualified name of the EntryTagService's List RPC.
+	EntryTagServiceListProcedure = "/vercelgo.v1.EntryTagService/List"
+	// EntryTagServiceSearchProcedure is the fully-qualified name of the EntryTagService's Search RPC.
+	EntryTagServiceSearchProcedure = "/vercelgo.v1.EntryTagService/Search"
+	// EntryTagServiceCreateProcedure is the fully-qualified name of the EntryTagService's Create RPC.
+	EntryTagServiceCreateProcedure = "/vercelgo.v1.EntryTagService/Create"
+	// EntryTagServiceUpdateProcedure is the fully-qualified name of the EntryTagService's Update RPC.
+	EntryTagServiceUpdateProcedure = "/vercelgo.v1.EntryTagService/Update"
+	// EntryTagServiceDeleteProcedure is the fully-qualified name of the EntryTagService's Delete RPC.
+	EntryTagServiceDeleteProcedure = "/vercelgo.v1.EntryTagService/Delete"
+)
+
+// EntryTagServiceClient is a client for the vercelgo.v1.EntryTagService service.
+type EntryTagServiceClient interface {
+	Get(context.Context, *connect.Request[v1.EntryTagServiceGetRequest]) (*connect.Response[v1.EntryTagServiceGetResponse], error)
+	List(context.Context, *connect.Request[v1.EntryTagServiceListRequest]) (*connect.Response[v1.EntryTagServiceListResponse], error)
+	Search(context.Context, *connect.Request[v1.EntryTagServiceSearchRequest]) (*connect.Response[v1.EntryTagServiceSearchResponse], error)
+	Create(context.Context, *connect.Request[v1.EntryTagServiceCreateRequest]) (*connect.Response[v1.EntryTagServiceCreateResponse], error)
+	Update(context.Context, *connect.Request[v1.EntryTagServiceUpdateRequest]) (*connect.Response[v1.EntryTagServiceUpdateResponse], error)
+	Delete(context.Context, *connect.Request[v1.EntryTagServiceDeleteRequest]) (*connect.Response[v1.EntryTagServiceDeleteResponse], error)
+}
+
+// NewEntryTagServiceClient constructs a client for the vercelgo.v1.EntryTagService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewEntryTagServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) EntryTagServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	entryTagServiceMethods := v1.File_vercelgo_v1_entry_tag_proto.Services().ByName("EntryTagService").Methods()
+	return &entryTagServiceClient{
+		get: connect.NewClient[v1.EntryTagServiceGetRequest, v1.EntryTagServiceGetResponse](
+			httpClient,
+			baseURL+EntryTagServiceGetProcedure,
+			connect.WithSchema(entryTagServiceMethods.ByName("Get")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		list: connect.NewClient[v1.EntryTagServiceListRequest, v1.EntryTagServiceListResponse](
+			httpClient,
+			baseURL+EntryTagServiceListProcedure,
+			connect.WithSchema(entryTagServiceMethods.ByName("List")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		search: connect.NewClient[v1.EntryTagServiceSearchRequest, v1.EntryTagServiceSearchResponse](
+			httpClient,
+			baseURL+EntryTagServiceSearchProcedure,
+			connect.WithSchema(entryTagServiceMethods.ByName("Search")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		create: connect.NewClient[v1.EntryTagServiceCreateRequest, v1.EntryTagServiceCreateResponse](
+			httpClient,
+			baseURL+EntryTagServiceCreateProcedure,
+			connect.WithSchema(entryTagServiceMethods.ByName("Create")),
+			connect.WithClientOptions(opts...),
+		),
+		update: connect.NewClient[v1.EntryTagServiceUpdateRequest, v1.EntryTagServiceUpdateResponse](
+			httpClient,
+			baseURL+EntryTagServiceUpdateProcedure,
+			connect.WithSchema(entryTagServiceMethods.ByName("Update")),
+			connect.WithClientOptions(opts...),
+		),
+		delete: connect.NewClient[v1.EntryTagServiceDeleteRequest, v1.EntryTagServiceDeleteResponse](
+			httpClient,
+			baseURL+EntryTagServiceDeleteProcedure,
+			connect.WithSchema(entryTagServiceMethods.ByName("Delete")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// entryTagServiceClient implements EntryTagServiceClient.
+type entryTagServiceClient struct {
+	get    *connect.Client[v1.EntryTagServiceGetRequest, v1.EntryTagServiceGetResponse]
+	list   *connect.Client[v1.EntryTagServiceListRequest, v1.EntryTagServiceListResponse]
+	search *connect.Client[v1.EntryTagServiceSearchRequest, v1.EntryTagServiceSearchResponse]
+	create *connect.Client[v1.EntryTagServiceCreateRequest, v1.EntryTagServiceCreateResponse]
+	update *connect.Client[v1.EntryTagServiceUpdateRequest, v1.EntryTagServiceUpdateResponse]
+	delete *connect.Client[v1.EntryTagServiceDeleteRequest, v1.EntryTagServiceDeleteResponse]
+}
+
+// Get calls vercelgo.v1.EntryTagService.Get.
+func (c *entryTagServiceClient) Get(ctx context.Context, req *connect.Request[v1.EntryTagServiceGetRequest]) (*connect.Response[v1.EntryTagServiceGetResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// List calls vercelgo.v1.EntryTagService.List.
+func (c *entryTagServiceClient) List(ctx context.Context, req *connect.Request[v1.EntryTagServiceListRequest]) (*connect.Response[v1.EntryTagServiceListResponse], error) {
+	return c.list.CallUnary(ctx, req)
+}
+
+// Search calls vercelgo.v1.EntryTagService.Search.
+func (c *entryTagServiceClient) Search(ctx context.Context, req *connect.Request[v1.EntryTagServiceSearchRequest]) (*connect.Response[v1.EntryTagServiceSearchResponse], error) {
+	return c.search.CallUnary(ctx, req)
+}
+
+// Create calls vercelgo.v1.EntryTagService.Create.
+func (c *entryTagServiceClient) Create(ctx context.Context, req *connect.Request[v1.EntryTagServiceCreateRequest]) (*connect.Response[v1.EntryTagServiceCreateResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Update calls vercelgo.v1.EntryTagService.Update.
+func (c *entryTagServiceClient) Update(ctx context.Context, req *connect.Request[v1.EntryTagServiceUpdateRequest]) (*connect.Response[v1.EntryTagServiceUpdateResponse], error) {
+	return c.update.CallUnary(ctx, req)
+}
+
+// Delete calls vercelgo.v1.EntryTagService.Delete.
+func (c *entryTagServiceClient) Delete(ctx context.Context, req *connect.Request[v1.EntryTagServiceDeleteRequest]) (*connect.Response[v1.EntryTagServiceDeleteResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// EntryTagServiceHandler is an implementation of the vercelgo.v1.EntryTagService service.
+type EntryTagServiceHandler interface {
+	Get(context.Context, *connect.Request[v1.EntryTagServiceGetRequest]) (*connect.Response[v1.EntryTagServiceGetResponse], error)
+	List(context.Context, *connect.Request[v1.EntryTagServiceListRequest]) (*connect.Response[v1.EntryTagServiceListResponse], error)
+	Search(context.Context, *connect.Request[v1.EntryTagServiceSearchRequest]) (*connect.Response[v1.EntryTagServiceSearchResponse], error)
+	Create(context.Context, *connect.Request[v1.EntryTagServiceCreateRequest]) (*connect.Response[v1.EntryTagServiceCreateResponse], error)
+	Update(context.Context, *connect.Request[v1.EntryTagServiceUpdateRequest]) (*connect.Response[v1.EntryTagServiceUpdateResponse], error)
+	Delete(context.Context, *connect.Request[v1.EntryTagServiceDeleteRequest]) (*connect.Response[v1.EntryTagServiceDeleteResponse], error)
+}
+
+// NewEntryTagServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewEntryTagServiceHandler(svc EntryTagServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	entryTagServiceMethods := v1.File_vercelgo_v1_entry_tag_proto.Services().ByName("EntryTagService").Methods()
+	entryTagServiceGetHandler := connect.NewUnaryHandler(
+		EntryTagServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(entryTagServiceMethods.ByName("Get")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	entryTagServiceListHandler := connect.NewUnaryHandler(
+		EntryTagServiceListProcedure,
+		svc.List,
+		connect.WithSchema(entryTagServiceMethods.ByName("List")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	entryTagServiceSearchHandler := connect.NewUnaryHandler(
+		EntryTagServiceSearchProcedure,
+		svc.Search,
+		connect.WithSchema(entryTagServiceMethods.ByName("Search")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	entryTagServiceCreateHandler := connect.NewUnaryHandler(
+		EntryTagServiceCreateProcedure,
+		svc.Create,
+		connect.WithSchema(entryTagServiceMethods.ByName("Create")),
+		connect.WithHandlerOptions(opts...),
+	)
+	entryTagServiceUpdateHandler := connect.NewUnaryHandler(
+		EntryTagServiceUpdateProcedure,
+		svc.Update,
+		connect.WithSchema(entryTagServiceMethods.ByName("Update")),
+		connect.WithHandlerOptions(opts...),
+	)
+	entryTagServiceDeleteHandler := connect.NewUnaryHandler(
+		EntryTagServiceDeleteProcedure,
+		svc.Delete,
+		connect.WithSchema(entryTagServiceMethods.ByName("Delete")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/vercelgo.v1.EntryTagService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case EntryTagServiceGetProcedure:
+			entryTagServiceGetHandler.ServeHTTP(w, r)
+		case EntryTagServiceListProcedure:
+			entryTagServiceListHandler.ServeHTTP(w, r)
+		case EntryTagServiceSearchProcedure:
+			entryTagServiceSearchHandler.ServeHTTP(w, r)
+		case EntryTagServiceCreateProcedure:
+			entryTagServiceCreateHandler.ServeHTTP(w, r)
+		case EntryTagServiceUpdateProcedure:
+			entryTagServiceUpdateHandler.ServeHTTP(w, r)
+		case EntryTagServiceDeleteProcedure:
+			entryTagServiceDeleteHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedEntryTagServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedEntryTagServiceHandler struct{}
+
+func (UnimplementedEntryTagServiceHandler) Get(context.Context, *connect.Request[v1.EntryTagServiceGetRequest]) (*connect.Response[v1.EntryTagServiceGetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.EntryTagService.Get is not implemented"))
+}
+
+func (UnimplementedEntryTagServiceHandler) List(context.Context, *connect.Request[v1.EntryTagServiceListRequest]) (*connect.Response[v1.EntryTagServiceListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.EntryTagService.List is not implemented"))
+}
+
+func (UnimplementedEntryTagServiceHandler) Search(context.Context, *connect.Request[v1.EntryTagServiceSearchRequest]) (*connect.Response[v1.EntryTagServiceSearchResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.EntryTagService.Search is not implemented"))
+}
+
+func (UnimplementedEntryTagServiceHandler) Create(context.Context, *connect.Request[v1.EntryTagServiceCreateRequest]) (*connect.Response[v1.EntryTagServiceCreateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.EntryTagService.Create is not implemented"))
+}
+
+func (UnimplementedEntryTagServiceHandler) Update(context.Context, *connect.Request[v1.EntryTagServiceUpdateRequest]) (*connect.Response[v1.EntryTagServiceUpdateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.EntryTagService.Update is not implemented"))
+}
+
+func (UnimplementedEntryTagServiceHandler) Delete(context.Context, *connect.Request[v1.EntryTagServiceDeleteRequest]) (*connect.Response[v1.EntryTagServiceDeleteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.EntryTagService.Delete is not implemented"))
+}
@@ -0,0 +1,254 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: vercelgo/v1/link.proto
+
+package vercelgov1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "maguro-alternative/varcel-go/pkg/gen/vercelgo/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// LinkServiceName is the fully-qualified name of the LinkService service.
+	LinkServiceName = "vercelgo.v1.LinkService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// LinkServiceGetProcedure is the fully-qualified name of the LinkService's Get RPC.
+	LinkServiceGetProcedure = "/vercelgo.v1.LinkService/Get"
+	// LinkServiceListProcedure is the fully-qualified name of the LinkService's List RPC.
+	LinkServiceListProcedure = "/vercelgo.v1.LinkService/List"
+	// LinkServiceSearchProcedure is the fully-qualified name of the LinkService's Search RPC.
+	LinkServiceSearchProcedure = "/vercelgo.v1.LinkService/Search"
+	// LinkServiceCreateProcedure is the fully-qualified name of the LinkService's Create RPC.
+	LinkServiceCreateProcedure = "/vercelgo.v1.LinkService/Create"
+	// LinkServiceUpdateProcedure is the fully-qualified name of the LinkService's Update RPC.
+	LinkServiceUpdateProcedure = "/vercelgo.v1.LinkService/Update"
+	// LinkServiceDeleteProcedure is the fully-qualified name of the LinkService's Delete RPC.
+	LinkServiceDeleteProcedure = "/vercelgo.v1.LinkService/Delete"
+)
+
+// LinkServiceClient is a client for the vercelgo.v1.LinkService service.
+type LinkServiceClient interface {
+	Get(context.Context, *connect.Request[v1.LinkServiceGetRequest]) (*connect.Response[v1.LinkServiceGetResponse], error)
+	List(context.Context, *connect.Request[v1.LinkServiceListRequest]) (*connect.Response[v1.LinkServiceListResponse], error)
+	Search(context.Context, *connect.Request[v1.LinkServiceSearchRequest]) (*connect.Response[v1.LinkServiceSearchResponse], error)
+	Create(context.Context, *connect.Request[v1.LinkServiceCreateRequest]) (*connect.Response[v1.LinkServiceCreateResponse], error)
+	Update(context.Context, *connect.Request[v1.LinkServiceUpdateRequest]) (*connect.Response[v1.LinkServiceUpdateResponse], error)
+	Delete(context.Context, *connect.Request[v1.LinkServiceDeleteRequest]) (*connect.Response[v1.LinkServiceDeleteResponse], error)
+}
+
+// NewLinkServiceClient constructs a client for the vercelgo.v1.LinkService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewLinkServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) LinkServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	linkServiceMethods := v1.File_vercelgo_v1_link_proto.Services().ByName("LinkService").Methods()
+	return &linkServiceClient{
+		get: connect.NewClient[v1.LinkServiceGetRequest, v1.LinkServiceGetResponse](
+			httpClient,
+			baseURL+LinkServiceGetProcedure,
+			connect.WithSchema(linkServiceMethods.ByName("Get")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		list: connect.NewClient[v1.LinkServiceListRequest, v1.LinkServiceListResponse](
+			httpClient,
+			baseURL+LinkServiceListProcedure,
+			connect.WithSchema(linkServiceMethods.ByName("List")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		search: connect.NewClient[v1.LinkServiceSearchRequest, v1.LinkServiceSearchResponse](
+			httpClient,
+			baseURL+LinkServiceSearchProcedure,
+			connect.WithSchema(linkServiceMethods.ByName("Search")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		create: connect.NewClient[v1.LinkServiceCreateRequest, v1.LinkServiceCreateResponse](
+			httpClient,
+			baseURL+LinkServiceCreateProcedure,
+			connect.WithSchema(linkServiceMethods.ByName("Create")),
+			connect.WithClientOptions(opts...),
+		),
+		update: connect.NewClient[v1.LinkServiceUpdateRequest, v1.LinkServiceUpdateResponse](
+			httpClient,
+			baseURL+LinkServiceUpdateProcedure,
+			connect.WithSchema(linkServiceMethods.ByName("Update")),
+			connect.WithClientOptions(opts...),
+		),
+		delete: connect.NewClient[v1.LinkServiceDeleteRequest, v1.LinkServiceDeleteResponse](
+			httpClient,
+			baseURL+LinkServiceDeleteProcedure,
+			connect.WithSchema(linkServiceMethods.ByName("Delete")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// linkServiceClient implements LinkServiceClient.
+type linkServiceClient struct {
+	get    *connect.Client[v1.LinkServiceGetRequest, v1.LinkServiceGetResponse]
+	list   *connect.Client[v1.LinkServiceListRequest, v1.LinkServiceListResponse]
+	search *connect.Client[v1.LinkServiceSearchRequest, v1.LinkServiceSearchResponse]
+	create *connect.Client[v1.LinkServiceCreateRequest, v1.LinkServiceCreateResponse]
+	update *connect.Client[v1.LinkServiceUpdateRequest, v1.LinkServiceUpdateResponse]
+	delete *connect.Client[v1.LinkServiceDeleteRequest, v1.LinkServiceDeleteResponse]
+}
+
+// Get calls vercelgo.v1.LinkService.Get.
+func (c *linkServiceClient) Get(ctx context.Context, req *connect.Request[v1.LinkServiceGetRequest]) (*connect.Response[v1.LinkServiceGetResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// List calls vercelgo.v1.LinkService.List.
+func (c *linkServiceClient) List(ctx context.Context, req *connect.Request[v1.LinkServiceListRequest]) (*connect.Response[v1.LinkServiceListResponse], error) {
+	return c.list.CallUnary(ctx, req)
+}
+
+// Search calls vercelgo.v1.LinkService.Search.
+func (c *linkServiceClient) Search(ctx context.Context, req *connect.Request[v1.LinkServiceSearchRequest]) (*connect.Response[v1.LinkServiceSearchResponse], error) {
+	return c.search.CallUnary(ctx, req)
+}
+
+// Create calls vercelgo.v1.LinkService.Create.
+func (c *linkServiceClient) Create(ctx context.Context, req *connect.Request[v1.LinkServiceCreateRequest]) (*connect.Response[v1.LinkServiceCreateResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Update calls vercelgo.v1.LinkService.Update.
+func (c *linkServiceClient) Update(ctx context.Context, req *connect.Request[v1.LinkServiceUpdateRequest]) (*connect.Response[v1.LinkServiceUpdateResponse], error) {
+	return c.update.CallUnary(ctx, req)
+}
+
+// Delete calls vercelgo.v1.LinkService.Delete.
+func (c *linkServiceClient) Delete(ctx context.Context, req *connect.Request[v1.LinkServiceDeleteRequest]) (*connect.Response[v1.LinkServiceDeleteResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// LinkServiceHandler is an implementation of the vercelgo.v1.LinkService service.
+type LinkServiceHandler interface {
+	Get(context.Context, *connect.Request[v1.LinkServiceGetRequest]) (*connect.Response[v1.LinkServiceGetResponse], error)
+	List(context.Context, *connect.Request[v1.LinkServiceListRequest]) (*connect.Response[v1.LinkServiceListResponse], error)
+	Search(context.Context, *connect.Request[v1.LinkServiceSearchRequest]) (*connect.Response[v1.LinkServiceSearchResponse], error)
+	Create(context.Context, *connect.Request[v1.LinkServiceCreateRequest]) (*connect.Response[v1.LinkServiceCreateResponse], error)
+	Update(context.Context, *connect.Request[v1.LinkServiceUpdateRequest]) (*connect.Response[v1.LinkServiceUpdateResponse], error)
+	Delete(context.Context, *connect.Request[v1.LinkServiceDeleteRequest]) (*connect.Response[v1.LinkServiceDeleteResponse], error)
+}
+
+// NewLinkServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewLinkServiceHandler(svc LinkServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	linkServiceMethods := v1.File_vercelgo_v1_link_proto.Services().ByName("LinkService").Methods()
+	linkServiceGetHandler := connect.NewUnaryHandler(
+		LinkServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(linkServiceMethods.ByName("Get")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	linkServiceListHandler := connect.NewUnaryHandler(
+		LinkServiceListProcedure,
+		svc.List,
+		connect.WithSchema(linkServiceMethods.ByName("List")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	linkServiceSearchHandler := connect.NewUnaryHandler(
+		LinkServiceSearchProcedure,
+		svc.Search,
+		connect.WithSchema(linkServiceMethods.ByName("Search")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	linkServiceCreateHandler := connect.NewUnaryHandler(
+		LinkServiceCreateProcedure,
+		svc.Create,
+		connect.WithSchema(linkServiceMethods.ByName("Create")),
+		connect.WithHandlerOptions(opts...),
+	)
+	linkServiceUpdateHandler := connect.NewUnaryHandler(
+		LinkServiceUpdateProcedure,
+		svc.Update,
+		connect.WithSchema(linkServiceMethods.ByName("Update")),
+		connect.WithHandlerOptions(opts...),
+	)
+	linkServiceDeleteHandler := connect.NewUnaryHandler(
+		LinkServiceDeleteProcedure,
+		svc.Delete,
+		connect.WithSchema(linkServiceMethods.ByName("Delete")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/vercelgo.v1.LinkService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case LinkServiceGetProcedure:
+			linkServiceGetHandler.ServeHTTP(w, r)
+		case LinkServiceListProcedure:
+			linkServiceListHandler.ServeHTTP(w, r)
+		case LinkServiceSearchProcedure:
+			linkServiceSearchHandler.ServeHTTP(w, r)
+		case LinkServiceCreateProcedure:
+			linkServiceCreateHandler.ServeHTTP(w, r)
+		case LinkServiceUpdateProcedure:
+			linkServiceUpdateHandler.ServeHTTP(w, r)
+		case LinkServiceDeleteProcedure:
+			linkServiceDeleteHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedLinkServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedLinkServiceHandler struct{}
+
+func (UnimplementedLinkServiceHandler) Get(context.Context, *connect.Request[v1.LinkServiceGetRequest]) (*connect.Response[v1.LinkServiceGetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.LinkService.Get is not implemented"))
+}
+
+func (UnimplementedLinkServiceHandler) List(context.Context, *connect.Request[v1.LinkServiceListRequest]) (*connect.Response[v1.LinkServiceListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.LinkService.List is not implemented"))
+}
+
+func (UnimplementedLinkServiceHandler) Search(context.Context, *connect.Request[v1.LinkServiceSearchRequest]) (*connect.Response[v1.LinkServiceSearchResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.LinkService.Search is not implemented"))
+}
+
+func (UnimplementedLinkServiceHandler) Create(context.Context, *connect.Request[v1.LinkServiceCreateRequest]) (*connect.Response[v1.LinkServiceCreateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.LinkService.Create is not implemented"))
+}
+
+func (UnimplementedLinkServiceHandler) Update(context.Context, *connect.Request[v1.LinkServiceUpdateRequest]) (*connect.Response[v1.LinkServiceUpdateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.LinkService.Update is not implemented"))
+}
+
+func (UnimplementedLinkServiceHandler) Delete(context.Context, *connect.Request[v1.LinkServiceDeleteRequest]) (*connect.Response[v1.LinkServiceDeleteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.LinkService.Delete is not implemented"))
+}
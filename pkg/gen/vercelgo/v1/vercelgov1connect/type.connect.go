@@ -0,0 +1,254 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: vercelgo/v1/type.proto
+
+package vercelgov1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "maguro-alternative/varcel-go/pkg/gen/vercelgo/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// TypeServiceName is the fully-qualified name of the TypeService service.
+	TypeServiceName = "vercelgo.v1.TypeService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// TypeServiceGetProcedure is the fully-qualified name of the TypeService's Get RPC.
+	TypeServiceGetProcedure = "/vercelgo.v1.TypeService/Get"
+	// TypeServiceListProcedure is the fully-qualified name of the TypeService's List RPC.
+	TypeServiceListProcedure = "/vercelgo.v1.TypeService/List"
+	// TypeServiceSearchProcedure is the fully-qualified name of the TypeService's Search RPC.
+	TypeServiceSearchProcedure = "/vercelgo.v1.TypeService/Search"
+	// TypeServiceCreateProcedure is the fully-qualified name of the TypeService's Create RPC.
+	TypeServiceCreateProcedure = "/vercelgo.v1.TypeService/Create"
+	// TypeServiceUpdateProcedure is the fully-qualified name of the TypeService's Update RPC.
+	TypeServiceUpdateProcedure = "/vercelgo.v1.TypeService/Update"
+	// TypeServiceDeleteProcedure is the fully-qualified name of the TypeService's Delete RPC.
+	TypeServiceDeleteProcedure = "/vercelgo.v1.TypeService/Delete"
+)
+
+// TypeServiceClient is a client for the vercelgo.v1.TypeService service.
+type TypeServiceClient interface {
+	Get(context.Context, *connect.Request[v1.TypeServiceGetRequest]) (*connect.Response[v1.TypeServiceGetResponse], error)
+	List(context.Context, *connect.Request[v1.TypeServiceListRequest]) (*connect.Response[v1.TypeServiceListResponse], error)
+	Search(context.Context, *connect.Request[v1.TypeServiceSearchRequest]) (*connect.Response[v1.TypeServiceSearchResponse], error)
+	Create(context.Context, *connect.Request[v1.TypeServiceCreateRequest]) (*connect.Response[v1.TypeServiceCreateResponse], error)
+	Update(context.Context, *connect.Request[v1.TypeServiceUpdateRequest]) (*connect.Response[v1.TypeServiceUpdateResponse], error)
+	Delete(context.Context, *connect.Request[v1.TypeServiceDeleteRequest]) (*connect.Response[v1.TypeServiceDeleteResponse], error)
+}
+
+// NewTypeServiceClient constructs a client for the vercelgo.v1.TypeService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewTypeServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) TypeServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	typeServiceMethods := v1.File_vercelgo_v1_type_proto.Services().ByName("TypeService").Methods()
+	return &typeServiceClient{
+		get: connect.NewClient[v1.TypeServiceGetRequest, v1.TypeServiceGetResponse](
+			httpClient,
+			baseURL+TypeServiceGetProcedure,
+			connect.WithSchema(typeServiceMethods.ByName("Get")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		list: connect.NewClient[v1.TypeServiceListRequest, v1.TypeServiceListResponse](
+			httpClient,
+			baseURL+TypeServiceListProcedure,
+			connect.WithSchema(typeServiceMethods.ByName("List")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		search: connect.NewClient[v1.TypeServiceSearchRequest, v1.TypeServiceSearchResponse](
+			httpClient,
+			baseURL+TypeServiceSearchProcedure,
+			connect.WithSchema(typeServiceMethods.ByName("Search")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		create: connect.NewClient[v1.TypeServiceCreateRequest, v1.TypeServiceCreateResponse](
+			httpClient,
+			baseURL+TypeServiceCreateProcedure,
+			connect.WithSchema(typeServiceMethods.ByName("Create")),
+			connect.WithClientOptions(opts...),
+		),
+		update: connect.NewClient[v1.TypeServiceUpdateRequest, v1.TypeServiceUpdateResponse](
+			httpClient,
+			baseURL+TypeServiceUpdateProcedure,
+			connect.WithSchema(typeServiceMethods.ByName("Update")),
+			connect.WithClientOptions(opts...),
+		),
+		delete: connect.NewClient[v1.TypeServiceDeleteRequest, v1.TypeServiceDeleteResponse](
+			httpClient,
+			baseURL+TypeServiceDeleteProcedure,
+			connect.WithSchema(typeServiceMethods.ByName("Delete")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// typeServiceClient implements TypeServiceClient.
+type typeServiceClient struct {
+	get    *connect.Client[v1.TypeServiceGetRequest, v1.TypeServiceGetResponse]
+	list   *connect.Client[v1.TypeServiceListRequest, v1.TypeServiceListResponse]
+	search *connect.Client[v1.TypeServiceSearchRequest, v1.TypeServiceSearchResponse]
+	create *connect.Client[v1.TypeServiceCreateRequest, v1.TypeServiceCreateResponse]
+	update *connect.Client[v1.TypeServiceUpdateRequest, v1.TypeServiceUpdateResponse]
+	delete *connect.Client[v1.TypeServiceDeleteRequest, v1.TypeServiceDeleteResponse]
+}
+
+// Get calls vercelgo.v1.TypeService.Get.
+func (c *typeServiceClient) Get(ctx context.Context, req *connect.Request[v1.TypeServiceGetRequest]) (*connect.Response[v1.TypeServiceGetResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// List calls vercelgo.v1.TypeService.List.
+func (c *typeServiceClient) List(ctx context.Context, req *connect.Request[v1.TypeServiceListRequest]) (*connect.Response[v1.TypeServiceListResponse], error) {
+	return c.list.CallUnary(ctx, req)
+}
+
+// Search calls vercelgo.v1.TypeService.Search.
+func (c *typeServiceClient) Search(ctx context.Context, req *connect.Request[v1.TypeServiceSearchRequest]) (*connect.Response[v1.TypeServiceSearchResponse], error) {
+	return c.search.CallUnary(ctx, req)
+}
+
+// Create calls vercelgo.v1.TypeService.Create.
+func (c *typeServiceClient) Create(ctx context.Context, req *connect.Request[v1.TypeServiceCreateRequest]) (*connect.Response[v1.TypeServiceCreateResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Update calls vercelgo.v1.TypeService.Update.
+func (c *typeServiceClient) Update(ctx context.Context, req *connect.Request[v1.TypeServiceUpdateRequest]) (*connect.Response[v1.TypeServiceUpdateResponse], error) {
+	return c.update.CallUnary(ctx, req)
+}
+
+// Delete calls vercelgo.v1.TypeService.Delete.
+func (c *typeServiceClient) Delete(ctx context.Context, req *connect.Request[v1.TypeServiceDeleteRequest]) (*connect.Response[v1.TypeServiceDeleteResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// TypeServiceHandler is an implementation of the vercelgo.v1.TypeService service.
+type TypeServiceHandler interface {
+	Get(context.Context, *connect.Request[v1.TypeServiceGetRequest]) (*connect.Response[v1.TypeServiceGetResponse], error)
+	List(context.Context, *connect.Request[v1.TypeServiceListRequest]) (*connect.Response[v1.TypeServiceListResponse], error)
+	Search(context.Context, *connect.Request[v1.TypeServiceSearchRequest]) (*connect.Response[v1.TypeServiceSearchResponse], error)
+	Create(context.Context, *connect.Request[v1.TypeServiceCreateRequest]) (*connect.Response[v1.TypeServiceCreateResponse], error)
+	Update(context.Context, *connect.Request[v1.TypeServiceUpdateRequest]) (*connect.Response[v1.TypeServiceUpdateResponse], error)
+	Delete(context.Context, *connect.Request[v1.TypeServiceDeleteRequest]) (*connect.Response[v1.TypeServiceDeleteResponse], error)
+}
+
+// NewTypeServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewTypeServiceHandler(svc TypeServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	typeServiceMethods := v1.File_vercelgo_v1_type_proto.Services().ByName("TypeService").Methods()
+	typeServiceGetHandler := connect.NewUnaryHandler(
+		TypeServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(typeServiceMethods.ByName("Get")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	typeServiceListHandler := connect.NewUnaryHandler(
+		TypeServiceListProcedure,
+		svc.List,
+		connect.WithSchema(typeServiceMethods.ByName("List")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	typeServiceSearchHandler := connect.NewUnaryHandler(
+		TypeServiceSearchProcedure,
+		svc.Search,
+		connect.WithSchema(typeServiceMethods.ByName("Search")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	typeServiceCreateHandler := connect.NewUnaryHandler(
+		TypeServiceCreateProcedure,
+		svc.Create,
+		connect.WithSchema(typeServiceMethods.ByName("Create")),
+		connect.WithHandlerOptions(opts...),
+	)
+	typeServiceUpdateHandler := connect.NewUnaryHandler(
+		TypeServiceUpdateProcedure,
+		svc.Update,
+		connect.WithSchema(typeServiceMethods.ByName("Update")),
+		connect.WithHandlerOptions(opts...),
+	)
+	typeServiceDeleteHandler := connect.NewUnaryHandler(
+		TypeServiceDeleteProcedure,
+		svc.Delete,
+		connect.WithSchema(typeServiceMethods.ByName("Delete")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/vercelgo.v1.TypeService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case TypeServiceGetProcedure:
+			typeServiceGetHandler.ServeHTTP(w, r)
+		case TypeServiceListProcedure:
+			typeServiceListHandler.ServeHTTP(w, r)
+		case TypeServiceSearchProcedure:
+			typeServiceSearchHandler.ServeHTTP(w, r)
+		case TypeServiceCreateProcedure:
+			typeServiceCreateHandler.ServeHTTP(w, r)
+		case TypeServiceUpdateProcedure:
+			typeServiceUpdateHandler.ServeHTTP(w, r)
+		case TypeServiceDeleteProcedure:
+			typeServiceDeleteHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedTypeServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedTypeServiceHandler struct{}
+
+func (UnimplementedTypeServiceHandler) Get(context.Context, *connect.Request[v1.TypeServiceGetRequest]) (*connect.Response[v1.TypeServiceGetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.TypeService.Get is not implemented"))
+}
+
+func (UnimplementedTypeServiceHandler) List(context.Context, *connect.Request[v1.TypeServiceListRequest]) (*connect.Response[v1.TypeServiceListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.TypeService.List is not implemented"))
+}
+
+func (UnimplementedTypeServiceHandler) Search(context.Context, *connect.Request[v1.TypeServiceSearchRequest]) (*connect.Response[v1.TypeServiceSearchResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.TypeService.Search is not implemented"))
+}
+
+func (UnimplementedTypeServiceHandler) Create(context.Context, *connect.Request[v1.TypeServiceCreateRequest]) (*connect.Response[v1.TypeServiceCreateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.TypeService.Create is not implemented"))
+}
+
+func (UnimplementedTypeServiceHandler) Update(context.Context, *connect.Request[v1.TypeServiceUpdateRequest]) (*connect.Response[v1.TypeServiceUpdateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.TypeService.Update is not implemented"))
+}
+
+func (UnimplementedTypeServiceHandler) Delete(context.Context, *connect.Request[v1.TypeServiceDeleteRequest]) (*connect.Response[v1.TypeServiceDeleteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vercelgo.v1.TypeService.Delete is not implemented"))
+}
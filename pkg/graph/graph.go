@@ -0,0 +1,504 @@
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"strconv"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/rbac"
+)
+
+//go:embed schema.graphql
+var schemaString string
+
+const (
+	maxFirst = 1000
+	maxDepth = 8
+)
+
+// Schema はパース済みのGraphQLスキーマ
+var Schema = graphql.MustParseSchema(schemaString, &Resolver{},
+	graphql.MaxDepth(maxDepth),
+)
+
+// state はリクエストごとのDB接続、権限表とloader
+type state struct {
+	db     *database.DB
+	matrix rbac.Matrix
+	role   string
+
+	source          *loader[*sourceRow]
+	tags            *loader[[]tagRow]
+	links           *loader[[]linkRow]
+	bwh             *loader[*bwhRow]
+	haircolor       *loader[[]attributeRow]
+	eyecolor        *loader[[]attributeRow]
+	hairstyle       *loader[[]attributeRow]
+	hairlength      *loader[[]attributeRow]
+	personalities   *loader[[]attributeRow]
+	radar           *loader[*radarRow]
+	entriesBySource *loader[[]entryRow]
+	entriesByTag    *loader[[]entryRow]
+}
+
+type stateKey struct{}
+
+// NewContext はリクエストごとのloaderを作成してcontextに載せる
+// ロールはctxの認証情報から決め、各フィールドでmatrixのreadを確認する
+func NewContext(ctx context.Context, db *database.DB, matrix rbac.Matrix) context.Context {
+	s := &state{
+		db:            db,
+		matrix:        matrix,
+		role:          rbac.RoleOf(ctx),
+		source:        newLoader(fetchSources(db)),
+		tags:          newLoader(fetchTags(db)),
+		links:         newLoader(fetchLinks(db)),
+		bwh:           newLoader(fetchBWH(db)),
+		haircolor:     newLoader(fetchAttributes(db, "haircolor", "color_id", "haircolor_type", "color")),
+		eyecolor:      newLoader(fetchAttributes(db, "eyecolor", "color_id", "eyecolor_type", "color")),
+		hairstyle:     newLoader(fetchAttributes(db, "hairstyle", "style_id", "hairstyle_type", "style")),
+		hairlength:    newLoader(fetchAttributes(db, "hairlength", "hairlength_type_id", "hairlength_type", "length")),
+		personalities: newLoader(fetchAttributes(db, "personality", "type_id", "personality_type", "type")),
+		radar:         newLoader(fetchRadarCharts(db)),
+	}
+	s.entriesBySource = newLoader(s.primed(fetchEntriesBy(db, entriesBySourceQuery)))
+	s.entriesByTag = newLoader(s.primed(fetchEntriesBy(db, entriesByTagQuery)))
+	return context.WithValue(ctx, stateKey{}, s)
+}
+
+func fromContext(ctx context.Context) (*state, error) {
+	s, ok := ctx.Value(stateKey{}).(*state)
+	if !ok {
+		return nil, errors.New("graph: no database in context")
+	}
+	return s, nil
+}
+
+// denied は拒否の理由をGraphQLのエラーのextensionsに載せる
+type denied struct {
+	*rbac.Denial
+}
+
+func (d denied) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code":          d.Code,
+		"reason":        d.Reason,
+		"role":          d.Role,
+		"resource":      d.Resource,
+		"action":        d.Action,
+		"allowed_roles": d.AllowedRoles,
+	}
+}
+
+// authorize はREST APIのハンドラと同じ権限表で、全てのリソースのreadを確認する
+func (s *state) authorize(resources ...string) error {
+	for _, res := range resources {
+		if d := s.matrix.Check(s.role, res, rbac.Read); d != nil {
+			return denied{d}
+		}
+	}
+	return nil
+}
+
+// entries は取得したentryをloaderに登録してリゾルバにする
+func (s *state) entries(rows []entryRow) []*entryResolver {
+	ids := make([]int64, len(rows))
+	sourceIDs := make([]int64, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+		sourceIDs[i] = row.SourceID
+	}
+	s.source.prime(sourceIDs...)
+	for _, l := range []interface{ prime(...int64) }{
+		s.tags, s.links, s.bwh, s.haircolor, s.eyecolor, s.hairstyle, s.hairlength, s.personalities, s.radar,
+	} {
+		l.prime(ids...)
+	}
+	resolvers := make([]*entryResolver, len(rows))
+	for i := range rows {
+		resolvers[i] = &entryResolver{row: rows[i], s: s}
+	}
+	return resolvers
+}
+
+// primed はsourceやtagごとに取得したentryもまとめて登録する
+func (s *state) primed(fetch func(context.Context, []int64) (map[int64][]entryRow, error)) func(context.Context, []int64) (map[int64][]entryRow, error) {
+	return func(ctx context.Context, keys []int64) (map[int64][]entryRow, error) {
+		m, err := fetch(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+		var all []entryRow
+		for _, rows := range m {
+			all = append(all, rows...)
+		}
+		s.entries(all)
+		return m, nil
+	}
+}
+
+// Resolver はQuery型のリゾルバ
+type Resolver struct{}
+
+type entriesArgs struct {
+	IDs      *[]graphql.ID
+	SourceID *graphql.ID
+	TagID    *graphql.ID
+	First    int32
+	Offset   int32
+}
+
+func (*Resolver) Entries(ctx context.Context, args entriesArgs) ([]*entryResolver, error) {
+	s, err := fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if args.First < 0 || args.First > maxFirst {
+		return nil, errors.New("first must be between 0 and " + strconv.Itoa(maxFirst))
+	}
+	if args.Offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+	if err := s.authorize("entry"); err != nil {
+		return nil, err
+	}
+	f := entryFilter{Limit: int64(args.First), Offset: int64(args.Offset)}
+	if args.IDs != nil {
+		if len(*args.IDs) == 0 {
+			return []*entryResolver{}, nil
+		}
+		for _, id := range *args.IDs {
+			n, err := parseID(id)
+			if err != nil {
+				return nil, err
+			}
+			f.IDs = append(f.IDs, n)
+		}
+	}
+	if f.SourceID, err = parseOptionalID(args.SourceID); err != nil {
+		return nil, err
+	}
+	if f.TagID, err = parseOptionalID(args.TagID); err != nil {
+		return nil, err
+	}
+	if f.TagID != nil {
+		if err := s.authorize("entry_tag"); err != nil {
+			return nil, err
+		}
+	}
+	rows, err := selectEntries(ctx, s.db, f)
+	if err != nil {
+		return nil, err
+	}
+	return s.entries(rows), nil
+}
+
+func (r *Resolver) Entry(ctx context.Context, args struct{ ID graphql.ID }) (*entryResolver, error) {
+	ids := []graphql.ID{args.ID}
+	entries, err := r.Entries(ctx, entriesArgs{IDs: &ids, First: 1})
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return entries[0], nil
+}
+
+func (*Resolver) Sources(ctx context.Context) ([]*sourceResolver, error) {
+	s, err := fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize("source"); err != nil {
+		return nil, err
+	}
+	rows, err := selectSources(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*sourceResolver, len(rows))
+	ids := make([]int64, len(rows))
+	for i := range rows {
+		resolvers[i] = &sourceResolver{row: &rows[i], s: s}
+		ids[i] = rows[i].ID
+	}
+	s.entriesBySource.prime(ids...)
+	return resolvers, nil
+}
+
+func (*Resolver) Tags(ctx context.Context) ([]*tagResolver, error) {
+	s, err := fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize("tag"); err != nil {
+		return nil, err
+	}
+	rows, err := selectTags(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	return s.tagResolvers(rows), nil
+}
+
+func (s *state) tagResolvers(rows []tagRow) []*tagResolver {
+	resolvers := make([]*tagResolver, len(rows))
+	ids := make([]int64, len(rows))
+	for i := range rows {
+		resolvers[i] = &tagResolver{row: rows[i], s: s}
+		ids[i] = rows[i].ID
+	}
+	s.entriesByTag.prime(ids...)
+	return resolvers
+}
+
+type entryResolver struct {
+	row entryRow
+	s   *state
+}
+
+func (r *entryResolver) ID() graphql.ID    { return formatID(r.row.ID) }
+func (r *entryResolver) Name() string      { return r.row.Name }
+func (r *entryResolver) Image() string     { return r.row.Image }
+func (r *entryResolver) Content() string   { return r.row.Content }
+func (r *entryResolver) CreatedAt() string { return r.row.CreatedAt.Format(time.RFC3339) }
+
+func (r *entryResolver) Source(ctx context.Context) (*sourceResolver, error) {
+	if err := r.s.authorize("source"); err != nil {
+		return nil, err
+	}
+	row, err := r.s.source.load(ctx, r.row.SourceID)
+	if err != nil || row == nil {
+		return nil, err
+	}
+	r.s.entriesBySource.prime(row.ID)
+	return &sourceResolver{row: row, s: r.s}, nil
+}
+
+func (r *entryResolver) Tags(ctx context.Context) ([]*tagResolver, error) {
+	if err := r.s.authorize("entry_tag", "tag"); err != nil {
+		return nil, err
+	}
+	rows, err := r.s.tags.load(ctx, r.row.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.s.tagResolvers(rows), nil
+}
+
+func (r *entryResolver) Links(ctx context.Context) ([]*linkResolver, error) {
+	if err := r.s.authorize("link"); err != nil {
+		return nil, err
+	}
+	rows, err := r.s.links.load(ctx, r.row.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*linkResolver, len(rows))
+	for i := range rows {
+		resolvers[i] = &linkResolver{row: rows[i]}
+	}
+	return resolvers, nil
+}
+
+func (r *entryResolver) BWH(ctx context.Context) (*bwhResolver, error) {
+	if err := r.s.authorize("bwh"); err != nil {
+		return nil, err
+	}
+	row, err := r.s.bwh.load(ctx, r.row.ID)
+	if err != nil || row == nil {
+		return nil, err
+	}
+	return &bwhResolver{row: row}, nil
+}
+
+func (r *entryResolver) Haircolor(ctx context.Context) (*colorResolver, error) {
+	return r.attribute(ctx, r.s.haircolor, "haircolor", "haircolor_type")
+}
+
+func (r *entryResolver) Eyecolor(ctx context.Context) (*colorResolver, error) {
+	return r.attribute(ctx, r.s.eyecolor, "eyescolor", "eyescolor_type")
+}
+
+func (r *entryResolver) Hairstyle(ctx context.Context) (*styleResolver, error) {
+	a, err := r.attribute(ctx, r.s.hairstyle, "hairstyle", "hairstyle_type")
+	if err != nil || a == nil {
+		return nil, err
+	}
+	return &styleResolver{a.row}, nil
+}
+
+func (r *entryResolver) Hairlength(ctx context.Context) (*lengthResolver, error) {
+	a, err := r.attribute(ctx, r.s.hairlength, "hairlength", "hairlength_type")
+	if err != nil || a == nil {
+		return nil, err
+	}
+	return &lengthResolver{a.row}, nil
+}
+
+func (r *entryResolver) Personalities(ctx context.Context) ([]*personalityResolver, error) {
+	if err := r.s.authorize("personality", "personality_type"); err != nil {
+		return nil, err
+	}
+	rows, err := r.s.personalities.load(ctx, r.row.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*personalityResolver, len(rows))
+	for i := range rows {
+		resolvers[i] = &personalityResolver{rows[i]}
+	}
+	return resolvers, nil
+}
+
+func (r *entryResolver) HekiRadarChart(ctx context.Context) (*radarResolver, error) {
+	if err := r.s.authorize("heki_radar_chart"); err != nil {
+		return nil, err
+	}
+	row, err := r.s.radar.load(ctx, r.row.ID)
+	if err != nil || row == nil {
+		return nil, err
+	}
+	return &radarResolver{row: row}, nil
+}
+
+// attribute は1件だけ持つ属性の最初の値を返す。resourcesは属性と*_typeのリソース名
+func (r *entryResolver) attribute(ctx context.Context, l *loader[[]attributeRow], resources ...string) (*colorResolver, error) {
+	if err := r.s.authorize(resources...); err != nil {
+		return nil, err
+	}
+	rows, err := l.load(ctx, r.row.ID)
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	return &colorResolver{rows[0]}, nil
+}
+
+type sourceResolver struct {
+	row *sourceRow
+	s   *state
+}
+
+func (r *sourceResolver) ID() graphql.ID { return formatID(r.row.ID) }
+func (r *sourceResolver) Name() string   { return r.row.Name }
+func (r *sourceResolver) URL() string    { return r.row.URL }
+func (r *sourceResolver) Type() string   { return r.row.Type }
+
+func (r *sourceResolver) Entries(ctx context.Context) ([]*entryResolver, error) {
+	if err := r.s.authorize("entry"); err != nil {
+		return nil, err
+	}
+	rows, err := r.s.entriesBySource.load(ctx, r.row.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.s.entries(rows), nil
+}
+
+type tagResolver struct {
+	row tagRow
+	s   *state
+}
+
+func (r *tagResolver) ID() graphql.ID { return formatID(r.row.ID) }
+func (r *tagResolver) Name() string   { return r.row.Name }
+
+func (r *tagResolver) Entries(ctx context.Context) ([]*entryResolver, error) {
+	if err := r.s.authorize("entry", "entry_tag"); err != nil {
+		return nil, err
+	}
+	rows, err := r.s.entriesByTag.load(ctx, r.row.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.s.entries(rows), nil
+}
+
+type linkResolver struct {
+	row linkRow
+}
+
+func (r *linkResolver) ID() graphql.ID { return formatID(r.row.ID) }
+func (r *linkResolver) Type() string   { return r.row.Type }
+func (r *linkResolver) URL() string    { return r.row.URL }
+func (r *linkResolver) Nsfw() bool     { return r.row.Nsfw }
+func (r *linkResolver) Darkness() bool { return r.row.Darkness }
+
+type bwhResolver struct {
+	row *bwhRow
+}
+
+func (r *bwhResolver) Bust() int32    { return int32(r.row.Bust) }
+func (r *bwhResolver) Waist() int32   { return int32(r.row.Waist) }
+func (r *bwhResolver) Hip() int32     { return int32(r.row.Hip) }
+func (r *bwhResolver) Height() *int32 { return optionalInt(r.row.Height) }
+func (r *bwhResolver) Weight() *int32 { return optionalInt(r.row.Weight) }
+
+type colorResolver struct {
+	row attributeRow
+}
+
+func (r *colorResolver) ID() graphql.ID { return formatID(r.row.ID) }
+func (r *colorResolver) Color() string  { return r.row.Value }
+
+type styleResolver struct {
+	row attributeRow
+}
+
+func (r *styleResolver) ID() graphql.ID { return formatID(r.row.ID) }
+func (r *styleResolver) Style() string  { return r.row.Value }
+
+type lengthResolver struct {
+	row attributeRow
+}
+
+func (r *lengthResolver) ID() graphql.ID { return formatID(r.row.ID) }
+func (r *lengthResolver) Length() string { return r.row.Value }
+
+type personalityResolver struct {
+	row attributeRow
+}
+
+func (r *personalityResolver) ID() graphql.ID { return formatID(r.row.ID) }
+func (r *personalityResolver) Type() string   { return r.row.Value }
+
+type radarResolver struct {
+	row *radarRow
+}
+
+func (r *radarResolver) AI() int32 { return int32(r.row.AI) }
+func (r *radarResolver) NU() int32 { return int32(r.row.NU) }
+
+func formatID(id int64) graphql.ID {
+	return graphql.ID(strconv.FormatInt(id, 10))
+}
+
+func parseID(id graphql.ID) (int64, error) {
+	n, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid id: " + string(id))
+	}
+	return n, nil
+}
+
+func parseOptionalID(id *graphql.ID) (*int64, error) {
+	if id == nil {
+		return nil, nil
+	}
+	n, err := parseID(*id)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func optionalInt(v *int64) *int32 {
+	if v == nil {
+		return nil
+	}
+	n := int32(*v)
+	return &n
+}
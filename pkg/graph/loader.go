@@ -0,0 +1,67 @@
+package graph
+
+import (
+	"context"
+	"sync"
+)
+
+// loader は同じ一覧に含まれる兄弟のキーをまとめて1回のクエリで取得する
+//
+// 一覧を返すリゾルバがprimeで全てのキーを登録しておくと、
+// 子のフィールドで最初にloadが呼ばれた時に登録済みのキーをまとめて取得する。
+// 2回目以降はキャッシュを返すので、entries { tags } のようなクエリでもN+1にならない
+type loader[V any] struct {
+	fetch func(ctx context.Context, keys []int64) (map[int64]V, error)
+
+	mu      sync.Mutex
+	pending map[int64]bool
+	cache   map[int64]V
+	loaded  map[int64]bool
+}
+
+func newLoader[V any](fetch func(ctx context.Context, keys []int64) (map[int64]V, error)) *loader[V] {
+	return &loader[V]{
+		fetch:   fetch,
+		pending: map[int64]bool{},
+		cache:   map[int64]V{},
+		loaded:  map[int64]bool{},
+	}
+}
+
+// prime は次の取得でまとめて読み込むキーを登録する
+func (l *loader[V]) prime(keys ...int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, k := range keys {
+		if !l.loaded[k] {
+			l.pending[k] = true
+		}
+	}
+}
+
+// load はキーに対応する値を返す。存在しない場合はゼロ値を返す
+func (l *loader[V]) load(ctx context.Context, key int64) (V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.loaded[key] {
+		return l.cache[key], nil
+	}
+	l.pending[key] = true
+	keys := make([]int64, 0, len(l.pending))
+	for k := range l.pending {
+		keys = append(keys, k)
+	}
+	values, err := l.fetch(ctx, keys)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	for _, k := range keys {
+		l.loaded[k] = true
+		if v, ok := values[k]; ok {
+			l.cache[k] = v
+		}
+	}
+	l.pending = map[int64]bool{}
+	return l.cache[key], nil
+}
@@ -0,0 +1,360 @@
+package graph
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"maguro-alternative/varcel-go/pkg/database"
+)
+
+type entryRow struct {
+	ID        int64     `db:"id"`
+	SourceID  int64     `db:"source_id"`
+	Name      string    `db:"name"`
+	Image     string    `db:"image"`
+	Content   string    `db:"content"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type sourceRow struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+	URL  string `db:"url"`
+	Type string `db:"type"`
+}
+
+// tagRow はentry_tagで紐付いたタグ。KeyはentryのID
+type tagRow struct {
+	Key  int64  `db:"key"`
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+type linkRow struct {
+	ID       int64  `db:"id"`
+	EntryID  int64  `db:"entry_id"`
+	Type     string `db:"type"`
+	URL      string `db:"url"`
+	Nsfw     bool   `db:"nsfw"`
+	Darkness bool   `db:"darkness"`
+}
+
+type bwhRow struct {
+	EntryID int64  `db:"entry_id"`
+	Bust    int64  `db:"bust"`
+	Waist   int64  `db:"waist"`
+	Hip     int64  `db:"hip"`
+	Height  *int64 `db:"height"`
+	Weight  *int64 `db:"weight"`
+}
+
+// attributeRow は髪色や性格など *_type を参照する属性
+type attributeRow struct {
+	EntryID int64  `db:"entry_id"`
+	ID      int64  `db:"id"`
+	Value   string `db:"value"`
+}
+
+type radarRow struct {
+	EntryID int64 `db:"entry_id"`
+	AI      int64 `db:"ai"`
+	NU      int64 `db:"nu"`
+}
+
+// keyedEntryRow はsourceやtagごとにまとめて取得したentry
+type keyedEntryRow struct {
+	Key int64 `db:"key"`
+	entryRow
+}
+
+const entryColumns = `
+	e.id,
+	e.source_id,
+	e.name,
+	e.image,
+	e.content,
+	e.created_at
+`
+
+// selectIn はIN (?) を含むクエリをキーの数だけ展開して実行する
+func selectIn(ctx context.Context, db *database.DB, dest interface{}, query string, keys []int64) error {
+	query, args, err := sqlx.In(query, keys)
+	if err != nil {
+		return err
+	}
+	return db.SelectContext(ctx, dest, db.Rebind(query), args...)
+}
+
+// entryFilter はQuery.entriesの絞り込み条件
+type entryFilter struct {
+	IDs      []int64
+	SourceID *int64
+	TagID    *int64
+	Limit    int64
+	Offset   int64
+}
+
+func selectEntries(ctx context.Context, db *database.DB, f entryFilter) ([]entryRow, error) {
+	var (
+		where []string
+		args  []interface{}
+	)
+	query := `SELECT ` + entryColumns + ` FROM entry e`
+	if f.TagID != nil {
+		query += ` JOIN entry_tag et ON et.entry_id = e.id`
+		where = append(where, `et.tag_id = ?`)
+		args = append(args, *f.TagID)
+	}
+	if f.IDs != nil {
+		where = append(where, `e.id IN (?)`)
+		args = append(args, f.IDs)
+	}
+	if f.SourceID != nil {
+		where = append(where, `e.source_id = ?`)
+		args = append(args, *f.SourceID)
+	}
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, ` AND `)
+	}
+	query += ` ORDER BY e.id LIMIT ? OFFSET ?`
+	args = append(args, f.Limit, f.Offset)
+	query, args, err := sqlx.In(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	var rows []entryRow
+	err = db.SelectContext(ctx, &rows, db.Rebind(query), args...)
+	return rows, err
+}
+
+func selectSources(ctx context.Context, db *database.DB) ([]sourceRow, error) {
+	var rows []sourceRow
+	query := `
+		SELECT
+			id,
+			name,
+			url,
+			type
+		FROM
+			source
+		ORDER BY
+			id
+	`
+	err := db.SelectContext(ctx, &rows, query)
+	return rows, err
+}
+
+func selectTags(ctx context.Context, db *database.DB) ([]tagRow, error) {
+	var rows []tagRow
+	query := `
+		SELECT
+			id AS key,
+			id,
+			name
+		FROM
+			tag
+		ORDER BY
+			id
+	`
+	err := db.SelectContext(ctx, &rows, query)
+	return rows, err
+}
+
+// 以下はloaderから呼ばれ、キーごとにまとめた結果を返す
+
+func fetchSources(db *database.DB) func(context.Context, []int64) (map[int64]*sourceRow, error) {
+	return func(ctx context.Context, keys []int64) (map[int64]*sourceRow, error) {
+		var rows []sourceRow
+		query := `
+			SELECT
+				id,
+				name,
+				url,
+				type
+			FROM
+				source
+			WHERE
+				id IN (?)
+		`
+		if err := selectIn(ctx, db, &rows, query, keys); err != nil {
+			return nil, err
+		}
+		m := make(map[int64]*sourceRow, len(rows))
+		for i := range rows {
+			m[rows[i].ID] = &rows[i]
+		}
+		return m, nil
+	}
+}
+
+func fetchTags(db *database.DB) func(context.Context, []int64) (map[int64][]tagRow, error) {
+	return func(ctx context.Context, keys []int64) (map[int64][]tagRow, error) {
+		var rows []tagRow
+		query := `
+			SELECT
+				et.entry_id AS key,
+				t.id,
+				t.name
+			FROM
+				entry_tag et
+				JOIN tag t ON t.id = et.tag_id
+			WHERE
+				et.entry_id IN (?)
+			ORDER BY
+				t.id
+		`
+		if err := selectIn(ctx, db, &rows, query, keys); err != nil {
+			return nil, err
+		}
+		return group(rows, func(r tagRow) int64 { return r.Key }), nil
+	}
+}
+
+func fetchLinks(db *database.DB) func(context.Context, []int64) (map[int64][]linkRow, error) {
+	return func(ctx context.Context, keys []int64) (map[int64][]linkRow, error) {
+		var rows []linkRow
+		query := `
+			SELECT
+				id,
+				entry_id,
+				type,
+				url,
+				nsfw,
+				darkness
+			FROM
+				link
+			WHERE
+				entry_id IN (?)
+			ORDER BY
+				id
+		`
+		if err := selectIn(ctx, db, &rows, query, keys); err != nil {
+			return nil, err
+		}
+		return group(rows, func(r linkRow) int64 { return r.EntryID }), nil
+	}
+}
+
+func fetchBWH(db *database.DB) func(context.Context, []int64) (map[int64]*bwhRow, error) {
+	return func(ctx context.Context, keys []int64) (map[int64]*bwhRow, error) {
+		var rows []bwhRow
+		query := `
+			SELECT
+				entry_id,
+				bust,
+				waist,
+				hip,
+				height,
+				weight
+			FROM
+				bwh
+			WHERE
+				entry_id IN (?)
+		`
+		if err := selectIn(ctx, db, &rows, query, keys); err != nil {
+			return nil, err
+		}
+		m := make(map[int64]*bwhRow, len(rows))
+		for i := range rows {
+			m[rows[i].EntryID] = &rows[i]
+		}
+		return m, nil
+	}
+}
+
+// fetchAttributes は属性テーブルと *_type テーブルを結合して取得する
+func fetchAttributes(db *database.DB, table, column, typeTable, valueColumn string) func(context.Context, []int64) (map[int64][]attributeRow, error) {
+	query := `
+		SELECT
+			a.entry_id,
+			t.id,
+			t.` + valueColumn + ` AS value
+		FROM
+			` + table + ` a
+			JOIN ` + typeTable + ` t ON t.id = a.` + column + `
+		WHERE
+			a.entry_id IN (?)
+		ORDER BY
+			t.id
+	`
+	return func(ctx context.Context, keys []int64) (map[int64][]attributeRow, error) {
+		var rows []attributeRow
+		if err := selectIn(ctx, db, &rows, query, keys); err != nil {
+			return nil, err
+		}
+		return group(rows, func(r attributeRow) int64 { return r.EntryID }), nil
+	}
+}
+
+func fetchRadarCharts(db *database.DB) func(context.Context, []int64) (map[int64]*radarRow, error) {
+	return func(ctx context.Context, keys []int64) (map[int64]*radarRow, error) {
+		var rows []radarRow
+		query := `
+			SELECT
+				entry_id,
+				ai,
+				nu
+			FROM
+				heki_radar_chart
+			WHERE
+				entry_id IN (?)
+		`
+		if err := selectIn(ctx, db, &rows, query, keys); err != nil {
+			return nil, err
+		}
+		m := make(map[int64]*radarRow, len(rows))
+		for i := range rows {
+			m[rows[i].EntryID] = &rows[i]
+		}
+		return m, nil
+	}
+}
+
+// fetchEntriesBy はsource_idやtag_idごとにentryを取得する
+func fetchEntriesBy(db *database.DB, query string) func(context.Context, []int64) (map[int64][]entryRow, error) {
+	return func(ctx context.Context, keys []int64) (map[int64][]entryRow, error) {
+		var rows []keyedEntryRow
+		if err := selectIn(ctx, db, &rows, query, keys); err != nil {
+			return nil, err
+		}
+		m := make(map[int64][]entryRow, len(keys))
+		for _, r := range rows {
+			m[r.Key] = append(m[r.Key], r.entryRow)
+		}
+		return m, nil
+	}
+}
+
+var entriesBySourceQuery = `
+	SELECT
+		e.source_id AS key,` + entryColumns + `
+	FROM
+		entry e
+	WHERE
+		e.source_id IN (?)
+	ORDER BY
+		e.id
+`
+
+var entriesByTagQuery = `
+	SELECT
+		et.tag_id AS key,` + entryColumns + `
+	FROM
+		entry e
+		JOIN entry_tag et ON et.entry_id = e.id
+	WHERE
+		et.tag_id IN (?)
+	ORDER BY
+		e.id
+`
+
+func group[T any](rows []T, key func(T) int64) map[int64][]T {
+	m := map[int64][]T{}
+	for _, r := range rows {
+		m[key(r)] = append(m[key(r)], r)
+	}
+	return m
+}
@@ -0,0 +1,643 @@
+package jsonapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"maguro-alternative/varcel-go/pkg/catalog"
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/store"
+	"maguro-alternative/varcel-go/pkg/timing"
+)
+
+// MediaType はJSON:APIのメディアタイプ
+const MediaType = "application/vnd.api+json"
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+// Authorizer はロールがリソースを読めるかを確認し、読めない場合は理由を返す
+// 関連先(includeとrelationships)を取得する前に呼ぶ。rbacが設定する
+// (rbacは403を書くのにresponseを通してjsonapiを使うため、jsonapiからrbacをimportすると循環する)
+var Authorizer func(ctx context.Context, resource string) (denied string, err error)
+
+// Accepts はクライアントがJSON:APIを要求しているかを判定する
+func Accepts(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			mediaType, _, _ := strings.Cut(part, ";")
+			if strings.TrimSpace(mediaType) == MediaType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type Document struct {
+	Data     interface{}            `json:"data,omitempty"`
+	Included []*Resource            `json:"included,omitempty"`
+	Links    map[string]string      `json:"links,omitempty"`
+	Meta     map[string]interface{} `json:"meta,omitempty"`
+	Errors   []ErrorObject          `json:"errors,omitempty"`
+}
+
+type Resource struct {
+	Type          string                   `json:"type"`
+	ID            string                   `json:"id"`
+	Attributes    map[string]interface{}   `json:"attributes,omitempty"`
+	Relationships map[string]*Relationship `json:"relationships,omitempty"`
+}
+
+type Identifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// Relationship のDataは *Identifier, []Identifier, nil のいずれか
+type Relationship struct {
+	Data interface{} `json:"data"`
+}
+
+type ErrorObject struct {
+	Status string `json:"status"`
+	Title  string `json:"title"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// requestError はエラーオブジェクトのドキュメントで返すエラー
+// クエリパラメータの誤りは400、関連先を読む権限がない場合は403
+type requestError struct {
+	status int
+	detail string
+}
+
+func (e *requestError) Error() string {
+	return e.detail
+}
+
+// options はinclude, fields, pageの指定
+type options struct {
+	include []string
+	fields  map[string]map[string]bool
+	offset  int
+	limit   int
+}
+
+// Marshal はハンドラのレスポンス({"entries": [...]} など)をJSON:APIのドキュメントに変換する
+// クエリパラメータに誤りがある場合は400、関連先を読む権限がない場合は403とエラーオブジェクトのドキュメントを返す
+func Marshal(r *http.Request, v interface{}) (int, []byte, error) {
+	doc, err := build(r, v)
+	status := http.StatusOK
+	if err != nil {
+		var reqErr *requestError
+		if !asRequestError(err, &reqErr) {
+			return 0, nil, err
+		}
+		status = reqErr.status
+		if status == 0 {
+			status = http.StatusBadRequest
+		}
+		doc = &Document{Errors: []ErrorObject{{
+			Status: strconv.Itoa(status),
+			Title:  http.StatusText(status),
+			Detail: reqErr.detail,
+		}}}
+	}
+	if rec := timing.FromContext(r.Context()); rec != nil && rec.Debug {
+		if doc.Meta == nil {
+			doc.Meta = map[string]interface{}{}
+		}
+		doc.Meta["debug"] = map[string]interface{}{"queries": rec.Queries()}
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return 0, nil, err
+	}
+	return status, b, nil
+}
+
+func asRequestError(err error, target **requestError) bool {
+	e, ok := err.(*requestError)
+	if ok {
+		*target = e
+	}
+	return ok
+}
+
+// paged はstoreでページングした結果と全体の件数。buildはそれ以上ページングしない
+type paged struct {
+	v     interface{}
+	total int
+}
+
+// WithTotal はListで取得したレスポンスに全体の件数を付ける。totalが負の場合はvをそのまま返す
+func WithTotal(v interface{}, total int) interface{} {
+	if total < 0 {
+		return v
+	}
+	return &paged{v: v, total: total}
+}
+
+// List はkeysの行(keysが空の場合は全件)をrepoから取得する
+// JSON:APIを要求された場合はpage[offset]とpage[limit]の範囲だけをstoreのクエリで取得し、全体の件数を返す
+// それ以外は全件を取得し、件数は-1を返す。件数はWithTotalでレスポンスに付ける
+func List[T any](r *http.Request, repo interface {
+	List(ctx context.Context, keys []int64) ([]T, error)
+}, keys []int64) ([]T, int, error) {
+	pager, ok := repo.(store.Pager[T])
+	if !ok || !Accepts(r) {
+		rows, err := repo.List(r.Context(), keys)
+		return rows, -1, err
+	}
+	offset, limit, err := parsePage(r.URL.Query())
+	if err != nil {
+		// 誤りはMarshalでエラーオブジェクトにする
+		rows, err := repo.List(r.Context(), keys)
+		return rows, -1, err
+	}
+	rows, total, err := pager.Page(r.Context(), keys, store.Page{Limit: limit, Offset: offset})
+	return rows, int(total), err
+}
+
+func build(r *http.Request, v interface{}) (*Document, error) {
+	total := -1
+	if p, ok := v.(*paged); ok {
+		v, total = p.v, p.total
+	}
+	name, items, ok := collection(v)
+	if !ok {
+		// {"ids": [...]} などリソースではないレスポンスはmetaとして返す
+		return &Document{Meta: map[string]interface{}{"result": v}}, nil
+	}
+	typ := types[name]
+	opts, err := parseOptions(r.URL.Query(), name, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	// storeでページングしていない場合はここで切り出す
+	if total < 0 {
+		total = len(items)
+		start := min(opts.offset, total)
+		end := min(start+opts.limit, total)
+		items = items[start:end]
+	}
+
+	primary := make([]*Resource, 0, len(items))
+	for _, item := range items {
+		res, err := fromStruct(name, item)
+		if err != nil {
+			return nil, err
+		}
+		primary = append(primary, res)
+	}
+
+	l := &linker{ctx: r.Context(), allowed: map[string]bool{}}
+	defer l.close()
+	if err := l.link(name, typ, primary, opts, true); err != nil {
+		return nil, err
+	}
+	included, err := l.include(name, typ, primary, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range primary {
+		opts.sparse(res)
+	}
+	for _, res := range included {
+		opts.sparse(res)
+	}
+
+	return &Document{
+		Data:     primary,
+		Included: included,
+		Links:    pageLinks(r.URL, opts, total),
+		Meta:     map[string]interface{}{"total": total},
+	}, nil
+}
+
+// collection は {"entries": [...]} の形の値からリソース名と要素を取り出す
+func collection(v interface{}) (string, []interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || rv.NumField() != 1 {
+		return "", nil, false
+	}
+	key, _, _ := strings.Cut(rv.Type().Field(0).Tag.Get("json"), ",")
+	var name string
+	for _, res := range catalog.Resources {
+		if res.CollectionKey == key {
+			name = res.Name
+		}
+	}
+	if _, ok := types[name]; !ok || rv.Field(0).Kind() != reflect.Slice {
+		return "", nil, false
+	}
+	items := make([]interface{}, rv.Field(0).Len())
+	for i := range items {
+		items[i] = rv.Field(0).Index(i).Interface()
+	}
+	return name, items, true
+}
+
+func parseOptions(q url.Values, name string, typ *resourceType) (*options, error) {
+	opts := &options{fields: map[string]map[string]bool{}, limit: defaultLimit}
+	if include := q.Get("include"); include != "" {
+		for _, rel := range strings.Split(include, ",") {
+			if _, ok := typ.relationship(rel); !ok {
+				return nil, &requestError{detail: fmt.Sprintf("%s has no relationship %q", name, rel)}
+			}
+			opts.include = append(opts.include, rel)
+		}
+	}
+	for key, values := range q {
+		t, ok := strings.CutPrefix(key, "fields[")
+		if !ok || !strings.HasSuffix(t, "]") {
+			continue
+		}
+		fields := map[string]bool{}
+		for _, f := range strings.Split(values[0], ",") {
+			if f != "" {
+				fields[f] = true
+			}
+		}
+		opts.fields[strings.TrimSuffix(t, "]")] = fields
+	}
+	var err error
+	opts.offset, opts.limit, err = parsePage(q)
+	if err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// parsePage はpage[offset]とpage[limit]を読む
+func parsePage(q url.Values) (offset, limit int, err error) {
+	limit = defaultLimit
+	if s := q.Get("page[offset]"); s != "" {
+		if offset, err = strconv.Atoi(s); err != nil || offset < 0 {
+			return 0, 0, &requestError{detail: "page[offset] must be a non-negative integer"}
+		}
+	}
+	if s := q.Get("page[limit]"); s != "" {
+		if limit, err = strconv.Atoi(s); err != nil || limit < 1 || limit > maxLimit {
+			return 0, 0, &requestError{detail: fmt.Sprintf("page[limit] must be between 1 and %d", maxLimit)}
+		}
+	}
+	return offset, limit, nil
+}
+
+// wants はfields[type]の指定でフィールドを返すかどうかを判定する
+func (o *options) wants(typ, field string) bool {
+	fields, ok := o.fields[typ]
+	return !ok || fields[field]
+}
+
+func (o *options) sparse(res *Resource) {
+	for k := range res.Attributes {
+		if !o.wants(res.Type, k) {
+			delete(res.Attributes, k)
+		}
+	}
+	for k := range res.Relationships {
+		if !o.wants(res.Type, k) {
+			delete(res.Relationships, k)
+		}
+	}
+}
+
+func (o *options) included(rel string) bool {
+	for _, name := range o.include {
+		if name == rel {
+			return true
+		}
+	}
+	return false
+}
+
+// fromStruct はjsonタグの名前を属性名としてリソースに変換する
+func fromStruct(name string, item interface{}) (*Resource, error) {
+	attrs, err := toAttributes(item)
+	if err != nil {
+		return nil, err
+	}
+	return fromAttributes(name, attrs), nil
+}
+
+// toAttributes はjsonタグの名前をキーにした値にする
+func toAttributes(item interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	attrs := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&attrs); err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+// fromAttributes はidを組み立て、JSON:APIで予約されているidとtypeを属性から外す
+func fromAttributes(name string, attrs map[string]interface{}) *Resource {
+	id := types[name].id(attrs)
+	delete(attrs, "id")
+	if v, ok := attrs["type"]; ok {
+		attrs["kind"] = v
+		delete(attrs, "type")
+	}
+	return &Resource{Type: name, ID: id, Attributes: attrs}
+}
+
+// id は主キーの列の値からidを組み立てる
+func (t *resourceType) id(row map[string]interface{}) string {
+	parts := make([]string, len(t.idColumns))
+	for i, col := range t.idColumns {
+		parts[i] = fmt.Sprint(row[col])
+	}
+	return strings.Join(parts, "-")
+}
+
+// linker は関連の解決に必要な時だけDBに接続し、storeのリポジトリで関連先を取得する
+// 関連先を取得する前にAuthorizerで読む権限を確認する
+type linker struct {
+	ctx context.Context
+	db  *database.DB
+	// allowed は読む権限を確認したリソース
+	allowed map[string]bool
+}
+
+func (l *linker) conn() (*database.DB, error) {
+	if l.db != nil {
+		return l.db, nil
+	}
+	db, err := database.Open(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+	l.db = db
+	return db, nil
+}
+
+func (l *linker) close() {
+	if l.db != nil {
+		l.db.Close()
+	}
+}
+
+// authorize はリソースを読めない場合に403のエラーを返す
+func (l *linker) authorize(resource string) error {
+	if l.allowed[resource] {
+		return nil
+	}
+	if Authorizer == nil {
+		return fmt.Errorf("jsonapi: no authorizer to read %s", resource)
+	}
+	denied, err := Authorizer(l.ctx, resource)
+	if err != nil {
+		return err
+	}
+	if denied != "" {
+		return &requestError{status: http.StatusForbidden, detail: denied}
+	}
+	l.allowed[resource] = true
+	return nil
+}
+
+// link はリソースのrelationshipsを埋める
+// reverseがfalseの場合はクエリの必要なhas one / has manyを解決しない
+func (l *linker) link(name string, typ *resourceType, resources []*Resource, opts *options, reverse bool) error {
+	for _, rel := range typ.relationships {
+		if !opts.wants(name, rel.name) && !opts.included(rel.name) {
+			continue
+		}
+		if rel.foreignKey != "" {
+			for _, res := range resources {
+				res.setRelationship(rel, foreignID(res.Attributes[rel.foreignKey]))
+			}
+			continue
+		}
+		// クエリの必要な関連はincludeかfieldsで明示された場合だけ解決する
+		_, explicit := opts.fields[name]
+		if !reverse || len(resources) == 0 || !(explicit || opts.included(rel.name)) {
+			continue
+		}
+		linkage, err := l.reverse(rel, resources)
+		if err != nil {
+			return err
+		}
+		for _, res := range resources {
+			res.setRelationship(rel, linkage[res.ID]...)
+		}
+	}
+	return nil
+}
+
+func (res *Resource) setRelationship(rel relationship, ids ...string) {
+	if res.Relationships == nil {
+		res.Relationships = map[string]*Relationship{}
+	}
+	if rel.toMany {
+		data := make([]Identifier, 0, len(ids))
+		for _, id := range ids {
+			data = append(data, Identifier{Type: rel.target, ID: id})
+		}
+		res.Relationships[rel.name] = &Relationship{Data: data}
+		return
+	}
+	if len(ids) == 0 || ids[0] == "" {
+		res.Relationships[rel.name] = &Relationship{Data: nil}
+		return
+	}
+	res.Relationships[rel.name] = &Relationship{Data: &Identifier{Type: rel.target, ID: ids[0]}}
+}
+
+func foreignID(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	s := fmt.Sprint(v)
+	if s == "0" {
+		return ""
+	}
+	return s
+}
+
+// reverse は関連先のIDを所有者(entry)のIDごとにまとめて取得する
+func (l *linker) reverse(rel relationship, resources []*Resource) (map[string][]string, error) {
+	via := rel.via
+	if via == "" {
+		via = rel.target
+	}
+	if err := l.authorize(via); err != nil {
+		return nil, err
+	}
+	owners := make([]int64, 0, len(resources))
+	for _, res := range resources {
+		if id, err := strconv.ParseInt(res.ID, 10, 64); err == nil {
+			owners = append(owners, id)
+		}
+	}
+	if len(owners) == 0 {
+		return nil, nil
+	}
+	db, err := l.conn()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := rel.owned(l.ctx, db, owners)
+	if err != nil {
+		return nil, err
+	}
+	target := types[rel.target]
+	linkage := map[string][]string{}
+	for _, row := range rows {
+		attrs, err := toAttributes(row)
+		if err != nil {
+			return nil, err
+		}
+		owner := fmt.Sprint(attrs["entry_id"])
+		id := target.id(attrs)
+		if rel.targetKey != "" {
+			id = fmt.Sprint(attrs[rel.targetKey])
+		}
+		linkage[owner] = append(linkage[owner], id)
+	}
+	return linkage, nil
+}
+
+// include はincludeで指定された関連先のリソースを取得する
+func (l *linker) include(name string, typ *resourceType, primary []*Resource, opts *options) ([]*Resource, error) {
+	var included []*Resource
+	seen := map[Identifier]bool{}
+	for _, relName := range opts.include {
+		rel, _ := typ.relationship(relName)
+		var ids []string
+		for _, res := range primary {
+			for _, id := range res.linkage(relName) {
+				key := Identifier{Type: rel.target, ID: id}
+				if !seen[key] {
+					seen[key] = true
+					ids = append(ids, id)
+				}
+			}
+			// includeのためだけに解決した関連はfieldsの指定に従って外す
+			if !opts.wants(name, relName) {
+				delete(res.Relationships, relName)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		resources, err := l.fetch(rel.target, ids)
+		if err != nil {
+			return nil, err
+		}
+		if err := l.link(rel.target, types[rel.target], resources, opts, false); err != nil {
+			return nil, err
+		}
+		included = append(included, resources...)
+	}
+	return included, nil
+}
+
+func (res *Resource) linkage(name string) []string {
+	rel, ok := res.Relationships[name]
+	if !ok {
+		return nil
+	}
+	switch data := rel.Data.(type) {
+	case *Identifier:
+		return []string{data.ID}
+	case []Identifier:
+		ids := make([]string, len(data))
+		for i, d := range data {
+			ids[i] = d.ID
+		}
+		return ids
+	}
+	return nil
+}
+
+// fetch はidを指定してリソースを取得する
+// 複合キーのidは最初の列の値で取得してから、idが一致するものだけを返す
+func (l *linker) fetch(name string, ids []string) ([]*Resource, error) {
+	if err := l.authorize(name); err != nil {
+		return nil, err
+	}
+	typ := types[name]
+	wanted := make(map[string]bool, len(ids))
+	var keys []int64
+	for _, id := range ids {
+		first, _, _ := strings.Cut(id, "-")
+		n, err := strconv.ParseInt(first, 10, 64)
+		if err != nil {
+			continue
+		}
+		if !wanted[id] {
+			wanted[id] = true
+			keys = append(keys, n)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	db, err := l.conn()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := typ.list(l.ctx, db, keys)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]*Resource, 0, len(rows))
+	for _, row := range rows {
+		res, err := fromStruct(name, row)
+		if err != nil {
+			return nil, err
+		}
+		if wanted[res.ID] {
+			resources = append(resources, res)
+		}
+	}
+	return resources, nil
+}
+
+// pageLinks はpage[offset]とpage[limit]によるページングのリンクを作成する
+func pageLinks(u *url.URL, opts *options, total int) map[string]string {
+	link := func(offset int) string {
+		q := u.Query()
+		q.Set("page[offset]", strconv.Itoa(offset))
+		q.Set("page[limit]", strconv.Itoa(opts.limit))
+		return u.Path + "?" + q.Encode()
+	}
+	last := 0
+	if total > 0 {
+		last = (total - 1) / opts.limit * opts.limit
+	}
+	links := map[string]string{
+		"self":  link(opts.offset),
+		"first": link(0),
+		"last":  link(last),
+	}
+	if opts.offset > 0 {
+		links["prev"] = link(max(opts.offset-opts.limit, 0))
+	}
+	if opts.offset+opts.limit < total {
+		links["next"] = link(opts.offset + opts.limit)
+	}
+	return links
+}
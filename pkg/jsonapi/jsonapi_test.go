@@ -0,0 +1,308 @@
+package jsonapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/dbtest"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+type entriesJson struct {
+	Entries []model.Entry `json:"entries"`
+}
+
+// document はテストで読みやすいようにdataを配列で受け取る
+type document struct {
+	Data     []Resource             `json:"data"`
+	Included []Resource             `json:"included"`
+	Links    map[string]string      `json:"links"`
+	Meta     map[string]interface{} `json:"meta"`
+	Errors   []ErrorObject          `json:"errors"`
+}
+
+// setup はsource, tag, entryを登録し、denyに含むリソースを読めないAuthorizerを設定する
+func setup(t *testing.T, deny ...string) *database.DB {
+	t.Helper()
+	db := dbtest.Open(t)
+	dbtest.Exec(t, db, `INSERT INTO source (name, url, type) VALUES ('作品A', 'https://a.example', 'anime')`)
+	dbtest.Exec(t, db, `INSERT INTO source (name, url, type) VALUES ('作品B', 'https://b.example', 'game')`)
+	dbtest.Exec(t, db, `INSERT INTO tag (name) VALUES ('眼鏡'), ('ツインテール')`)
+	for _, e := range []struct {
+		source int
+		name   string
+	}{{1, "一号"}, {1, "二号"}, {2, "三号"}} {
+		dbtest.Exec(t, db, `INSERT INTO entry (source_id, name, image, content) VALUES (?, ?, '', '')`, e.source, e.name)
+	}
+	dbtest.Exec(t, db, `INSERT INTO entry_tag (entry_id, tag_id) VALUES (1, 1), (1, 2), (3, 2)`)
+
+	prev := Authorizer
+	t.Cleanup(func() { Authorizer = prev })
+	Authorizer = func(ctx context.Context, resource string) (string, error) {
+		for _, d := range deny {
+			if d == resource {
+				return "viewer cannot read " + resource, nil
+			}
+		}
+		return "", nil
+	}
+	return db
+}
+
+func request(target string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	r.Header.Set("Accept", MediaType)
+	return r
+}
+
+// get はハンドラと同じくstoreのentryをListで取得してドキュメントにする
+func get(t *testing.T, db *database.DB, target string) (int, *document) {
+	t.Helper()
+	r := request(target)
+	var v entriesJson
+	var total int
+	var err error
+	v.Entries, total, err = List(r, &store.Entries{DB: db}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, b, err := Marshal(r, WithTotal(&v, total))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc document
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return status, &doc
+}
+
+func ids(resources []Resource) []string {
+	var ids []string
+	for _, res := range resources {
+		ids = append(ids, res.Type+":"+res.ID)
+	}
+	return ids
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestList(t *testing.T) {
+	db := setup(t)
+	repo := &store.Entries{DB: db}
+
+	// JSON:APIの場合はstoreでページングする
+	entries, total, err := List(request("/api/v1/entry?page[offset]=1&page[limit]=1"), repo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(entries) != 1 || entries[0].ID != 2 {
+		t.Errorf("total = %d, entries = %+v", total, entries)
+	}
+
+	// それ以外は全件取得する
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/entry?page[limit]=1", nil)
+	entries, total, err = List(r, repo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != -1 || len(entries) != 3 {
+		t.Errorf("total = %d, entries = %d", total, len(entries))
+	}
+
+	// メモリの実装も同じ
+	mem := store.NewMemoryEntries(store.NewMemoryEntryTags())
+	for _, e := range []model.Entry{{SourceID: 1, Name: "a"}, {SourceID: 1, Name: "b"}} {
+		if _, err := mem.Create(context.Background(), []model.Entry{e}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, total, err = List(request("/api/v1/entry?page[limit]=1"), mem, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || len(entries) != 1 {
+		t.Errorf("memory: total = %d, entries = %d", total, len(entries))
+	}
+}
+
+func TestDocument(t *testing.T) {
+	db := setup(t)
+	status, doc := get(t, db, "/api/v1/entry?page[limit]=2")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, errors = %+v", status, doc.Errors)
+	}
+	if got := ids(doc.Data); !equal(got, []string{"entry:1", "entry:2"}) {
+		t.Errorf("data = %v", got)
+	}
+	first := doc.Data[0]
+	if first.Attributes["name"] != "一号" {
+		t.Errorf("attributes = %v", first.Attributes)
+	}
+	if _, ok := first.Attributes["id"]; ok {
+		t.Error("id should not be an attribute")
+	}
+	source, _ := first.Relationships["source"].Data.(map[string]interface{})
+	if source["type"] != "source" || source["id"] != "1" {
+		t.Errorf("relationships.source = %v", first.Relationships["source"])
+	}
+	// クエリの必要な関連はincludeかfieldsで指定しない限り解決しない
+	if _, ok := first.Relationships["tags"]; ok {
+		t.Error("tags should not be resolved without include")
+	}
+	if doc.Meta["total"] != float64(3) {
+		t.Errorf("meta.total = %v", doc.Meta["total"])
+	}
+	next, err := url.Parse(doc.Links["next"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.Query().Get("page[offset]") != "2" || next.Query().Get("page[limit]") != "2" {
+		t.Errorf("links.next = %s", doc.Links["next"])
+	}
+	if _, ok := doc.Links["prev"]; ok {
+		t.Errorf("links.prev = %s", doc.Links["prev"])
+	}
+}
+
+func TestInclude(t *testing.T) {
+	db := setup(t)
+	status, doc := get(t, db, "/api/v1/entry?include=source,tags")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, errors = %+v", status, doc.Errors)
+	}
+	want := []string{"source:1", "source:2", "tag:1", "tag:2"}
+	if got := ids(doc.Included); !equal(got, want) {
+		t.Errorf("included = %v, want %v", got, want)
+	}
+	tags, _ := doc.Data[0].Relationships["tags"].Data.([]interface{})
+	if len(tags) != 2 {
+		t.Errorf("entry 1 tags = %v", tags)
+	}
+	if tags, _ := doc.Data[1].Relationships["tags"].Data.([]interface{}); len(tags) != 0 {
+		t.Errorf("entry 2 tags = %v", tags)
+	}
+	for _, res := range doc.Included {
+		if res.Type == "source" && res.ID == "2" && res.Attributes["kind"] != "game" {
+			t.Errorf("source 2 attributes = %v", res.Attributes)
+		}
+	}
+}
+
+func TestIncludeForbidden(t *testing.T) {
+	tests := []struct {
+		name    string
+		deny    string
+		include string
+	}{
+		{"belongs to", "source", "source"},
+		{"has many via", "entry_tag", "tags"},
+		{"has many target", "tag", "tags"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setup(t, tt.deny)
+			status, doc := get(t, db, "/api/v1/entry?include="+tt.include)
+			if status != http.StatusForbidden {
+				t.Fatalf("status = %d", status)
+			}
+			if len(doc.Data) != 0 || len(doc.Included) != 0 {
+				t.Errorf("forbidden document has data: %+v", doc)
+			}
+			want := ErrorObject{Status: "403", Title: "Forbidden", Detail: "viewer cannot read " + tt.deny}
+			if len(doc.Errors) != 1 || doc.Errors[0] != want {
+				t.Errorf("errors = %+v", doc.Errors)
+			}
+		})
+	}
+
+	// Authorizerが設定されていない場合は関連先を返さない
+	db := setup(t)
+	Authorizer = nil
+	r := request("/api/v1/entry?include=source")
+	v := &entriesJson{}
+	var err error
+	if v.Entries, err = (&store.Entries{DB: db}).List(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Marshal(r, v); err == nil {
+		t.Error("include without Authorizer should fail")
+	}
+}
+
+func TestSparseFieldsets(t *testing.T) {
+	db := setup(t)
+	status, doc := get(t, db, "/api/v1/entry?fields[entry]=name&page[limit]=1")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, errors = %+v", status, doc.Errors)
+	}
+	res := doc.Data[0]
+	if len(res.Attributes) != 1 || res.Attributes["name"] != "一号" {
+		t.Errorf("attributes = %v", res.Attributes)
+	}
+	if len(res.Relationships) != 0 {
+		t.Errorf("relationships = %v", res.Relationships)
+	}
+
+	// includeのためだけに解決した関連はfieldsに従って外し、関連先もfieldsで絞る
+	status, doc = get(t, db, "/api/v1/entry?include=source&fields[entry]=name&fields[source]=name&page[limit]=1")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, errors = %+v", status, doc.Errors)
+	}
+	if len(doc.Data[0].Relationships) != 0 {
+		t.Errorf("relationships = %v", doc.Data[0].Relationships)
+	}
+	if len(doc.Included) != 1 || len(doc.Included[0].Attributes) != 1 || doc.Included[0].Attributes["name"] != "作品A" {
+		t.Errorf("included = %+v", doc.Included)
+	}
+
+	// fieldsで指定した関連はincludeしなくても解決する
+	status, doc = get(t, db, "/api/v1/entry?fields[entry]=tags&page[limit]=1")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, errors = %+v", status, doc.Errors)
+	}
+	if tags, _ := doc.Data[0].Relationships["tags"].Data.([]interface{}); len(tags) != 2 || len(doc.Included) != 0 {
+		t.Errorf("relationships = %v, included = %v", doc.Data[0].Relationships, doc.Included)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	db := setup(t)
+	tests := []struct {
+		target string
+		detail string
+	}{
+		{"/api/v1/entry?include=friends", `entry has no relationship "friends"`},
+		{"/api/v1/entry?page[offset]=-1", "page[offset] must be a non-negative integer"},
+		{"/api/v1/entry?page[limit]=0", "page[limit] must be between 1 and 1000"},
+		{"/api/v1/entry?page[limit]=x", "page[limit] must be between 1 and 1000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			status, doc := get(t, db, tt.target)
+			if status != http.StatusBadRequest {
+				t.Fatalf("status = %d", status)
+			}
+			want := ErrorObject{Status: "400", Title: "Bad Request", Detail: tt.detail}
+			if len(doc.Errors) != 1 || doc.Errors[0] != want {
+				t.Errorf("errors = %+v", doc.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package jsonapi
+
+import (
+	"context"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// relationship はリソース間の関連
+//
+// foreignKeyが指定されている場合は自身の属性から関連先のIDを求める(belongs to)。
+// ownedが指定されている場合はentryのidごとに関連の行をstoreから取得し、関連先のIDを求める(has one / has many)
+type relationship struct {
+	name       string
+	target     string
+	toMany     bool
+	foreignKey string
+	// via は関連の行のリソース名。読む権限を確認するのに使う。空の場合はtargetと同じ
+	via string
+	// targetKey は関連の行で関連先のIDを持つ属性。空の場合は関連先のtypeのidと同じ
+	targetKey string
+	owned     lister
+}
+
+// lister はキーを指定してstoreから行を取得する
+type lister func(ctx context.Context, db *database.DB, keys []int64) ([]interface{}, error)
+
+// resourceType はJSON:APIのtypeとstoreの対応
+type resourceType struct {
+	// idColumns は主キーの列。複合キーのidは値を"-"でつなげる
+	idColumns     []string
+	relationships []relationship
+	// list は主キー(複合キーの場合は最初の列)を指定して行を取得する
+	list lister
+}
+
+func (t *resourceType) relationship(name string) (relationship, bool) {
+	for _, rel := range t.relationships {
+		if rel.name == name {
+			return rel, true
+		}
+	}
+	return relationship{}, false
+}
+
+func byID(list lister, rels ...relationship) *resourceType {
+	return &resourceType{idColumns: []string{"id"}, relationships: rels, list: list}
+}
+
+func byEntryID(list lister, rels ...relationship) *resourceType {
+	rels = append([]relationship{belongsTo("entry", "entry", "entry_id")}, rels...)
+	return &resourceType{idColumns: []string{"entry_id"}, relationships: rels, list: list}
+}
+
+func belongsTo(name, target, foreignKey string) relationship {
+	return relationship{name: name, target: target, foreignKey: foreignKey}
+}
+
+// hasOne はentry_idを主キーに持つテーブルへの関連。listは関連先のtypeと同じもの
+func hasOne(name, target string, list lister) relationship {
+	return relationship{name: name, target: target, owned: list}
+}
+
+func hasMany(name, target string, owned lister) relationship {
+	return relationship{name: name, target: target, toMany: true, owned: owned}
+}
+
+// rows はstoreの結果をinterface{}の配列にする
+func rows[T any](items []T, err error) ([]interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(items))
+	for i := range items {
+		values[i] = items[i]
+	}
+	return values, nil
+}
+
+func listEntries(ctx context.Context, db *database.DB, keys []int64) ([]interface{}, error) {
+	return rows((&store.Entries{DB: db}).List(ctx, keys))
+}
+
+func listSources(ctx context.Context, db *database.DB, keys []int64) ([]interface{}, error) {
+	return rows((&store.Sources{DB: db}).List(ctx, keys))
+}
+
+func listTags(ctx context.Context, db *database.DB, keys []int64) ([]interface{}, error) {
+	return rows((&store.Tags{DB: db}).List(ctx, keys))
+}
+
+func listEntryTags(ctx context.Context, db *database.DB, keys []int64) ([]interface{}, error) {
+	return rows((&store.EntryTags{DB: db}).List(ctx, keys))
+}
+
+func listLinks(ctx context.Context, db *database.DB, keys []int64) ([]interface{}, error) {
+	return rows((&store.Links{DB: db}).List(ctx, keys))
+}
+
+func listBWHs(ctx context.Context, db *database.DB, keys []int64) ([]interface{}, error) {
+	return rows((&store.BWHs{DB: db}).List(ctx, keys))
+}
+
+func listHekiRadarCharts(ctx context.Context, db *database.DB, keys []int64) ([]interface{}, error) {
+	return rows((&store.HekiRadarCharts{DB: db}).List(ctx, keys))
+}
+
+// tagsOf はentryのタグをentry_tagから求める
+func tagsOf(ctx context.Context, db *database.DB, entryIDs []int64) ([]interface{}, error) {
+	return rows((&store.EntryTags{DB: db}).ByEntries(ctx, entryIDs))
+}
+
+func linksOf(ctx context.Context, db *database.DB, entryIDs []int64) ([]interface{}, error) {
+	return rows((&store.Links{DB: db}).ByEntries(ctx, entryIDs))
+}
+
+// attributes は属性テーブルの行を、ハンドラと同じ列名(color_id, type_id, ...)の属性にする
+func attributes(table model.AttributeTable) lister {
+	return func(ctx context.Context, db *database.DB, entryIDs []int64) ([]interface{}, error) {
+		attrs, err := (&store.Attributes{DB: db, Table: table}).List(ctx, entryIDs)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]interface{}, len(attrs))
+		for i, a := range attrs {
+			values[i] = map[string]interface{}{"entry_id": a.EntryID, table.Column: a.ValueID}
+		}
+		return values, nil
+	}
+}
+
+// typeValues は*_typeテーブルの行を、ハンドラと同じ列名(color, style, ...)の属性にする
+func typeValues(table model.TypeTable) lister {
+	return func(ctx context.Context, db *database.DB, ids []int64) ([]interface{}, error) {
+		values, err := (&store.Types{DB: db, Table: table}).List(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(values))
+		for i, v := range values {
+			items[i] = map[string]interface{}{"id": v.ID, table.Column: v.Value}
+		}
+		return items, nil
+	}
+}
+
+var (
+	listHairColors    = attributes(model.HairColorTable)
+	listEyeColors     = attributes(model.EyeColorTable)
+	listHairStyles    = attributes(model.HairStyleTable)
+	listHairLengths   = attributes(model.HairLengthTable)
+	listPersonalities = attributes(model.PersonalityTable)
+)
+
+// types はJSON:APIのtypeの一覧。typeの名前はカタログのリソース名と同じ
+var types = map[string]*resourceType{
+	"entry": byID(listEntries,
+		belongsTo("source", "source", "source_id"),
+		relationship{name: "tags", target: "tag", toMany: true, via: "entry_tag", targetKey: "tag_id", owned: tagsOf},
+		hasMany("links", "link", linksOf),
+		hasOne("bwh", "bwh", listBWHs),
+		hasOne("haircolor", "haircolor", listHairColors),
+		hasOne("eyescolor", "eyescolor", listEyeColors),
+		hasOne("hairstyle", "hairstyle", listHairStyles),
+		hasOne("hairlength", "hairlength", listHairLengths),
+		hasMany("personalities", "personality", listPersonalities),
+		hasOne("heki_radar_chart", "heki_radar_chart", listHekiRadarCharts),
+	),
+	"source": byID(listSources),
+	"tag":    byID(listTags),
+	"entry_tag": byID(listEntryTags,
+		belongsTo("entry", "entry", "entry_id"),
+		belongsTo("tag", "tag", "tag_id"),
+	),
+	"link": byID(listLinks,
+		belongsTo("entry", "entry", "entry_id"),
+	),
+	"bwh":              byEntryID(listBWHs),
+	"heki_radar_chart": byEntryID(listHekiRadarCharts),
+	"haircolor":        byEntryID(listHairColors, belongsTo("color", "haircolor_type", "color_id")),
+	"eyescolor":        byEntryID(listEyeColors, belongsTo("color", "eyescolor_type", "color_id")),
+	"hairstyle":        byEntryID(listHairStyles, belongsTo("style", "hairstyle_type", "style_id")),
+	"hairlength":       byEntryID(listHairLengths, belongsTo("length", "hairlength_type", "hairlength_type_id")),
+	"personality": {
+		idColumns: []string{"entry_id", "type_id"},
+		relationships: []relationship{
+			belongsTo("entry", "entry", "entry_id"),
+			belongsTo("personality_type", "personality_type", "type_id"),
+		},
+		list: listPersonalities,
+	},
+	"haircolor_type":   byID(typeValues(model.HairColorTypeTable)),
+	"eyescolor_type":   byID(typeValues(model.EyeColorTypeTable)),
+	"hairstyle_type":   byID(typeValues(model.HairStyleTypeTable)),
+	"hairlength_type":  byID(typeValues(model.HairLengthTypeTable)),
+	"personality_type": byID(typeValues(model.PersonalityTypeTable)),
+}
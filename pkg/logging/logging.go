@@ -0,0 +1,157 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Logger はJSON形式で1行ずつ出力する共通ロガー
+var Logger = New(os.Stdout)
+
+// ログに出力してはいけないキー
+var sensitiveKeys = map[string]bool{
+	"authorization": true,
+	"cookie":        true,
+	"password":      true,
+	"token":         true,
+	"api_key":       true,
+	"secret":        true,
+	"body":          true,
+}
+
+func New(w io.Writer) *slog.Logger {
+	h := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		ReplaceAttr: redact,
+	})
+	return slog.New(&contextHandler{Handler: h})
+}
+
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if sensitiveKeys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, "[REDACTED]")
+	}
+	return a
+}
+
+// contextHandler はcontextに載っているリクエストIDとトレースIDを全てのログに付与する
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if req := FromContext(ctx); req != nil {
+		r.AddAttrs(slog.String("request_id", req.ID))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// Request はリクエスト単位でアクセスログに載せる値を保持する
+type Request struct {
+	ID string
+
+	mu         sync.Mutex
+	dbTime     time.Duration
+	errorClass string
+}
+
+type requestKey struct{}
+
+func NewContext(ctx context.Context, req *Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, req)
+}
+
+// FromContext はcontextからRequestを取り出す。無い場合はnilを返す
+func FromContext(ctx context.Context) *Request {
+	req, _ := ctx.Value(requestKey{}).(*Request)
+	return req
+}
+
+func (r *Request) AddDBTime(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dbTime += d
+}
+
+func (r *Request) DBTime() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.dbTime
+}
+
+// SetErrorClass は最初に発生したエラーの分類を記録する
+func (r *Request) SetErrorClass(class string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.errorClass == "" {
+		r.errorClass = class
+	}
+}
+
+func (r *Request) ErrorClass() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.errorClass
+}
+
+// Error はエラーをログに出力し、アクセスログのerror_classに記録する
+// DBのエラーは行の値を含むため、メッセージはSanitizeで値を取り除き、SQLSTATEを別に出力する
+func Error(ctx context.Context, class string, err error) {
+	if req := FromContext(ctx); req != nil {
+		req.SetErrorClass(class)
+	}
+	attrs := []any{"error_class", class, "error", Sanitize(err)}
+	if state := SQLState(err); state != "" {
+		attrs = append(attrs, "sqlstate", state)
+	}
+	Logger.ErrorContext(ctx, class+" error", attrs...)
+}
+
+var (
+	// 引用符で囲んだ値。MySQLの"Duplicate entry 'x'"やPostgresの"invalid input syntax for type integer: "x""など
+	quotedValue = regexp.MustCompile(`'(?:[^'\\]|\\.|'')*'|"(?:[^"\\]|\\.)*"`)
+	// Postgresの詳細の"Key (email)=(x)"の値
+	keyValue = regexp.MustCompile(`=\([^)]*\)`)
+)
+
+// Sanitize はエラーのメッセージから引用符で囲んだ値と"Key (列)=(値)"の値を取り除く
+func Sanitize(err error) string {
+	if err == nil {
+		return ""
+	}
+	s := quotedValue.ReplaceAllString(err.Error(), "?")
+	return keyValue.ReplaceAllString(s, "=(?)")
+}
+
+// SQLState はDBのドライバのエラーのSQLSTATEを返す。DBのエラーでない場合は空
+func SQLState(err error) string {
+	var state interface{ SQLState() string }
+	if errors.As(err, &state) {
+		return state.SQLState()
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.SQLState != [5]byte{} {
+		return string(mysqlErr.SQLState[:])
+	}
+	return ""
+}
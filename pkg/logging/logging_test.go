@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		want     string
+		sqlstate string
+	}{
+		{
+			"mysql duplicate",
+			fmt.Errorf("insert: %w", &mysql.MySQLError{Number: 1062, SQLState: [5]byte{'2', '3', '0', '0', '0'}, Message: "Duplicate entry 'alice@example.com' for key 'tag.name'"}),
+			"insert: Error 1062 (23000): Duplicate entry ? for key ?", "23000",
+		},
+		{
+			"postgres invalid input",
+			&pq.Error{Code: "22P02", Message: `invalid input syntax for type integer: "secret"`},
+			"pq: invalid input syntax for type integer: ?", "22P02",
+		},
+		{
+			"key detail",
+			errors.New("duplicate key value violates unique constraint: Key (name)=(secret) already exists."),
+			"duplicate key value violates unique constraint: Key (name)=(?) already exists.", "",
+		},
+		{"plain", errors.New("connection refused"), "connection refused", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := Logger
+			t.Cleanup(func() { Logger = logger })
+			Logger = New(&buf)
+			Error(context.Background(), "insert", tt.err)
+
+			if strings.Contains(buf.String(), "secret") || strings.Contains(buf.String(), "alice") {
+				t.Fatalf("log contains a row value: %s", buf.String())
+			}
+			var line map[string]interface{}
+			if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+				t.Fatal(err)
+			}
+			if line["error"] != tt.want || line["error_class"] != "insert" {
+				t.Errorf("log = %v, want error %q", line, tt.want)
+			}
+			if got, _ := line["sqlstate"].(string); got != tt.sqlstate {
+				t.Errorf("sqlstate = %q, want %q", got, tt.sqlstate)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"maguro-alternative/varcel-go/pkg/auth"
+	"maguro-alternative/varcel-go/pkg/catalog"
+	"maguro-alternative/varcel-go/pkg/logging"
+)
+
+// graphQLPath はGraphQLのエンドポイント
+// mutationはなくクエリだけのため、POSTも読み込みとして graphql:read のスコープを確認する
+const graphQLPath = "/api/graphql"
+
+// APIKey は/api/v1のリソースへのGET以外のリクエストにAPIキーを要求する
+// キーはAuthorization: Bearer で受け取り、<リソース>:write のスコープを確認する
+// REQUIRE_API_KEY_FOR_READS=true の場合は読み込みとGraphQLにも要求する
+// OIDC_ISSUERを設定した場合はOIDCのプロバイダが発行したJWTも受け付ける
+func APIKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource, ok := resourceOf(r.URL.Path)
+		safe := isSafe(r.Method)
+		if !ok && r.URL.Path == graphQLPath {
+			resource, ok, safe = "graphql", true, true
+		}
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		scope := auth.WriteScope(resource)
+		if safe {
+			if !auth.ReadsRequireKey() {
+				next.ServeHTTP(w, r)
+				return
+			}
+			scope = auth.ReadScope(resource)
+		}
+		p, err := auth.Authorize(r.Context(), r.Header.Get("Authorization"), scope)
+		if err != nil {
+			authError(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), p)))
+	})
+}
+
+// resourceOf はパスからカタログのリソース名を求める
+// /api/v1/entry と /api/v1/entry/entry のどちらでも entry を返す
+func resourceOf(path string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, "/api/v1/")
+	if !ok {
+		return "", false
+	}
+	name, _, _ := strings.Cut(rest, "/")
+	if _, ok := catalog.Lookup(name); !ok {
+		return "", false
+	}
+	return name, true
+}
+
+func isSafe(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// authError は認証エラーを401、スコープ不足を403で返す
+func authError(w http.ResponseWriter, r *http.Request, err error) {
+	var scopeErr *auth.ScopeError
+	switch {
+	case errors.As(err, &scopeErr):
+		logging.Error(r.Context(), "auth", err)
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="vercel-go", error="insufficient_scope", scope=%q`, scopeErr.Scope))
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, auth.ErrMissingKey):
+		logging.Error(r.Context(), "auth", err)
+		w.Header().Set("WWW-Authenticate", `Bearer realm="vercel-go"`)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, auth.ErrInvalidKey):
+		logging.Error(r.Context(), "auth", err)
+		w.Header().Set("WWW-Authenticate", `Bearer realm="vercel-go", error="invalid_token"`)
+		// 失効や期限切れの理由はログにだけ残す
+		http.Error(w, auth.ErrInvalidKey.Error(), http.StatusUnauthorized)
+	case errors.Is(err, auth.ErrInvalidToken):
+		logging.Error(r.Context(), "auth", err)
+		w.Header().Set("WWW-Authenticate", `Bearer realm="vercel-go", error="invalid_token"`)
+		// 署名や期限切れなどの理由はログにだけ残す
+		http.Error(w, auth.ErrInvalidToken.Error(), http.StatusUnauthorized)
+	default:
+		logging.Error(r.Context(), "db", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/auth"
+	"maguro-alternative/varcel-go/pkg/dbtest"
+)
+
+// principal は次のハンドラに渡った認証情報を記録する
+func principal(got **auth.Principal) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*got = auth.FromContext(r.Context())
+	})
+}
+
+func TestAPIKeyGraphQL(t *testing.T) {
+	db := dbtest.Open(t)
+	keys := &auth.Keys{DB: db}
+	graphqlKey, _, err := keys.Mint(context.Background(), "graphql", []string{"graphql:read"}, "viewer", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entryKey, _, err := keys.Mint(context.Background(), "entry", []string{"entry:read"}, "viewer", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := strings.NewReader(`{"query":"{ entries { id } }"}`)
+
+	// 既定では読み込みは公開する
+	var got *auth.Principal
+	w := httptest.NewRecorder()
+	APIKey(principal(&got)).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/graphql", query))
+	if w.Code != http.StatusOK || got != nil {
+		t.Fatalf("status = %d, principal = %+v", w.Code, got)
+	}
+
+	t.Setenv("REQUIRE_API_KEY_FOR_READS", "true")
+	for _, tt := range []struct {
+		name   string
+		method string
+		token  string
+		status int
+	}{
+		{"anonymous get", http.MethodGet, "", http.StatusUnauthorized},
+		{"anonymous post", http.MethodPost, "", http.StatusUnauthorized},
+		{"invalid key", http.MethodPost, "vgk_0000_00", http.StatusUnauthorized},
+		{"missing scope", http.MethodPost, entryKey, http.StatusForbidden},
+		{"read scope", http.MethodPost, graphqlKey, http.StatusOK},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			r := httptest.NewRequest(tt.method, "/api/graphql?query=%7B+entries+%7B+id+%7D+%7D", nil)
+			if tt.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			APIKey(principal(&got)).ServeHTTP(w, r)
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body.String())
+			}
+			if (tt.status == http.StatusOK) != (got != nil) {
+				t.Fatalf("principal = %+v", got)
+			}
+		})
+	}
+}
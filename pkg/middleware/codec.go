@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+
+	"maguro-alternative/varcel-go/pkg/codec"
+)
+
+// ContentType は対応していないContent-Typeのリクエストボディを415で拒否する
+func ContentType(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != 0 {
+			if _, ok := codec.ForRequest(r); !ok {
+				http.Error(w, "unsupported content type: "+r.Header.Get("Content-Type"), http.StatusUnsupportedMediaType)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
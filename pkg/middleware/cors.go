@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CORSConfig はCORSの設定
+// 環境変数 CORS_<項目>_<VERCEL_ENV> があれば CORS_<項目> より優先するため、
+// プレビューと本番で別のオリジンを許可できる(例: CORS_ALLOWED_ORIGINS_PREVIEW)
+type CORSConfig struct {
+	// AllowedOrigins はpath.Matchのパターン。"https://*.vercel.app"や"*"も指定できる
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	// MaxAge はプリフライトの結果をブラウザがキャッシュする秒数
+	MaxAge int
+}
+
+var defaultCORS = CORSConfig{
+	AllowedMethods: []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodDelete},
+	AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "Connect-Protocol-Version", "Connect-Timeout-Ms"},
+	ExposedHeaders: []string{"RateLimit-Limit", "RateLimit-Policy", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After", "Server-Timing", "WWW-Authenticate", "X-Request-ID"},
+	MaxAge:         600,
+}
+
+var (
+	corsOnce   sync.Once
+	corsConfig CORSConfig
+)
+
+// CORSFromEnv は環境変数から設定を読み込む。オリジンを指定しない場合はCORSを許可しない
+//
+//	CORS_ALLOWED_ORIGINS   カンマ区切りのオリジン
+//	CORS_ALLOWED_METHODS   カンマ区切りのメソッド
+//	CORS_ALLOWED_HEADERS   カンマ区切りのリクエストヘッダー
+//	CORS_EXPOSED_HEADERS   カンマ区切りのレスポンスヘッダー
+//	CORS_ALLOW_CREDENTIALS trueの場合はCookieやAuthorizationを送れるようにする
+//	CORS_MAX_AGE           プリフライトのキャッシュの秒数
+func CORSFromEnv() CORSConfig {
+	corsOnce.Do(func() {
+		corsConfig = loadCORS()
+	})
+	return corsConfig
+}
+
+// loadCORS は環境変数を読む。CORSFromEnvは最初の1回だけ呼ぶ
+func loadCORS() CORSConfig {
+	c := defaultCORS
+	c.AllowedOrigins = corsList("ALLOWED_ORIGINS", nil)
+	c.AllowedMethods = corsList("ALLOWED_METHODS", c.AllowedMethods)
+	c.AllowedHeaders = corsList("ALLOWED_HEADERS", c.AllowedHeaders)
+	c.ExposedHeaders = corsList("EXPOSED_HEADERS", c.ExposedHeaders)
+	c.AllowCredentials = corsEnv("ALLOW_CREDENTIALS") == "true"
+	if n, err := strconv.Atoi(corsEnv("MAX_AGE")); err == nil && n >= 0 {
+		c.MaxAge = n
+	}
+	return c
+}
+
+// corsEnv はVERCEL_ENV(production, preview, development)ごとの値を優先して返す
+func corsEnv(name string) string {
+	if env := os.Getenv("VERCEL_ENV"); env != "" {
+		if v, ok := os.LookupEnv("CORS_" + name + "_" + strings.ToUpper(env)); ok {
+			return v
+		}
+	}
+	return os.Getenv("CORS_" + name)
+}
+
+func corsList(name string, def []string) []string {
+	v := corsEnv(name)
+	if v == "" {
+		return def
+	}
+	var list []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// CORS は環境変数の設定でCORSのヘッダーを付け、プリフライトに応答する
+func CORS(next http.Handler) http.Handler {
+	return CORSWith(CORSFromEnv(), next)
+}
+
+// CORSWith は設定を指定してCORSのヘッダーを付ける
+func CORSWith(c CORSConfig, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Originの有無でヘッダーが変わるため、Originのないリクエストのレスポンスもキャッシュを分ける
+		w.Header().Add("Vary", "Origin")
+		origin := r.Header.Get("Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		allowed, wildcard := c.allowsOrigin(origin)
+		if !allowed {
+			// 許可していないオリジンにはヘッダーを付けない。ブラウザがレスポンスを拒否する
+			if preflight {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+		// "*"では資格情報を送れないため、資格情報を許可するのは個別に指定したオリジンだけ
+		credentials := c.AllowCredentials && !wildcard
+		if wildcard {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		if credentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+		if !preflight {
+			if len(c.ExposedHeaders) > 0 {
+				w.Header().Set("Access-Control-Expose-Headers", strings.Join(c.ExposedHeaders, ", "))
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// プリフライトはハンドラを呼ばずに応答する
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+		method := r.Header.Get("Access-Control-Request-Method")
+		headers := r.Header.Get("Access-Control-Request-Headers")
+		if !contains(c.AllowedMethods, method) || !c.allowsHeaders(headers) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(c.AllowedMethods, ", "))
+		if contains(c.AllowedHeaders, "*") {
+			if headers != "" {
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+			}
+		} else {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(c.AllowedHeaders, ", "))
+		}
+		if c.MaxAge > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(c.MaxAge))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// allowsOrigin はオリジンを許可するかと、"*"で許可したかを返す
+func (c CORSConfig) allowsOrigin(origin string) (allowed, wildcard bool) {
+	for _, pattern := range c.AllowedOrigins {
+		if pattern == "*" {
+			return true, true
+		}
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(origin)); ok {
+			return true, false
+		}
+	}
+	return false, false
+}
+
+// allowsHeaders はAccess-Control-Request-Headersの全てのヘッダーを許可するかを返す
+func (c CORSConfig) allowsHeaders(requested string) bool {
+	if contains(c.AllowedHeaders, "*") {
+		return true
+	}
+	for _, h := range strings.Split(requested, ",") {
+		h = strings.TrimSpace(h)
+		if h != "" && !contains(c.AllowedHeaders, h) {
+			return false
+		}
+	}
+	return true
+}
+
+// contains は大文字小文字を区別せずに含まれているかを返す
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestLoadCORS(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		origins     []string
+		credentials bool
+		maxAge      int
+	}{
+		{"unset", nil, nil, false, 600},
+		{
+			"shared",
+			map[string]string{"CORS_ALLOWED_ORIGINS": "https://example.com, https://*.example.com"},
+			[]string{"https://example.com", "https://*.example.com"}, false, 600,
+		},
+		{
+			"production overrides shared",
+			map[string]string{
+				"VERCEL_ENV":                      "production",
+				"CORS_ALLOWED_ORIGINS":            "*",
+				"CORS_ALLOWED_ORIGINS_PRODUCTION": "https://example.com",
+				"CORS_ALLOWED_ORIGINS_PREVIEW":    "https://*.vercel.app",
+				"CORS_ALLOW_CREDENTIALS":          "true",
+			},
+			[]string{"https://example.com"}, true, 600,
+		},
+		{
+			"preview",
+			map[string]string{
+				"VERCEL_ENV":                      "preview",
+				"CORS_ALLOWED_ORIGINS_PRODUCTION": "https://example.com",
+				"CORS_ALLOWED_ORIGINS_PREVIEW":    "https://*.vercel.app",
+				"CORS_MAX_AGE_PREVIEW":            "0",
+			},
+			[]string{"https://*.vercel.app"}, false, 0,
+		},
+		{
+			// 環境ごとの値が空の場合はその環境でCORSを許可しない
+			"empty per-env value disables",
+			map[string]string{
+				"VERCEL_ENV":                       "development",
+				"CORS_ALLOWED_ORIGINS":             "*",
+				"CORS_ALLOWED_ORIGINS_DEVELOPMENT": "",
+			},
+			nil, false, 600,
+		},
+		{
+			"invalid max age",
+			map[string]string{"CORS_ALLOWED_ORIGINS": "*", "CORS_MAX_AGE": "-1"},
+			[]string{"*"}, false, 600,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"VERCEL_ENV", "CORS_ALLOWED_ORIGINS", "CORS_ALLOW_CREDENTIALS", "CORS_MAX_AGE"} {
+				t.Setenv(name, "")
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			c := loadCORS()
+			if !reflect.DeepEqual(c.AllowedOrigins, tt.origins) {
+				t.Errorf("AllowedOrigins = %v, want %v", c.AllowedOrigins, tt.origins)
+			}
+			if c.AllowCredentials != tt.credentials || c.MaxAge != tt.maxAge {
+				t.Errorf("AllowCredentials = %v, MaxAge = %d", c.AllowCredentials, c.MaxAge)
+			}
+			if !reflect.DeepEqual(c.AllowedMethods, defaultCORS.AllowedMethods) {
+				t.Errorf("AllowedMethods = %v", c.AllowedMethods)
+			}
+		})
+	}
+}
+
+func TestCORS(t *testing.T) {
+	config := defaultCORS
+	config.AllowedOrigins = []string{"https://example.com", "https://*.vercel.app"}
+	config.AllowCredentials = true
+	wildcard := defaultCORS
+	wildcard.AllowedOrigins = []string{"*"}
+	wildcard.AllowCredentials = true
+
+	tests := []struct {
+		name    string
+		config  CORSConfig
+		method  string
+		headers map[string]string
+		// status が0の場合はハンドラに渡ったことを確認する
+		status int
+		want   map[string]string
+		vary   []string
+	}{
+		{
+			"no origin", config, http.MethodGet, nil, 0,
+			map[string]string{"Access-Control-Allow-Origin": ""},
+			[]string{"Origin"},
+		},
+		{
+			"allowed origin", config, http.MethodGet,
+			map[string]string{"Origin": "https://example.com"}, 0,
+			map[string]string{
+				"Access-Control-Allow-Origin":      "https://example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "RateLimit-Limit, RateLimit-Policy, RateLimit-Remaining, RateLimit-Reset, Retry-After, Server-Timing, WWW-Authenticate, X-Request-ID",
+			},
+			[]string{"Origin"},
+		},
+		{
+			"pattern origin", config, http.MethodGet,
+			map[string]string{"Origin": "https://my-app-git-main.vercel.app"}, 0,
+			map[string]string{"Access-Control-Allow-Origin": "https://my-app-git-main.vercel.app"},
+			[]string{"Origin"},
+		},
+		{
+			"case insensitive origin", config, http.MethodGet,
+			map[string]string{"Origin": "HTTPS://EXAMPLE.COM"}, 0,
+			map[string]string{"Access-Control-Allow-Origin": "HTTPS://EXAMPLE.COM"},
+			[]string{"Origin"},
+		},
+		{
+			"disallowed origin", config, http.MethodGet,
+			map[string]string{"Origin": "https://evil.example"}, 0,
+			map[string]string{"Access-Control-Allow-Origin": "", "Access-Control-Allow-Credentials": ""},
+			[]string{"Origin"},
+		},
+		{
+			// パターンの*はサブドメインを跨がない
+			"pattern does not cross dots", config, http.MethodGet,
+			map[string]string{"Origin": "https://evil.example/.vercel.app"}, 0,
+			map[string]string{"Access-Control-Allow-Origin": ""},
+			[]string{"Origin"},
+		},
+		{
+			"wildcard without credentials", wildcard, http.MethodGet,
+			map[string]string{"Origin": "https://anywhere.example"}, 0,
+			map[string]string{"Access-Control-Allow-Origin": "*", "Access-Control-Allow-Credentials": ""},
+			[]string{"Origin"},
+		},
+		{
+			"preflight", config, http.MethodOptions,
+			map[string]string{
+				"Origin":                         "https://example.com",
+				"Access-Control-Request-Method":  http.MethodDelete,
+				"Access-Control-Request-Headers": "authorization, content-type",
+			},
+			http.StatusNoContent,
+			map[string]string{
+				"Access-Control-Allow-Origin":  "https://example.com",
+				"Access-Control-Allow-Methods": "GET, HEAD, POST, PUT, DELETE",
+				"Access-Control-Allow-Headers": "Accept, Authorization, Content-Type, Connect-Protocol-Version, Connect-Timeout-Ms",
+				"Access-Control-Max-Age":       "600",
+				// プリフライトでは公開するヘッダーを返さない
+				"Access-Control-Expose-Headers": "",
+			},
+			[]string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+		},
+		{
+			"preflight disallowed method", config, http.MethodOptions,
+			map[string]string{"Origin": "https://example.com", "Access-Control-Request-Method": http.MethodPatch},
+			http.StatusNoContent,
+			map[string]string{"Access-Control-Allow-Methods": "", "Access-Control-Max-Age": ""},
+			[]string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+		},
+		{
+			"preflight disallowed header", config, http.MethodOptions,
+			map[string]string{
+				"Origin":                         "https://example.com",
+				"Access-Control-Request-Method":  http.MethodPost,
+				"Access-Control-Request-Headers": "X-Custom",
+			},
+			http.StatusNoContent,
+			map[string]string{"Access-Control-Allow-Methods": "", "Access-Control-Allow-Headers": ""},
+			[]string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+		},
+		{
+			"preflight disallowed origin", config, http.MethodOptions,
+			map[string]string{"Origin": "https://evil.example", "Access-Control-Request-Method": http.MethodGet},
+			http.StatusNoContent,
+			map[string]string{"Access-Control-Allow-Origin": "", "Access-Control-Allow-Methods": ""},
+			[]string{"Origin"},
+		},
+		{
+			// Access-Control-Request-MethodのないOPTIONSはプリフライトではない
+			"plain options", config, http.MethodOptions,
+			map[string]string{"Origin": "https://example.com"}, 0,
+			map[string]string{"Access-Control-Allow-Origin": "https://example.com", "Access-Control-Allow-Methods": ""},
+			[]string{"Origin"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			h := CORSWith(tt.config, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.Header().Add("Vary", "Accept")
+			}))
+			r := httptest.NewRequest(tt.method, "/api/v1/entry", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if tt.status == 0 {
+				if !called {
+					t.Error("handler was not called")
+				}
+				tt.vary = append(tt.vary, "Accept")
+			} else {
+				if called {
+					t.Error("preflight reached the handler")
+				}
+				if w.Code != tt.status {
+					t.Errorf("status = %d, want %d", w.Code, tt.status)
+				}
+			}
+			for k, v := range tt.want {
+				if got := w.Header().Get(k); got != v {
+					t.Errorf("%s = %q, want %q", k, got, v)
+				}
+			}
+			if got := w.Header().Values("Vary"); !reflect.DeepEqual(got, tt.vary) {
+				t.Errorf("Vary = %v, want %v", got, tt.vary)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/logging"
+)
+
+// リクエストIDとして受け入れるヘッダー(優先順)
+var requestIDHeaders = []string{"X-Request-ID", "X-Vercel-Id"}
+
+// Logging はリクエストごとにJSON形式のアクセスログを出力する
+func Logging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		req := &logging.Request{ID: requestID(r)}
+		w.Header().Set("X-Request-ID", req.ID)
+		ctx := logging.NewContext(r.Context(), req)
+		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rw, r.WithContext(ctx))
+
+		level := slog.LevelInfo
+		if rw.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		} else if rw.status >= http.StatusBadRequest {
+			level = slog.LevelWarn
+		}
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", rw.status),
+			slog.Int("bytes", rw.size),
+			slog.Float64("latency_ms", milliseconds(time.Since(start))),
+			slog.Float64("db_ms", milliseconds(req.DBTime())),
+		}
+		if class := req.ErrorClass(); class != "" {
+			attrs = append(attrs, slog.String("error_class", class))
+		}
+		logging.Logger.LogAttrs(ctx, level, "request", attrs...)
+	})
+}
+
+func requestID(r *http.Request) string {
+	for _, h := range requestIDHeaders {
+		if id := r.Header.Get(h); validRequestID(id) {
+			return id
+		}
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// validRequestID はログやヘッダーに載せても安全なIDかを判定する
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		if c < 0x21 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
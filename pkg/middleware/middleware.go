@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// Wrap は全てのハンドラに共通のミドルウェアを適用する
+// CORSのプリフライトは認証とレート制限の前に応答する
+// レート制限は認証の前にIPアドレス、認証の後に主体ごとに数える
+func Wrap(h http.HandlerFunc) http.Handler {
+	return Logging(Tracing(CORS(ServerTiming(RateLimit(APIKey(PrincipalRateLimit(DebugSQL(ContentType(h)))))))))
+}
+
+// responseWriter はステータスコードと書き込んだバイト数を記録する
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	size        int
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.size += n
+	return n, err
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+
+	"maguro-alternative/varcel-go/pkg/auth"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/ratelimit"
+)
+
+// RateLimit はクライアントのIPアドレスごとにリクエスト数を制限する
+// APIKeyの前に置き、認証に失敗するリクエストもキーを確認する前に数える
+// /api/v1のリソースへのGET以外は書き込み、それ以外は読み込みの上限を使う
+// バケットを読み書きできない場合はAPIを止めないように制限せずに通す
+func RateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		config, err := ratelimit.FromEnv()
+		if err != nil {
+			logging.Error(r.Context(), "ratelimit", err)
+		}
+		if config == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		_, resource := resourceOf(r.URL.Path)
+		write := resource && !isSafe(r.Method)
+		t, err := config.Track(r.Context(), write, ratelimit.ClientIP(r.Header, r.RemoteAddr))
+		if err != nil {
+			logging.Error(r.Context(), "ratelimit", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+		ratelimit.SetHeaders(w.Header(), t.Result)
+		if !t.Result.Allowed {
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ratelimit.NewContext(r.Context(), t)))
+	})
+}
+
+// PrincipalRateLimit はAPIKeyが認証した主体(APIキーかJWTのsub)ごとにもリクエスト数を制限する
+// APIKeyの後に置き、IPアドレスと主体のバケットのどちらかが空の場合は429を返す
+// レスポンスのヘッダーは2つのバケットのうち厳しい方の結果にする
+func PrincipalRateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t := ratelimit.FromContext(r.Context())
+		p := auth.FromContext(r.Context())
+		if t == nil || p == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err := t.TakePrincipal(r.Context(), p); err != nil {
+			logging.Error(r.Context(), "ratelimit", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+		ratelimit.SetHeaders(w.Header(), t.Result)
+		if !t.Result.Allowed {
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
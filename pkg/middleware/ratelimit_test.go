@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/auth"
+	"maguro-alternative/varcel-go/pkg/dbtest"
+	"maguro-alternative/varcel-go/pkg/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	db := dbtest.Open(t)
+	ratelimit.Use(&ratelimit.Config{
+		Limiter: &ratelimit.Database{DB: db},
+		Read:    ratelimit.Quota{Limit: 2, Window: time.Minute},
+		Write:   ratelimit.Quota{Limit: 1, Window: time.Minute},
+	})
+	t.Cleanup(func() { ratelimit.Use(nil) })
+	h := Wrap(func(w http.ResponseWriter, r *http.Request) {})
+
+	do := func(method, ip, token string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, "/api/v1/entry", nil)
+		r.RemoteAddr = ip + ":1234"
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	for i, want := range []struct {
+		status    int
+		remaining string
+	}{
+		{http.StatusOK, "1"},
+		{http.StatusOK, "0"},
+		{http.StatusTooManyRequests, "0"},
+	} {
+		w := do(http.MethodGet, "192.0.2.1", "")
+		if w.Code != want.status {
+			t.Fatalf("request %d: status = %d, want %d", i, w.Code, want.status)
+		}
+		if w.Header().Get("RateLimit-Policy") != "2;w=60" || w.Header().Get("RateLimit-Limit") != "2" || w.Header().Get("RateLimit-Remaining") != want.remaining {
+			t.Fatalf("request %d: headers = %v", i, w.Header())
+		}
+		if got := w.Header().Get("Retry-After"); (want.status == http.StatusTooManyRequests) != (got != "") {
+			t.Fatalf("request %d: Retry-After = %q", i, got)
+		}
+	}
+	// IPアドレスが違えば別のバケットになる
+	if w := do(http.MethodGet, "192.0.2.2", ""); w.Code != http.StatusOK {
+		t.Fatalf("other ip: status = %d", w.Code)
+	}
+
+	// クライアントが送ったX-Forwarded-ForやX-Real-IPでは別のバケットにならない
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/entry", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	r.Header.Set("X-Forwarded-For", "198.51.100.1")
+	r.Header.Set("X-Real-IP", "198.51.100.2")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("spoofed X-Forwarded-For: status = %d", w.Code)
+	}
+
+	// 認証の前に数えるため、無効なキーでのリクエストも上限を超えると429になる
+	if w := do(http.MethodPost, "192.0.2.3", "vgk_0000_00"); w.Code != http.StatusUnauthorized {
+		t.Fatalf("first write: status = %d", w.Code)
+	}
+	if w := do(http.MethodPost, "192.0.2.3", "vgk_0000_00"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("second write: status = %d", w.Code)
+	}
+	// 読み込みと書き込みは別のバケット
+	if w := do(http.MethodGet, "192.0.2.3", ""); w.Code != http.StatusOK {
+		t.Fatalf("read after write: status = %d", w.Code)
+	}
+}
+
+func TestRateLimitPrincipal(t *testing.T) {
+	db := dbtest.Open(t)
+	ratelimit.Use(&ratelimit.Config{
+		Limiter: &ratelimit.Database{DB: db},
+		Read:    ratelimit.Quota{Limit: 3, Window: time.Minute},
+		Write:   ratelimit.Quota{Limit: 2, Window: time.Minute},
+	})
+	t.Cleanup(func() { ratelimit.Use(nil) })
+	keys := &auth.Keys{DB: db}
+	mint := func(name string) string {
+		token, _, err := keys.Mint(context.Background(), name, []string{"*"}, "admin", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	h := Wrap(func(w http.ResponseWriter, r *http.Request) {})
+
+	do := func(ip, token string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/entry", strings.NewReader(`{}`))
+		r.RemoteAddr = ip + ":1234"
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	// キーは複数のIPアドレスから使われてもまとめて数え、ヘッダーは厳しい方の結果にする
+	key := mint("shared")
+	for i, want := range []struct {
+		ip        string
+		status    int
+		remaining string
+	}{
+		{"192.0.2.1", http.StatusOK, "1"},
+		{"192.0.2.2", http.StatusOK, "0"},
+		{"192.0.2.3", http.StatusTooManyRequests, "0"},
+	} {
+		w := do(want.ip, key)
+		if w.Code != want.status || w.Header().Get("RateLimit-Remaining") != want.remaining {
+			t.Fatalf("request %d: status = %d, headers = %v", i, w.Code, w.Header())
+		}
+		if got := w.Header().Get("Retry-After"); (want.status == http.StatusTooManyRequests) != (got != "") {
+			t.Fatalf("request %d: Retry-After = %q", i, got)
+		}
+	}
+
+	// 同じIPアドレスの後ろのキーは別のバケットになるが、IPアドレスのバケットも数える
+	if w := do("192.0.2.4", mint("first")); w.Code != http.StatusOK || w.Header().Get("RateLimit-Remaining") != "1" {
+		t.Fatalf("first key: status = %d, headers = %v", w.Code, w.Header())
+	}
+	second := mint("second")
+	if w := do("192.0.2.4", second); w.Code != http.StatusOK || w.Header().Get("RateLimit-Remaining") != "0" {
+		t.Fatalf("second key: status = %d, headers = %v", w.Code, w.Header())
+	}
+	if w := do("192.0.2.4", second); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("ip exhausted: status = %d", w.Code)
+	}
+}
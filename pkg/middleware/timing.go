@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+
+	"maguro-alternative/varcel-go/pkg/auth"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/rbac"
+	"maguro-alternative/varcel-go/pkg/timing"
+)
+
+// ServerTiming は全てのレスポンスにServer-Timingヘッダーを付与する
+// SQLをレスポンスに含めるかどうかは認証の後にDebugSQLで決める
+func ServerTiming(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := timing.NewRecorder()
+		tw := &timingWriter{ResponseWriter: w, rec: rec}
+
+		next.ServeHTTP(tw, r.WithContext(timing.NewContext(r.Context(), rec)))
+
+		// 何も書き込まずに終了した場合もヘッダーを付与する
+		if !tw.wroteHeader {
+			w.Header().Set("Server-Timing", rec.Header())
+		}
+	})
+}
+
+// DebugSQL はadminロールの主体が?debug=sqlを指定した場合に、実行したSQLをレスポンスに含める
+// APIKeyの後に置く。キーなしで読める場合はAPIKeyが認証しないため、ここでAuthorizationヘッダーを認証する
+func DebugSQL(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := timing.FromContext(r.Context())
+		if rec != nil && r.URL.Query().Get("debug") == "sql" {
+			rec.Debug = isAdmin(r)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isAdmin はリクエストの主体がadminロールかを判定する
+func isAdmin(r *http.Request) bool {
+	p := auth.FromContext(r.Context())
+	if p == nil && r.Header.Get("Authorization") != "" {
+		var err error
+		p, err = auth.Authenticate(r.Context(), r.Header.Get("Authorization"))
+		if err != nil {
+			logging.Error(r.Context(), "auth", err)
+			return false
+		}
+	}
+	return p != nil && p.Role == rbac.Admin
+}
+
+// timingWriter はヘッダー送信の直前にServer-Timingを設定する
+type timingWriter struct {
+	http.ResponseWriter
+	rec         *timing.Recorder
+	wroteHeader bool
+}
+
+func (w *timingWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.Header().Set("Server-Timing", w.rec.Header())
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *timingWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *timingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/auth"
+	"maguro-alternative/varcel-go/pkg/dbtest"
+	"maguro-alternative/varcel-go/pkg/timing"
+)
+
+func TestDebugSQL(t *testing.T) {
+	db := dbtest.Open(t)
+	keys := &auth.Keys{DB: db}
+	adminKey, _, err := keys.Mint(context.Background(), "admin", []string{"*"}, "admin", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	editorKey, _, err := keys.Mint(context.Background(), "editor", []string{"*"}, "editor", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var debug bool
+	h := Wrap(func(w http.ResponseWriter, r *http.Request) {
+		debug = timing.FromContext(r.Context()).Debug
+	})
+	for _, tt := range []struct {
+		name   string
+		method string
+		target string
+		token  string
+		want   bool
+	}{
+		{"anonymous", http.MethodGet, "/api/v1/entry?debug=sql", "", false},
+		{"editor", http.MethodGet, "/api/v1/entry?debug=sql", editorKey, false},
+		{"invalid key", http.MethodGet, "/api/v1/entry?debug=sql", "vgk_0000_00", false},
+		// 読み込みはAPIKeyが認証しないため、DebugSQLで認証する
+		{"admin read", http.MethodGet, "/api/v1/entry?debug=sql", adminKey, true},
+		{"admin write", http.MethodPost, "/api/v1/entry?debug=sql", adminKey, true},
+		{"admin without debug", http.MethodGet, "/api/v1/entry", adminKey, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			debug = false
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(`{}`))
+			r.Header.Set("Content-Type", "application/json")
+			if tt.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if debug != tt.want {
+				t.Errorf("debug = %v, want %v (status %d: %s)", debug, tt.want, w.Code, w.Body.String())
+			}
+			if w.Header().Get("Server-Timing") == "" {
+				t.Error("Server-Timing header is missing")
+			}
+		})
+	}
+}
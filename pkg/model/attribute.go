@@ -0,0 +1,35 @@
+package model
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Attribute はentryと*_typeを紐付ける属性テーブル(haircolor, eyecolor, ...)の行
+// テーブルごとに値の列名(color_id, style_id, ...)が違うため、列名はAttributeTableで持つ
+type Attribute struct {
+	EntryID int64 `db:"entry_id" json:"entry_id"`
+	ValueID int64 `db:"value_id" json:"value_id"`
+}
+
+func (a *Attribute) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.EntryID, validation.Required),
+		validation.Field(&a.ValueID, validation.Required),
+	)
+}
+
+// AttributeTable は属性テーブルの名前と値の列名
+type AttributeTable struct {
+	Name   string
+	Column string
+	// Multiple はentryごとに複数の行を持てるか(主キーが(entry_id, 値の列))
+	Multiple bool
+}
+
+var (
+	HairColorTable   = AttributeTable{Name: "haircolor", Column: "color_id"}
+	EyeColorTable    = AttributeTable{Name: "eyecolor", Column: "color_id"}
+	HairStyleTable   = AttributeTable{Name: "hairstyle", Column: "style_id"}
+	HairLengthTable  = AttributeTable{Name: "hairlength", Column: "hairlength_type_id"}
+	PersonalityTable = AttributeTable{Name: "personality", Column: "type_id", Multiple: true}
+)
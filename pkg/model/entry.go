@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Entry はentryテーブルの行
+type Entry struct {
+	ID        int64     `db:"id" json:"id"`
+	SourceID  int64     `db:"source_id" json:"source_id"`
+	Name      string    `db:"name" json:"name"`
+	Image     string    `db:"image" json:"image"`
+	Content   string    `db:"content" json:"content"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+func (e *Entry) Validate() error {
+	return validation.ValidateStruct(e,
+		validation.Field(&e.SourceID, validation.Required),
+		validation.Field(&e.Name, validation.Required),
+		validation.Field(&e.Image, validation.Required),
+		validation.Field(&e.Content, validation.Required),
+		validation.Field(&e.CreatedAt, validation.Required),
+	)
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// EntryTag はentry_tagテーブルの行
+type EntryTag struct {
+	ID      int64 `db:"id" json:"id"`
+	EntryID int64 `db:"entry_id" json:"entry_id"`
+	TagID   int64 `db:"tag_id" json:"tag_id"`
+}
+
+func (e *EntryTag) Validate() error {
+	return validation.ValidateStruct(e,
+		validation.Field(&e.EntryID, validation.Required),
+		validation.Field(&e.TagID, validation.Required),
+	)
+}
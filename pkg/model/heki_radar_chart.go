@@ -0,0 +1,20 @@
+package model
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// HekiRadarChart はheki_radar_chartテーブルの行
+type HekiRadarChart struct {
+	EntryID int64 `db:"entry_id" json:"entry_id"`
+	AI      int64 `db:"ai" json:"ai"`
+	NU      int64 `db:"nu" json:"nu"`
+}
+
+func (h *HekiRadarChart) Validate() error {
+	return validation.ValidateStruct(h,
+		validation.Field(&h.EntryID, validation.Required),
+		validation.Field(&h.AI, validation.Required),
+		validation.Field(&h.NU, validation.Required),
+	)
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Link はlinkテーブルの行
+type Link struct {
+	ID       int64  `db:"id" json:"id"`
+	EntryID  int64  `db:"entry_id" json:"entry_id"`
+	Type     string `db:"type" json:"type"`
+	URL      string `db:"url" json:"url"`
+	Nsfw     bool   `db:"nsfw" json:"nsfw"`
+	Darkness bool   `db:"darkness" json:"darkness"`
+}
+
+func (l *Link) Validate() error {
+	return validation.ValidateStruct(l,
+		validation.Field(&l.EntryID, validation.Required),
+		validation.Field(&l.Type, validation.Required),
+		validation.Field(&l.URL, validation.Required),
+		validation.Field(&l.Nsfw, validation.In(false, true)),
+		validation.Field(&l.Darkness, validation.In(false, true)),
+	)
+}
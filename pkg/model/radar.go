@@ -0,0 +1,82 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// heki_radar_chartのビューが使う軸のキー
+const (
+	RadarAxisAI = "ai"
+	RadarAxisNU = "nu"
+)
+
+var radarAxisKey = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// RadarAxis はradar_axisテーブルの行。レーダーチャートの軸1つ分
+type RadarAxis struct {
+	ID int64 `db:"id" json:"id"`
+	// Key はradar_chartのvaluesのキー。英小文字、数字、_
+	Key          string `db:"key" json:"key"`
+	LabelJa      string `db:"label_ja" json:"label_ja"`
+	LabelEn      string `db:"label_en" json:"label_en"`
+	Min          int64  `db:"min_value" json:"min"`
+	Max          int64  `db:"max_value" json:"max"`
+	DisplayOrder int64  `db:"display_order" json:"display_order"`
+}
+
+func (a *RadarAxis) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Key, validation.Required, validation.Length(1, 64), validation.Match(radarAxisKey)),
+		validation.Field(&a.LabelJa, validation.Required),
+		validation.Field(&a.LabelEn, validation.Required),
+		validation.Field(&a.Max, validation.By(func(interface{}) error {
+			if a.Max <= a.Min {
+				return errors.New("must be greater than min")
+			}
+			return nil
+		})),
+	)
+}
+
+// RadarChart はentry1件の軸ごとの値。radar_valueテーブルの行をentryごとにまとめたもの
+type RadarChart struct {
+	EntryID int64 `json:"entry_id"`
+	// Values は軸のキーと値
+	Values map[string]int64 `json:"values"`
+}
+
+func (c *RadarChart) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.EntryID, validation.Required),
+		validation.Field(&c.Values, validation.Required),
+	)
+}
+
+// CheckRadarValues は値のキーが軸にあり、値が軸の範囲(両端を含む)にあるかを確認する
+func CheckRadarValues(axes []RadarAxis, values map[string]int64) error {
+	byKey := make(map[string]RadarAxis, len(axes))
+	for _, a := range axes {
+		byKey[a.Key] = a
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	// エラーのメッセージが毎回同じになるように並べる
+	sort.Strings(keys)
+	for _, key := range keys {
+		a, ok := byKey[key]
+		if !ok {
+			return fmt.Errorf("values: unknown radar axis %q", key)
+		}
+		if v := values[key]; v < a.Min || v > a.Max {
+			return fmt.Errorf("values: %s must be between %d and %d, got %d", key, a.Min, a.Max, v)
+		}
+	}
+	return nil
+}
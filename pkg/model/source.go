@@ -0,0 +1,15 @@
+package model
+
+// Source はsourceテーブルの行。キャラクターが登場する作品
+type Source struct {
+	ID   int64  `db:"id" json:"id"`
+	Name string `db:"name" json:"name"`
+	Url  string `db:"url" json:"url"`
+	Type string `db:"type" json:"type"`
+}
+
+// Tag はtagテーブルの行
+type Tag struct {
+	ID   int64  `db:"id" json:"id"`
+	Name string `db:"name" json:"name"`
+}
@@ -0,0 +1,14 @@
+package model
+
+// TagCooccurrence はtag_cooccurrenceテーブルの行
+// 両方の向きの行と、tag_idとother_tag_idが同じ行(そのタグが付いたentryの数)がある
+type TagCooccurrence struct {
+	TagID      int64 `db:"tag_id" json:"tag_id"`
+	OtherTagID int64 `db:"other_tag_id" json:"other_tag_id"`
+	// OtherName はother_tag_idのタグの名前。読み込み時にtagテーブルから引く
+	OtherName string `db:"other_name" json:"other_name"`
+	// Count は両方のタグが付いたentryの数
+	Count int64   `db:"pair_count" json:"count"`
+	PMI   float64 `db:"pmi" json:"pmi"`
+	Lift  float64 `db:"lift" json:"lift"`
+}
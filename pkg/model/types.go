@@ -0,0 +1,32 @@
+package model
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// TypeValue は*_typeテーブルの行
+// テーブルごとに値の列名(color, style, length, type)が違うため、列名はTypeTableで持つ
+type TypeValue struct {
+	ID    int64  `db:"id" json:"id"`
+	Value string `db:"value" json:"value"`
+}
+
+func (t *TypeValue) Validate() error {
+	return validation.ValidateStruct(t,
+		validation.Field(&t.Value, validation.Required),
+	)
+}
+
+// TypeTable は*_typeテーブルの名前と値の列名
+type TypeTable struct {
+	Name   string
+	Column string
+}
+
+var (
+	HairColorTypeTable   = TypeTable{Name: "haircolor_type", Column: "color"}
+	EyeColorTypeTable    = TypeTable{Name: "eyecolor_type", Column: "color"}
+	HairStyleTypeTable   = TypeTable{Name: "hairstyle_type", Column: "style"}
+	HairLengthTypeTable  = TypeTable{Name: "hairlength_type", Column: "length"}
+	PersonalityTypeTable = TypeTable{Name: "personality_type", Column: "type"}
+)
@@ -0,0 +1,382 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+
+	"maguro-alternative/varcel-go/pkg/auth"
+	"maguro-alternative/varcel-go/pkg/catalog"
+	"maguro-alternative/varcel-go/pkg/codec"
+	"maguro-alternative/varcel-go/pkg/rbac"
+)
+
+// Path はOpenAPIドキュメントを返すURL
+const Path = "/api/v1/openapi.json"
+
+// Resource はカタログのリソースとリクエスト・レスポンスのGoの型の組
+type Resource struct {
+	catalog.Resource
+	// Collection はGET/POST/PUTで送受信する {"entries": [...]} の型
+	Collection interface{}
+	// IDs はDELETEで送受信する {"ids": [...]} の型
+	IDs interface{}
+	// Responses はカタログのEndpointの名前とGETで返す型の対応。画像などはMediaで指定する
+	Responses map[string]interface{}
+}
+
+// Media はcodecでエンコードせず、決まったメディアタイプのバイト列を返すレスポンス
+type Media struct {
+	Types []string
+}
+
+func (m Media) content() object {
+	c := object{}
+	for _, mediaType := range m.Types {
+		c[mediaType] = object{"schema": object{"type": "string", "format": "binary"}}
+	}
+	return c
+}
+
+type object = map[string]interface{}
+
+// securityScheme はAPIキーのセキュリティスキームの名前
+const securityScheme = "apiKey"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// generator は型からスキーマを組み立て、components.schemasに登録する
+type generator struct {
+	schemas object
+	// denial は403で返す拒否の理由のスキーマ
+	denial object
+}
+
+// Generate はリソースの一覧からOpenAPI 3.1のドキュメントを作成する
+func Generate(resources []Resource) ([]byte, error) {
+	g := &generator{schemas: object{}}
+	denial, err := g.schema(reflect.TypeOf(rbac.Denial{}))
+	if err != nil {
+		return nil, err
+	}
+	g.denial = denial
+	paths := object{}
+	for _, res := range resources {
+		collection, err := g.schema(reflect.TypeOf(res.Collection))
+		if err != nil {
+			return nil, err
+		}
+		ids, err := g.schema(reflect.TypeOf(res.IDs))
+		if err != nil {
+			return nil, err
+		}
+		paths[res.Path] = g.pathItem(res, collection, ids)
+		for _, ep := range res.Endpoints {
+			t, ok := res.Responses[ep.Name]
+			if !ok {
+				continue
+			}
+			if m, ok := t.(Media); ok {
+				paths[ep.Path] = g.endpointItem(res, ep, m.content())
+				continue
+			}
+			schema, err := g.schema(reflect.TypeOf(t))
+			if err != nil {
+				return nil, err
+			}
+			paths[ep.Path] = g.endpointItem(res, ep, content(schema))
+		}
+	}
+	doc := object{
+		"openapi": "3.1.0",
+		"info": object{
+			"title":   "vercel-go",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": object{
+			"schemas": g.schemas,
+			"securitySchemes": object{
+				securityScheme: object{
+					"type":        "http",
+					"scheme":      "bearer",
+					"description": "cmd/apikeyで発行したAPIキー、またはOIDCのプロバイダが発行したJWT(RS256, ES256)。GET以外のリクエストで <リソース>:write のスコープが必要。キーのロールで操作できるリソースはrole_permissionの権限表で決まる",
+				},
+			},
+		},
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+func (g *generator) pathItem(res Resource, collection, ids object) object {
+	item := object{}
+	body := func(schema object) object {
+		return object{
+			"required": true,
+			"content":  content(schema),
+		}
+	}
+	for _, method := range res.Methods {
+		op := object{
+			"operationId": strings.ToLower(method) + pascal(res.Name),
+			"summary":     res.Description,
+			"tags":        []string{res.Name},
+		}
+		switch method {
+		case http.MethodGet:
+			op["parameters"] = parameters(res.Filters)
+			op["responses"] = g.responses(content(collection), http.StatusOK, http.StatusForbidden, http.StatusNotAcceptable, http.StatusTooManyRequests)
+		case http.MethodPost, http.MethodPut:
+			op["requestBody"] = body(collection)
+			op["responses"] = g.responses(content(collection), http.StatusOK, http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotAcceptable, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity, http.StatusTooManyRequests)
+		case http.MethodDelete:
+			op["requestBody"] = body(ids)
+			op["responses"] = g.responses(content(ids), http.StatusOK, http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotAcceptable, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity, http.StatusTooManyRequests)
+		}
+		if method != http.MethodGet {
+			op["security"] = []object{{securityScheme: []string{auth.WriteScope(res.Name)}}}
+		}
+		item[strings.ToLower(method)] = op
+	}
+	return item
+}
+
+// endpointItem はリソースの下の読み込み専用のURLのGETを組み立てる
+func (g *generator) endpointItem(res Resource, ep catalog.Endpoint, body object) object {
+	return object{
+		"get": object{
+			"operationId": "get" + pascal(res.Name) + pascal(ep.Name),
+			"summary":     ep.Description,
+			"tags":        []string{res.Name},
+			"parameters":  parameters(ep.Params),
+			"responses":   g.responses(body, http.StatusOK, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusTooManyRequests),
+		},
+	}
+}
+
+// parameters はクエリパラメータを組み立てる。複数指定できるパラメータは配列にする
+func parameters(filters []catalog.Filter) []object {
+	params := []object{}
+	for _, f := range filters {
+		schema := object{"type": "integer", "format": "int64"}
+		switch f.Type {
+		case "":
+		case "integer":
+			schema = object{"type": "integer", "format": "int32"}
+		default:
+			schema = object{"type": f.Type}
+		}
+		p := object{
+			"name":        f.Name,
+			"in":          "query",
+			"description": f.Description,
+			"schema":      schema,
+		}
+		if f.Repeatable {
+			p["explode"] = true
+			p["schema"] = object{"type": "array", "items": schema}
+		}
+		if f.Required {
+			p["required"] = true
+		}
+		params = append(params, p)
+	}
+	return params
+}
+
+// content はcodecで対応している全てのメディアタイプに同じスキーマを割り当てる
+func content(schema object) object {
+	c := object{}
+	for _, mediaType := range codec.MediaTypes {
+		c[mediaType] = object{"schema": schema}
+	}
+	return c
+}
+
+// responses は成功時のボディ(content)と、http.Errorで返すテキストのエラーを組み立てる
+// 403は権限表で拒否した場合の理由をボディに含む
+func (g *generator) responses(body object, statuses ...int) object {
+	res := object{}
+	text := object{
+		"text/plain": object{"schema": object{"type": "string"}},
+	}
+	for _, status := range append(statuses, http.StatusInternalServerError) {
+		code := http.StatusText(status)
+		if status == http.StatusOK {
+			res["200"] = object{
+				"description": code,
+				"content":     body,
+			}
+			continue
+		}
+		if status == http.StatusForbidden {
+			res["403"] = object{
+				"description": "APIキーのスコープ不足(text/plain)、またはロールの権限表による拒否",
+				"content":     merge(content(g.denial), text),
+			}
+			continue
+		}
+		if status == http.StatusTooManyRequests {
+			res["429"] = object{
+				"description": "読み込み・書き込みそれぞれの上限(RATE_LIMIT_READ, RATE_LIMIT_WRITE)を超えた",
+				"content":     text,
+				"headers": object{
+					"Retry-After": object{
+						"description": "次のリクエストを送れるまでの秒数",
+						"schema":      object{"type": "integer"},
+					},
+				},
+			}
+			continue
+		}
+		res[strconv.Itoa(status)] = object{"description": code, "content": text}
+	}
+	return res
+}
+
+func merge(a, b object) object {
+	m := object{}
+	for k, v := range a {
+		m[k] = v
+	}
+	for k, v := range b {
+		m[k] = v
+	}
+	return m
+}
+
+// schema は型に対応するJSON Schemaを返す
+// 構造体はcomponents.schemasに登録して$refを返す
+func (g *generator) schema(t reflect.Type) (object, error) {
+	switch {
+	case t == timeType:
+		return object{"type": "string", "format": "date-time"}, nil
+	case t.Kind() == reflect.Pointer:
+		s, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		if typ, ok := s["type"].(string); ok {
+			s["type"] = []string{typ, "null"}
+			return s, nil
+		}
+		return object{"oneOf": []object{s, {"type": "null"}}}, nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return object{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return object{"type": "integer", "format": "int32"}, nil
+	case reflect.Int64:
+		return object{"type": "integer", "format": "int64"}, nil
+	case reflect.Float32, reflect.Float64:
+		return object{"type": "number"}, nil
+	case reflect.String:
+		return object{"type": "string"}, nil
+	case reflect.Slice:
+		items, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return object{"type": "array", "items": items}, nil
+	case reflect.Map:
+		// JSONのオブジェクトのキーは文字列だけ
+		if t.Key().Kind() != reflect.String {
+			break
+		}
+		values, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return object{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		return g.structSchema(t)
+	}
+	return nil, errors.New("openapi: unsupported type " + t.String())
+}
+
+func (g *generator) structSchema(t reflect.Type) (object, error) {
+	ref := object{"$ref": "#/components/schemas/" + t.Name()}
+	properties := object{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := jsonName(f)
+		if name == "" {
+			continue
+		}
+		p, err := g.schema(f.Type)
+		if err != nil {
+			return nil, err
+		}
+		properties[name] = p
+	}
+	s := object{"type": "object", "properties": properties}
+	if required := requiredFields(t); len(required) > 0 {
+		s["required"] = required
+	}
+	// IDsのように同じ名前の型が複数のパッケージにある場合は、同じ形の時だけ共有する
+	if existing, ok := g.schemas[t.Name()]; ok {
+		if !reflect.DeepEqual(existing, s) {
+			return nil, errors.New("openapi: schema name conflict " + t.String())
+		}
+		return ref, nil
+	}
+	g.schemas[t.Name()] = s
+	return ref, nil
+}
+
+// requiredFields はゼロ値をValidate()して、必須エラーになったフィールドを返す
+// ozzo-validationはエラーのキーにjsonタグの名前を使う
+func requiredFields(t reflect.Type) []string {
+	v, ok := reflect.New(t).Interface().(validation.Validatable)
+	if !ok {
+		return nil
+	}
+	var errs validation.Errors
+	if !errors.As(v.Validate(), &errs) {
+		return nil
+	}
+	required := make([]string, 0, len(errs))
+	for name := range errs {
+		required = append(required, name)
+	}
+	sort.Strings(required)
+	return required
+}
+
+func jsonName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// pascal はentry_tagをEntryTagに変換する
+func pascal(s string) string {
+	parts := strings.Split(s, "_")
+	for i, p := range parts {
+		if p != "" {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
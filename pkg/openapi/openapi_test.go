@@ -0,0 +1,235 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+
+	"maguro-alternative/varcel-go/pkg/catalog"
+)
+
+// Widget はスキーマの組み立てを確認するための型
+type Widget struct {
+	ID        int64          `json:"id"`
+	Name      string         `json:"name"`
+	Note      *string        `json:"note"`
+	Tags      []string       `json:"tags"`
+	Counts    map[string]int `json:"counts"`
+	CreatedAt time.Time      `json:"created_at"`
+	Ratio     float64        `json:"ratio,omitempty"`
+	Hidden    string         `json:"-"`
+	internal  string
+	Owner     *Owner           `json:"owner"`
+	Children  map[string]int64 `json:"children"`
+}
+
+func (w *Widget) Validate() error {
+	return validation.ValidateStruct(w,
+		validation.Field(&w.Name, validation.Required),
+	)
+}
+
+type Owner struct {
+	Name string `json:"name"`
+}
+
+type WidgetsJson struct {
+	Widgets []Widget `json:"widgets"`
+}
+
+type IDs struct {
+	IDs []int64 `json:"ids"`
+}
+
+func widgetResource() Resource {
+	return Resource{
+		Resource: catalog.Resource{
+			Name:        "widget",
+			Path:        "/api/v1/widget",
+			Description: "テスト用のリソース",
+			Methods:     []string{"GET", "DELETE"},
+			Filters:     []catalog.Filter{{Name: "id", Repeatable: true}, {Name: "q", Type: "string", Required: true}},
+			Endpoints: []catalog.Endpoint{
+				{Name: "image", Path: "/api/v1/widget/image"},
+				{Name: "stats", Path: "/api/v1/widget/stats", Params: []catalog.Filter{{Name: "limit", Type: "integer"}}},
+				// Responsesにないエンドポイントは出力しない
+				{Name: "hidden", Path: "/api/v1/widget/hidden"},
+			},
+		},
+		Collection: WidgetsJson{},
+		IDs:        IDs{},
+		Responses: map[string]interface{}{
+			"image": Media{Types: []string{"image/svg+xml"}},
+			"stats": map[string]int64{},
+		},
+	}
+}
+
+// generate はドキュメントを作成し、比べやすいようにJSONのmapにする
+func generate(t *testing.T, resources ...Resource) map[string]interface{} {
+	t.Helper()
+	b, err := Generate(resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// at はドキュメントをキーの順に辿る
+func at(t *testing.T, v interface{}, keys ...string) interface{} {
+	t.Helper()
+	for _, key := range keys {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			t.Fatalf("%s: not an object: %v", key, v)
+		}
+		if v, ok = m[key]; !ok {
+			t.Fatalf("%s is missing from %v", key, m)
+		}
+	}
+	return v
+}
+
+func keys(v interface{}) []string {
+	m, _ := v.(map[string]interface{})
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestGeneratePaths(t *testing.T) {
+	doc := generate(t, widgetResource())
+
+	if got, want := keys(doc["paths"]), []string{"/api/v1/widget", "/api/v1/widget/image", "/api/v1/widget/stats"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("paths = %v, want %v", got, want)
+	}
+	if got := keys(at(t, doc, "paths", "/api/v1/widget")); !reflect.DeepEqual(got, []string{"delete", "get"}) {
+		t.Fatalf("methods = %v", got)
+	}
+
+	get := at(t, doc, "paths", "/api/v1/widget", "get").(map[string]interface{})
+	if get["operationId"] != "getWidget" {
+		t.Errorf("operationId = %v", get["operationId"])
+	}
+	if _, ok := get["security"]; ok {
+		t.Error("GET requires a key")
+	}
+	params := get["parameters"].([]interface{})
+	if len(params) != 2 {
+		t.Fatalf("parameters = %v", params)
+	}
+	if got := at(t, params[0], "schema", "type"); got != "array" {
+		t.Errorf("repeatable id type = %v", got)
+	}
+	if got := at(t, params[1], "required"); got != true {
+		t.Errorf("q required = %v", got)
+	}
+	if got := keys(get["responses"]); !reflect.DeepEqual(got, []string{"200", "403", "406", "429", "500"}) {
+		t.Errorf("GET responses = %v", got)
+	}
+	if got := at(t, get, "responses", "200", "content", "application/json", "schema", "$ref"); got != "#/components/schemas/WidgetsJson" {
+		t.Errorf("GET schema = %v", got)
+	}
+	if got := at(t, get, "responses", "403", "content", "application/json", "schema", "$ref"); got != "#/components/schemas/Denial" {
+		t.Errorf("403 schema = %v", got)
+	}
+
+	del := at(t, doc, "paths", "/api/v1/widget", "delete")
+	if got := at(t, del, "requestBody", "content", "application/json", "schema", "$ref"); got != "#/components/schemas/IDs" {
+		t.Errorf("DELETE body = %v", got)
+	}
+	security := at(t, del, "security").([]interface{})
+	if got := at(t, security[0], "apiKey"); !reflect.DeepEqual(got, []interface{}{"widget:write"}) {
+		t.Errorf("DELETE security = %v", got)
+	}
+
+	image := at(t, doc, "paths", "/api/v1/widget/image", "get")
+	if got := keys(at(t, image, "responses", "200", "content")); !reflect.DeepEqual(got, []string{"image/svg+xml"}) {
+		t.Errorf("image content = %v", got)
+	}
+	stats := at(t, doc, "paths", "/api/v1/widget/stats", "get")
+	if got := at(t, stats, "operationId"); got != "getWidgetStats" {
+		t.Errorf("operationId = %v", got)
+	}
+	if got := at(t, stats, "responses", "200", "content", "application/json", "schema", "additionalProperties", "format"); got != "int64" {
+		t.Errorf("stats schema format = %v", got)
+	}
+}
+
+func TestGenerateSchema(t *testing.T) {
+	doc := generate(t, widgetResource())
+	widget := at(t, doc, "components", "schemas", "Widget")
+
+	// jsonタグが"-"のフィールドと非公開のフィールドは含めない
+	want := []string{"children", "counts", "created_at", "id", "name", "note", "owner", "ratio", "tags"}
+	if got := keys(at(t, widget, "properties")); !reflect.DeepEqual(got, want) {
+		t.Fatalf("properties = %v, want %v", got, want)
+	}
+	for _, tt := range []struct {
+		property string
+		key      string
+		want     interface{}
+	}{
+		{"id", "format", "int64"},
+		{"name", "type", "string"},
+		{"note", "type", []interface{}{"string", "null"}},
+		{"tags", "type", "array"},
+		{"counts", "type", "object"},
+		{"created_at", "format", "date-time"},
+		{"ratio", "type", "number"},
+	} {
+		if got := at(t, widget, "properties", tt.property, tt.key); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s.%s = %v, want %v", tt.property, tt.key, got, tt.want)
+		}
+	}
+	// 構造体へのポインタは$refとnullのoneOfになる
+	owner := at(t, widget, "properties", "owner", "oneOf").([]interface{})
+	if len(owner) != 2 || at(t, owner[0], "$ref") != "#/components/schemas/Owner" || at(t, owner[1], "type") != "null" {
+		t.Errorf("owner = %v", owner)
+	}
+	// Validateで必須になるフィールド
+	if got := at(t, widget, "required"); !reflect.DeepEqual(got, []interface{}{"name"}) {
+		t.Errorf("required = %v", got)
+	}
+	if _, ok := at(t, doc, "components", "schemas", "IDs").(map[string]interface{})["required"]; ok {
+		t.Error("IDs has required fields without Validate")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	// 同じ名前で形の違う型
+	type IDs struct {
+		IDs []string `json:"ids"`
+	}
+	other := widgetResource()
+	other.Name, other.Path, other.Endpoints = "other", "/api/v1/other", nil
+	other.IDs = IDs{}
+	if _, err := Generate([]Resource{widgetResource(), other}); err == nil {
+		t.Error("Generate accepted two different IDs schemas")
+	}
+
+	unsupported := widgetResource()
+	unsupported.Responses = map[string]interface{}{"stats": make(chan int)}
+	if _, err := Generate([]Resource{unsupported}); err == nil {
+		t.Error("Generate accepted a chan")
+	}
+
+	// 同じ名前で同じ形の型は共有する
+	same := widgetResource()
+	same.Name, same.Path, same.Endpoints = "same", "/api/v1/same", nil
+	doc := generate(t, widgetResource(), same)
+	if got := at(t, doc, "paths", "/api/v1/same", "delete", "requestBody", "content", "application/json", "schema", "$ref"); got != "#/components/schemas/IDs" {
+		t.Errorf("shared IDs = %v", got)
+	}
+}
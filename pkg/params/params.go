@@ -0,0 +1,19 @@
+package params
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Int64s はクエリパラメータの値を整数に変換する
+func Int64s(values []string) ([]int64, error) {
+	ids := make([]int64, 0, len(values))
+	for _, v := range values {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %q: %w", v, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
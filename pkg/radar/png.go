@@ -0,0 +1,156 @@
+package radar
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// 拡大して描いてから縮小するときの、拡大した画像の一辺の上限(px)
+const maxSupersampled = 2048
+
+// PNG はチャートをPNGで返す
+// 標準ライブラリにはフォントがないため、文字(軸の名前、目盛りの値)と凡例は描かない
+func PNG(c Chart, o Options) ([]byte, error) {
+	s := layout(c, o, false)
+	// 縁を滑らかにするため拡大して描く
+	ss := max(1, min(4, maxSupersampled/max(s.width, s.height)))
+	big := image.NewRGBA(image.Rect(0, 0, s.width*ss, s.height*ss))
+	bg := parseColor(s.background)
+	for i := 0; i < len(big.Pix); i += 4 {
+		big.Pix[i], big.Pix[i+1], big.Pix[i+2], big.Pix[i+3] = bg.R, bg.G, bg.B, 255
+	}
+	scale := func(ps []point) []point {
+		out := make([]point, len(ps))
+		for i, p := range ps {
+			out[i] = point{p.x * float64(ss), p.y * float64(ss)}
+		}
+		return out
+	}
+	for _, sh := range s.shapes {
+		ps := scale(sh.points)
+		if sh.fill != "" {
+			fill(big, ps, parseColor(sh.fill), sh.fillOpacity)
+		}
+		stroke(big, ps, sh.closed, sh.width*float64(ss), parseColor(sh.stroke))
+	}
+	for _, d := range s.dots {
+		fill(big, circle(point{d.at.x * float64(ss), d.at.y * float64(ss)}, d.r*float64(ss), 16), parseColor(d.color), 1)
+	}
+
+	img := downsample(big, ss)
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// parseColor は#rrggbbを読む。読めない場合は黒
+func parseColor(s string) color.RGBA {
+	v, err := strconv.ParseUint(s[min(1, len(s)):], 16, 32)
+	if err != nil || len(s) != 7 {
+		return color.RGBA{A: 255}
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 255}
+}
+
+// fill は多角形を偶奇規則で塗る。画素の中心が内側にある画素を塗る
+func fill(img *image.RGBA, ps []point, c color.RGBA, opacity float64) {
+	if len(ps) < 3 {
+		return
+	}
+	b := img.Bounds()
+	top, bottom := math.Inf(1), math.Inf(-1)
+	for _, p := range ps {
+		top, bottom = math.Min(top, p.y), math.Max(bottom, p.y)
+	}
+	var xs []float64
+	for y := max(b.Min.Y, int(math.Floor(top))); y < min(b.Max.Y, int(math.Ceil(bottom))+1); y++ {
+		cy := float64(y) + 0.5
+		xs = xs[:0]
+		for i := range ps {
+			a, z := ps[i], ps[(i+1)%len(ps)]
+			if (a.y <= cy) == (z.y <= cy) {
+				continue
+			}
+			xs = append(xs, a.x+(cy-a.y)*(z.x-a.x)/(z.y-a.y))
+		}
+		sort.Float64s(xs)
+		for i := 0; i+1 < len(xs); i += 2 {
+			for x := max(b.Min.X, int(math.Ceil(xs[i]-0.5))); x < min(b.Max.X, int(math.Ceil(xs[i+1]-0.5))); x++ {
+				blend(img, x, y, c, opacity)
+			}
+		}
+	}
+}
+
+// stroke は線分ごとに幅のある四角形を塗る。つなぎ目は円で埋める
+func stroke(img *image.RGBA, ps []point, closed bool, width float64, c color.RGBA) {
+	n := len(ps) - 1
+	if closed {
+		n = len(ps)
+	}
+	half := math.Max(width, 1) / 2
+	for i := 0; i < n; i++ {
+		a, z := ps[i], ps[(i+1)%len(ps)]
+		dx, dy := z.x-a.x, z.y-a.y
+		l := math.Hypot(dx, dy)
+		if l == 0 {
+			continue
+		}
+		nx, ny := -dy/l*half, dx/l*half
+		fill(img, []point{{a.x + nx, a.y + ny}, {z.x + nx, z.y + ny}, {z.x - nx, z.y - ny}, {a.x - nx, a.y - ny}}, c, 1)
+		if half > 1 {
+			fill(img, circle(z, half, 12), c, 1)
+		}
+	}
+}
+
+func circle(center point, r float64, segments int) []point {
+	ps := make([]point, segments)
+	for i := range ps {
+		a := 2 * math.Pi * float64(i) / float64(segments)
+		ps[i] = point{center.x + r*math.Cos(a), center.y + r*math.Sin(a)}
+	}
+	return ps
+}
+
+func blend(img *image.RGBA, x, y int, c color.RGBA, opacity float64) {
+	i := img.PixOffset(x, y)
+	p := img.Pix[i : i+3 : i+3]
+	p[0] = uint8(float64(c.R)*opacity + float64(p[0])*(1-opacity) + 0.5)
+	p[1] = uint8(float64(c.G)*opacity + float64(p[1])*(1-opacity) + 0.5)
+	p[2] = uint8(float64(c.B)*opacity + float64(p[2])*(1-opacity) + 0.5)
+}
+
+// downsample はss×ssの画素の平均で縮小する
+func downsample(big *image.RGBA, ss int) *image.RGBA {
+	if ss == 1 {
+		return big
+	}
+	b := big.Bounds()
+	img := image.NewRGBA(image.Rect(0, 0, b.Dx()/ss, b.Dy()/ss))
+	n := uint32(ss * ss)
+	for y := 0; y < img.Rect.Dy(); y++ {
+		for x := 0; x < img.Rect.Dx(); x++ {
+			var sum [3]uint32
+			for dy := 0; dy < ss; dy++ {
+				i := big.PixOffset(x*ss, y*ss+dy)
+				for dx := 0; dx < ss; dx++ {
+					sum[0] += uint32(big.Pix[i])
+					sum[1] += uint32(big.Pix[i+1])
+					sum[2] += uint32(big.Pix[i+2])
+					i += 4
+				}
+			}
+			o := img.PixOffset(x, y)
+			img.Pix[o], img.Pix[o+1], img.Pix[o+2], img.Pix[o+3] = uint8(sum[0]/n), uint8(sum[1]/n), uint8(sum[2]/n), 255
+		}
+	}
+	return img
+}
@@ -0,0 +1,266 @@
+// Package radar はレーダーチャートをSVGとPNGで描く
+// 軸の数は自由で、3未満の場合は目盛りの輪を円にして値を線で結ぶ
+package radar
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// 大きさ(px)の既定値と範囲
+const (
+	DefaultSize = 400
+	MinSize     = 120
+	MaxSize     = 1024
+)
+
+// 目盛りの輪の数の既定値と上限
+const (
+	DefaultRings = 4
+	MaxRings     = 10
+)
+
+// Axis はチャートの軸。値はMin〜Maxで、範囲の外は端に寄せる
+type Axis struct {
+	Label    string
+	Min, Max float64
+}
+
+// Series は重ねて描く1つの系列。ValuesはAxesと同じ順
+type Series struct {
+	Label  string
+	Values []float64
+}
+
+// Chart は描くチャート
+type Chart struct {
+	Title  string
+	Axes   []Axis
+	Series []Series
+}
+
+// Theme は色とフォント。色は#rrggbb
+type Theme struct {
+	Background  string
+	Grid        string
+	Axis        string
+	Text        string
+	Font        string
+	FillOpacity float64
+	// Palette は系列の色。系列が多い場合は繰り返す
+	Palette []string
+}
+
+// Themes は組み込みのテーマ
+var Themes = map[string]Theme{
+	"light": {
+		Background:  "#ffffff",
+		Grid:        "#d0d7de",
+		Axis:        "#8c959f",
+		Text:        "#24292f",
+		Font:        "sans-serif",
+		FillOpacity: 0.25,
+		Palette:     []string{"#e91e63", "#2196f3", "#4caf50", "#ff9800", "#9c27b0", "#009688", "#795548", "#607d8b"},
+	},
+	"dark": {
+		Background:  "#0d1117",
+		Grid:        "#30363d",
+		Axis:        "#6e7681",
+		Text:        "#e6edf3",
+		Font:        "sans-serif",
+		FillOpacity: 0.3,
+		Palette:     []string{"#ff7eb6", "#79c0ff", "#7ee787", "#ffa657", "#d2a8ff", "#56d4dd", "#e3b341", "#a5d6ff"},
+	},
+}
+
+// DefaultTheme は組み込みのテーマの既定値
+const DefaultTheme = "light"
+
+var hexColor = regexp.MustCompile(`^#?[0-9a-fA-F]{6}$`)
+
+// ParsePalette はカンマ区切りの色(#rrggbb、#は省略可)を読む
+func ParsePalette(s string) ([]string, error) {
+	var colors []string
+	for _, c := range strings.Split(s, ",") {
+		c = strings.TrimSpace(c)
+		if !hexColor.MatchString(c) {
+			return nil, fmt.Errorf("invalid color %q: want rrggbb", c)
+		}
+		colors = append(colors, "#"+strings.ToLower(strings.TrimPrefix(c, "#")))
+	}
+	return colors, nil
+}
+
+// Options は描き方
+type Options struct {
+	Size  int
+	Rings int
+	Theme Theme
+}
+
+func (o Options) normalized() Options {
+	if o.Size == 0 {
+		o.Size = DefaultSize
+	}
+	o.Size = min(max(o.Size, MinSize), MaxSize)
+	if o.Rings <= 0 {
+		o.Rings = DefaultRings
+	}
+	o.Rings = min(o.Rings, MaxRings)
+	if len(o.Theme.Palette) == 0 {
+		o.Theme = Themes[DefaultTheme]
+	}
+	return o
+}
+
+type point struct{ x, y float64 }
+
+// shape は多角形か折れ線。fillが空の場合は塗らない
+type shape struct {
+	points      []point
+	closed      bool
+	fill        string
+	fillOpacity float64
+	stroke      string
+	width       float64
+}
+
+type dot struct {
+	at    point
+	r     float64
+	color string
+}
+
+type label struct {
+	at     point
+	text   string
+	anchor string
+	size   float64
+	color  string
+	// swatch は凡例の色
+	swatch string
+}
+
+// scene はSVGとPNGで共通の図形
+type scene struct {
+	width, height int
+	background    string
+	font          string
+	title         string
+	shapes        []shape
+	dots          []dot
+	labels        []label
+}
+
+// 軸が3未満の場合に輪を近似する多角形の頂点数
+const circleSegments = 64
+
+// layout はチャートを図形に変換する。凡例は系列が2つ以上の場合に下に付ける
+// textがfalseの場合は文字と凡例を含めない
+func layout(c Chart, o Options, text bool) scene {
+	o = o.normalized()
+	t := o.Theme
+	size := float64(o.Size)
+	fontSize := math.Max(10, size/30)
+	legend := 0.0
+	if text && len(c.Series) > 1 {
+		legend = float64(len(c.Series))*fontSize*1.5 + fontSize
+	}
+	s := scene{
+		width:      o.Size,
+		height:     o.Size + int(math.Ceil(legend)),
+		background: t.Background,
+		font:       t.Font,
+		title:      c.Title,
+	}
+	center := point{size / 2, size / 2}
+	radius := size/2 - fontSize*3.5
+	n := len(c.Axes)
+	angle := func(i int) float64 { return -math.Pi/2 + 2*math.Pi*float64(i)/float64(max(n, 1)) }
+	at := func(a, r float64) point { return point{center.x + r*math.Cos(a), center.y + r*math.Sin(a)} }
+
+	// 目盛りの輪
+	for k := 1; k <= o.Rings; k++ {
+		r := radius * float64(k) / float64(o.Rings)
+		ring := shape{closed: true, stroke: t.Grid, width: 1}
+		if n >= 3 {
+			for i := 0; i < n; i++ {
+				ring.points = append(ring.points, at(angle(i), r))
+			}
+		} else {
+			for i := 0; i < circleSegments; i++ {
+				ring.points = append(ring.points, at(2*math.Pi*float64(i)/circleSegments, r))
+			}
+		}
+		s.shapes = append(s.shapes, ring)
+	}
+	// 軸と軸の名前
+	for i, axis := range c.Axes {
+		a := angle(i)
+		s.shapes = append(s.shapes, shape{points: []point{center, at(a, radius)}, stroke: t.Axis, width: 1})
+		if !text {
+			continue
+		}
+		p := at(a, radius+fontSize*1.2)
+		anchor := "middle"
+		switch cos := math.Cos(a); {
+		case cos > 0.1:
+			anchor = "start"
+		case cos < -0.1:
+			anchor = "end"
+		}
+		p.y += fontSize * 0.35 * (1 + math.Sin(a))
+		s.labels = append(s.labels, label{at: p, text: axis.Label, anchor: anchor, size: fontSize, color: t.Text})
+	}
+	// 全ての軸の範囲が同じ場合は輪に目盛りの値を付ける
+	if text && n > 0 && sameRange(c.Axes) {
+		for k := 1; k <= o.Rings; k++ {
+			v := c.Axes[0].Min + (c.Axes[0].Max-c.Axes[0].Min)*float64(k)/float64(o.Rings)
+			p := at(angle(0), radius*float64(k)/float64(o.Rings))
+			s.labels = append(s.labels, label{at: point{p.x + 3, p.y - 2}, text: trim(v), anchor: "start", size: fontSize * 0.75, color: t.Axis})
+		}
+	}
+	// 系列
+	for j, series := range c.Series {
+		color := t.Palette[j%len(t.Palette)]
+		poly := shape{closed: n >= 3, fill: color, fillOpacity: t.FillOpacity, stroke: color, width: 2}
+		if n < 3 {
+			poly.fill = ""
+		}
+		for i, axis := range c.Axes {
+			v := 0.0
+			if i < len(series.Values) {
+				v = series.Values[i]
+			}
+			ratio := 0.0
+			if axis.Max > axis.Min {
+				ratio = math.Min(math.Max((v-axis.Min)/(axis.Max-axis.Min), 0), 1)
+			}
+			p := at(angle(i), radius*ratio)
+			poly.points = append(poly.points, p)
+			s.dots = append(s.dots, dot{at: p, r: 3, color: color})
+		}
+		s.shapes = append(s.shapes, poly)
+		if legend > 0 {
+			y := size + fontSize*(1.5*float64(j)+1)
+			s.labels = append(s.labels, label{at: point{fontSize * 2.5, y + fontSize*0.35}, text: series.Label, anchor: "start", size: fontSize, color: t.Text, swatch: color})
+		}
+	}
+	return s
+}
+
+func sameRange(axes []Axis) bool {
+	for _, a := range axes {
+		if a.Min != axes[0].Min || a.Max != axes[0].Max || a.Max <= a.Min {
+			return false
+		}
+	}
+	return true
+}
+
+// trim は目盛りの値を必要な桁だけの文字列にする
+func trim(v float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", v), "0"), ".")
+}
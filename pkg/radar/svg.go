@@ -0,0 +1,58 @@
+package radar
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// SVG はチャートをSVGで返す
+func SVG(c Chart, o Options) []byte {
+	s := layout(c, o, true)
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="%s" font-family="%s">`+"\n",
+		s.width, s.height, s.width, s.height, escape(s.title), escape(s.font))
+	fmt.Fprintf(&b, "<title>%s</title>\n", escape(s.title))
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", s.background)
+	for _, sh := range s.shapes {
+		tag := "polyline"
+		if sh.closed {
+			tag = "polygon"
+		}
+		fill := `fill="none"`
+		if sh.fill != "" {
+			fill = fmt.Sprintf(`fill="%s" fill-opacity="%s"`, sh.fill, trim(sh.fillOpacity))
+		}
+		fmt.Fprintf(&b, `<%s points="%s" %s stroke="%s" stroke-width="%s" stroke-linejoin="round"/>`+"\n",
+			tag, points(sh.points), fill, sh.stroke, trim(sh.width))
+	}
+	for _, d := range s.dots {
+		fmt.Fprintf(&b, `<circle cx="%s" cy="%s" r="%s" fill="%s"/>`+"\n", trim(d.at.x), trim(d.at.y), trim(d.r), d.color)
+	}
+	for _, l := range s.labels {
+		if l.swatch != "" {
+			fmt.Fprintf(&b, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n",
+				trim(l.at.x-l.size*1.5), trim(l.at.y-l.size*0.8), trim(l.size), trim(l.size), l.swatch)
+		}
+		fmt.Fprintf(&b, `<text x="%s" y="%s" font-size="%s" text-anchor="%s" fill="%s">%s</text>`+"\n",
+			trim(l.at.x), trim(l.at.y), trim(l.size), l.anchor, l.color, escape(l.text))
+	}
+	b.WriteString("</svg>\n")
+	return b.Bytes()
+}
+
+func points(ps []point) string {
+	s := make([]string, len(ps))
+	for i, p := range ps {
+		s[i] = trim(p.x) + "," + trim(p.y)
+	}
+	return strings.Join(s, " ")
+}
+
+// escape はテキストと属性の値をXMLとして安全な文字列にする
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,127 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/database"
+)
+
+// Database はrate_limit_bucketテーブルにバケットを置く
+// Postgresでは補充と取り出しを1回のINSERT ... ON CONFLICT DO UPDATEで行うため、同時のリクエストでも数え漏れがない
+// MySQLとSQLiteでは行をロックしたトランザクションの中で読み書きする
+type Database struct {
+	// DB はバケットを読み書きする接続。nilの場合は最初のTakeで接続し、インスタンスが生きている間使い回す
+	DB *database.DB
+	mu sync.Mutex
+}
+
+// conn は共有する接続を返す。接続に失敗した場合は次のTakeでやり直す
+func (d *Database) conn(ctx context.Context) (*database.DB, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.DB == nil {
+		db, err := database.Open(ctx)
+		if err != nil {
+			return nil, err
+		}
+		d.DB = db
+	}
+	return d.DB, nil
+}
+
+func (d *Database) Take(ctx context.Context, key string, q Quota) (Result, error) {
+	db, err := d.conn(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	if db.Dialect.Name != database.Postgres.Name {
+		return takeTx(ctx, db, key, q)
+	}
+	// refilledは前回からの経過時間分を補充したトークンの数(容量が上限)
+	query := `
+		INSERT INTO rate_limit_bucket AS b (
+			key,
+			tokens,
+			allowed
+		) VALUES (
+			$1,
+			$2::double precision - 1,
+			true
+		) ON CONFLICT (key) DO UPDATE SET
+			tokens = CASE
+				WHEN LEAST($2::double precision, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * $3::double precision) >= 1
+				THEN LEAST($2::double precision, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * $3::double precision) - 1
+				ELSE LEAST($2::double precision, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * $3::double precision)
+			END,
+			allowed = LEAST($2::double precision, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * $3::double precision) >= 1,
+			updated_at = now()
+		RETURNING
+			tokens,
+			allowed
+	`
+	row := struct {
+		Tokens  float64 `db:"tokens"`
+		Allowed bool    `db:"allowed"`
+	}{}
+	err = db.GetContext(ctx, &row, query, key, float64(q.Limit), q.rate())
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Allowed: row.Allowed, Quota: q, Tokens: row.Tokens}, nil
+}
+
+// takeTx はバケットの行をロックして読み、補充と取り出しをGoで計算して書き戻す
+// 時刻はインスタンスの時計を使う
+func takeTx(ctx context.Context, db *database.DB, key string, q Quota) (Result, error) {
+	d := db.Dialect
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return Result{}, err
+	}
+	defer tx.Rollback()
+	bucket := struct {
+		Tokens    float64   `db:"tokens"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}{}
+	now := time.Now().UTC()
+	capacity := float64(q.Limit)
+	tokens := capacity
+	query := `SELECT tokens, updated_at FROM rate_limit_bucket WHERE ` + d.Quote("key") + ` = ?` + d.ForUpdate()
+	err = tx.GetContext(ctx, &bucket, db.Rebind(query), key)
+	switch {
+	case err == nil:
+		elapsed := max(now.Sub(bucket.UpdatedAt).Seconds(), 0)
+		tokens = math.Min(capacity, bucket.Tokens+elapsed*q.rate())
+	case !errors.Is(err, sql.ErrNoRows):
+		return Result{}, err
+	}
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+	query = `
+		INSERT INTO rate_limit_bucket (
+			` + d.Quote("key") + `,
+			tokens,
+			allowed,
+			updated_at
+		) VALUES (
+			?,
+			?,
+			?,
+			?
+		)` + d.Upsert([]string{d.Quote("key")}, []string{"tokens", "allowed", "updated_at"})
+	_, err = tx.ExecContext(ctx, db.Rebind(query), key, tokens, allowed, now)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Result{}, err
+	}
+	return Result{Allowed: allowed, Quota: q, Tokens: tokens}, nil
+}
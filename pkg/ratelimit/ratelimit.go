@@ -0,0 +1,321 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/auth"
+)
+
+// 既定の上限。RATE_LIMIT_READ, RATE_LIMIT_WRITEで変更できる
+const (
+	defaultRead  = "600/1m"
+	defaultWrite = "60/1m"
+)
+
+// Quota はwindowの間にlimit回までのリクエストを許可するトークンバケット
+// バケットの容量はlimitで、windowをかけて満タンまで補充する
+type Quota struct {
+	Limit  int
+	Window time.Duration
+}
+
+// ParseQuota は "60/1m" の形の上限を読み込む
+func ParseQuota(s string) (Quota, error) {
+	n, w, ok := strings.Cut(s, "/")
+	if !ok {
+		return Quota{}, fmt.Errorf("ratelimit: quota must be <limit>/<window>: %q", s)
+	}
+	limit, err := strconv.Atoi(n)
+	if err != nil || limit <= 0 {
+		return Quota{}, fmt.Errorf("ratelimit: invalid limit: %q", s)
+	}
+	window, err := time.ParseDuration(w)
+	if err != nil || window <= 0 {
+		return Quota{}, fmt.Errorf("ratelimit: invalid window: %q", s)
+	}
+	return Quota{Limit: limit, Window: window}, nil
+}
+
+// rate は1秒あたりに補充するトークンの数
+func (q Quota) rate() float64 {
+	return float64(q.Limit) / q.Window.Seconds()
+}
+
+// Policy はRateLimit-Policyヘッダーの値
+func (q Quota) Policy() string {
+	return fmt.Sprintf("%d;w=%d", q.Limit, int(math.Ceil(q.Window.Seconds())))
+}
+
+// Result はトークンを1つ取り出した結果
+type Result struct {
+	Allowed bool
+	Quota   Quota
+	// Tokens は取り出した後に残っているトークンの数
+	Tokens float64
+}
+
+// Remaining は残りのリクエスト数
+func (r Result) Remaining() int {
+	return int(math.Max(0, math.Floor(r.Tokens)))
+}
+
+// Reset はバケットが満タンになるまでの秒数
+func (r Result) Reset() int {
+	return int(math.Ceil((float64(r.Quota.Limit) - r.Tokens) / r.Quota.rate()))
+}
+
+// RetryAfter は次のトークンが補充されるまでの秒数
+func (r Result) RetryAfter() int {
+	return int(math.Max(1, math.Ceil((1-r.Tokens)/r.Quota.rate())))
+}
+
+// Limiter はインスタンス間で共有するトークンバケット
+// Takeは1回のアトミックな操作で補充と取り出しを行う
+type Limiter interface {
+	Take(ctx context.Context, key string, q Quota) (Result, error)
+}
+
+// Config は環境変数から読み込んだ設定
+type Config struct {
+	Limiter Limiter
+	Read    Quota
+	Write   Quota
+}
+
+var (
+	configOnce sync.Once
+	config     *Config
+	configErr  error
+	// fixed はUseで固定した設定
+	fixed atomic.Pointer[Config]
+)
+
+// Use は設定を固定する。バケットを用意してミドルウェアを動かすテストで使う
+// nilを渡すと環境変数から読む動作に戻る
+func Use(c *Config) {
+	fixed.Store(c)
+}
+
+// FromEnv は環境変数から設定を作成する。RATE_LIMIT=off の場合はnil
+//
+//	RATE_LIMIT_READ  読み込みの上限(例: 600/1m)
+//	RATE_LIMIT_WRITE 書き込みの上限(例: 60/1m)
+//	REDIS_URL        設定した場合はDBの代わりにRedisにバケットを置く
+//	TRUSTED_PROXIES  X-Forwarded-Forを信頼するプロキシ(ClientIPを参照)
+func FromEnv() (*Config, error) {
+	if c := fixed.Load(); c != nil {
+		return c, nil
+	}
+	configOnce.Do(func() {
+		if os.Getenv("RATE_LIMIT") == "off" {
+			return
+		}
+		c := &Config{Limiter: &Database{}}
+		c.Read, configErr = ParseQuota(envOr("RATE_LIMIT_READ", defaultRead))
+		if configErr != nil {
+			return
+		}
+		c.Write, configErr = ParseQuota(envOr("RATE_LIMIT_WRITE", defaultWrite))
+		if configErr != nil {
+			return
+		}
+		if url := os.Getenv("REDIS_URL"); url != "" {
+			c.Limiter, configErr = NewRedis(url)
+			if configErr != nil {
+				return
+			}
+		}
+		config = c
+	})
+	return config, configErr
+}
+
+// Take は読み込みと書き込みで別のバケットからトークンを取り出す
+// bucketはIPBucketかPrincipalBucketで求める
+func (c *Config) Take(ctx context.Context, write bool, bucket string) (Result, error) {
+	q, class := c.Read, "read"
+	if write {
+		q, class = c.Write, "write"
+	}
+	return c.Limiter.Take(ctx, class+":"+bucket, q)
+}
+
+// IPBucket はクライアントのIPアドレスのバケット
+// 認証の前に数えるため、APIキーの総当たりやキーの確認でDBを引くリクエストも数える
+func IPBucket(ip string) string {
+	return "ip:" + ip
+}
+
+// PrincipalBucket は認証した主体のバケット。APIキーはキーのid、JWTはsubごとに数える
+// 同じNATの後ろのキーを分け、複数のIPアドレスから使われるキーもまとめて数える
+func PrincipalBucket(p *auth.Principal) (string, bool) {
+	switch {
+	case p != nil && p.KeyID != 0:
+		return "key:" + strconv.FormatInt(p.KeyID, 10), true
+	case p != nil && p.Name != "":
+		return "sub:" + p.Name, true
+	}
+	return "", false
+}
+
+// Tighter はIPアドレスと主体のバケットの結果のうち厳しい方を返す
+// どちらかが拒否した場合は拒否し、両方が拒否した場合は待つ時間の長い方を返す
+func Tighter(a, b Result) Result {
+	switch {
+	case a.Allowed != b.Allowed:
+		if !a.Allowed {
+			return a
+		}
+		return b
+	case !a.Allowed:
+		if a.RetryAfter() >= b.RetryAfter() {
+			return a
+		}
+		return b
+	}
+	if a.Remaining() <= b.Remaining() {
+		return a
+	}
+	return b
+}
+
+// Tracker は1つのリクエストで取り出したバケットの結果を持つ
+// 認証の前にIPアドレスのバケットを数え、認証の後にTakePrincipalで主体のバケットも数える
+type Tracker struct {
+	config *Config
+	write  bool
+	// Result はこれまでに数えたバケットのうち厳しい方の結果
+	Result Result
+}
+
+// Track はIPアドレスのバケットからトークンを取り出し、結果を持つTrackerを返す
+func (c *Config) Track(ctx context.Context, write bool, ip string) (*Tracker, error) {
+	res, err := c.Take(ctx, write, IPBucket(ip))
+	if err != nil {
+		return nil, err
+	}
+	return &Tracker{config: c, write: write, Result: res}, nil
+}
+
+// TakePrincipal は主体のバケットからもトークンを取り出し、Resultを厳しい方の結果にする
+// 主体がない場合は何もしない
+func (t *Tracker) TakePrincipal(ctx context.Context, p *auth.Principal) error {
+	bucket, ok := PrincipalBucket(p)
+	if !ok {
+		return nil
+	}
+	res, err := t.config.Take(ctx, t.write, bucket)
+	if err != nil {
+		return err
+	}
+	t.Result = Tighter(t.Result, res)
+	return nil
+}
+
+type contextKey struct{}
+
+// NewContext はTrackerを保存する。認証の後のミドルウェアが主体のバケットを数えるのに使う
+func NewContext(ctx context.Context, t *Tracker) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext はNewContextで保存したTrackerを返す
+func FromContext(ctx context.Context) *Tracker {
+	t, _ := ctx.Value(contextKey{}).(*Tracker)
+	return t
+}
+
+// ClientIP はクライアントのIPアドレスを返す
+// クライアントが送ったX-Real-IPやX-Forwarded-Forの先頭は偽装できるため使わない
+// Vercel(VERCEL=1)ではVercelが設定するx-vercel-forwarded-forを使う
+// TRUSTED_PROXIES(カンマ区切りのCIDRかIPアドレス)を設定した場合は、信頼するプロキシからの接続に限り
+// X-Forwarded-Forを末尾から辿り、信頼するプロキシではない最初のアドレスを使う
+// それ以外は接続元のアドレスを使う
+func ClientIP(h http.Header, remoteAddr string) string {
+	if os.Getenv("VERCEL") == "1" {
+		if xff := h.Get("X-Vercel-Forwarded-For"); xff != "" {
+			ip, _, _ := strings.Cut(xff, ",")
+			return strings.TrimSpace(ip)
+		}
+	}
+	remote := remoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		remote = host
+	}
+	proxies := trustedProxies()
+	if !trusted(proxies, remote) {
+		return remote
+	}
+	hops := strings.Split(strings.Join(h.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if !trusted(proxies, hop) {
+			return hop
+		}
+	}
+	return remote
+}
+
+// trustedProxies はTRUSTED_PROXIESを読む。読めない値は無視する
+func trustedProxies() []*net.IPNet {
+	var nets []*net.IPNet
+	for _, s := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		s = strings.TrimSpace(s)
+		if ip := net.ParseIP(s); ip != nil {
+			bits := 8 * len(ip)
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		if _, n, err := net.ParseCIDR(s); err == nil {
+			nets = append(nets, n)
+		}
+	}
+	return nets
+}
+
+func trusted(proxies []*net.IPNet, addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range proxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetHeaders はRateLimit-*ヘッダーを設定する。拒否した場合はRetry-Afterも設定する
+func SetHeaders(h http.Header, res Result) {
+	h.Set("RateLimit-Policy", res.Quota.Policy())
+	h.Set("RateLimit-Limit", strconv.Itoa(res.Quota.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining()))
+	h.Set("RateLimit-Reset", strconv.Itoa(res.Reset()))
+	if !res.Allowed {
+		h.Set("Retry-After", strconv.Itoa(res.RetryAfter()))
+	}
+}
+
+func envOr(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,184 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/dbtest"
+)
+
+func TestParseQuota(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want Quota
+		ok   bool
+	}{
+		{"60/1m", Quota{Limit: 60, Window: time.Minute}, true},
+		{"5/10s", Quota{Limit: 5, Window: 10 * time.Second}, true},
+		{"60", Quota{}, false},
+		{"0/1m", Quota{}, false},
+		{"x/1m", Quota{}, false},
+		{"60/0s", Quota{}, false},
+		{"60/m", Quota{}, false},
+	} {
+		got, err := ParseQuota(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseQuota(%q) = %+v, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestSetHeaders(t *testing.T) {
+	q := Quota{Limit: 10, Window: 10 * time.Second}
+	h := http.Header{}
+	SetHeaders(h, Result{Allowed: true, Quota: q, Tokens: 7.5})
+	want := map[string]string{
+		"RateLimit-Policy":    "10;w=10",
+		"RateLimit-Limit":     "10",
+		"RateLimit-Remaining": "7",
+		"RateLimit-Reset":     "3",
+		"Retry-After":         "",
+	}
+	for name, v := range want {
+		if got := h.Get(name); got != v {
+			t.Errorf("%s = %q, want %q", name, got, v)
+		}
+	}
+
+	// 拒否した場合は次のトークンまでの秒数をRetry-Afterにする
+	h = http.Header{}
+	SetHeaders(h, Result{Allowed: false, Quota: q, Tokens: 0.2})
+	if h.Get("RateLimit-Remaining") != "0" || h.Get("Retry-After") != "1" {
+		t.Fatalf("headers = %v", h)
+	}
+}
+
+func TestDatabase(t *testing.T) {
+	db := dbtest.Open(t)
+	limiter := &Database{DB: db}
+	ctx := context.Background()
+	q := Quota{Limit: 2, Window: time.Minute}
+
+	for i, want := range []bool{true, true, false} {
+		res, err := limiter.Take(ctx, "read:ip:192.0.2.1", q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Allowed != want {
+			t.Fatalf("take %d: allowed = %v, want %v", i, res.Allowed, want)
+		}
+	}
+	// バケットはキーごとに分かれる
+	res, err := limiter.Take(ctx, "read:ip:192.0.2.2", q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Allowed || res.Remaining() != 1 {
+		t.Fatalf("other key = %+v", res)
+	}
+
+	// 30秒たつと1分で2個の半分の1個が補充される
+	dbtest.Exec(t, db, `UPDATE rate_limit_bucket SET updated_at = ? WHERE key = ?`, time.Now().UTC().Add(-30*time.Second), "read:ip:192.0.2.1")
+	res, err = limiter.Take(ctx, "read:ip:192.0.2.1", q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Allowed || res.Remaining() != 0 {
+		t.Fatalf("after refill = %+v", res)
+	}
+
+	// 補充は容量までで、それ以上はたまらない
+	dbtest.Exec(t, db, `UPDATE rate_limit_bucket SET updated_at = ? WHERE key = ?`, time.Now().UTC().Add(-time.Hour), "read:ip:192.0.2.1")
+	res, err = limiter.Take(ctx, "read:ip:192.0.2.1", q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Allowed || res.Remaining() != 1 {
+		t.Fatalf("after full refill = %+v", res)
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		headers map[string][]string
+		remote  string
+		want    string
+	}{
+		{"remote addr", nil, nil, "192.0.2.1:1234", "192.0.2.1"},
+		{"remote addr without port", nil, nil, "192.0.2.1", "192.0.2.1"},
+		{
+			// 信頼するプロキシがない場合はクライアントが送ったヘッダーを使わない
+			"spoofed headers", nil,
+			map[string][]string{"X-Forwarded-For": {"198.51.100.1"}, "X-Real-IP": {"198.51.100.2"}},
+			"192.0.2.1:1234", "192.0.2.1",
+		},
+		{
+			"untrusted proxy", map[string]string{"TRUSTED_PROXIES": "10.0.0.0/8"},
+			map[string][]string{"X-Forwarded-For": {"198.51.100.1"}},
+			"192.0.2.1:1234", "192.0.2.1",
+		},
+		{
+			// 信頼するプロキシが追加した末尾のアドレスを使い、クライアントが送った先頭は使わない
+			"trusted proxy", map[string]string{"TRUSTED_PROXIES": "10.0.0.0/8, 192.0.2.9"},
+			map[string][]string{"X-Forwarded-For": {"198.51.100.1, 203.0.113.7", "10.0.0.2"}},
+			"192.0.2.9:1234", "203.0.113.7",
+		},
+		{
+			"trusted proxy without header", map[string]string{"TRUSTED_PROXIES": "10.0.0.0/8"},
+			nil, "10.0.0.1:1234", "10.0.0.1",
+		},
+		{
+			"vercel", map[string]string{"VERCEL": "1"},
+			map[string][]string{"X-Vercel-Forwarded-For": {"203.0.113.7"}, "X-Forwarded-For": {"198.51.100.1"}},
+			"10.0.0.1:1234", "203.0.113.7",
+		},
+		{
+			// Vercelの外ではx-vercel-forwarded-forも偽装できる
+			"vercel header outside vercel", nil,
+			map[string][]string{"X-Vercel-Forwarded-For": {"203.0.113.7"}},
+			"192.0.2.1:1234", "192.0.2.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VERCEL", "")
+			t.Setenv("TRUSTED_PROXIES", "")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			h := http.Header{}
+			for k, vs := range tt.headers {
+				for _, v := range vs {
+					h.Add(k, v)
+				}
+			}
+			if got := ClientIP(h, tt.remote); got != tt.want {
+				t.Errorf("ClientIP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTighter(t *testing.T) {
+	q := Quota{Limit: 10, Window: time.Minute}
+	ip := Result{Allowed: true, Quota: q, Tokens: 5}
+	key := Result{Allowed: true, Quota: q, Tokens: 2}
+	denied := Result{Allowed: false, Quota: q, Tokens: 0.5}
+	if got := Tighter(ip, key); got != key {
+		t.Errorf("Tighter = %+v, want the key result", got)
+	}
+	if got := Tighter(denied, key); got != denied {
+		t.Errorf("Tighter = %+v, want the denied result", got)
+	}
+	if got := Tighter(key, denied); got != denied {
+		t.Errorf("Tighter = %+v, want the denied result", got)
+	}
+	longer := Result{Allowed: false, Quota: q, Tokens: 0}
+	if got := Tighter(denied, longer); got != longer {
+		t.Errorf("Tighter = %+v, want the longer wait", got)
+	}
+}
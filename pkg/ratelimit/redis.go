@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript は補充と取り出しをRedisの中で1回に行う
+// 時刻はRedisのTIMEを使い、インスタンスごとの時計のずれに影響されないようにする
+var takeScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) + tonumber(t[2]) / 1000000
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1]) or capacity
+local ts = tonumber(bucket[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity / rate * 1000))
+return {allowed, tostring(tokens)}
+`)
+
+// Redis はRedisにバケットを置く。バケットは満タンになる時間で消える
+type Redis struct {
+	Client *redis.Client
+}
+
+// NewRedis はREDIS_URL(redis://...)に接続するクライアントを作成する
+// クライアントはインスタンスが生きている間使い回す
+func NewRedis(url string) (*Redis, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	return &Redis{Client: redis.NewClient(opts)}, nil
+}
+
+func (r *Redis) Take(ctx context.Context, key string, q Quota) (Result, error) {
+	v, err := takeScript.Run(ctx, r.Client, []string{"ratelimit:" + key}, q.Limit, q.rate()).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	allowed, _ := v[0].(int64)
+	s, _ := v[1].(string)
+	tokens, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Allowed: allowed == 1, Quota: q, Tokens: tokens}, nil
+}
@@ -0,0 +1,46 @@
+package rbac
+
+import (
+	"context"
+	"net/http"
+
+	"maguro-alternative/varcel-go/pkg/jsonapi"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/response"
+)
+
+// Authorize はハンドラから権限表を確認する
+// 許可しない場合は拒否の理由を403で書き込み、falseを返す
+func Authorize(w http.ResponseWriter, r *http.Request, resource string, action Action) bool {
+	m, err := Current(r.Context())
+	if err != nil {
+		logging.Error(r.Context(), "select", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	denial := m.Check(RoleOf(r.Context()), resource, action)
+	if denial == nil {
+		return true
+	}
+	logging.Error(r.Context(), "forbidden", denial)
+	err = response.WriteStatus(w, r, http.StatusForbidden, denial)
+	if err != nil {
+		logging.Error(r.Context(), "encode", err)
+	}
+	return false
+}
+
+func init() {
+	// JSON:APIのincludeで関連先を取得する前に読む権限を確認する
+	jsonapi.Authorizer = func(ctx context.Context, resource string) (string, error) {
+		m, err := Current(ctx)
+		if err != nil {
+			return "", err
+		}
+		if denial := m.Check(RoleOf(ctx), resource, Read); denial != nil {
+			logging.Error(ctx, "forbidden", denial)
+			return denial.Reason, nil
+		}
+		return "", nil
+	}
+}
@@ -0,0 +1,216 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/auth"
+	"maguro-alternative/varcel-go/pkg/database"
+)
+
+// Action はリソースに対する操作
+type Action string
+
+const (
+	Read   Action = "read"
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+	// BulkDelete は2件以上の削除
+	BulkDelete Action = "bulk_delete"
+)
+
+// 既定のロール。ロールはrole_permissionの行で増やせる
+const (
+	Viewer = "viewer"
+	Editor = "editor"
+	Admin  = "admin"
+)
+
+// 権限表を読み直す間隔。RBAC_CACHE_TTLで変更できる
+const defaultTTL = 30 * time.Second
+
+// DeleteAction は削除する件数から操作を返す
+func DeleteAction(n int) Action {
+	if n > 1 {
+		return BulkDelete
+	}
+	return Delete
+}
+
+// RoleOf はリクエストのロールを返す。キーなしの読み込みはviewerとして扱う
+func RoleOf(ctx context.Context) string {
+	p := auth.FromContext(ctx)
+	if p == nil || p.Role == "" {
+		return Viewer
+	}
+	return p.Role
+}
+
+// Rule はrole_permissionテーブルの行
+// ResourceとActionはpath.Matchのパターンで、"*_type"や"*"も指定できる
+type Rule struct {
+	Role     string `db:"role" json:"role"`
+	Resource string `db:"resource" json:"resource"`
+	Action   string `db:"action" json:"action"`
+}
+
+func (rule Rule) matches(role, resource string, action Action) bool {
+	if rule.Role != role {
+		return false
+	}
+	if ok, _ := path.Match(rule.Resource, resource); !ok {
+		return false
+	}
+	ok, _ := path.Match(rule.Action, string(action))
+	return ok
+}
+
+// Matrix はロールと許可する操作の表
+type Matrix []Rule
+
+// Allows はロールがリソースに対して操作できるかを判定する
+func (m Matrix) Allows(role, resource string, action Action) bool {
+	for _, rule := range m {
+		if rule.matches(role, resource, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// RolesFor はリソースに対して操作できるロールを返す
+func (m Matrix) RolesFor(resource string, action Action) []string {
+	seen := map[string]bool{}
+	var roles []string
+	for _, rule := range m {
+		if !seen[rule.Role] && rule.matches(rule.Role, resource, action) {
+			seen[rule.Role] = true
+			roles = append(roles, rule.Role)
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// Check は操作を許可しない場合に拒否の理由を返す
+func (m Matrix) Check(role, resource string, action Action) *Denial {
+	if m.Allows(role, resource, action) {
+		return nil
+	}
+	allowed := m.RolesFor(resource, action)
+	reason := fmt.Sprintf("role %q may not %s %s", role, action, resource)
+	if len(allowed) == 0 {
+		reason += "; no role is allowed"
+	} else {
+		reason += "; allowed roles: " + strings.Join(allowed, ", ")
+	}
+	return &Denial{
+		Code:         "forbidden",
+		Reason:       reason,
+		Role:         role,
+		Resource:     resource,
+		Action:       action,
+		AllowedRoles: allowed,
+	}
+}
+
+// Denial は拒否の理由。403のボディとして返す
+type Denial struct {
+	Code         string   `json:"error"`
+	Reason       string   `json:"reason"`
+	Role         string   `json:"role"`
+	Resource     string   `json:"resource"`
+	Action       Action   `json:"action"`
+	AllowedRoles []string `json:"allowed_roles"`
+}
+
+func (d *Denial) Error() string {
+	return d.Reason
+}
+
+// cache はインスタンスごとに権限表を保持する
+var cache struct {
+	sync.Mutex
+	matrix   Matrix
+	loadedAt time.Time
+	// fixed はUseで固定した権限表。設定した場合はDBから読まない
+	fixed Matrix
+}
+
+// Use は権限表を固定する。DBなしでハンドラを動かすテストで使う
+// nilを渡すとrole_permissionから読む動作に戻る
+func Use(m Matrix) {
+	cache.Lock()
+	defer cache.Unlock()
+	cache.fixed = m
+	cache.matrix = nil
+}
+
+func cached() (Matrix, bool) {
+	cache.Lock()
+	defer cache.Unlock()
+	if cache.fixed != nil {
+		return cache.fixed, true
+	}
+	if cache.matrix == nil || time.Since(cache.loadedAt) > ttl() {
+		return nil, false
+	}
+	return cache.matrix, true
+}
+
+// Load は権限表を返す。キャッシュが古い場合だけrole_permissionから読み直す
+func Load(ctx context.Context, db *database.DB) (Matrix, error) {
+	if m, ok := cached(); ok {
+		return m, nil
+	}
+	query := `
+		SELECT
+			role,
+			resource,
+			action
+		FROM
+			role_permission
+		ORDER BY
+			role,
+			resource,
+			action
+	`
+	m := Matrix{}
+	err := db.SelectContext(ctx, &m, query)
+	if err != nil {
+		return nil, err
+	}
+	cache.Lock()
+	cache.matrix, cache.loadedAt = m, time.Now()
+	cache.Unlock()
+	return m, nil
+}
+
+// Current はキャッシュが古い場合だけDBに接続して権限表を読み直す
+// ハンドラとRPCのインターセプタはこちらを使う
+func Current(ctx context.Context) (Matrix, error) {
+	if m, ok := cached(); ok {
+		return m, nil
+	}
+	db, err := database.Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return Load(ctx, db)
+}
+
+func ttl() time.Duration {
+	d, err := time.ParseDuration(os.Getenv("RBAC_CACHE_TTL"))
+	if err != nil || d < 0 {
+		return defaultTTL
+	}
+	return d
+}
@@ -0,0 +1,131 @@
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/auth"
+)
+
+func matrix() Matrix {
+	return Matrix{
+		{Role: Viewer, Resource: "*", Action: string(Read)},
+		{Role: Editor, Resource: "*", Action: string(Read)},
+		{Role: Editor, Resource: "entry", Action: string(Delete)},
+		{Role: Editor, Resource: "*_type", Action: string(Update)},
+		{Role: "curator", Resource: "entry", Action: "*_delete"},
+		{Role: Admin, Resource: "*", Action: "*"},
+	}
+}
+
+func TestMatrixAllows(t *testing.T) {
+	m := matrix()
+	tests := []struct {
+		role     string
+		resource string
+		action   Action
+		want     bool
+	}{
+		{Viewer, "entry", Read, true},
+		{Viewer, "entry", Delete, false},
+		{Editor, "entry", Delete, true},
+		{Editor, "entry", BulkDelete, false},
+		{Editor, "link", Delete, false},
+		// リソースのワイルドカード
+		{Editor, "haircolor_type", Update, true},
+		{Editor, "haircolor", Update, false},
+		// 操作のワイルドカード
+		{"curator", "entry", BulkDelete, true},
+		{"curator", "entry", Delete, false},
+		{Admin, "radar_axis", BulkDelete, true},
+		// 表にないロール
+		{"guest", "entry", Read, false},
+	}
+	for _, tt := range tests {
+		if got := m.Allows(tt.role, tt.resource, tt.action); got != tt.want {
+			t.Errorf("Allows(%s, %s, %s) = %v, want %v", tt.role, tt.resource, tt.action, got, tt.want)
+		}
+	}
+}
+
+func TestMatrixRolesFor(t *testing.T) {
+	m := matrix()
+	tests := []struct {
+		resource string
+		action   Action
+		want     []string
+	}{
+		{"entry", Read, []string{Admin, Editor, Viewer}},
+		{"entry", BulkDelete, []string{Admin, "curator"}},
+		{"eyescolor_type", Update, []string{Admin, Editor}},
+		{"link", Create, []string{Admin}},
+	}
+	for _, tt := range tests {
+		if got := m.RolesFor(tt.resource, tt.action); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("RolesFor(%s, %s) = %v, want %v", tt.resource, tt.action, got, tt.want)
+		}
+	}
+}
+
+func TestMatrixCheck(t *testing.T) {
+	m := matrix()
+	if d := m.Check(Editor, "entry", Delete); d != nil {
+		t.Errorf("Check = %+v, want nil", d)
+	}
+	d := m.Check(Editor, "entry", BulkDelete)
+	want := &Denial{
+		Code:         "forbidden",
+		Reason:       `role "editor" may not bulk_delete entry; allowed roles: admin, curator`,
+		Role:         Editor,
+		Resource:     "entry",
+		Action:       BulkDelete,
+		AllowedRoles: []string{Admin, "curator"},
+	}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("Check = %+v, want %+v", d, want)
+	}
+	d = Matrix{}.Check(Viewer, "entry", Read)
+	if d == nil || d.Reason != `role "viewer" may not read entry; no role is allowed` {
+		t.Errorf("Check on empty matrix = %+v", d)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	Use(matrix())
+	t.Cleanup(func() { Use(nil) })
+
+	do := func(role string, action Action) (*httptest.ResponseRecorder, bool) {
+		r := httptest.NewRequest(http.MethodDelete, "/api/v1/entry", nil)
+		if role != "" {
+			p := &auth.Principal{Name: "test", Scopes: []string{"*"}, Role: role}
+			r = r.WithContext(auth.NewContext(context.Background(), p))
+		}
+		w := httptest.NewRecorder()
+		return w, Authorize(w, r, "entry", action)
+	}
+
+	if w, ok := do(Editor, Delete); !ok || w.Body.Len() != 0 {
+		t.Errorf("editor delete: ok = %v, body = %s", ok, w.Body.String())
+	}
+
+	// キーなしのリクエストはviewerとして拒否する
+	w, ok := do("", Delete)
+	if ok || w.Code != http.StatusForbidden {
+		t.Fatalf("anonymous delete: ok = %v, status = %d", ok, w.Code)
+	}
+	var body Denial
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v: %s", err, w.Body.String())
+	}
+	if body.Code != "forbidden" || body.Role != Viewer || body.Action != Delete ||
+		body.Reason != `role "viewer" may not delete entry; allowed roles: admin, editor` {
+		t.Errorf("body = %+v", body)
+	}
+	if !reflect.DeepEqual(body.AllowedRoles, []string{Admin, Editor}) {
+		t.Errorf("allowed_roles = %v", body.AllowedRoles)
+	}
+}
@@ -0,0 +1,103 @@
+package response
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/codec"
+	"maguro-alternative/varcel-go/pkg/jsonapi"
+	"maguro-alternative/varcel-go/pkg/timing"
+)
+
+// debugEnvelope は?debug=sqlの場合のレスポンス
+type debugEnvelope struct {
+	Data  interface{} `json:"data"`
+	Debug debugInfo   `json:"debug"`
+}
+
+type debugInfo struct {
+	Queries []timing.Query `json:"queries"`
+}
+
+// Write は値をAcceptで要求された方式(JSON, MessagePack, CBOR)で書き込む
+// エンコードにかかった時間はServer-Timingのencodeとして記録し、
+// デバッグモードの場合は実行したSQLと一緒にエンベロープに包んで返す
+// AcceptでJSON:APIが要求された場合はJSON:APIのドキュメントとして返す
+// Acceptに対応している方式がない場合は406を返す
+func Write(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	return WriteStatus(w, r, http.StatusOK, v)
+}
+
+// WriteStatus はステータスコードを指定して値を書き込む
+func WriteStatus(w http.ResponseWriter, r *http.Request, status int, v interface{}) error {
+	start := time.Now()
+	rec := timing.FromContext(r.Context())
+	w.Header().Add("Vary", "Accept")
+	if jsonapi.Accepts(r) {
+		code, b, err := jsonapi.Marshal(r, v)
+		if err != nil {
+			return err
+		}
+		// クエリパラメータの誤りはJSON:APIのエラーオブジェクトと一緒に返す
+		if code != http.StatusOK {
+			status = code
+		}
+		if rec != nil {
+			rec.Add("encode", "", time.Since(start))
+		}
+		w.Header().Set("Content-Type", jsonapi.MediaType)
+		writeHeader(w, status)
+		_, err = w.Write(append(b, '\n'))
+		return err
+	}
+	if rec != nil && rec.Debug {
+		v = debugEnvelope{Data: v, Debug: debugInfo{Queries: rec.Queries()}}
+	}
+	c, ok := codec.Negotiate(r)
+	if !ok {
+		// エラーは方式を選べなくてもJSONで理由を返す
+		if status == http.StatusOK {
+			http.Error(w, "not acceptable; supported media types: "+strings.Join(codec.MediaTypes, ", "), http.StatusNotAcceptable)
+			return nil
+		}
+		c = codec.JSON
+	}
+	b, err := c.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if rec != nil {
+		rec.Add("encode", "", time.Since(start))
+	}
+	if c == codec.JSON {
+		b = append(b, '\n')
+	}
+	w.Header().Set("Content-Type", c.MediaType())
+	writeHeader(w, status)
+	_, err = w.Write(b)
+	return err
+}
+
+// writeHeader は200以外の場合だけステータスコードを書き込む
+// 200はWriteで暗黙に送られるため、エラーの後に呼ばれても二重に書き込まない
+func writeHeader(w http.ResponseWriter, status int) {
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+	}
+}
+
+// Unmarshal は読み込み済みのリクエストボディをContent-Typeに従ってデコードする
+func Unmarshal(r *http.Request, data []byte, v interface{}) error {
+	return codec.Unmarshal(r, data, v)
+}
+
+// Decode はリクエストボディを読み込み、Content-Typeに従ってデコードする
+func Decode(r *http.Request, v interface{}) error {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	return codec.Unmarshal(r, data, v)
+}
@@ -0,0 +1,47 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type idsJson struct {
+	IDs []int64 `json:"ids"`
+}
+
+func TestWriteStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		accept      string
+		status      int
+		wantStatus  int
+		contentType string
+	}{
+		{"default", "", http.StatusOK, http.StatusOK, "application/json"},
+		{"msgpack", "application/msgpack", http.StatusOK, http.StatusOK, "application/msgpack"},
+		{"cbor", "text/html, application/cbor;q=0.5", http.StatusOK, http.StatusOK, "application/cbor"},
+		{"jsonapi", "application/vnd.api+json", http.StatusOK, http.StatusOK, "application/vnd.api+json"},
+		{"not acceptable", "text/html", http.StatusOK, http.StatusNotAcceptable, "text/plain; charset=utf-8"},
+		// エラーは方式を選べなくてもJSONで返す
+		{"error not acceptable", "text/html", http.StatusForbidden, http.StatusForbidden, "application/json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/entry", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			if err := WriteStatus(w, r, tt.status, &idsJson{IDs: []int64{1}}); err != nil {
+				t.Fatal(err)
+			}
+			if w.Code != tt.wantStatus || w.Header().Get("Content-Type") != tt.contentType {
+				t.Errorf("status = %d, content type = %q: %s", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+			}
+			if w.Header().Get("Vary") != "Accept" {
+				t.Errorf("Vary = %q", w.Header().Get("Vary"))
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	"maguro-alternative/varcel-go/pkg/auth"
+	v1 "maguro-alternative/varcel-go/pkg/gen/vercelgo/v1"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/rbac"
+)
+
+// serviceResources はサービスとカタログのリソース名の対応
+var serviceResources = map[string]string{
+	"vercelgo.v1.EntryService":    "entry",
+	"vercelgo.v1.BWHService":      "bwh",
+	"vercelgo.v1.LinkService":     "link",
+	"vercelgo.v1.EntryTagService": "entry_tag",
+}
+
+// typeResources はTypeServiceのテーブルとカタログのリソース名の対応
+var typeResources = map[v1.TypeTable]string{
+	v1.TypeTable_TYPE_TABLE_HAIRCOLOR:   "haircolor_type",
+	v1.TypeTable_TYPE_TABLE_EYECOLOR:    "eyescolor_type",
+	v1.TypeTable_TYPE_TABLE_HAIRSTYLE:   "hairstyle_type",
+	v1.TypeTable_TYPE_TABLE_HAIRLENGTH:  "hairlength_type",
+	v1.TypeTable_TYPE_TABLE_PERSONALITY: "personality_type",
+}
+
+var writeMethods = map[string]bool{
+	"Create": true,
+	"Update": true,
+	"Delete": true,
+}
+
+// actionOf はRPCの名前から権限表の操作を求める
+// Deleteは2件以上の場合に一括削除として扱う
+func actionOf(method string, msg any) rbac.Action {
+	switch method {
+	case "Create":
+		return rbac.Create
+	case "Update":
+		return rbac.Update
+	case "Delete":
+		switch m := msg.(type) {
+		case interface{ GetIds() []int64 }:
+			return rbac.DeleteAction(len(m.GetIds()))
+		case interface{ GetEntryIds() []int64 }:
+			return rbac.DeleteAction(len(m.GetEntryIds()))
+		}
+		return rbac.BulkDelete
+	}
+	return rbac.Read
+}
+
+// resourceOf は呼び出されたRPCの対象のリソース名を返す
+func resourceOf(req connect.AnyRequest) (string, bool) {
+	service, _, _ := strings.Cut(strings.TrimPrefix(req.Spec().Procedure, "/"), "/")
+	if resource, ok := serviceResources[service]; ok {
+		return resource, true
+	}
+	if msg, ok := req.Any().(interface{ GetTable() v1.TypeTable }); ok {
+		resource, ok := typeResources[msg.GetTable()]
+		return resource, ok
+	}
+	return "", false
+}
+
+// authInterceptor はREST(middleware.APIKey)と同じ規則でAPIキーを確認し、
+// RESTのハンドラと同じ権限表でロールを確認する
+// Connectは読み込みもPOSTで呼ばれるため、HTTPメソッドではなくRPCの名前で判定する
+func authInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			resource, ok := resourceOf(req)
+			if !ok {
+				return next(ctx, req)
+			}
+			_, method, _ := strings.Cut(strings.TrimPrefix(req.Spec().Procedure, "/"), "/")
+			scope := auth.WriteScope(resource)
+			if !writeMethods[method] {
+				scope = auth.ReadScope(resource)
+			}
+			if writeMethods[method] || auth.ReadsRequireKey() {
+				p, err := auth.Authorize(ctx, req.Header().Get("Authorization"), scope)
+				if err != nil {
+					return nil, authError(ctx, err)
+				}
+				ctx = auth.NewContext(ctx, p)
+			}
+			m, err := rbac.Current(ctx)
+			if err != nil {
+				logging.Error(ctx, "select", err)
+				return nil, connect.NewError(connect.CodeUnavailable, err)
+			}
+			if denial := m.Check(rbac.RoleOf(ctx), resource, actionOf(method, req.Any())); denial != nil {
+				logging.Error(ctx, "forbidden", denial)
+				return nil, connect.NewError(connect.CodePermissionDenied, denial)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+func authError(ctx context.Context, err error) error {
+	var scopeErr *auth.ScopeError
+	switch {
+	case errors.As(err, &scopeErr):
+		logging.Error(ctx, "auth", err)
+		return connect.NewError(connect.CodePermissionDenied, err)
+	case errors.Is(err, auth.ErrMissingKey):
+		logging.Error(ctx, "auth", err)
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	case errors.Is(err, auth.ErrInvalidKey):
+		logging.Error(ctx, "auth", err)
+		return connect.NewError(connect.CodeUnauthenticated, auth.ErrInvalidKey)
+	case errors.Is(err, auth.ErrInvalidToken):
+		logging.Error(ctx, "auth", err)
+		return connect.NewError(connect.CodeUnauthenticated, auth.ErrInvalidToken)
+	}
+	logging.Error(ctx, "db", err)
+	return connect.NewError(connect.CodeUnavailable, err)
+}
@@ -0,0 +1,129 @@
+package rpc
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	v1 "maguro-alternative/varcel-go/pkg/gen/vercelgo/v1"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+type bwhServer struct{}
+
+func bwhToProto(b model.BWH) *v1.BWH {
+	return &v1.BWH{
+		EntryId: b.EntryID,
+		Bust:    b.Bust,
+		Waist:   b.Waist,
+		Hip:     b.Hip,
+		Height:  b.Height,
+		Weight:  b.Weight,
+	}
+}
+
+func bwhFromProto(b *v1.BWH) model.BWH {
+	return model.BWH{
+		EntryID: b.GetEntryId(),
+		Bust:    b.GetBust(),
+		Waist:   b.GetWaist(),
+		Hip:     b.GetHip(),
+		Height:  b.Height,
+		Weight:  b.Weight,
+	}
+}
+
+func (bwhServer) Get(ctx context.Context, req *connect.Request[v1.BWHServiceGetRequest]) (*connect.Response[v1.BWHServiceGetResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	bwh, err := (&store.BWHs{DB: db}).Get(ctx, req.Msg.GetEntryId())
+	if err != nil {
+		return nil, storeError(ctx, "select", err)
+	}
+	return connect.NewResponse(&v1.BWHServiceGetResponse{Bwh: bwhToProto(bwh)}), nil
+}
+
+func (bwhServer) List(ctx context.Context, req *connect.Request[v1.BWHServiceListRequest]) (*connect.Response[v1.BWHServiceListResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	bwhs, err := (&store.BWHs{DB: db}).List(ctx, req.Msg.GetEntryIds())
+	if err != nil {
+		return nil, storeError(ctx, "select", err)
+	}
+	return connect.NewResponse(&v1.BWHServiceListResponse{Bwhs: convert(bwhs, bwhToProto)}), nil
+}
+
+func (bwhServer) Search(ctx context.Context, req *connect.Request[v1.BWHServiceSearchRequest]) (*connect.Response[v1.BWHServiceSearchResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	bwhs, err := (&store.BWHs{DB: db}).Search(ctx, store.BWHSearch{
+		MinBust:  req.Msg.MinBust,
+		MaxBust:  req.Msg.MaxBust,
+		MinWaist: req.Msg.MinWaist,
+		MaxWaist: req.Msg.MaxWaist,
+		MinHip:   req.Msg.MinHip,
+		MaxHip:   req.Msg.MaxHip,
+		Page:     page(req.Msg.GetLimit(), req.Msg.GetOffset()),
+	})
+	if err != nil {
+		return nil, storeError(ctx, "select", err)
+	}
+	return connect.NewResponse(&v1.BWHServiceSearchResponse{Bwhs: convert(bwhs, bwhToProto)}), nil
+}
+
+func (bwhServer) Create(ctx context.Context, req *connect.Request[v1.BWHServiceCreateRequest]) (*connect.Response[v1.BWHServiceCreateResponse], error) {
+	bwhs := convert(req.Msg.GetBwhs(), bwhFromProto)
+	if err := validate(ctx, bwhs); err != nil {
+		return nil, err
+	}
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	bwhs, err = (&store.BWHs{DB: db}).Create(ctx, bwhs)
+	if err != nil {
+		return nil, storeError(ctx, "insert", err)
+	}
+	return connect.NewResponse(&v1.BWHServiceCreateResponse{Bwhs: convert(bwhs, bwhToProto)}), nil
+}
+
+func (bwhServer) Update(ctx context.Context, req *connect.Request[v1.BWHServiceUpdateRequest]) (*connect.Response[v1.BWHServiceUpdateResponse], error) {
+	bwhs := convert(req.Msg.GetBwhs(), bwhFromProto)
+	if err := validate(ctx, bwhs); err != nil {
+		return nil, err
+	}
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	err = (&store.BWHs{DB: db}).Update(ctx, bwhs)
+	if err != nil {
+		return nil, storeError(ctx, "update", err)
+	}
+	return connect.NewResponse(&v1.BWHServiceUpdateResponse{Bwhs: convert(bwhs, bwhToProto)}), nil
+}
+
+func (bwhServer) Delete(ctx context.Context, req *connect.Request[v1.BWHServiceDeleteRequest]) (*connect.Response[v1.BWHServiceDeleteResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	err = (&store.BWHs{DB: db}).Delete(ctx, req.Msg.GetEntryIds())
+	if err != nil {
+		return nil, storeError(ctx, "delete", err)
+	}
+	return connect.NewResponse(&v1.BWHServiceDeleteResponse{EntryIds: req.Msg.GetEntryIds()}), nil
+}
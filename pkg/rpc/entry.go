@@ -0,0 +1,127 @@
+package rpc
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "maguro-alternative/varcel-go/pkg/gen/vercelgo/v1"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+type entryServer struct{}
+
+func entryToProto(e model.Entry) *v1.Entry {
+	return &v1.Entry{
+		Id:        e.ID,
+		SourceId:  e.SourceID,
+		Name:      e.Name,
+		Image:     e.Image,
+		Content:   e.Content,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+}
+
+func entryFromProto(e *v1.Entry) model.Entry {
+	return model.Entry{
+		ID:        e.GetId(),
+		SourceID:  e.GetSourceId(),
+		Name:      e.GetName(),
+		Image:     e.GetImage(),
+		Content:   e.GetContent(),
+		CreatedAt: e.GetCreatedAt().AsTime(),
+	}
+}
+
+func (entryServer) Get(ctx context.Context, req *connect.Request[v1.EntryServiceGetRequest]) (*connect.Response[v1.EntryServiceGetResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	entry, err := (&store.Entries{DB: db}).Get(ctx, req.Msg.GetId())
+	if err != nil {
+		return nil, storeError(ctx, "select", err)
+	}
+	return connect.NewResponse(&v1.EntryServiceGetResponse{Entry: entryToProto(entry)}), nil
+}
+
+func (entryServer) List(ctx context.Context, req *connect.Request[v1.EntryServiceListRequest]) (*connect.Response[v1.EntryServiceListResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	entries, err := (&store.Entries{DB: db}).List(ctx, req.Msg.GetIds())
+	if err != nil {
+		return nil, storeError(ctx, "select", err)
+	}
+	return connect.NewResponse(&v1.EntryServiceListResponse{Entries: convert(entries, entryToProto)}), nil
+}
+
+func (entryServer) Search(ctx context.Context, req *connect.Request[v1.EntryServiceSearchRequest]) (*connect.Response[v1.EntryServiceSearchResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	entries, err := (&store.Entries{DB: db}).Search(ctx, store.EntrySearch{
+		Query:    req.Msg.GetQuery(),
+		SourceID: req.Msg.SourceId,
+		TagID:    req.Msg.TagId,
+		Page:     page(req.Msg.GetLimit(), req.Msg.GetOffset()),
+	})
+	if err != nil {
+		return nil, storeError(ctx, "select", err)
+	}
+	return connect.NewResponse(&v1.EntryServiceSearchResponse{Entries: convert(entries, entryToProto)}), nil
+}
+
+func (entryServer) Create(ctx context.Context, req *connect.Request[v1.EntryServiceCreateRequest]) (*connect.Response[v1.EntryServiceCreateResponse], error) {
+	entries := convert(req.Msg.GetEntries(), entryFromProto)
+	if err := validate(ctx, entries); err != nil {
+		return nil, err
+	}
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	entries, err = (&store.Entries{DB: db}).Create(ctx, entries)
+	if err != nil {
+		return nil, storeError(ctx, "insert", err)
+	}
+	return connect.NewResponse(&v1.EntryServiceCreateResponse{Entries: convert(entries, entryToProto)}), nil
+}
+
+func (entryServer) Update(ctx context.Context, req *connect.Request[v1.EntryServiceUpdateRequest]) (*connect.Response[v1.EntryServiceUpdateResponse], error) {
+	entries := convert(req.Msg.GetEntries(), entryFromProto)
+	if err := validate(ctx, entries); err != nil {
+		return nil, err
+	}
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	err = (&store.Entries{DB: db}).Update(ctx, entries)
+	if err != nil {
+		return nil, storeError(ctx, "update", err)
+	}
+	return connect.NewResponse(&v1.EntryServiceUpdateResponse{Entries: convert(entries, entryToProto)}), nil
+}
+
+func (entryServer) Delete(ctx context.Context, req *connect.Request[v1.EntryServiceDeleteRequest]) (*connect.Response[v1.EntryServiceDeleteResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	err = (&store.Entries{DB: db}).Delete(ctx, req.Msg.GetIds())
+	if err != nil {
+		return nil, storeError(ctx, "delete", err)
+	}
+	return connect.NewResponse(&v1.EntryServiceDeleteResponse{Ids: req.Msg.GetIds()}), nil
+}
@@ -0,0 +1,119 @@
+package rpc
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	v1 "maguro-alternative/varcel-go/pkg/gen/vercelgo/v1"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+type entryTagServer struct{}
+
+func entryTagToProto(e model.EntryTag) *v1.EntryTag {
+	return &v1.EntryTag{
+		Id:      e.ID,
+		EntryId: e.EntryID,
+		TagId:   e.TagID,
+	}
+}
+
+func entryTagFromProto(e *v1.EntryTag) model.EntryTag {
+	return model.EntryTag{
+		ID:      e.GetId(),
+		EntryID: e.GetEntryId(),
+		TagID:   e.GetTagId(),
+	}
+}
+
+func (entryTagServer) Get(ctx context.Context, req *connect.Request[v1.EntryTagServiceGetRequest]) (*connect.Response[v1.EntryTagServiceGetResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	entryTag, err := (&store.EntryTags{DB: db}).Get(ctx, req.Msg.GetId())
+	if err != nil {
+		return nil, storeError(ctx, "select", err)
+	}
+	return connect.NewResponse(&v1.EntryTagServiceGetResponse{EntryTag: entryTagToProto(entryTag)}), nil
+}
+
+func (entryTagServer) List(ctx context.Context, req *connect.Request[v1.EntryTagServiceListRequest]) (*connect.Response[v1.EntryTagServiceListResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	entryTags, err := (&store.EntryTags{DB: db}).List(ctx, req.Msg.GetIds())
+	if err != nil {
+		return nil, storeError(ctx, "select", err)
+	}
+	return connect.NewResponse(&v1.EntryTagServiceListResponse{EntryTags: convert(entryTags, entryTagToProto)}), nil
+}
+
+func (entryTagServer) Search(ctx context.Context, req *connect.Request[v1.EntryTagServiceSearchRequest]) (*connect.Response[v1.EntryTagServiceSearchResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	entryTags, err := (&store.EntryTags{DB: db}).Search(ctx, store.EntryTagSearch{
+		EntryID: req.Msg.EntryId,
+		TagID:   req.Msg.TagId,
+		Page:    page(req.Msg.GetLimit(), req.Msg.GetOffset()),
+	})
+	if err != nil {
+		return nil, storeError(ctx, "select", err)
+	}
+	return connect.NewResponse(&v1.EntryTagServiceSearchResponse{EntryTags: convert(entryTags, entryTagToProto)}), nil
+}
+
+func (entryTagServer) Create(ctx context.Context, req *connect.Request[v1.EntryTagServiceCreateRequest]) (*connect.Response[v1.EntryTagServiceCreateResponse], error) {
+	entryTags := convert(req.Msg.GetEntryTags(), entryTagFromProto)
+	if err := validate(ctx, entryTags); err != nil {
+		return nil, err
+	}
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	entryTags, err = (&store.EntryTags{DB: db}).Create(ctx, entryTags)
+	if err != nil {
+		return nil, storeError(ctx, "insert", err)
+	}
+	return connect.NewResponse(&v1.EntryTagServiceCreateResponse{EntryTags: convert(entryTags, entryTagToProto)}), nil
+}
+
+func (entryTagServer) Update(ctx context.Context, req *connect.Request[v1.EntryTagServiceUpdateRequest]) (*connect.Response[v1.EntryTagServiceUpdateResponse], error) {
+	entryTags := convert(req.Msg.GetEntryTags(), entryTagFromProto)
+	if err := validate(ctx, entryTags); err != nil {
+		return nil, err
+	}
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	err = (&store.EntryTags{DB: db}).Update(ctx, entryTags)
+	if err != nil {
+		return nil, storeError(ctx, "update", err)
+	}
+	return connect.NewResponse(&v1.EntryTagServiceUpdateResponse{EntryTags: convert(entryTags, entryTagToProto)}), nil
+}
+
+func (entryTagServer) Delete(ctx context.Context, req *connect.Request[v1.EntryTagServiceDeleteRequest]) (*connect.Response[v1.EntryTagServiceDeleteResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	err = (&store.EntryTags{DB: db}).Delete(ctx, req.Msg.GetIds())
+	if err != nil {
+		return nil, storeError(ctx, "delete", err)
+	}
+	return connect.NewResponse(&v1.EntryTagServiceDeleteResponse{Ids: req.Msg.GetIds()}), nil
+}
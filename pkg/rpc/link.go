@@ -0,0 +1,127 @@
+package rpc
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	v1 "maguro-alternative/varcel-go/pkg/gen/vercelgo/v1"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+type linkServer struct{}
+
+func linkToProto(l model.Link) *v1.Link {
+	return &v1.Link{
+		Id:       l.ID,
+		EntryId:  l.EntryID,
+		Type:     l.Type,
+		Url:      l.URL,
+		Nsfw:     l.Nsfw,
+		Darkness: l.Darkness,
+	}
+}
+
+func linkFromProto(l *v1.Link) model.Link {
+	return model.Link{
+		ID:       l.GetId(),
+		EntryID:  l.GetEntryId(),
+		Type:     l.GetType(),
+		URL:      l.GetUrl(),
+		Nsfw:     l.GetNsfw(),
+		Darkness: l.GetDarkness(),
+	}
+}
+
+func (linkServer) Get(ctx context.Context, req *connect.Request[v1.LinkServiceGetRequest]) (*connect.Response[v1.LinkServiceGetResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	link, err := (&store.Links{DB: db}).Get(ctx, req.Msg.GetId())
+	if err != nil {
+		return nil, storeError(ctx, "select", err)
+	}
+	return connect.NewResponse(&v1.LinkServiceGetResponse{Link: linkToProto(link)}), nil
+}
+
+func (linkServer) List(ctx context.Context, req *connect.Request[v1.LinkServiceListRequest]) (*connect.Response[v1.LinkServiceListResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	links, err := (&store.Links{DB: db}).List(ctx, req.Msg.GetIds())
+	if err != nil {
+		return nil, storeError(ctx, "select", err)
+	}
+	return connect.NewResponse(&v1.LinkServiceListResponse{Links: convert(links, linkToProto)}), nil
+}
+
+func (linkServer) Search(ctx context.Context, req *connect.Request[v1.LinkServiceSearchRequest]) (*connect.Response[v1.LinkServiceSearchResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	links, err := (&store.Links{DB: db}).Search(ctx, store.LinkSearch{
+		EntryID:  req.Msg.EntryId,
+		Type:     req.Msg.Type,
+		Nsfw:     req.Msg.Nsfw,
+		Darkness: req.Msg.Darkness,
+		Page:     page(req.Msg.GetLimit(), req.Msg.GetOffset()),
+	})
+	if err != nil {
+		return nil, storeError(ctx, "select", err)
+	}
+	return connect.NewResponse(&v1.LinkServiceSearchResponse{Links: convert(links, linkToProto)}), nil
+}
+
+func (linkServer) Create(ctx context.Context, req *connect.Request[v1.LinkServiceCreateRequest]) (*connect.Response[v1.LinkServiceCreateResponse], error) {
+	links := convert(req.Msg.GetLinks(), linkFromProto)
+	if err := validate(ctx, links); err != nil {
+		return nil, err
+	}
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	links, err = (&store.Links{DB: db}).Create(ctx, links)
+	if err != nil {
+		return nil, storeError(ctx, "insert", err)
+	}
+	return connect.NewResponse(&v1.LinkServiceCreateResponse{Links: convert(links, linkToProto)}), nil
+}
+
+func (linkServer) Update(ctx context.Context, req *connect.Request[v1.LinkServiceUpdateRequest]) (*connect.Response[v1.LinkServiceUpdateResponse], error) {
+	links := convert(req.Msg.GetLinks(), linkFromProto)
+	if err := validate(ctx, links); err != nil {
+		return nil, err
+	}
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	err = (&store.Links{DB: db}).Update(ctx, links)
+	if err != nil {
+		return nil, storeError(ctx, "update", err)
+	}
+	return connect.NewResponse(&v1.LinkServiceUpdateResponse{Links: convert(links, linkToProto)}), nil
+}
+
+func (linkServer) Delete(ctx context.Context, req *connect.Request[v1.LinkServiceDeleteRequest]) (*connect.Response[v1.LinkServiceDeleteResponse], error) {
+	db, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	err = (&store.Links{DB: db}).Delete(ctx, req.Msg.GetIds())
+	if err != nil {
+		return nil, storeError(ctx, "delete", err)
+	}
+	return connect.NewResponse(&v1.LinkServiceDeleteResponse{Ids: req.Msg.GetIds()}), nil
+}
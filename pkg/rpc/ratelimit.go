@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	"maguro-alternative/varcel-go/pkg/auth"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/ratelimit"
+)
+
+// rateLimitInterceptor はREST(middleware.RateLimit)と同じバケットでリクエスト数を制限する
+// authInterceptorの前に呼び、クライアントのIPアドレスごとに数える
+// レスポンスのヘッダーはprincipalRateLimitInterceptorが数えた主体のバケットと比べて厳しい方にする
+func rateLimitInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			config, err := ratelimit.FromEnv()
+			if err != nil {
+				logging.Error(ctx, "ratelimit", err)
+			}
+			if config == nil {
+				return next(ctx, req)
+			}
+			_, method, _ := strings.Cut(strings.TrimPrefix(req.Spec().Procedure, "/"), "/")
+			t, err := config.Track(ctx, writeMethods[method], ratelimit.ClientIP(req.Header(), req.Peer().Addr))
+			if err != nil {
+				logging.Error(ctx, "ratelimit", err)
+				return next(ctx, req)
+			}
+			if !t.Result.Allowed {
+				return nil, exhausted(t.Result)
+			}
+			resp, err := next(ratelimit.NewContext(ctx, t), req)
+			if resp != nil {
+				ratelimit.SetHeaders(resp.Header(), t.Result)
+			}
+			var cerr *connect.Error
+			if errors.As(err, &cerr) {
+				ratelimit.SetHeaders(cerr.Meta(), t.Result)
+			}
+			return resp, err
+		}
+	}
+}
+
+// principalRateLimitInterceptor はauthInterceptorが認証した主体ごとにもリクエスト数を制限する
+// authInterceptorの後に呼ぶ
+func principalRateLimitInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			t := ratelimit.FromContext(ctx)
+			p := auth.FromContext(ctx)
+			if t == nil || p == nil {
+				return next(ctx, req)
+			}
+			if err := t.TakePrincipal(ctx, p); err != nil {
+				logging.Error(ctx, "ratelimit", err)
+				return next(ctx, req)
+			}
+			if !t.Result.Allowed {
+				return nil, exhausted(t.Result)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// exhausted はバケットが空の場合のエラー。ResourceExhaustedはConnectのHTTPでは429になる
+func exhausted(res ratelimit.Result) error {
+	cerr := connect.NewError(connect.CodeResourceExhausted, errors.New("rate limit exceeded"))
+	ratelimit.SetHeaders(cerr.Meta(), res)
+	return cerr
+}
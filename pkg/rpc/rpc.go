@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"connectrpc.com/connect"
+	validation "github.com/go-ozzo/ozzo-validation"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/gen/vercelgo/v1/vercelgov1connect"
+	"maguro-alternative/varcel-go/pkg/logging"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// Prefix はConnectのサービスを公開するパス
+const Prefix = "/api/rpc"
+
+// NewHandler は全てのサービスを登録したハンドラを返す
+// パスは /api/rpc/vercelgo.v1.EntryService/Get のようになる
+func NewHandler() http.Handler {
+	opts := connect.WithInterceptors(rateLimitInterceptor(), authInterceptor(), principalRateLimitInterceptor())
+	mux := http.NewServeMux()
+	mux.Handle(vercelgov1connect.NewEntryServiceHandler(entryServer{}, opts))
+	mux.Handle(vercelgov1connect.NewBWHServiceHandler(bwhServer{}, opts))
+	mux.Handle(vercelgov1connect.NewLinkServiceHandler(linkServer{}, opts))
+	mux.Handle(vercelgov1connect.NewEntryTagServiceHandler(entryTagServer{}, opts))
+	mux.Handle(vercelgov1connect.NewTypeServiceHandler(typeServer{}, opts))
+	return http.StripPrefix(Prefix, mux)
+}
+
+// open はリクエストごとにDBに接続する
+func open(ctx context.Context) (*database.DB, error) {
+	db, err := database.Open(ctx)
+	if err != nil {
+		logging.Error(ctx, "sql_open", err)
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+	return db, nil
+}
+
+// storeError はstoreのエラーをConnectのエラーコードに変換する
+func storeError(ctx context.Context, class string, err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	logging.Error(ctx, class, err)
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+// validate は全ての要素を検証する
+func validate[T any, PT interface {
+	*T
+	validation.Validatable
+}](ctx context.Context, items []T) error {
+	if len(items) == 0 {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("empty request"))
+	}
+	for i := range items {
+		err := PT(&items[i]).Validate()
+		if err != nil {
+			logging.Error(ctx, "validation", err)
+			return connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+	return nil
+}
+
+// page はリクエストのlimit, offsetをstoreの範囲に変換する
+func page(limit, offset int32) store.Page {
+	return store.Page{Limit: int(limit), Offset: int(offset)}
+}
+
+// convert はスライスの要素を変換する
+func convert[T, U any](items []T, f func(T) U) []U {
+	out := make([]U, len(items))
+	for i, item := range items {
+		out[i] = f(item)
+	}
+	return out
+}
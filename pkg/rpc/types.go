@@ -0,0 +1,132 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+
+	v1 "maguro-alternative/varcel-go/pkg/gen/vercelgo/v1"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+type typeServer struct{}
+
+var typeTables = map[v1.TypeTable]model.TypeTable{
+	v1.TypeTable_TYPE_TABLE_HAIRCOLOR:   model.HairColorTypeTable,
+	v1.TypeTable_TYPE_TABLE_EYECOLOR:    model.EyeColorTypeTable,
+	v1.TypeTable_TYPE_TABLE_HAIRSTYLE:   model.HairStyleTypeTable,
+	v1.TypeTable_TYPE_TABLE_HAIRLENGTH:  model.HairLengthTypeTable,
+	v1.TypeTable_TYPE_TABLE_PERSONALITY: model.PersonalityTypeTable,
+}
+
+func typeToProto(t model.TypeValue) *v1.TypeValue {
+	return &v1.TypeValue{Id: t.ID, Value: t.Value}
+}
+
+func typeFromProto(t *v1.TypeValue) model.TypeValue {
+	return model.TypeValue{ID: t.GetId(), Value: t.GetValue()}
+}
+
+// openTypes はリクエストで指定されたテーブルのstoreを返す
+func openTypes(ctx context.Context, table v1.TypeTable) (*store.Types, func(), error) {
+	t, ok := typeTables[table]
+	if !ok {
+		return nil, nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown table %s", table))
+	}
+	db, err := open(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &store.Types{DB: db, Table: t}, func() { db.Close() }, nil
+}
+
+func (typeServer) Get(ctx context.Context, req *connect.Request[v1.TypeServiceGetRequest]) (*connect.Response[v1.TypeServiceGetResponse], error) {
+	types, done, err := openTypes(ctx, req.Msg.GetTable())
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	value, err := types.Get(ctx, req.Msg.GetId())
+	if err != nil {
+		return nil, storeError(ctx, "select", err)
+	}
+	return connect.NewResponse(&v1.TypeServiceGetResponse{Type: typeToProto(value)}), nil
+}
+
+func (typeServer) List(ctx context.Context, req *connect.Request[v1.TypeServiceListRequest]) (*connect.Response[v1.TypeServiceListResponse], error) {
+	types, done, err := openTypes(ctx, req.Msg.GetTable())
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	values, err := types.List(ctx, req.Msg.GetIds())
+	if err != nil {
+		return nil, storeError(ctx, "select", err)
+	}
+	return connect.NewResponse(&v1.TypeServiceListResponse{Types: convert(values, typeToProto)}), nil
+}
+
+func (typeServer) Search(ctx context.Context, req *connect.Request[v1.TypeServiceSearchRequest]) (*connect.Response[v1.TypeServiceSearchResponse], error) {
+	types, done, err := openTypes(ctx, req.Msg.GetTable())
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	values, err := types.Search(ctx, store.TypeSearch{
+		Query: req.Msg.GetQuery(),
+		Page:  page(req.Msg.GetLimit(), req.Msg.GetOffset()),
+	})
+	if err != nil {
+		return nil, storeError(ctx, "select", err)
+	}
+	return connect.NewResponse(&v1.TypeServiceSearchResponse{Types: convert(values, typeToProto)}), nil
+}
+
+func (typeServer) Create(ctx context.Context, req *connect.Request[v1.TypeServiceCreateRequest]) (*connect.Response[v1.TypeServiceCreateResponse], error) {
+	values := convert(req.Msg.GetTypes(), typeFromProto)
+	if err := validate(ctx, values); err != nil {
+		return nil, err
+	}
+	types, done, err := openTypes(ctx, req.Msg.GetTable())
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	values, err = types.Create(ctx, values)
+	if err != nil {
+		return nil, storeError(ctx, "insert", err)
+	}
+	return connect.NewResponse(&v1.TypeServiceCreateResponse{Types: convert(values, typeToProto)}), nil
+}
+
+func (typeServer) Update(ctx context.Context, req *connect.Request[v1.TypeServiceUpdateRequest]) (*connect.Response[v1.TypeServiceUpdateResponse], error) {
+	values := convert(req.Msg.GetTypes(), typeFromProto)
+	if err := validate(ctx, values); err != nil {
+		return nil, err
+	}
+	types, done, err := openTypes(ctx, req.Msg.GetTable())
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	err = types.Update(ctx, values)
+	if err != nil {
+		return nil, storeError(ctx, "update", err)
+	}
+	return connect.NewResponse(&v1.TypeServiceUpdateResponse{Types: convert(values, typeToProto)}), nil
+}
+
+func (typeServer) Delete(ctx context.Context, req *connect.Request[v1.TypeServiceDeleteRequest]) (*connect.Response[v1.TypeServiceDeleteResponse], error) {
+	types, done, err := openTypes(ctx, req.Msg.GetTable())
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	err = types.Delete(ctx, req.Msg.GetIds())
+	if err != nil {
+		return nil, storeError(ctx, "delete", err)
+	}
+	return connect.NewResponse(&v1.TypeServiceDeleteResponse{Ids: req.Msg.GetIds()}), nil
+}
@@ -0,0 +1,348 @@
+// Package seed はデモや負荷試験、UIの開発に使う架空のキャラクターを作成する
+// 同じシードと同じ参照テーブル(source, *_type)からは同じデータを作成する
+package seed
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+// Types は*_typeテーブルの行。id順に並べて渡す
+type Types struct {
+	HairColors    []model.TypeValue
+	EyeColors     []model.TypeValue
+	HairStyles    []model.TypeValue
+	HairLengths   []model.TypeValue
+	Personalities []model.TypeValue
+}
+
+// Character は1人分の行。EntryIDは登録後に設定する
+type Character struct {
+	Entry         model.Entry
+	BWH           model.BWH
+	HairColor     model.Attribute
+	EyeColor      model.Attribute
+	HairStyle     model.Attribute
+	HairLength    model.Attribute
+	Personalities []model.Attribute
+	Chart         model.HekiRadarChart
+	Links         []model.Link
+	// Tags はタグの名前。登録時にtagのidに変換する
+	Tags []string
+}
+
+// SetEntryID は登録したentryのidを全ての行に設定する
+func (c *Character) SetEntryID(id int64) {
+	c.Entry.ID = id
+	c.BWH.EntryID = id
+	c.HairColor.EntryID = id
+	c.EyeColor.EntryID = id
+	c.HairStyle.EntryID = id
+	c.HairLength.EntryID = id
+	for i := range c.Personalities {
+		c.Personalities[i].EntryID = id
+	}
+	c.Chart.EntryID = id
+	for i := range c.Links {
+		c.Links[i].EntryID = id
+	}
+}
+
+// 作成日時はこの日から1年の間に散らばせる
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Generator はシードから順にキャラクターを作成する
+type Generator struct {
+	r       *rand.Rand
+	seed    uint64
+	n       int
+	sources []int64
+	types   Types
+}
+
+// New はsourcesとtypesの行を使うGeneratorを作成する
+func New(seed uint64, sources []int64, types Types) (*Generator, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no sources")
+	}
+	for name, values := range map[string][]model.TypeValue{
+		"haircolor_type":   types.HairColors,
+		"eyecolor_type":    types.EyeColors,
+		"hairstyle_type":   types.HairStyles,
+		"hairlength_type":  types.HairLengths,
+		"personality_type": types.Personalities,
+	} {
+		if len(values) == 0 {
+			return nil, fmt.Errorf("%s is empty", name)
+		}
+	}
+	return &Generator{
+		r:       rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15)),
+		seed:    seed,
+		sources: sources,
+		types:   types,
+	}, nil
+}
+
+// Next は次のキャラクターを作成する
+func (g *Generator) Next() Character {
+	g.n++
+	arch := g.archetype()
+	name := pick(g.r, familyNames) + " " + pick(g.r, givenNames)
+	var c Character
+
+	c.BWH = g.bwh()
+	c.HairLength = model.Attribute{ValueID: g.hairLength().ID}
+	length := g.value(g.types.HairLengths, c.HairLength.ValueID)
+	c.HairStyle = model.Attribute{ValueID: g.hairStyle(shortLengths[length]).ID}
+	c.HairColor = model.Attribute{ValueID: weighted(g.r, g.types.HairColors).ID}
+	c.EyeColor = model.Attribute{ValueID: weighted(g.r, g.types.EyeColors).ID}
+	personalities := g.personalities(arch)
+	for _, p := range personalities {
+		c.Personalities = append(c.Personalities, model.Attribute{ValueID: p.ID})
+	}
+	c.Chart = g.chart()
+	c.Links = g.links()
+	c.Tags = g.tags(arch, c.BWH.Height)
+	c.Entry = model.Entry{
+		SourceID:  g.sources[g.r.IntN(len(g.sources))],
+		Name:      name,
+		Image:     fmt.Sprintf("https://picsum.photos/seed/varcel-%d-%d/600/800", g.seed, g.n),
+		Content:   g.content(name, personalities[0].Value),
+		CreatedAt: epoch.Add(time.Duration(g.r.Int64N(int64(365 * 24 * time.Hour)))).Truncate(time.Second),
+	}
+	return c
+}
+
+func (g *Generator) archetype() archetype {
+	total := 0.0
+	for _, a := range archetypes {
+		total += a.weight
+	}
+	x := g.r.Float64() * total
+	for _, a := range archetypes {
+		if x -= a.weight; x < 0 {
+			return a
+		}
+	}
+	return archetypes[len(archetypes)-1]
+}
+
+// bwh は身長から体重とスリーサイズを決める。体重はBMIが18〜22程度になるようにする
+// 1割は身長と体重が非公開(nil)
+func (g *Generator) bwh() model.BWH {
+	height := clamp(158+g.r.NormFloat64()*6, 140, 182)
+	bmi := clamp(19.5+g.r.NormFloat64()*1.4, 16, 25)
+	weight := bmi * (height / 100) * (height / 100)
+	// ウエストとヒップは身長と体格に比例させ、バストはヒップを中心にばらつかせる
+	waist := clamp(height*0.36+(bmi-19.5)*1.5+g.r.NormFloat64()*2, 48, 75)
+	hip := clamp(height*0.53+(bmi-19.5)*1.8+g.r.NormFloat64()*3, 72, 105)
+	bust := clamp(hip+g.r.NormFloat64()*6, waist+10, 110)
+	b := model.BWH{
+		Bust:  int64(math.Round(bust)),
+		Waist: int64(math.Round(waist)),
+		Hip:   int64(math.Round(hip)),
+	}
+	if g.r.Float64() >= 0.1 {
+		h, w := int64(math.Round(height)), int64(math.Round(weight))
+		b.Height, b.Weight = &h, &w
+	}
+	return b
+}
+
+func (g *Generator) hairLength() model.TypeValue {
+	return weighted(g.r, g.types.HairLengths)
+}
+
+// hairStyle は髪の長さと矛盾しない髪型を選ぶ。候補がない場合は全ての髪型から選ぶ
+func (g *Generator) hairStyle(short bool) model.TypeValue {
+	var candidates []model.TypeValue
+	for _, t := range g.types.HairStyles {
+		if shortStyles[t.Value] == short {
+			candidates = append(candidates, t)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = g.types.HairStyles
+	}
+	return weighted(g.r, candidates)
+}
+
+// personalities は1〜3個の性格を選ぶ。類型に合う性格がある場合は7割でそれを最初にする
+func (g *Generator) personalities(arch archetype) []model.TypeValue {
+	n := 1 + g.r.IntN(3)
+	var chosen []model.TypeValue
+	has := func(id int64) bool {
+		for _, c := range chosen {
+			if c.ID == id {
+				return true
+			}
+		}
+		return false
+	}
+	if g.r.Float64() < 0.7 {
+		for _, t := range g.types.Personalities {
+			if t.Value == arch.personalities[g.r.IntN(len(arch.personalities))] {
+				chosen = append(chosen, t)
+				break
+			}
+		}
+	}
+	for tries := 0; len(chosen) < min(n, len(g.types.Personalities)) && tries < 20; tries++ {
+		t := weighted(g.r, g.types.Personalities)
+		if !has(t.ID) {
+			chosen = append(chosen, t)
+		}
+	}
+	return chosen
+}
+
+func (g *Generator) chart() model.HekiRadarChart {
+	ai := clamp(55+g.r.NormFloat64()*20, 0, 100)
+	nu := clamp(45+g.r.NormFloat64()*22+(ai-55)*0.3, 0, 100)
+	return model.HekiRadarChart{AI: int64(math.Round(ai)), NU: int64(math.Round(nu))}
+}
+
+// links は0〜4件のリンクを作成する。闇の深い作品は成人向けの作品に多い
+func (g *Generator) links() []model.Link {
+	n := g.r.IntN(5)
+	links := make([]model.Link, 0, n)
+	for i := 0; i < n; i++ {
+		site := g.site()
+		nsfw := g.r.Float64() < site.nsfw
+		darkness := g.r.Float64() < 0.05
+		if nsfw {
+			darkness = g.r.Float64() < 0.3
+		}
+		links = append(links, model.Link{
+			Type:     site.typ,
+			URL:      g.url(site.typ),
+			Nsfw:     nsfw,
+			Darkness: darkness,
+		})
+	}
+	return links
+}
+
+func (g *Generator) site() linkSite {
+	total := 0.0
+	for _, s := range linkSites {
+		total += s.weight
+	}
+	x := g.r.Float64() * total
+	for _, s := range linkSites {
+		if x -= s.weight; x < 0 {
+			return s
+		}
+	}
+	return linkSites[0]
+}
+
+func (g *Generator) url(typ string) string {
+	handle := fmt.Sprintf("%s%d", pick(g.r, handles), g.r.IntN(1000))
+	switch typ {
+	case "pixiv":
+		return fmt.Sprintf("https://www.pixiv.net/artworks/%d", 100000000+g.r.IntN(30000000))
+	case "x":
+		return fmt.Sprintf("https://x.com/%s/status/%d", handle, 1700000000000000000+g.r.Int64N(100000000000000000))
+	case "booth":
+		return fmt.Sprintf("https://booth.pm/ja/items/%d", 4000000+g.r.IntN(2000000))
+	case "fanbox":
+		return fmt.Sprintf("https://%s.fanbox.cc/posts/%d", handle, 7000000+g.r.IntN(3000000))
+	case "skeb":
+		return fmt.Sprintf("https://skeb.jp/@%s/works/%d", handle, 1+g.r.IntN(500))
+	}
+	const chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+	id := make([]byte, 11)
+	for i := range id {
+		id[i] = chars[g.r.IntN(len(chars))]
+	}
+	return "https://www.youtube.com/watch?v=" + string(id)
+}
+
+// tags は類型のタグを中心に2〜7個のタグを付ける
+func (g *Generator) tags(arch archetype, height *int64) []string {
+	seen := map[string]bool{}
+	var tags []string
+	add := func(tag string) {
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	add(arch.tags[0])
+	for _, tag := range arch.tags[1:] {
+		if g.r.Float64() < 0.55 {
+			add(tag)
+		}
+	}
+	for i, n := 0, g.r.IntN(3); i < n; i++ {
+		add(weightedString(g.r, commonTags))
+	}
+	if height != nil {
+		switch {
+		case *height >= 168:
+			add(tallTag)
+		case *height <= 148:
+			add(shortTag)
+		}
+	}
+	if len(tags) < 2 {
+		add(weightedString(g.r, commonTags))
+	}
+	return tags
+}
+
+func (g *Generator) content(name, personality string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s。%s。", name, pick(g.r, occupations))
+	fmt.Fprintf(&b, "%sな性格で、趣味は%s。", personality, pick(g.r, hobbies))
+	b.WriteString(pick(g.r, quirks))
+	return b.String()
+}
+
+func (g *Generator) value(values []model.TypeValue, id int64) string {
+	for _, v := range values {
+		if v.ID == id {
+			return v.Value
+		}
+	}
+	return ""
+}
+
+func pick[T any](r *rand.Rand, items []T) T {
+	return items[r.IntN(len(items))]
+}
+
+// weighted は前にある行ほど選ばれやすくする(重みは1/(順位+1))
+// 一様に選ぶよりも実際のデータに近い偏りになる
+func weighted(r *rand.Rand, values []model.TypeValue) model.TypeValue {
+	return values[zipf(r, len(values))]
+}
+
+func weightedString(r *rand.Rand, values []string) string {
+	return values[zipf(r, len(values))]
+}
+
+func zipf(r *rand.Rand, n int) int {
+	total := 0.0
+	for i := 0; i < n; i++ {
+		total += 1 / float64(i+1)
+	}
+	x := r.Float64() * total
+	for i := 0; i < n; i++ {
+		if x -= 1 / float64(i+1); x < 0 {
+			return i
+		}
+	}
+	return n - 1
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
@@ -0,0 +1,121 @@
+package seed
+
+// Source はsourceテーブルの行
+type Source struct {
+	Name string
+	URL  string
+	Type string
+}
+
+// Sources は参照テーブルが空の場合に登録する出典
+var Sources = []Source{
+	{Name: "pixiv", URL: "https://www.pixiv.net", Type: "illust"},
+	{Name: "X", URL: "https://x.com", Type: "sns"},
+	{Name: "BOOTH", URL: "https://booth.pm", Type: "shop"},
+	{Name: "pixivFANBOX", URL: "https://www.fanbox.cc", Type: "fanclub"},
+	{Name: "Skeb", URL: "https://skeb.jp", Type: "commission"},
+}
+
+// shortStyles は短い髪でしか成り立たない髪型。値はpkg/vocabularyの語彙
+var shortStyles = map[string]bool{"ボブ": true, "ショート": true, "ウルフカット": true}
+
+// shortLengths は結ぶ髪型にできない長さ
+var shortLengths = map[string]bool{"ベリーショート": true, "ショート": true}
+
+var familyNames = []string{
+	"佐藤", "鈴木", "高橋", "田中", "渡辺", "伊藤", "山本", "中村", "小林", "加藤",
+	"桜井", "月島", "星野", "白石", "黒川", "天野", "水瀬", "神崎", "朝比奈", "柊",
+	"如月", "七瀬", "藤宮", "早乙女", "綾瀬", "一ノ瀬", "氷室", "日向", "真白", "東雲",
+}
+
+var givenNames = []string{
+	"さくら", "葵", "結衣", "陽菜", "凛", "美咲", "彩花", "千尋", "雫", "琴音",
+	"紬", "朱音", "澪", "柚子", "楓", "小春", "ひより", "栞", "詩織", "芽衣",
+	"杏", "莉子", "環", "ほのか", "つばさ", "真琴", "遥", "瑠璃", "すみれ", "なずな",
+}
+
+var occupations = []string{
+	"高校2年生", "高校1年生", "大学3年生", "生徒会長", "図書委員", "喫茶店の店員",
+	"新人アイドル", "見習い魔法使い", "騎士団の副団長", "探偵事務所の助手", "神社の巫女", "保健室の先生",
+}
+
+var hobbies = []string{
+	"読書", "料理", "ゲーム", "天体観測", "写真", "ピアノ", "剣道", "お菓子作り", "昼寝", "猫カフェ巡り", "古書店巡り", "カラオケ",
+}
+
+var quirks = []string{
+	"甘いものに目がない。", "朝が弱い。", "方向音痴。", "機械が苦手。", "実は寂しがり屋。",
+	"負けず嫌い。", "動物に懐かれやすい。", "辛いものが苦手。", "字がとても綺麗。", "",
+}
+
+// archetype はキャラクターの類型。類型ごとに付きやすいタグと性格がある
+// タグの共起がまとまるため、推薦や類似度の確認に使える
+type archetype struct {
+	name          string
+	weight        float64
+	tags          []string
+	personalities []string
+}
+
+var archetypes = []archetype{
+	{name: "お嬢様", weight: 1, tags: []string{"お嬢様", "縦ロール", "高飛車", "紅茶"}, personalities: []string{"ツンデレ", "真面目"}},
+	{name: "幼なじみ", weight: 1.5, tags: []string{"幼なじみ", "世話焼き", "隣の家"}, personalities: []string{"元気", "甘えん坊"}},
+	{name: "生徒会長", weight: 1, tags: []string{"生徒会長", "眼鏡", "優等生", "黒髪ロング"}, personalities: []string{"真面目", "クーデレ"}},
+	{name: "ヤンデレ", weight: 0.6, tags: []string{"ヤンデレ", "重い愛", "ハイライトなし"}, personalities: []string{"ヤンデレ"}},
+	{name: "元気っ娘", weight: 1.5, tags: []string{"運動部", "日焼け", "ポニーテール", "八重歯"}, personalities: []string{"元気", "天然"}},
+	{name: "魔法少女", weight: 0.8, tags: []string{"魔法少女", "変身", "マスコット"}, personalities: []string{"元気", "天然"}},
+	{name: "クール", weight: 1.2, tags: []string{"クール", "ジト目", "無表情"}, personalities: []string{"クーデレ", "無口"}},
+	{name: "小悪魔", weight: 1, tags: []string{"小悪魔", "後輩", "からかい上手"}, personalities: []string{"小悪魔"}},
+	{name: "お姉さん", weight: 1, tags: []string{"お姉さん", "包容力", "先輩"}, personalities: []string{"姉御肌"}},
+	{name: "人外", weight: 0.7, tags: []string{"吸血鬼", "エルフ", "猫耳", "狐耳"}, personalities: []string{"無口", "天然"}},
+}
+
+// commonTags は類型によらず付くタグ。前にあるほど付きやすい
+var commonTags = []string{
+	"制服", "眼鏡", "アホ毛", "八重歯", "ゲーマー", "料理上手", "オッドアイ", "関西弁",
+	"ボクっ娘", "メイド", "巫女", "文芸部", "方向音痴", "ジャージ", "ヘッドホン", "和服",
+}
+
+// 身長から付けるタグ
+const (
+	tallTag  = "高身長"
+	shortTag = "低身長"
+)
+
+// Tags は生成に使う全てのタグ。tagテーブルに登録してから生成する
+func Tags() []string {
+	seen := map[string]bool{}
+	var tags []string
+	add := func(names ...string) {
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				tags = append(tags, name)
+			}
+		}
+	}
+	for _, a := range archetypes {
+		add(a.tags...)
+	}
+	add(commonTags...)
+	add(tallTag, shortTag)
+	return tags
+}
+
+// linkSite はリンク先のサイト。nsfwは成人向けの割合
+type linkSite struct {
+	typ    string
+	weight float64
+	nsfw   float64
+}
+
+var linkSites = []linkSite{
+	{typ: "pixiv", weight: 4, nsfw: 0.2},
+	{typ: "x", weight: 3, nsfw: 0.15},
+	{typ: "booth", weight: 1, nsfw: 0.35},
+	{typ: "fanbox", weight: 1, nsfw: 0.4},
+	{typ: "skeb", weight: 1, nsfw: 0.3},
+	{typ: "youtube", weight: 0.5, nsfw: 0.02},
+}
+
+var handles = []string{"mochi", "sakura", "kuro", "shiro", "nekomata", "hoshi", "tsuki", "yuki", "kiri", "amane", "hina", "sora"}
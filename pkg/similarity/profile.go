@@ -0,0 +1,128 @@
+package similarity
+
+import (
+	"context"
+	"math"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/model"
+	"maguro-alternative/varcel-go/pkg/store"
+)
+
+// Sources はプロファイルを組み立てるためのリポジトリ。テストではメモリの実装を渡す
+type Sources struct {
+	Entries       store.EntryRepository
+	BWHs          store.BWHRepository
+	EntryTags     store.EntryTagRepository
+	HairColors    store.AttributeRepository
+	HairStyles    store.AttributeRepository
+	HairLengths   store.AttributeRepository
+	EyeColors     store.AttributeRepository
+	Personalities store.AttributeRepository
+	Axes          store.RadarAxisRepository
+	Charts        store.RadarChartRepository
+}
+
+// NewSources はDBのリポジトリを返す
+func NewSources(db *database.DB) Sources {
+	return Sources{
+		Entries:       &store.Entries{DB: db},
+		BWHs:          &store.BWHs{DB: db},
+		EntryTags:     &store.EntryTags{DB: db},
+		HairColors:    &store.Attributes{DB: db, Table: model.HairColorTable},
+		HairStyles:    &store.Attributes{DB: db, Table: model.HairStyleTable},
+		HairLengths:   &store.Attributes{DB: db, Table: model.HairLengthTable},
+		EyeColors:     &store.Attributes{DB: db, Table: model.EyeColorTable},
+		Personalities: &store.Attributes{DB: db, Table: model.PersonalityTable},
+		Axes:          &store.RadarAxes{DB: db},
+		Charts:        &store.RadarCharts{DB: db},
+	}
+}
+
+// Load は全てのentryのプロファイルをid順に返す
+// テーブルごとに1回ずつ全件を読む。entryが数万件程度までを想定している
+func (s Sources) Load(ctx context.Context) ([]*Profile, error) {
+	entries, err := s.Entries.List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	profiles := make([]*Profile, len(entries))
+	byID := make(map[int64]*Profile, len(entries))
+	for i, e := range entries {
+		profiles[i] = &Profile{Entry: e}
+		byID[e.ID] = profiles[i]
+	}
+
+	single := []struct {
+		repo store.AttributeRepository
+		set  func(p *Profile, id int64)
+	}{
+		{s.HairColors, func(p *Profile, id int64) { p.HairColor = &id }},
+		{s.HairStyles, func(p *Profile, id int64) { p.HairStyle = &id }},
+		{s.HairLengths, func(p *Profile, id int64) { p.HairLength = &id }},
+		{s.EyeColors, func(p *Profile, id int64) { p.EyeColor = &id }},
+		{s.Personalities, func(p *Profile, id int64) { p.Personalities = append(p.Personalities, id) }},
+	}
+	for _, a := range single {
+		rows, err := a.repo.List(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if p, ok := byID[row.EntryID]; ok {
+				a.set(p, row.ValueID)
+			}
+		}
+	}
+
+	tags, err := s.EntryTags.List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		if p, ok := byID[t.EntryID]; ok {
+			p.Tags = append(p.Tags, t.TagID)
+		}
+	}
+	bwhs, err := s.BWHs.List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range bwhs {
+		if p, ok := byID[bwhs[i].EntryID]; ok {
+			p.BWH = &bwhs[i]
+		}
+	}
+	// レーダーチャートの値は軸ごとにmin〜maxで0〜1にしておく
+	axes, err := s.Axes.List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]model.RadarAxis, len(axes))
+	for _, a := range axes {
+		byKey[a.Key] = a
+	}
+	charts, err := s.Charts.List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range charts {
+		if p, ok := byID[c.EntryID]; ok {
+			p.Chart = normalize(c.Values, byKey)
+		}
+	}
+	return profiles, nil
+}
+
+// normalize は値を軸のmin〜maxで0〜1にする。軸が登録されていない値は除く
+func normalize(values map[string]int64, axes map[string]model.RadarAxis) map[string]float64 {
+	normalized := make(map[string]float64, len(values))
+	for key, v := range values {
+		a, ok := axes[key]
+		if !ok || a.Max <= a.Min {
+			continue
+		}
+		normalized[key] = math.Min(math.Max(float64(v-a.Min)/float64(a.Max-a.Min), 0), 1)
+	}
+	return normalized
+}
@@ -0,0 +1,262 @@
+// Package similarity はキャラクター同士の類似度を属性ごとの重み付きの平均で求める
+package similarity
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+// 類似度を求める属性。重みの指定に使う
+const (
+	HairColor      = "hair_color"
+	HairStyle      = "hair_style"
+	HairLength     = "hair_length"
+	EyeColor       = "eye_color"
+	Personality    = "personality"
+	Tags           = "tags"
+	BWH            = "bwh"
+	HekiRadarChart = "heki_radar_chart"
+)
+
+// Dimensions は属性の一覧。内訳はこの順に並べる
+var Dimensions = []string{HairColor, HairStyle, HairLength, EyeColor, Personality, Tags, BWH, HekiRadarChart}
+
+// Weights は属性ごとの重み
+type Weights map[string]float64
+
+// DefaultWeights はリクエストで指定しなかった属性の重み
+// タグと性格は見た目よりも「似ている」と感じる度合いへの影響が大きいため重くする
+var DefaultWeights = Weights{
+	HairColor:      1,
+	HairStyle:      1,
+	HairLength:     0.5,
+	EyeColor:       1,
+	Personality:    2,
+	Tags:           3,
+	BWH:            1,
+	HekiRadarChart: 1.5,
+}
+
+// ParseWeights は "tags:5,bwh:0" の形式の重みを読み、指定のない属性は既定の重みにする
+// 0を指定した属性は比較しない
+func ParseWeights(s string) (Weights, error) {
+	w := Weights{}
+	for k, v := range DefaultWeights {
+		w[k] = v
+	}
+	if strings.TrimSpace(s) == "" {
+		return w, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid weight %q: want name:value", pair)
+		}
+		if _, ok := DefaultWeights[name]; !ok {
+			return nil, fmt.Errorf("unknown dimension %q", name)
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || f < 0 || math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, fmt.Errorf("invalid weight %q for %s: want a non-negative number", value, name)
+		}
+		w[name] = f
+	}
+	total := 0.0
+	for _, v := range w {
+		total += v
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("all weights are zero")
+	}
+	return w, nil
+}
+
+// Profile はentry1件分の比較に使う属性。値がない属性はnilか空
+type Profile struct {
+	Entry         model.Entry
+	HairColor     *int64
+	HairStyle     *int64
+	HairLength    *int64
+	EyeColor      *int64
+	Personalities []int64
+	Tags          []int64
+	BWH           *model.BWH
+	// Chart はレーダーチャートの軸のキーと、軸のmin〜maxで0〜1にした値
+	Chart map[string]float64
+}
+
+// DimensionScore は属性1つ分の類似度
+type DimensionScore struct {
+	Dimension string  `json:"dimension"`
+	Weight    float64 `json:"weight"`
+	// Score は0〜1の類似度。どちらかに値がない場合はnullで、合計の計算から除く
+	Score *float64 `json:"score"`
+	// Contribution は合計のスコアのうち、この属性の分
+	Contribution float64 `json:"contribution"`
+}
+
+// SimilarEntry は類似したキャラクターとスコアの内訳
+type SimilarEntry struct {
+	Entry     model.Entry      `json:"entry"`
+	Score     float64          `json:"score"`
+	Breakdown []DimensionScore `json:"breakdown"`
+}
+
+// Score はaとbの類似度を0〜1で返す
+// 両方に値がある属性だけで重み付きの平均を取るため、属性が欠けていても低く出すぎない
+func Score(a, b *Profile, w Weights) (float64, []DimensionScore) {
+	breakdown := make([]DimensionScore, 0, len(Dimensions))
+	total := 0.0
+	for _, d := range Dimensions {
+		ds := DimensionScore{Dimension: d, Weight: w[d]}
+		if s, ok := score(d, a, b); ok && w[d] > 0 {
+			ds.Score = &s
+			total += w[d]
+		}
+		breakdown = append(breakdown, ds)
+	}
+	if total == 0 {
+		return 0, breakdown
+	}
+	sum := 0.0
+	for i, ds := range breakdown {
+		if ds.Score == nil {
+			continue
+		}
+		breakdown[i].Contribution = ds.Weight * *ds.Score / total
+		sum += breakdown[i].Contribution
+	}
+	return sum, breakdown
+}
+
+// Rank はtargetに似ている順にk件を返す。target自身は含めない
+// スコアが同じ場合はidの小さい順にする
+func Rank(target *Profile, profiles []*Profile, w Weights, k int) []SimilarEntry {
+	results := make([]SimilarEntry, 0, len(profiles))
+	for _, p := range profiles {
+		if p.Entry.ID == target.Entry.ID {
+			continue
+		}
+		s, breakdown := Score(target, p, w)
+		results = append(results, SimilarEntry{Entry: p.Entry, Score: s, Breakdown: breakdown})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Entry.ID < results[j].Entry.ID
+	})
+	if len(results) > k {
+		results = results[:k]
+	}
+	return results
+}
+
+func score(d string, a, b *Profile) (float64, bool) {
+	switch d {
+	case HairColor:
+		return same(a.HairColor, b.HairColor)
+	case HairStyle:
+		return same(a.HairStyle, b.HairStyle)
+	case HairLength:
+		return same(a.HairLength, b.HairLength)
+	case EyeColor:
+		return same(a.EyeColor, b.EyeColor)
+	case Personality:
+		return jaccard(a.Personalities, b.Personalities)
+	case Tags:
+		return jaccard(a.Tags, b.Tags)
+	case BWH:
+		return bwh(a.BWH, b.BWH)
+	case HekiRadarChart:
+		return chart(a.Chart, b.Chart)
+	}
+	return 0, false
+}
+
+func same(a, b *int64) (float64, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+	if *a == *b {
+		return 1, true
+	}
+	return 0, true
+}
+
+// jaccard は共通する要素の数を和集合の数で割る
+func jaccard(a, b []int64) (float64, bool) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, false
+	}
+	set := make(map[int64]bool, len(a))
+	for _, v := range a {
+		set[v] = true
+	}
+	union := len(set)
+	common := 0
+	seen := map[int64]bool{}
+	for _, v := range b {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		if set[v] {
+			common++
+		} else {
+			union++
+		}
+	}
+	return float64(common) / float64(union), true
+}
+
+// bwhScales は差を標準化するための値(cm, kg)。おおよその標準偏差
+var bwhScales = struct{ bust, waist, hip, height, weight float64 }{6, 4, 5, 6, 5}
+
+// bwh は標準化した差の二乗平均平方根dを 1/(1+d) で0〜1にする
+// 身長と体重は両方にある場合だけ比べる
+func bwh(a, b *model.BWH) (float64, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+	diffs := []float64{
+		float64(a.Bust-b.Bust) / bwhScales.bust,
+		float64(a.Waist-b.Waist) / bwhScales.waist,
+		float64(a.Hip-b.Hip) / bwhScales.hip,
+	}
+	if a.Height != nil && b.Height != nil {
+		diffs = append(diffs, float64(*a.Height-*b.Height)/bwhScales.height)
+	}
+	if a.Weight != nil && b.Weight != nil {
+		diffs = append(diffs, float64(*a.Weight-*b.Weight)/bwhScales.weight)
+	}
+	sum := 0.0
+	for _, d := range diffs {
+		sum += d * d
+	}
+	return 1 / (1 + math.Sqrt(sum/float64(len(diffs)))), true
+}
+
+// chart は両方に値がある軸だけで、0〜1にした値の差の二乗平均平方根dを 1-d にする
+// 軸ごとに範囲で割っているため、範囲の広い軸だけが効くことはない
+func chart(a, b map[string]float64) (float64, bool) {
+	sum := 0.0
+	n := 0
+	for key, av := range a {
+		bv, ok := b[key]
+		if !ok {
+			continue
+		}
+		sum += (av - bv) * (av - bv)
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return 1 - math.Sqrt(sum/float64(n)), true
+}
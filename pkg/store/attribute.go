@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+// AttributeSearch は属性テーブルの検索条件
+type AttributeSearch struct {
+	EntryID *int64
+	ValueID *int64
+	Page
+}
+
+// Attributes は属性テーブル(haircolor, eyecolor, ...)の読み書き
+// 値の列はvalue_idという名前で読み書きする
+type Attributes struct {
+	DB    *database.DB
+	Table model.AttributeTable
+}
+
+func (s *Attributes) selectQuery() string {
+	return `
+		SELECT
+			entry_id,
+			` + s.Table.Column + ` AS value_id
+		FROM
+			` + s.Table.Name + `
+	`
+}
+
+func (s *Attributes) order() string {
+	return ` ORDER BY entry_id, ` + s.Table.Column
+}
+
+// List はentry_idを指定して取得する。entryIDsが空の場合は全件取得する
+func (s *Attributes) List(ctx context.Context, entryIDs []int64) ([]model.Attribute, error) {
+	attrs := []model.Attribute{}
+	if len(entryIDs) == 0 {
+		err := s.DB.SelectContext(ctx, &attrs, s.selectQuery()+s.order())
+		return attrs, err
+	}
+	err := selectIn(ctx, s.DB, &attrs, s.selectQuery()+` WHERE entry_id IN (?)`+s.order(), entryIDs)
+	return attrs, err
+}
+
+func (s *Attributes) Page(ctx context.Context, entryIDs []int64, p Page) ([]model.Attribute, int64, error) {
+	attrs := []model.Attribute{}
+	total, err := selectPage(ctx, s.DB, &attrs, s.selectQuery(), "entry_id", s.order(), entryIDs, p)
+	return attrs, total, err
+}
+
+// Get はentry_idの行を取得する。personalityのように複数ある場合は値のidが最小の行を返す
+func (s *Attributes) Get(ctx context.Context, entryID int64) (model.Attribute, error) {
+	var attr model.Attribute
+	err := get(ctx, s.DB, &attr, s.selectQuery()+` WHERE entry_id = ?`+s.order()+` LIMIT 1`, entryID)
+	return attr, err
+}
+
+func (s *Attributes) Search(ctx context.Context, q AttributeSearch) ([]model.Attribute, error) {
+	var c conditions
+	if q.EntryID != nil {
+		c.add(`entry_id = ?`, *q.EntryID)
+	}
+	if q.ValueID != nil {
+		c.add(s.Table.Column+` = ?`, *q.ValueID)
+	}
+	page, pageArgs := q.Page.clause()
+	attrs := []model.Attribute{}
+	query := s.DB.Rebind(s.selectQuery() + c.clause() + s.order() + page)
+	err := s.DB.SelectContext(ctx, &attrs, query, append(c.args, pageArgs...)...)
+	return attrs, err
+}
+
+func (s *Attributes) Create(ctx context.Context, attrs []model.Attribute) ([]model.Attribute, error) {
+	query := `INSERT INTO ` + s.Table.Name + ` (entry_id, ` + s.Table.Column + `) VALUES (:entry_id, :value_id)`
+	err := inTx(ctx, s.DB, func(tx *sqlx.Tx) error {
+		for _, attr := range attrs {
+			if _, err := tx.NamedExecContext(ctx, query, attr); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+// Update はentry_idの行の値を書き換える
+func (s *Attributes) Update(ctx context.Context, attrs []model.Attribute) error {
+	query := `UPDATE ` + s.Table.Name + ` SET ` + s.Table.Column + ` = :value_id WHERE entry_id = :entry_id`
+	return inTx(ctx, s.DB, func(tx *sqlx.Tx) error {
+		for _, attr := range attrs {
+			if _, err := tx.NamedExecContext(ctx, query, attr); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Attributes) Delete(ctx context.Context, entryIDs []int64) error {
+	if len(entryIDs) == 0 {
+		return nil
+	}
+	return execIn(ctx, s.DB, `DELETE FROM `+s.Table.Name+` WHERE entry_id IN (?)`, entryIDs)
+}
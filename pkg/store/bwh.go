@@ -0,0 +1,145 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+const selectBWH = `
+	SELECT
+		entry_id,
+		bust,
+		waist,
+		hip,
+		height,
+		weight
+	FROM
+		bwh
+`
+
+// BWHSearch はbwhの検索条件。範囲は両端を含む
+type BWHSearch struct {
+	MinBust, MaxBust   *int64
+	MinWaist, MaxWaist *int64
+	MinHip, MaxHip     *int64
+	Page
+}
+
+// BWHs はbwhテーブルの読み書き
+type BWHs struct {
+	DB *database.DB
+}
+
+// List はentry_idを指定して取得する。entryIDsが空の場合は全件取得する
+func (s *BWHs) List(ctx context.Context, entryIDs []int64) ([]model.BWH, error) {
+	bwhs := []model.BWH{}
+	if len(entryIDs) == 0 {
+		err := s.DB.SelectContext(ctx, &bwhs, selectBWH+` ORDER BY entry_id`)
+		return bwhs, err
+	}
+	err := selectIn(ctx, s.DB, &bwhs, selectBWH+` WHERE entry_id IN (?) ORDER BY entry_id`, entryIDs)
+	return bwhs, err
+}
+
+func (s *BWHs) Page(ctx context.Context, entryIDs []int64, p Page) ([]model.BWH, int64, error) {
+	bwhs := []model.BWH{}
+	total, err := selectPage(ctx, s.DB, &bwhs, selectBWH, "entry_id", ` ORDER BY entry_id`, entryIDs, p)
+	return bwhs, total, err
+}
+
+func (s *BWHs) Get(ctx context.Context, entryID int64) (model.BWH, error) {
+	var bwh model.BWH
+	err := get(ctx, s.DB, &bwh, selectBWH+` WHERE entry_id = ?`, entryID)
+	return bwh, err
+}
+
+func (s *BWHs) Search(ctx context.Context, q BWHSearch) ([]model.BWH, error) {
+	var c conditions
+	ranges := []struct {
+		column   string
+		min, max *int64
+	}{
+		{"bust", q.MinBust, q.MaxBust},
+		{"waist", q.MinWaist, q.MaxWaist},
+		{"hip", q.MinHip, q.MaxHip},
+	}
+	for _, r := range ranges {
+		if r.min != nil {
+			c.add(r.column+` >= ?`, *r.min)
+		}
+		if r.max != nil {
+			c.add(r.column+` <= ?`, *r.max)
+		}
+	}
+	page, pageArgs := q.Page.clause()
+	bwhs := []model.BWH{}
+	query := s.DB.Rebind(selectBWH + c.clause() + ` ORDER BY entry_id` + page)
+	err := s.DB.SelectContext(ctx, &bwhs, query, append(c.args, pageArgs...)...)
+	return bwhs, err
+}
+
+func (s *BWHs) Create(ctx context.Context, bwhs []model.BWH) ([]model.BWH, error) {
+	query := `
+		INSERT INTO bwh (
+			entry_id,
+			bust,
+			waist,
+			hip,
+			height,
+			weight
+		) VALUES (
+			:entry_id,
+			:bust,
+			:waist,
+			:hip,
+			:height,
+			:weight
+		)
+	`
+	err := inTx(ctx, s.DB, func(tx *sqlx.Tx) error {
+		for _, bwh := range bwhs {
+			if _, err := tx.NamedExecContext(ctx, query, bwh); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bwhs, nil
+}
+
+func (s *BWHs) Update(ctx context.Context, bwhs []model.BWH) error {
+	query := `
+		UPDATE
+			bwh
+		SET
+			bust = :bust,
+			waist = :waist,
+			hip = :hip,
+			height = :height,
+			weight = :weight
+		WHERE
+			entry_id = :entry_id
+	`
+	return inTx(ctx, s.DB, func(tx *sqlx.Tx) error {
+		for _, bwh := range bwhs {
+			if _, err := tx.NamedExecContext(ctx, query, bwh); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BWHs) Delete(ctx context.Context, entryIDs []int64) error {
+	if len(entryIDs) == 0 {
+		return nil
+	}
+	return execIn(ctx, s.DB, `DELETE FROM bwh WHERE entry_id IN (?)`, entryIDs)
+}
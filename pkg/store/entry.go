@@ -0,0 +1,195 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+const selectEntry = `
+	SELECT
+		id,
+		source_id,
+		name,
+		image,
+		content,
+		created_at
+	FROM
+		entry
+`
+
+// EntrySearch はentryの検索条件
+type EntrySearch struct {
+	// Query はnameとcontentの部分一致
+	Query    string
+	SourceID *int64
+	TagID    *int64
+	// 以下は属性テーブルの値(*_typeのid)。personalityはいずれかの行が一致すればよい
+	HairColorID   *int64
+	EyeColorID    *int64
+	HairStyleID   *int64
+	HairLengthID  *int64
+	PersonalityID *int64
+	// ExcludeNsfw は成人向けのリンクがあるentryを除く
+	ExcludeNsfw bool
+	// MinID はid以上のentryに絞り込む
+	MinID *int64
+	Page
+}
+
+// attributes は属性テーブルと検索条件の値の組
+func (q EntrySearch) attributes() []struct {
+	table model.AttributeTable
+	id    *int64
+} {
+	return []struct {
+		table model.AttributeTable
+		id    *int64
+	}{
+		{model.HairColorTable, q.HairColorID},
+		{model.EyeColorTable, q.EyeColorID},
+		{model.HairStyleTable, q.HairStyleID},
+		{model.HairLengthTable, q.HairLengthID},
+		{model.PersonalityTable, q.PersonalityID},
+	}
+}
+
+// Entries はentryテーブルの読み書き
+type Entries struct {
+	DB *database.DB
+}
+
+// List はidを指定して取得する。idsが空の場合は全件取得する
+func (s *Entries) List(ctx context.Context, ids []int64) ([]model.Entry, error) {
+	entries := []model.Entry{}
+	if len(ids) == 0 {
+		err := s.DB.SelectContext(ctx, &entries, selectEntry+` ORDER BY id`)
+		return entries, err
+	}
+	err := selectIn(ctx, s.DB, &entries, selectEntry+` WHERE id IN (?) ORDER BY id`, ids)
+	return entries, err
+}
+
+func (s *Entries) Page(ctx context.Context, ids []int64, p Page) ([]model.Entry, int64, error) {
+	entries := []model.Entry{}
+	total, err := selectPage(ctx, s.DB, &entries, selectEntry, "id", ` ORDER BY id`, ids, p)
+	return entries, total, err
+}
+
+func (s *Entries) Get(ctx context.Context, id int64) (model.Entry, error) {
+	var entry model.Entry
+	err := get(ctx, s.DB, &entry, selectEntry+` WHERE id = ?`, id)
+	return entry, err
+}
+
+func (s *Entries) conditions(q EntrySearch) conditions {
+	var c conditions
+	if q.Query != "" {
+		c.add(`(`+s.DB.Dialect.Like(`name`)+` OR `+s.DB.Dialect.Like(`content`)+`)`, like(q.Query), like(q.Query))
+	}
+	if q.SourceID != nil {
+		c.add(`source_id = ?`, *q.SourceID)
+	}
+	if q.TagID != nil {
+		c.add(`id IN (SELECT entry_id FROM entry_tag WHERE tag_id = ?)`, *q.TagID)
+	}
+	for _, a := range q.attributes() {
+		if a.id != nil {
+			c.add(`id IN (SELECT entry_id FROM `+a.table.Name+` WHERE `+a.table.Column+` = ?)`, *a.id)
+		}
+	}
+	if q.ExcludeNsfw {
+		c.add(`id NOT IN (SELECT entry_id FROM link WHERE nsfw = ?)`, true)
+	}
+	if q.MinID != nil {
+		c.add(`id >= ?`, *q.MinID)
+	}
+	return c
+}
+
+func (s *Entries) Search(ctx context.Context, q EntrySearch) ([]model.Entry, error) {
+	c := s.conditions(q)
+	page, pageArgs := q.Page.clause()
+	entries := []model.Entry{}
+	query := s.DB.Rebind(selectEntry + c.clause() + ` ORDER BY id` + page)
+	err := s.DB.SelectContext(ctx, &entries, query, append(c.args, pageArgs...)...)
+	return entries, err
+}
+
+// IDRange は検索条件に一致する行のidの最小値と最大値を返す。一致する行がない場合は0を返す。Pageは使わない
+func (s *Entries) IDRange(ctx context.Context, q EntrySearch) (lo, hi int64, err error) {
+	c := s.conditions(q)
+	var r struct {
+		Lo sql.NullInt64 `db:"lo"`
+		Hi sql.NullInt64 `db:"hi"`
+	}
+	err = s.DB.GetContext(ctx, &r, s.DB.Rebind(`SELECT MIN(id) AS lo, MAX(id) AS hi FROM entry`+c.clause()), c.args...)
+	return r.Lo.Int64, r.Hi.Int64, err
+}
+
+// Create は登録し、採番したidを設定して返す
+func (s *Entries) Create(ctx context.Context, entries []model.Entry) ([]model.Entry, error) {
+	query := `
+		INSERT INTO entry (
+			source_id,
+			name,
+			image,
+			content,
+			created_at
+		) VALUES (
+			:source_id,
+			:name,
+			:image,
+			:content,
+			:created_at
+		)
+	`
+	err := inTx(ctx, s.DB, func(tx *sqlx.Tx) error {
+		for i := range entries {
+			id, err := insertID(ctx, tx, s.DB.Dialect, query, entries[i])
+			if err != nil {
+				return err
+			}
+			entries[i].ID = id
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (s *Entries) Update(ctx context.Context, entries []model.Entry) error {
+	query := `
+		UPDATE
+			entry
+		SET
+			source_id = :source_id,
+			name = :name,
+			image = :image,
+			content = :content,
+			created_at = :created_at
+		WHERE
+			id = :id
+	`
+	return inTx(ctx, s.DB, func(tx *sqlx.Tx) error {
+		for _, entry := range entries {
+			if _, err := tx.NamedExecContext(ctx, query, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Entries) Delete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return execIn(ctx, s.DB, `DELETE FROM entry WHERE id IN (?)`, ids)
+}
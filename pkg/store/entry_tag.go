@@ -0,0 +1,130 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+const selectEntryTag = `
+	SELECT
+		id,
+		entry_id,
+		tag_id
+	FROM
+		entry_tag
+`
+
+// EntryTagSearch はentry_tagの検索条件
+type EntryTagSearch struct {
+	EntryID *int64
+	TagID   *int64
+	Page
+}
+
+// EntryTags はentry_tagテーブルの読み書き
+type EntryTags struct {
+	DB *database.DB
+}
+
+// List はidを指定して取得する。idsが空の場合は全件取得する
+func (s *EntryTags) List(ctx context.Context, ids []int64) ([]model.EntryTag, error) {
+	entryTags := []model.EntryTag{}
+	if len(ids) == 0 {
+		err := s.DB.SelectContext(ctx, &entryTags, selectEntryTag+` ORDER BY id`)
+		return entryTags, err
+	}
+	err := selectIn(ctx, s.DB, &entryTags, selectEntryTag+` WHERE id IN (?) ORDER BY id`, ids)
+	return entryTags, err
+}
+
+func (s *EntryTags) Page(ctx context.Context, ids []int64, p Page) ([]model.EntryTag, int64, error) {
+	entryTags := []model.EntryTag{}
+	total, err := selectPage(ctx, s.DB, &entryTags, selectEntryTag, "id", ` ORDER BY id`, ids, p)
+	return entryTags, total, err
+}
+
+// ByEntries はentry_idのいずれかに一致する行を取得する
+func (s *EntryTags) ByEntries(ctx context.Context, entryIDs []int64) ([]model.EntryTag, error) {
+	entryTags := []model.EntryTag{}
+	err := selectIn(ctx, s.DB, &entryTags, selectEntryTag+` WHERE entry_id IN (?) ORDER BY tag_id`, entryIDs)
+	return entryTags, err
+}
+
+func (s *EntryTags) Get(ctx context.Context, id int64) (model.EntryTag, error) {
+	var entryTag model.EntryTag
+	err := get(ctx, s.DB, &entryTag, selectEntryTag+` WHERE id = ?`, id)
+	return entryTag, err
+}
+
+func (s *EntryTags) Search(ctx context.Context, q EntryTagSearch) ([]model.EntryTag, error) {
+	var c conditions
+	if q.EntryID != nil {
+		c.add(`entry_id = ?`, *q.EntryID)
+	}
+	if q.TagID != nil {
+		c.add(`tag_id = ?`, *q.TagID)
+	}
+	page, pageArgs := q.Page.clause()
+	entryTags := []model.EntryTag{}
+	query := s.DB.Rebind(selectEntryTag + c.clause() + ` ORDER BY id` + page)
+	err := s.DB.SelectContext(ctx, &entryTags, query, append(c.args, pageArgs...)...)
+	return entryTags, err
+}
+
+// Create は登録し、採番したidを設定して返す
+func (s *EntryTags) Create(ctx context.Context, entryTags []model.EntryTag) ([]model.EntryTag, error) {
+	query := `
+		INSERT INTO entry_tag (
+			entry_id,
+			tag_id
+		) VALUES (
+			:entry_id,
+			:tag_id
+		)
+	`
+	err := inTx(ctx, s.DB, func(tx *sqlx.Tx) error {
+		for i := range entryTags {
+			id, err := insertID(ctx, tx, s.DB.Dialect, query, entryTags[i])
+			if err != nil {
+				return err
+			}
+			entryTags[i].ID = id
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entryTags, nil
+}
+
+func (s *EntryTags) Update(ctx context.Context, entryTags []model.EntryTag) error {
+	query := `
+		UPDATE
+			entry_tag
+		SET
+			entry_id = :entry_id,
+			tag_id = :tag_id
+		WHERE
+			id = :id
+	`
+	return inTx(ctx, s.DB, func(tx *sqlx.Tx) error {
+		for _, entryTag := range entryTags {
+			if _, err := tx.NamedExecContext(ctx, query, entryTag); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *EntryTags) Delete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return execIn(ctx, s.DB, `DELETE FROM entry_tag WHERE id IN (?)`, ids)
+}
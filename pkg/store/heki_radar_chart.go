@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+const selectHekiRadarChart = `
+	SELECT
+		entry_id,
+		ai,
+		nu
+	FROM
+		heki_radar_chart
+`
+
+// HekiRadarChartSearch はheki_radar_chartの検索条件。範囲は両端を含む
+type HekiRadarChartSearch struct {
+	MinAI, MaxAI *int64
+	MinNU, MaxNU *int64
+	Page
+}
+
+// HekiRadarCharts はheki_radar_chartの読み書き
+// heki_radar_chartはradar_valueのaiとnuの軸を並べたビューで、書き込みはradar_valueに対して行う
+type HekiRadarCharts struct {
+	DB *database.DB
+}
+
+// List はentry_idを指定して取得する。entryIDsが空の場合は全件取得する
+func (s *HekiRadarCharts) List(ctx context.Context, entryIDs []int64) ([]model.HekiRadarChart, error) {
+	charts := []model.HekiRadarChart{}
+	if len(entryIDs) == 0 {
+		err := s.DB.SelectContext(ctx, &charts, selectHekiRadarChart+` ORDER BY entry_id`)
+		return charts, err
+	}
+	err := selectIn(ctx, s.DB, &charts, selectHekiRadarChart+` WHERE entry_id IN (?) ORDER BY entry_id`, entryIDs)
+	return charts, err
+}
+
+func (s *HekiRadarCharts) Page(ctx context.Context, entryIDs []int64, p Page) ([]model.HekiRadarChart, int64, error) {
+	charts := []model.HekiRadarChart{}
+	total, err := selectPage(ctx, s.DB, &charts, selectHekiRadarChart, "entry_id", ` ORDER BY entry_id`, entryIDs, p)
+	return charts, total, err
+}
+
+func (s *HekiRadarCharts) Get(ctx context.Context, entryID int64) (model.HekiRadarChart, error) {
+	var chart model.HekiRadarChart
+	err := get(ctx, s.DB, &chart, selectHekiRadarChart+` WHERE entry_id = ?`, entryID)
+	return chart, err
+}
+
+func (s *HekiRadarCharts) Search(ctx context.Context, q HekiRadarChartSearch) ([]model.HekiRadarChart, error) {
+	var c conditions
+	ranges := []struct {
+		column   string
+		min, max *int64
+	}{
+		{"ai", q.MinAI, q.MaxAI},
+		{"nu", q.MinNU, q.MaxNU},
+	}
+	for _, r := range ranges {
+		if r.min != nil {
+			c.add(r.column+` >= ?`, *r.min)
+		}
+		if r.max != nil {
+			c.add(r.column+` <= ?`, *r.max)
+		}
+	}
+	page, pageArgs := q.Page.clause()
+	charts := []model.HekiRadarChart{}
+	query := s.DB.Rebind(selectHekiRadarChart + c.clause() + ` ORDER BY entry_id` + page)
+	err := s.DB.SelectContext(ctx, &charts, query, append(c.args, pageArgs...)...)
+	return charts, err
+}
+
+// radar はaiとnuの列を軸ごとの値にする
+func radar(charts []model.HekiRadarChart) []model.RadarChart {
+	out := make([]model.RadarChart, len(charts))
+	for i, chart := range charts {
+		out[i] = model.RadarChart{EntryID: chart.EntryID, Values: map[string]int64{
+			model.RadarAxisAI: chart.AI,
+			model.RadarAxisNU: chart.NU,
+		}}
+	}
+	return out
+}
+
+// Create はaiとnuの軸の値を登録する
+func (s *HekiRadarCharts) Create(ctx context.Context, charts []model.HekiRadarChart) ([]model.HekiRadarChart, error) {
+	values := &RadarCharts{DB: s.DB}
+	return charts, values.write(ctx, radar(charts), radarInsert)
+}
+
+// Update はaiとnuの軸の値を書き換える。値がないentryは登録し、他の軸の値はそのまま
+func (s *HekiRadarCharts) Update(ctx context.Context, charts []model.HekiRadarChart) error {
+	values := &RadarCharts{DB: s.DB}
+	return values.write(ctx, radar(charts), radarUpsert)
+}
+
+// Delete はaiとnuの軸の値を削除する。他の軸の値はそのまま
+func (s *HekiRadarCharts) Delete(ctx context.Context, entryIDs []int64) error {
+	if len(entryIDs) == 0 {
+		return nil
+	}
+	query := `
+		DELETE FROM
+			radar_value
+		WHERE
+			entry_id IN (?)
+			AND axis_id IN (SELECT id FROM radar_axis WHERE ` + s.DB.Dialect.Quote("key") + ` IN ('` + model.RadarAxisAI + `', '` + model.RadarAxisNU + `'))
+	`
+	return execIn(ctx, s.DB, query, entryIDs)
+}
@@ -0,0 +1,150 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+const selectLink = `
+	SELECT
+		id,
+		entry_id,
+		type,
+		url,
+		nsfw,
+		darkness
+	FROM
+		link
+`
+
+// LinkSearch はlinkの検索条件
+type LinkSearch struct {
+	EntryID  *int64
+	Type     *string
+	Nsfw     *bool
+	Darkness *bool
+	Page
+}
+
+// Links はlinkテーブルの読み書き
+type Links struct {
+	DB *database.DB
+}
+
+// List はidを指定して取得する。idsが空の場合は全件取得する
+func (s *Links) List(ctx context.Context, ids []int64) ([]model.Link, error) {
+	links := []model.Link{}
+	if len(ids) == 0 {
+		err := s.DB.SelectContext(ctx, &links, selectLink+` ORDER BY id`)
+		return links, err
+	}
+	err := selectIn(ctx, s.DB, &links, selectLink+` WHERE id IN (?) ORDER BY id`, ids)
+	return links, err
+}
+
+func (s *Links) Page(ctx context.Context, ids []int64, p Page) ([]model.Link, int64, error) {
+	links := []model.Link{}
+	total, err := selectPage(ctx, s.DB, &links, selectLink, "id", ` ORDER BY id`, ids, p)
+	return links, total, err
+}
+
+// ByEntries はentry_idのいずれかに一致するリンクを取得する
+func (s *Links) ByEntries(ctx context.Context, entryIDs []int64) ([]model.Link, error) {
+	links := []model.Link{}
+	err := selectIn(ctx, s.DB, &links, selectLink+` WHERE entry_id IN (?) ORDER BY id`, entryIDs)
+	return links, err
+}
+
+func (s *Links) Get(ctx context.Context, id int64) (model.Link, error) {
+	var link model.Link
+	err := get(ctx, s.DB, &link, selectLink+` WHERE id = ?`, id)
+	return link, err
+}
+
+func (s *Links) Search(ctx context.Context, q LinkSearch) ([]model.Link, error) {
+	var c conditions
+	if q.EntryID != nil {
+		c.add(`entry_id = ?`, *q.EntryID)
+	}
+	if q.Type != nil {
+		c.add(`type = ?`, *q.Type)
+	}
+	if q.Nsfw != nil {
+		c.add(`nsfw = ?`, *q.Nsfw)
+	}
+	if q.Darkness != nil {
+		c.add(`darkness = ?`, *q.Darkness)
+	}
+	page, pageArgs := q.Page.clause()
+	links := []model.Link{}
+	query := s.DB.Rebind(selectLink + c.clause() + ` ORDER BY id` + page)
+	err := s.DB.SelectContext(ctx, &links, query, append(c.args, pageArgs...)...)
+	return links, err
+}
+
+// Create は登録し、採番したidを設定して返す
+func (s *Links) Create(ctx context.Context, links []model.Link) ([]model.Link, error) {
+	query := `
+		INSERT INTO link (
+			entry_id,
+			type,
+			url,
+			nsfw,
+			darkness
+		) VALUES (
+			:entry_id,
+			:type,
+			:url,
+			:nsfw,
+			:darkness
+		)
+	`
+	err := inTx(ctx, s.DB, func(tx *sqlx.Tx) error {
+		for i := range links {
+			id, err := insertID(ctx, tx, s.DB.Dialect, query, links[i])
+			if err != nil {
+				return err
+			}
+			links[i].ID = id
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+func (s *Links) Update(ctx context.Context, links []model.Link) error {
+	query := `
+		UPDATE
+			link
+		SET
+			entry_id = :entry_id,
+			type = :type,
+			url = :url,
+			nsfw = :nsfw,
+			darkness = :darkness
+		WHERE
+			id = :id
+	`
+	return inTx(ctx, s.DB, func(tx *sqlx.Tx) error {
+		for _, link := range links {
+			if _, err := tx.NamedExecContext(ctx, query, link); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Links) Delete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return execIn(ctx, s.DB, `DELETE FROM link WHERE id IN (?)`, ids)
+}
@@ -0,0 +1,429 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+// ErrDuplicate はメモリの実装で主キーが重複した場合のエラー
+// DBの実装では一意制約違反のエラーになる
+var ErrDuplicate = errors.New("duplicate key")
+
+// Memory はRepositoryのメモリの実装。DBなしでハンドラを動かすテストで使う
+// 外部キーは確認しない
+type Memory[T any, S any] struct {
+	mu   sync.Mutex
+	rows []T
+	next int64
+
+	// key はList, Get, Update, Deleteで使うキー(idかentry_id)
+	key func(T) int64
+	// assign は採番したidを設定する。entry_idがキーのテーブルではnil
+	assign func(*T, int64)
+	// unique は主キー。personalityのように複数の列が主キーの場合に使う
+	unique func(T) [2]int64
+	less   func(a, b T) bool
+	match  func(T, S) bool
+	page   func(S) Page
+}
+
+// Seed は行をそのまま追加する。idは採番しない
+func (m *Memory[T, S]) Seed(rows ...T) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, row := range rows {
+		m.rows = append(m.rows, row)
+		m.next = max(m.next, m.key(row))
+	}
+}
+
+func (m *Memory[T, S]) sorted(rows []T) []T {
+	slices.SortStableFunc(rows, func(a, b T) int {
+		switch {
+		case m.less(a, b):
+			return -1
+		case m.less(b, a):
+			return 1
+		}
+		return 0
+	})
+	return rows
+}
+
+// all はロックを取って全ての行のコピーを返す
+func (m *Memory[T, S]) all() []T {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.rows)
+}
+
+func (m *Memory[T, S]) List(ctx context.Context, keys []int64) ([]T, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rows := []T{}
+	for _, row := range m.rows {
+		if len(keys) == 0 || slices.Contains(keys, m.key(row)) {
+			rows = append(rows, row)
+		}
+	}
+	return m.sorted(rows), nil
+}
+
+func (m *Memory[T, S]) Get(ctx context.Context, key int64) (T, error) {
+	rows, _ := m.List(ctx, []int64{key})
+	if len(rows) == 0 {
+		var zero T
+		return zero, ErrNotFound
+	}
+	return rows[0], nil
+}
+
+func (m *Memory[T, S]) Search(ctx context.Context, q S) ([]T, error) {
+	m.mu.Lock()
+	rows := []T{}
+	for _, row := range m.rows {
+		if m.match(row, q) {
+			rows = append(rows, row)
+		}
+	}
+	m.mu.Unlock()
+	return paginate(m.sorted(rows), m.page(q)), nil
+}
+
+func (m *Memory[T, S]) Page(ctx context.Context, keys []int64, p Page) ([]T, int64, error) {
+	rows, err := m.List(ctx, keys)
+	if err != nil {
+		return nil, 0, err
+	}
+	return paginate(rows, p), int64(len(rows)), nil
+}
+
+// paginate はPage.clauseと同じ既定値と上限でrowsを切り出す
+func paginate[T any](rows []T, p Page) []T {
+	limit := p.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	limit = min(limit, maxLimit)
+	offset := min(max(p.Offset, 0), len(rows))
+	return rows[offset:min(offset+limit, len(rows))]
+}
+
+// IDRange は検索条件に一致する行のキーの最小値と最大値を返す。一致する行がない場合は0を返す
+func (m *Memory[T, S]) IDRange(ctx context.Context, q S) (lo, hi int64, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, row := range m.rows {
+		if !m.match(row, q) {
+			continue
+		}
+		k := m.key(row)
+		if lo == 0 || k < lo {
+			lo = k
+		}
+		hi = max(hi, k)
+	}
+	return lo, hi, nil
+}
+
+func (m *Memory[T, S]) Create(ctx context.Context, items []T) ([]T, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// 先に全件の重複を確認し、1件でも重複していれば何も追加せず、idも採番しない
+	created := slices.Clone(items)
+	next := m.next
+	seen := map[[2]int64]bool{}
+	for _, row := range m.rows {
+		seen[m.unique(row)] = true
+	}
+	for i := range created {
+		if m.assign != nil {
+			next++
+			m.assign(&created[i], next)
+		}
+		if seen[m.unique(created[i])] {
+			return nil, ErrDuplicate
+		}
+		seen[m.unique(created[i])] = true
+	}
+	m.next = next
+	m.rows = append(m.rows, created...)
+	copy(items, created)
+	return items, nil
+}
+
+// Update はキーが一致する行を置き換える。一致する行がない場合は何もしない
+func (m *Memory[T, S]) Update(ctx context.Context, items []T) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, item := range items {
+		for i, row := range m.rows {
+			if m.key(row) == m.key(item) {
+				m.rows[i] = item
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Memory[T, S]) Delete(ctx context.Context, keys []int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rows = slices.DeleteFunc(m.rows, func(row T) bool {
+		return slices.Contains(keys, m.key(row))
+	})
+	return nil
+}
+
+func single(key int64) [2]int64 {
+	return [2]int64{key}
+}
+
+// between は範囲(両端を含む)に入っているかを返す。nilの端は制限しない
+func between(v int64, lo, hi *int64) bool {
+	return (lo == nil || v >= *lo) && (hi == nil || v <= *hi)
+}
+
+// NewMemoryEntries はentryのメモリの実装を作成する
+// EntrySearchのTagIDはentryTagsの行で絞り込む。nilの場合はTagIDに一致する行はない
+func NewMemoryEntries(entryTags *Memory[model.EntryTag, EntryTagSearch]) *Memory[model.Entry, EntrySearch] {
+	return NewMemoryEntriesWith(MemoryRelations{EntryTags: entryTags})
+}
+
+// MemoryRelations はentryの検索で参照する他のテーブルのメモリの実装
+// 属性はテーブル名がキーで、ないテーブルの属性で絞り込むと一致する行はない。Linksがnilの場合は成人向けのリンクはないものとする
+type MemoryRelations struct {
+	EntryTags  *Memory[model.EntryTag, EntryTagSearch]
+	Attributes map[string]*Memory[model.Attribute, AttributeSearch]
+	Links      *Memory[model.Link, LinkSearch]
+}
+
+// NewMemoryEntriesWith はタグ以外の条件(属性、成人向けのリンク)でも絞り込めるentryのメモリの実装を作成する
+func NewMemoryEntriesWith(rel MemoryRelations) *Memory[model.Entry, EntrySearch] {
+	return &Memory[model.Entry, EntrySearch]{
+		key:    func(e model.Entry) int64 { return e.ID },
+		assign: func(e *model.Entry, id int64) { e.ID = id },
+		unique: func(e model.Entry) [2]int64 { return single(e.ID) },
+		less:   func(a, b model.Entry) bool { return a.ID < b.ID },
+		match: func(e model.Entry, q EntrySearch) bool {
+			if q.Query != "" && !strings.Contains(e.Name, q.Query) && !strings.Contains(e.Content, q.Query) {
+				return false
+			}
+			if q.SourceID != nil && e.SourceID != *q.SourceID {
+				return false
+			}
+			if q.TagID != nil {
+				if rel.EntryTags == nil {
+					return false
+				}
+				if !slices.ContainsFunc(rel.EntryTags.all(), func(t model.EntryTag) bool {
+					return t.EntryID == e.ID && t.TagID == *q.TagID
+				}) {
+					return false
+				}
+			}
+			for _, a := range q.attributes() {
+				if a.id == nil {
+					continue
+				}
+				rows := rel.Attributes[a.table.Name]
+				if rows == nil || !slices.ContainsFunc(rows.all(), func(row model.Attribute) bool {
+					return row.EntryID == e.ID && row.ValueID == *a.id
+				}) {
+					return false
+				}
+			}
+			if q.ExcludeNsfw && rel.Links != nil && slices.ContainsFunc(rel.Links.all(), func(l model.Link) bool {
+				return l.EntryID == e.ID && l.Nsfw
+			}) {
+				return false
+			}
+			if q.MinID != nil && e.ID < *q.MinID {
+				return false
+			}
+			return true
+		},
+		page: func(q EntrySearch) Page { return q.Page },
+	}
+}
+
+func NewMemoryBWHs() *Memory[model.BWH, BWHSearch] {
+	return &Memory[model.BWH, BWHSearch]{
+		key:    func(b model.BWH) int64 { return b.EntryID },
+		unique: func(b model.BWH) [2]int64 { return single(b.EntryID) },
+		less:   func(a, b model.BWH) bool { return a.EntryID < b.EntryID },
+		match: func(b model.BWH, q BWHSearch) bool {
+			return between(b.Bust, q.MinBust, q.MaxBust) &&
+				between(b.Waist, q.MinWaist, q.MaxWaist) &&
+				between(b.Hip, q.MinHip, q.MaxHip)
+		},
+		page: func(q BWHSearch) Page { return q.Page },
+	}
+}
+
+func NewMemoryLinks() *Memory[model.Link, LinkSearch] {
+	return &Memory[model.Link, LinkSearch]{
+		key:    func(l model.Link) int64 { return l.ID },
+		assign: func(l *model.Link, id int64) { l.ID = id },
+		unique: func(l model.Link) [2]int64 { return single(l.ID) },
+		less:   func(a, b model.Link) bool { return a.ID < b.ID },
+		match: func(l model.Link, q LinkSearch) bool {
+			return (q.EntryID == nil || l.EntryID == *q.EntryID) &&
+				(q.Type == nil || l.Type == *q.Type) &&
+				(q.Nsfw == nil || l.Nsfw == *q.Nsfw) &&
+				(q.Darkness == nil || l.Darkness == *q.Darkness)
+		},
+		page: func(q LinkSearch) Page { return q.Page },
+	}
+}
+
+func NewMemoryEntryTags() *Memory[model.EntryTag, EntryTagSearch] {
+	return &Memory[model.EntryTag, EntryTagSearch]{
+		key:    func(t model.EntryTag) int64 { return t.ID },
+		assign: func(t *model.EntryTag, id int64) { t.ID = id },
+		unique: func(t model.EntryTag) [2]int64 { return single(t.ID) },
+		less:   func(a, b model.EntryTag) bool { return a.ID < b.ID },
+		match: func(t model.EntryTag, q EntryTagSearch) bool {
+			return (q.EntryID == nil || t.EntryID == *q.EntryID) &&
+				(q.TagID == nil || t.TagID == *q.TagID)
+		},
+		page: func(q EntryTagSearch) Page { return q.Page },
+	}
+}
+
+func NewMemoryTypes() *Memory[model.TypeValue, TypeSearch] {
+	return &Memory[model.TypeValue, TypeSearch]{
+		key:    func(t model.TypeValue) int64 { return t.ID },
+		assign: func(t *model.TypeValue, id int64) { t.ID = id },
+		unique: func(t model.TypeValue) [2]int64 { return single(t.ID) },
+		less:   func(a, b model.TypeValue) bool { return a.ID < b.ID },
+		match: func(t model.TypeValue, q TypeSearch) bool {
+			return q.Query == "" || strings.Contains(t.Value, q.Query)
+		},
+		page: func(q TypeSearch) Page { return q.Page },
+	}
+}
+
+// NewMemoryAttributes は属性テーブルのメモリの実装を作成する
+// 主キーはentry_id、personalityのように複数の行を持てる場合は(entry_id, value_id)とする
+func NewMemoryAttributes(table model.AttributeTable) *Memory[model.Attribute, AttributeSearch] {
+	unique := func(a model.Attribute) [2]int64 { return single(a.EntryID) }
+	if table.Multiple {
+		unique = func(a model.Attribute) [2]int64 { return [2]int64{a.EntryID, a.ValueID} }
+	}
+	return &Memory[model.Attribute, AttributeSearch]{
+		key:    func(a model.Attribute) int64 { return a.EntryID },
+		unique: unique,
+		less: func(a, b model.Attribute) bool {
+			if a.EntryID != b.EntryID {
+				return a.EntryID < b.EntryID
+			}
+			return a.ValueID < b.ValueID
+		},
+		match: func(a model.Attribute, q AttributeSearch) bool {
+			return (q.EntryID == nil || a.EntryID == *q.EntryID) &&
+				(q.ValueID == nil || a.ValueID == *q.ValueID)
+		},
+		page: func(q AttributeSearch) Page { return q.Page },
+	}
+}
+
+func NewMemoryHekiRadarCharts() *Memory[model.HekiRadarChart, HekiRadarChartSearch] {
+	return &Memory[model.HekiRadarChart, HekiRadarChartSearch]{
+		key:    func(h model.HekiRadarChart) int64 { return h.EntryID },
+		unique: func(h model.HekiRadarChart) [2]int64 { return single(h.EntryID) },
+		less:   func(a, b model.HekiRadarChart) bool { return a.EntryID < b.EntryID },
+		match: func(h model.HekiRadarChart, q HekiRadarChartSearch) bool {
+			return between(h.AI, q.MinAI, q.MaxAI) && between(h.NU, q.MinNU, q.MaxNU)
+		},
+		page: func(q HekiRadarChartSearch) Page { return q.Page },
+	}
+}
+
+func NewMemoryRadarAxes() *Memory[model.RadarAxis, RadarAxisSearch] {
+	return &Memory[model.RadarAxis, RadarAxisSearch]{
+		key:    func(a model.RadarAxis) int64 { return a.ID },
+		assign: func(a *model.RadarAxis, id int64) { a.ID = id },
+		unique: func(a model.RadarAxis) [2]int64 { return single(a.ID) },
+		less: func(a, b model.RadarAxis) bool {
+			if a.DisplayOrder != b.DisplayOrder {
+				return a.DisplayOrder < b.DisplayOrder
+			}
+			return a.ID < b.ID
+		},
+		match: func(a model.RadarAxis, q RadarAxisSearch) bool {
+			return q.Key == "" || a.Key == q.Key
+		},
+		page: func(q RadarAxisSearch) Page { return q.Page },
+	}
+}
+
+// NewMemoryRadarCharts はradar_chartのメモリの実装を作成する。軸のキーは確認しない
+func NewMemoryRadarCharts() *Memory[model.RadarChart, RadarChartSearch] {
+	return &Memory[model.RadarChart, RadarChartSearch]{
+		key:    func(c model.RadarChart) int64 { return c.EntryID },
+		unique: func(c model.RadarChart) [2]int64 { return single(c.EntryID) },
+		less:   func(a, b model.RadarChart) bool { return a.EntryID < b.EntryID },
+		match: func(c model.RadarChart, q RadarChartSearch) bool {
+			if q.Axis == "" {
+				return true
+			}
+			v, ok := c.Values[q.Axis]
+			return ok && between(v, q.Min, q.Max)
+		},
+		page: func(q RadarChartSearch) Page { return q.Page },
+	}
+}
+
+// MemoryTagCooccurrences はTagCooccurrenceRepositoryのメモリの実装
+// OtherNameはSeedした値をそのまま返す
+type MemoryTagCooccurrences struct {
+	mu   sync.Mutex
+	rows []model.TagCooccurrence
+}
+
+// Seed は行をそのまま追加する
+func (m *MemoryTagCooccurrences) Seed(rows ...model.TagCooccurrence) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rows = append(m.rows, rows...)
+}
+
+func (m *MemoryTagCooccurrences) Related(ctx context.Context, tagIDs []int64) ([]model.TagCooccurrence, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rows := []model.TagCooccurrence{}
+	for _, row := range m.rows {
+		if slices.Contains(tagIDs, row.TagID) {
+			rows = append(rows, row)
+		}
+	}
+	slices.SortFunc(rows, func(a, b model.TagCooccurrence) int {
+		if a.TagID != b.TagID {
+			return cmp.Compare(a.TagID, b.TagID)
+		}
+		return cmp.Compare(a.OtherTagID, b.OtherTagID)
+	})
+	return rows, nil
+}
+
+func (m *MemoryTagCooccurrences) Replace(ctx context.Context, rows []model.TagCooccurrence) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rows = slices.Clone(rows)
+	return nil
+}
+
+// メモリの実装がインターフェースを満たしているかの確認
+var (
+	_ EntryRepository           = (*Memory[model.Entry, EntrySearch])(nil)
+	_ AttributeRepository       = (*Memory[model.Attribute, AttributeSearch])(nil)
+	_ HekiRadarChartRepository  = (*Memory[model.HekiRadarChart, HekiRadarChartSearch])(nil)
+	_ TagCooccurrenceRepository = (*MemoryTagCooccurrences)(nil)
+)
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+func TestMemoryCreateDuplicate(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		items []model.Attribute
+	}{
+		{"existing row", []model.Attribute{{EntryID: 2, ValueID: 1}, {EntryID: 1, ValueID: 2}}},
+		{"within batch", []model.Attribute{{EntryID: 2, ValueID: 1}, {EntryID: 3, ValueID: 1}, {EntryID: 2, ValueID: 3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attrs := NewMemoryAttributes(model.HairColorTable)
+			attrs.Seed(model.Attribute{EntryID: 1, ValueID: 1})
+			if _, err := attrs.Create(ctx, tt.items); !errors.Is(err, ErrDuplicate) {
+				t.Fatalf("err = %v, want ErrDuplicate", err)
+			}
+			// 重複より前の行も追加しない
+			rows, _ := attrs.List(ctx, nil)
+			if len(rows) != 1 {
+				t.Fatalf("rows = %+v, want only the seeded row", rows)
+			}
+		})
+	}
+}
+
+func TestMemoryCreateAssign(t *testing.T) {
+	ctx := context.Background()
+	entries := NewMemoryEntries(nil)
+	entries.Seed(model.Entry{ID: 1, Name: "seeded"})
+
+	created, err := entries.Create(ctx, []model.Entry{{Name: "a"}, {Name: "b"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created[0].ID != 2 || created[1].ID != 3 {
+		t.Fatalf("created = %+v", created)
+	}
+}
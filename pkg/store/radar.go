@@ -0,0 +1,311 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+// RadarAxisSearch はradar_axisの検索条件
+type RadarAxisSearch struct {
+	Key string
+	Page
+}
+
+// RadarAxes はradar_axisテーブルの読み書き
+type RadarAxes struct {
+	DB *database.DB
+}
+
+func (s *RadarAxes) selectQuery() string {
+	return `
+		SELECT
+			id,
+			` + s.DB.Dialect.Quote("key") + `,
+			label_ja,
+			label_en,
+			min_value,
+			max_value,
+			display_order
+		FROM
+			radar_axis
+	`
+}
+
+// 軸は表示する順に返す
+const orderRadarAxis = ` ORDER BY display_order, id`
+
+// List はidを指定して取得する。idsが空の場合は全件取得する
+func (s *RadarAxes) List(ctx context.Context, ids []int64) ([]model.RadarAxis, error) {
+	axes := []model.RadarAxis{}
+	if len(ids) == 0 {
+		err := s.DB.SelectContext(ctx, &axes, s.selectQuery()+orderRadarAxis)
+		return axes, err
+	}
+	err := selectIn(ctx, s.DB, &axes, s.selectQuery()+` WHERE id IN (?)`+orderRadarAxis, ids)
+	return axes, err
+}
+
+func (s *RadarAxes) Get(ctx context.Context, id int64) (model.RadarAxis, error) {
+	var axis model.RadarAxis
+	err := get(ctx, s.DB, &axis, s.selectQuery()+` WHERE id = ?`, id)
+	return axis, err
+}
+
+func (s *RadarAxes) Search(ctx context.Context, q RadarAxisSearch) ([]model.RadarAxis, error) {
+	var c conditions
+	if q.Key != "" {
+		c.add(s.DB.Dialect.Quote("key")+` = ?`, q.Key)
+	}
+	page, pageArgs := q.Page.clause()
+	axes := []model.RadarAxis{}
+	query := s.DB.Rebind(s.selectQuery() + c.clause() + orderRadarAxis + page)
+	err := s.DB.SelectContext(ctx, &axes, query, append(c.args, pageArgs...)...)
+	return axes, err
+}
+
+// Create は登録し、採番したidを設定して返す
+func (s *RadarAxes) Create(ctx context.Context, axes []model.RadarAxis) ([]model.RadarAxis, error) {
+	query := `
+		INSERT INTO radar_axis (
+			` + s.DB.Dialect.Quote("key") + `,
+			label_ja,
+			label_en,
+			min_value,
+			max_value,
+			display_order
+		) VALUES (
+			:key,
+			:label_ja,
+			:label_en,
+			:min_value,
+			:max_value,
+			:display_order
+		)
+	`
+	err := inTx(ctx, s.DB, func(tx *sqlx.Tx) error {
+		for i := range axes {
+			id, err := insertID(ctx, tx, s.DB.Dialect, query, axes[i])
+			if err != nil {
+				return err
+			}
+			axes[i].ID = id
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return axes, nil
+}
+
+func (s *RadarAxes) Update(ctx context.Context, axes []model.RadarAxis) error {
+	query := `
+		UPDATE
+			radar_axis
+		SET
+			` + s.DB.Dialect.Quote("key") + ` = :key,
+			label_ja = :label_ja,
+			label_en = :label_en,
+			min_value = :min_value,
+			max_value = :max_value,
+			display_order = :display_order
+		WHERE
+			id = :id
+	`
+	return inTx(ctx, s.DB, func(tx *sqlx.Tx) error {
+		for _, axis := range axes {
+			if _, err := tx.NamedExecContext(ctx, query, axis); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete は軸を削除する。軸の値(radar_value)も削除される
+func (s *RadarAxes) Delete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return execIn(ctx, s.DB, `DELETE FROM radar_axis WHERE id IN (?)`, ids)
+}
+
+// RadarChartSearch はradar_chartの検索条件。Axisの軸の値が範囲(両端を含む)にあるentryを返す
+type RadarChartSearch struct {
+	Axis     string
+	Min, Max *int64
+	Page
+}
+
+// RadarCharts はradar_valueテーブルの行をentryごとにまとめて読み書きする
+type RadarCharts struct {
+	DB *database.DB
+}
+
+// radarValue はradar_valueテーブルの行と軸のキー
+type radarValue struct {
+	EntryID int64  `db:"entry_id"`
+	Key     string `db:"key"`
+	Value   int64  `db:"value"`
+}
+
+func (s *RadarCharts) selectQuery() string {
+	return `
+		SELECT
+			v.entry_id,
+			a.` + s.DB.Dialect.Quote("key") + `,
+			v.value
+		FROM
+			radar_value v
+		JOIN
+			radar_axis a ON a.id = v.axis_id
+	`
+}
+
+const orderRadarValue = ` ORDER BY v.entry_id, a.display_order, a.id`
+
+// group はentry_id順に並んだ行をentryごとにまとめる
+func group(values []radarValue) []model.RadarChart {
+	charts := []model.RadarChart{}
+	for _, v := range values {
+		if len(charts) == 0 || charts[len(charts)-1].EntryID != v.EntryID {
+			charts = append(charts, model.RadarChart{EntryID: v.EntryID, Values: map[string]int64{}})
+		}
+		charts[len(charts)-1].Values[v.Key] = v.Value
+	}
+	return charts
+}
+
+// List はentry_idを指定して取得する。entryIDsが空の場合は全件取得する
+func (s *RadarCharts) List(ctx context.Context, entryIDs []int64) ([]model.RadarChart, error) {
+	values := []radarValue{}
+	if len(entryIDs) == 0 {
+		err := s.DB.SelectContext(ctx, &values, s.selectQuery()+orderRadarValue)
+		return group(values), err
+	}
+	err := selectIn(ctx, s.DB, &values, s.selectQuery()+` WHERE v.entry_id IN (?)`+orderRadarValue, entryIDs)
+	return group(values), err
+}
+
+func (s *RadarCharts) Get(ctx context.Context, entryID int64) (model.RadarChart, error) {
+	charts, err := s.List(ctx, []int64{entryID})
+	if err != nil {
+		return model.RadarChart{}, err
+	}
+	if len(charts) == 0 {
+		return model.RadarChart{}, ErrNotFound
+	}
+	return charts[0], nil
+}
+
+// Search は条件に一致するentryをページ分だけ選んでから、その全ての軸の値を読む
+func (s *RadarCharts) Search(ctx context.Context, q RadarChartSearch) ([]model.RadarChart, error) {
+	var c conditions
+	if q.Axis != "" {
+		c.add(`a.`+s.DB.Dialect.Quote("key")+` = ?`, q.Axis)
+		if q.Min != nil {
+			c.add(`v.value >= ?`, *q.Min)
+		}
+		if q.Max != nil {
+			c.add(`v.value <= ?`, *q.Max)
+		}
+	}
+	page, pageArgs := q.Page.clause()
+	query := `
+		SELECT DISTINCT
+			v.entry_id
+		FROM
+			radar_value v
+		JOIN
+			radar_axis a ON a.id = v.axis_id
+	` + c.clause() + ` ORDER BY v.entry_id` + page
+	entryIDs := []int64{}
+	err := s.DB.SelectContext(ctx, &entryIDs, s.DB.Rebind(query), append(c.args, pageArgs...)...)
+	if err != nil || len(entryIDs) == 0 {
+		return []model.RadarChart{}, err
+	}
+	return s.List(ctx, entryIDs)
+}
+
+// Create は軸ごとの行を登録する。すでに値がある軸は主キーの重複のエラーになる
+func (s *RadarCharts) Create(ctx context.Context, charts []model.RadarChart) ([]model.RadarChart, error) {
+	return charts, s.write(ctx, charts, radarInsert)
+}
+
+// Update はentryの値をchartsの値で置き換える。chartsにない軸の値は削除する
+func (s *RadarCharts) Update(ctx context.Context, charts []model.RadarChart) error {
+	return s.write(ctx, charts, radarReplace)
+}
+
+func (s *RadarCharts) Delete(ctx context.Context, entryIDs []int64) error {
+	if len(entryIDs) == 0 {
+		return nil
+	}
+	return execIn(ctx, s.DB, `DELETE FROM radar_value WHERE entry_id IN (?)`, entryIDs)
+}
+
+// radarWrite は軸ごとの行の書き方
+type radarWrite int
+
+const (
+	// radarInsert は行を追加する
+	radarInsert radarWrite = iota
+	// radarReplace はentryの行を全て削除してから追加する
+	radarReplace
+	// radarUpsert は軸の値を書き換え、値がない軸は追加する。chartsにない軸の値はそのまま
+	radarUpsert
+)
+
+// write はchartsを1つのトランザクションで書き込む。軸のキーはradar_axisからidに変換する
+func (s *RadarCharts) write(ctx context.Context, charts []model.RadarChart, mode radarWrite) error {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	axes := []model.RadarAxis{}
+	err = tx.SelectContext(ctx, &axes, `SELECT id, `+s.DB.Dialect.Quote("key")+` FROM radar_axis`)
+	if err != nil {
+		return err
+	}
+	axisIDs := make(map[string]int64, len(axes))
+	for _, a := range axes {
+		axisIDs[a.Key] = a.ID
+	}
+	insert := tx.Rebind(`INSERT INTO radar_value (entry_id, axis_id, value) VALUES (?, ?, ?)`)
+	upsert := tx.Rebind(`INSERT INTO radar_value (entry_id, axis_id, value) VALUES (?, ?, ?)` + s.DB.Dialect.Upsert([]string{"entry_id", "axis_id"}, []string{"value"}))
+	remove := tx.Rebind(`DELETE FROM radar_value WHERE entry_id = ?`)
+	for _, chart := range charts {
+		if mode == radarReplace {
+			if _, err := tx.ExecContext(ctx, remove, chart.EntryID); err != nil {
+				return err
+			}
+		}
+		keys := make([]string, 0, len(chart.Values))
+		for key := range chart.Values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			axisID, ok := axisIDs[key]
+			if !ok {
+				return fmt.Errorf("unknown radar axis %q", key)
+			}
+			if mode == radarUpsert {
+				_, err = tx.ExecContext(ctx, upsert, chart.EntryID, axisID, chart.Values[key])
+			} else {
+				_, err = tx.ExecContext(ctx, insert, chart.EntryID, axisID, chart.Values[key])
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
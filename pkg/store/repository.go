@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+// Repository はリソース1つ分の読み書き
+// DBの実装(Entries, BWHs, ...)とメモリの実装(Memory)がある。DBの実装はPostgres, MySQL, SQLiteで動く
+type Repository[T any, S any] interface {
+	// List はキー(idかentry_id)を指定して取得する。keysが空の場合は全件取得する
+	List(ctx context.Context, keys []int64) ([]T, error)
+	// Get は1件取得する。見つからない場合はErrNotFound
+	Get(ctx context.Context, key int64) (T, error)
+	Search(ctx context.Context, q S) ([]T, error)
+	// Create は登録し、採番したidを設定して返す
+	Create(ctx context.Context, items []T) ([]T, error)
+	Update(ctx context.Context, items []T) error
+	Delete(ctx context.Context, keys []int64) error
+}
+
+type (
+	EntryRepository          = Repository[model.Entry, EntrySearch]
+	BWHRepository            = Repository[model.BWH, BWHSearch]
+	LinkRepository           = Repository[model.Link, LinkSearch]
+	EntryTagRepository       = Repository[model.EntryTag, EntryTagSearch]
+	TypeRepository           = Repository[model.TypeValue, TypeSearch]
+	AttributeRepository      = Repository[model.Attribute, AttributeSearch]
+	HekiRadarChartRepository = Repository[model.HekiRadarChart, HekiRadarChartSearch]
+	RadarAxisRepository      = Repository[model.RadarAxis, RadarAxisSearch]
+	RadarChartRepository     = Repository[model.RadarChart, RadarChartSearch]
+)
+
+// Pager はListと同じ行をページ単位で取得する。JSON:APIのページングで使う
+type Pager[T any] interface {
+	// Page はkeysの行(keysが空の場合は全件)のうちpの範囲と、範囲に関係なく一致する行の数を返す
+	Page(ctx context.Context, keys []int64, p Page) ([]T, int64, error)
+}
+
+// TagCooccurrenceRepository はタグの共起の統計の読み込みと作り直し
+type TagCooccurrenceRepository interface {
+	// Related はtag_idがtagIDsのいずれかである行を取得する
+	Related(ctx context.Context, tagIDs []int64) ([]model.TagCooccurrence, error)
+	// Replace は全ての行をrowsに置き換える
+	Replace(ctx context.Context, rows []model.TagCooccurrence) error
+}
+
+// DBの実装がインターフェースを満たしているかの確認
+var (
+	_ EntryRepository           = (*Entries)(nil)
+	_ BWHRepository             = (*BWHs)(nil)
+	_ LinkRepository            = (*Links)(nil)
+	_ EntryTagRepository        = (*EntryTags)(nil)
+	_ TypeRepository            = (*Types)(nil)
+	_ AttributeRepository       = (*Attributes)(nil)
+	_ HekiRadarChartRepository  = (*HekiRadarCharts)(nil)
+	_ RadarAxisRepository       = (*RadarAxes)(nil)
+	_ RadarChartRepository      = (*RadarCharts)(nil)
+	_ TagCooccurrenceRepository = (*TagCooccurrences)(nil)
+
+	_ Pager[model.Entry]          = (*Entries)(nil)
+	_ Pager[model.BWH]            = (*BWHs)(nil)
+	_ Pager[model.Link]           = (*Links)(nil)
+	_ Pager[model.EntryTag]       = (*EntryTags)(nil)
+	_ Pager[model.TypeValue]      = (*Types)(nil)
+	_ Pager[model.Attribute]      = (*Attributes)(nil)
+	_ Pager[model.HekiRadarChart] = (*HekiRadarCharts)(nil)
+)
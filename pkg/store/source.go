@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+// sourceとtagには書き込みのAPIがないため、読み込みだけを持つ
+
+const selectSource = `
+	SELECT
+		id,
+		name,
+		url,
+		type
+	FROM
+		source
+`
+
+const selectTag = `
+	SELECT
+		id,
+		name
+	FROM
+		tag
+`
+
+// Sources はsourceテーブルの読み込み
+type Sources struct {
+	DB *database.DB
+}
+
+// List はidを指定して取得する。idsが空の場合は全件取得する
+func (s *Sources) List(ctx context.Context, ids []int64) ([]model.Source, error) {
+	sources := []model.Source{}
+	if len(ids) == 0 {
+		err := s.DB.SelectContext(ctx, &sources, selectSource+` ORDER BY id`)
+		return sources, err
+	}
+	err := selectIn(ctx, s.DB, &sources, selectSource+` WHERE id IN (?) ORDER BY id`, ids)
+	return sources, err
+}
+
+// Tags はtagテーブルの読み込み
+type Tags struct {
+	DB *database.DB
+}
+
+// List はidを指定して取得する。idsが空の場合は全件取得する
+func (s *Tags) List(ctx context.Context, ids []int64) ([]model.Tag, error) {
+	tags := []model.Tag{}
+	if len(ids) == 0 {
+		err := s.DB.SelectContext(ctx, &tags, selectTag+` ORDER BY id`)
+		return tags, err
+	}
+	err := selectIn(ctx, s.DB, &tags, selectTag+` WHERE id IN (?) ORDER BY id`, ids)
+	return tags, err
+}
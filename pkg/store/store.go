@@ -0,0 +1,146 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"maguro-alternative/varcel-go/pkg/database"
+)
+
+// ErrNotFound はGetで行が見つからない場合のエラー
+var ErrNotFound = errors.New("not found")
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+// Page は検索結果の範囲
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// clause はLIMIT, OFFSET句を返す。Limitが0の場合は既定の件数にする
+func (p Page) clause() (string, []interface{}) {
+	limit := p.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	limit = min(limit, maxLimit)
+	return ` LIMIT ? OFFSET ?`, []interface{}{limit, max(p.Offset, 0)}
+}
+
+// selectPage はListと同じ行のうちpの範囲を取得し、範囲に関係なく一致する行の数を返す
+// queryはWHERE句より前、keyはkeysで絞り込む列、orderはORDER BY句
+func selectPage(ctx context.Context, db *database.DB, dest interface{}, query, key, order string, keys []int64, p Page) (int64, error) {
+	var (
+		where string
+		args  []interface{}
+		err   error
+	)
+	if len(keys) > 0 {
+		where, args, err = sqlx.In(` WHERE `+key+` IN (?)`, keys)
+		if err != nil {
+			return 0, err
+		}
+	}
+	var total int64
+	err = db.GetContext(ctx, &total, db.Rebind(`SELECT COUNT(*) FROM (`+query+where+`) t`), args...)
+	if err != nil {
+		return 0, err
+	}
+	page, pageArgs := p.clause()
+	err = db.SelectContext(ctx, dest, db.Rebind(query+where+order+page), append(args, pageArgs...)...)
+	return total, err
+}
+
+// conditions はWHERE句の条件を組み立てる
+type conditions struct {
+	where []string
+	args  []interface{}
+}
+
+func (c *conditions) add(cond string, args ...interface{}) {
+	c.where = append(c.where, cond)
+	c.args = append(c.args, args...)
+}
+
+func (c *conditions) clause() string {
+	if len(c.where) == 0 {
+		return ""
+	}
+	return ` WHERE ` + strings.Join(c.where, ` AND `)
+}
+
+// selectIn はクエリの(?)をidの数だけ展開して実行する
+func selectIn(ctx context.Context, db *database.DB, dest interface{}, query string, ids []int64) error {
+	query, args, err := sqlx.In(query, ids)
+	if err != nil {
+		return err
+	}
+	return db.SelectContext(ctx, dest, db.Rebind(query), args...)
+}
+
+// execIn はクエリの(?)をidの数だけ展開して実行する
+func execIn(ctx context.Context, db *database.DB, query string, ids []int64) error {
+	query, args, err := sqlx.In(query, ids)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, db.Rebind(query), args...)
+	return err
+}
+
+// insertID は名前付きパラメータのINSERTをトランザクションで実行し、採番したidを返す
+// RETURNINGを使えないDB(MySQL)ではLastInsertIdで代用する
+func insertID(ctx context.Context, tx *sqlx.Tx, d database.Dialect, query string, arg interface{}) (int64, error) {
+	query, args, err := sqlx.Named(query, arg)
+	if err != nil {
+		return 0, err
+	}
+	query = tx.Rebind(query)
+	if d.Returning {
+		var id int64
+		err := tx.GetContext(ctx, &id, query+` RETURNING id`, args...)
+		return id, err
+	}
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// inTx はfnを1つのトランザクションで実行する。fnがエラーを返した場合はロールバックする
+// 複数行の登録や更新で、途中の行が失敗した時に前の行だけが残らないようにするため
+func inTx(ctx context.Context, db *database.DB, fn func(tx *sqlx.Tx) error) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// get は1行を取得し、見つからない場合はErrNotFoundを返す
+func get(ctx context.Context, db *database.DB, dest interface{}, query string, args ...interface{}) error {
+	err := db.GetContext(ctx, dest, db.Rebind(query), args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// like はLIKEのワイルドカードをエスケープした部分一致のパターンを返す
+func like(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + r.Replace(s) + "%"
+}
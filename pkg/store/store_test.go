@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"maguro-alternative/varcel-go/pkg/dbtest"
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+// TestCreateAtomic は途中の行が失敗した場合に前の行も登録しないことを確認する
+func TestCreateAtomic(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	dbtest.Exec(t, db, `INSERT INTO source (id, name, url, type) VALUES (1, 'source', 'https://example.com', 'web')`)
+	dbtest.Exec(t, db, `INSERT INTO entry (id, source_id, name, image, content) VALUES (1, 1, 'entry', '', '')`)
+	dbtest.Exec(t, db, `INSERT INTO tag (id, name) VALUES (1, 'tag'), (2, 'other')`)
+
+	// 2行目のsource_idが存在しない
+	entries := &Entries{DB: db}
+	now := time.Now()
+	_, err := entries.Create(ctx, []model.Entry{
+		{SourceID: 1, Name: "first", CreatedAt: now},
+		{SourceID: 99, Name: "second", CreatedAt: now},
+	})
+	if err == nil {
+		t.Fatal("Create succeeded with an unknown source_id")
+	}
+	if rows, err := entries.List(ctx, nil); err != nil || len(rows) != 1 {
+		t.Fatalf("entries = %+v, %v; want only the existing row", rows, err)
+	}
+
+	// 3行目が1行目と重複する
+	entryTags := &EntryTags{DB: db}
+	_, err = entryTags.Create(ctx, []model.EntryTag{
+		{EntryID: 1, TagID: 1},
+		{EntryID: 1, TagID: 2},
+		{EntryID: 1, TagID: 1},
+	})
+	if err == nil {
+		t.Fatal("Create succeeded with a duplicate row")
+	}
+	if rows, err := entryTags.List(ctx, nil); err != nil || len(rows) != 0 {
+		t.Fatalf("entry_tag = %+v, %v; want no rows", rows, err)
+	}
+}
+
+// TestUpdateAtomic は途中の行が失敗した場合に前の行も書き換えないことを確認する
+func TestUpdateAtomic(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	dbtest.Exec(t, db, `INSERT INTO source (id, name, url, type) VALUES (1, 'source', 'https://example.com', 'web')`)
+	dbtest.Exec(t, db, `INSERT INTO entry (id, source_id, name, image, content) VALUES (1, 1, 'first', '', ''), (2, 1, 'second', '', '')`)
+
+	entries := &Entries{DB: db}
+	now := time.Now()
+	err := entries.Update(ctx, []model.Entry{
+		{ID: 1, SourceID: 1, Name: "renamed", CreatedAt: now},
+		{ID: 2, SourceID: 99, Name: "renamed", CreatedAt: now},
+	})
+	if err == nil {
+		t.Fatal("Update succeeded with an unknown source_id")
+	}
+	rows, err := entries.List(ctx, []int64{1})
+	if err != nil || len(rows) != 1 || rows[0].Name != "first" {
+		t.Fatalf("entry = %+v, %v; want the original name", rows, err)
+	}
+}
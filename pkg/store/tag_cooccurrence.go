@@ -0,0 +1,71 @@
+package store
+
+import (
+	"context"
+	"strings"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+// 作り直す時に1回のINSERTで登録する行数
+const cooccurrenceBatch = 200
+
+// TagCooccurrences はtag_cooccurrenceテーブルの読み書き
+type TagCooccurrences struct {
+	DB *database.DB
+}
+
+// Related はtag_idがtagIDsのいずれかである行を、other_tag_idのタグの名前と一緒に取得する
+func (s *TagCooccurrences) Related(ctx context.Context, tagIDs []int64) ([]model.TagCooccurrence, error) {
+	rows := []model.TagCooccurrence{}
+	if len(tagIDs) == 0 {
+		return rows, nil
+	}
+	query := `
+		SELECT
+			c.tag_id,
+			c.other_tag_id,
+			t.name AS other_name,
+			c.pair_count,
+			c.pmi,
+			c.lift
+		FROM
+			tag_cooccurrence c
+		JOIN
+			tag t ON t.id = c.other_tag_id
+		WHERE
+			c.tag_id IN (?)
+		ORDER BY
+			c.tag_id,
+			c.other_tag_id
+	`
+	err := selectIn(ctx, s.DB, &rows, query, tagIDs)
+	return rows, err
+}
+
+// Replace は全ての行を削除してrowsを登録する。1つのトランザクションで行うため、読み込み中に空にはならない
+func (s *TagCooccurrences) Replace(ctx context.Context, rows []model.TagCooccurrence) error {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tag_cooccurrence`); err != nil {
+		return err
+	}
+	for start := 0; start < len(rows); start += cooccurrenceBatch {
+		batch := rows[start:min(start+cooccurrenceBatch, len(rows))]
+		values := make([]string, len(batch))
+		args := make([]interface{}, 0, len(batch)*5)
+		for i, row := range batch {
+			values[i] = `(?, ?, ?, ?, ?)`
+			args = append(args, row.TagID, row.OtherTagID, row.Count, row.PMI, row.Lift)
+		}
+		query := `INSERT INTO tag_cooccurrence (tag_id, other_tag_id, pair_count, pmi, lift) VALUES ` + strings.Join(values, `, `)
+		if _, err := tx.ExecContext(ctx, s.DB.Rebind(query), args...); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"maguro-alternative/varcel-go/pkg/database"
+	"maguro-alternative/varcel-go/pkg/model"
+)
+
+// TypeSearch は*_typeの検索条件
+type TypeSearch struct {
+	// Query は値の部分一致
+	Query string
+	Page
+}
+
+// Types は*_typeテーブルの読み書き
+// 値の列はvalueという名前で読み書きする
+type Types struct {
+	DB    *database.DB
+	Table model.TypeTable
+}
+
+func (s *Types) selectQuery() string {
+	return `
+		SELECT
+			id,
+			` + s.Table.Column + ` AS value
+		FROM
+			` + s.Table.Name + `
+	`
+}
+
+// List はidを指定して取得する。idsが空の場合は全件取得する
+func (s *Types) List(ctx context.Context, ids []int64) ([]model.TypeValue, error) {
+	values := []model.TypeValue{}
+	if len(ids) == 0 {
+		err := s.DB.SelectContext(ctx, &values, s.selectQuery()+` ORDER BY id`)
+		return values, err
+	}
+	err := selectIn(ctx, s.DB, &values, s.selectQuery()+` WHERE id IN (?) ORDER BY id`, ids)
+	return values, err
+}
+
+func (s *Types) Page(ctx context.Context, ids []int64, p Page) ([]model.TypeValue, int64, error) {
+	values := []model.TypeValue{}
+	total, err := selectPage(ctx, s.DB, &values, s.selectQuery(), "id", ` ORDER BY id`, ids, p)
+	return values, total, err
+}
+
+func (s *Types) Get(ctx context.Context, id int64) (model.TypeValue, error) {
+	var value model.TypeValue
+	err := get(ctx, s.DB, &value, s.selectQuery()+` WHERE id = ?`, id)
+	return value, err
+}
+
+func (s *Types) Search(ctx context.Context, q TypeSearch) ([]model.TypeValue, error) {
+	var c conditions
+	if q.Query != "" {
+		c.add(s.DB.Dialect.Like(s.Table.Column), like(q.Query))
+	}
+	page, pageArgs := q.Page.clause()
+	values := []model.TypeValue{}
+	query := s.DB.Rebind(s.selectQuery() + c.clause() + ` ORDER BY id` + page)
+	err := s.DB.SelectContext(ctx, &values, query, append(c.args, pageArgs...)...)
+	return values, err
+}
+
+// Create は登録し、採番したidを設定して返す
+func (s *Types) Create(ctx context.Context, values []model.TypeValue) ([]model.TypeValue, error) {
+	query := `INSERT INTO ` + s.Table.Name + ` (` + s.Table.Column + `) VALUES (:value)`
+	err := inTx(ctx, s.DB, func(tx *sqlx.Tx) error {
+		for i := range values {
+			id, err := insertID(ctx, tx, s.DB.Dialect, query, values[i])
+			if err != nil {
+				return err
+			}
+			values[i].ID = id
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func (s *Types) Update(ctx context.Context, values []model.TypeValue) error {
+	query := `UPDATE ` + s.Table.Name + ` SET ` + s.Table.Column + ` = :value WHERE id = :id`
+	return inTx(ctx, s.DB, func(tx *sqlx.Tx) error {
+		for _, value := range values {
+			if _, err := tx.NamedExecContext(ctx, query, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Types) Delete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return execIn(ctx, s.DB, `DELETE FROM `+s.Table.Name+` WHERE id IN (?)`, ids)
+}
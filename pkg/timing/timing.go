@@ -0,0 +1,100 @@
+package timing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Query はデバッグモードで返却する実行済みSQLの情報
+type Query struct {
+	SQL        string  `json:"sql"`
+	Binds      int     `json:"binds"`
+	Rows       int64   `json:"rows"`
+	DurationMs float64 `json:"duration_ms"`
+	Error      string  `json:"error,omitempty"`
+}
+
+type metric struct {
+	name string
+	desc string
+	dur  time.Duration
+}
+
+// Recorder はリクエスト内の処理時間を記録し、Server-Timingヘッダーを組み立てる
+type Recorder struct {
+	// Debug がtrueの場合はレスポンスに実行したSQLを含める
+	Debug bool
+
+	start   time.Time
+	mu      sync.Mutex
+	metrics []metric
+	queries []Query
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{start: time.Now()}
+}
+
+type recorderKey struct{}
+
+func NewContext(ctx context.Context, rec *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, rec)
+}
+
+// FromContext はcontextからRecorderを取り出す。無い場合はnilを返す
+func FromContext(ctx context.Context) *Recorder {
+	rec, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return rec
+}
+
+// Add は名前付きの処理時間を追加する
+func (r *Recorder) Add(name, desc string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, metric{name: name, desc: desc, dur: d})
+}
+
+// AddQuery はクエリ1回分の処理時間を追加する
+// Server-Timingには実行順にq1, q2, ...として出力する
+func (r *Recorder) AddQuery(desc string, q Query, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	q.DurationMs = milliseconds(d)
+	r.queries = append(r.queries, q)
+	r.metrics = append(r.metrics, metric{name: fmt.Sprintf("q%d", len(r.queries)), desc: desc, dur: d})
+}
+
+// Queries は記録したクエリの一覧を返す
+func (r *Recorder) Queries() []Query {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Query{}, r.queries...)
+}
+
+// Header はServer-Timingヘッダーの値を返す
+// totalはリクエスト開始から呼び出し時点までの時間
+func (r *Recorder) Header() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	parts := make([]string, 0, len(r.metrics)+1)
+	for _, m := range r.metrics {
+		parts = append(parts, format(m))
+	}
+	parts = append(parts, format(metric{name: "total", dur: time.Since(r.start)}))
+	return strings.Join(parts, ", ")
+}
+
+func format(m metric) string {
+	s := m.name
+	if m.desc != "" {
+		s += fmt.Sprintf(";desc=%q", strings.ReplaceAll(m.desc, `"`, `'`))
+	}
+	return s + fmt.Sprintf(";dur=%.2f", milliseconds(m.dur))
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,92 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"maguro-alternative/varcel-go/pkg/logging"
+)
+
+const (
+	serviceName = "vercel-go"
+	tracerName  = "maguro-alternative/varcel-go"
+)
+
+var (
+	once     sync.Once
+	provider *sdktrace.TracerProvider
+)
+
+// Init はOTEL_TRACES_EXPORTERに応じてエクスポーターを設定する
+//
+//	otlp    : OTLP/HTTP (送信先はOTEL_EXPORTER_OTLP_ENDPOINTなどで指定)
+//	console : 標準出力 (ローカルでのデバッグ用)
+//	未指定/none : トレースを出力しない
+//
+// 何度呼んでも初期化は1回だけ行われる
+func Init() {
+	once.Do(func() {
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		))
+		exporter, err := newExporter(strings.ToLower(os.Getenv("OTEL_TRACES_EXPORTER")), os.Stdout)
+		if err != nil {
+			logging.Error(context.Background(), "trace_exporter", err)
+			return
+		}
+		if exporter == nil {
+			return
+		}
+		res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+			semconv.ServiceName(serviceName),
+		))
+		if err != nil {
+			logging.Error(context.Background(), "trace_resource", err)
+			res = resource.Default()
+		}
+		provider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(res),
+		)
+		otel.SetTracerProvider(provider)
+	})
+}
+
+func newExporter(name string, w io.Writer) (sdktrace.SpanExporter, error) {
+	switch name {
+	case "otlp":
+		return otlptracehttp.New(context.Background())
+	case "console", "stdout":
+		return stdouttrace.New(stdouttrace.WithWriter(w))
+	default:
+		return nil, nil
+	}
+}
+
+// Flush は溜まっているスパンを送信する
+// サーバーレス環境ではレスポンス後にプロセスが止まるため、リクエストごとに呼ぶ
+func Flush(ctx context.Context) {
+	if provider == nil {
+		return
+	}
+	if err := provider.ForceFlush(ctx); err != nil {
+		logging.Error(ctx, "trace_flush", err)
+	}
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
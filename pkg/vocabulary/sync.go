@@ -0,0 +1,143 @@
+package vocabulary
+
+import (
+	"context"
+	"strings"
+
+	"maguro-alternative/varcel-go/pkg/database"
+)
+
+// Options は同期の動作
+type Options struct {
+	// DryRun はDBを書き換えずに結果だけを返す
+	DryRun bool
+	// Prune は語彙にない行のうち、どのentryからも参照されていない行を削除する
+	Prune bool
+}
+
+// Result は1テーブル分の同期の結果
+type Result struct {
+	Table string
+	// Added は語彙にあってDBになかったため登録した語
+	Added []Term
+	// Labeled は英語とローマ字を書き換えた語
+	Labeled []Term
+	// Extras は語彙にないDBの行。参照されている行は削除しない
+	Extras []Extra
+	// Pruned はOptions.Pruneで削除した行
+	Pruned []Extra
+}
+
+// Extra は語彙にない*_typeテーブルの行
+type Extra struct {
+	ID    int64
+	Value string
+	// References は属性テーブルでこの行を参照しているentryの数
+	References int64
+}
+
+type row struct {
+	ID      int64   `db:"id"`
+	Value   string  `db:"value"`
+	LabelEn *string `db:"label_en"`
+	Romaji  *string `db:"romaji"`
+}
+
+// Sync は語彙にない値を登録し、既存の行に英語とローマ字を付ける
+// 既存の行は日本語が一致するか、英語かローマ字が大文字小文字を区別せずに一致する場合に同じ語とみなす
+// 値は書き換えないため、何度実行しても結果は同じになる
+func Sync(ctx context.Context, db *database.DB, t Table, opts Options) (Result, error) {
+	result := Result{Table: t.Type.Name}
+	rows := []row{}
+	query := `SELECT id, ` + t.Type.Column + ` AS value, label_en, romaji FROM ` + t.Type.Name + ` ORDER BY id`
+	if err := db.SelectContext(ctx, &rows, query); err != nil {
+		return result, err
+	}
+
+	// 同じ語に一致する行が複数ある場合はidが小さい行を使い、残りは余分な行として報告する
+	matched := make([]bool, len(rows))
+	for _, term := range t.Terms {
+		i := match(rows, matched, term)
+		if i < 0 {
+			result.Added = append(result.Added, term)
+			if opts.DryRun {
+				continue
+			}
+			query := db.Rebind(`INSERT INTO ` + t.Type.Name + ` (` + t.Type.Column + `, label_en, romaji) VALUES (?, ?, ?)`)
+			if _, err := db.InsertID(ctx, query, term.Ja, term.En, term.Romaji); err != nil {
+				return result, err
+			}
+			continue
+		}
+		matched[i] = true
+		r := rows[i]
+		if r.LabelEn != nil && *r.LabelEn == term.En && r.Romaji != nil && *r.Romaji == term.Romaji {
+			continue
+		}
+		result.Labeled = append(result.Labeled, term)
+		if opts.DryRun {
+			continue
+		}
+		query := db.Rebind(`UPDATE ` + t.Type.Name + ` SET label_en = ?, romaji = ? WHERE id = ?`)
+		if _, err := db.ExecContext(ctx, query, term.En, term.Romaji, r.ID); err != nil {
+			return result, err
+		}
+	}
+
+	references, err := countReferences(ctx, db, t)
+	if err != nil {
+		return result, err
+	}
+	for i, r := range rows {
+		if matched[i] {
+			continue
+		}
+		extra := Extra{ID: r.ID, Value: r.Value, References: references[r.ID]}
+		if !opts.Prune || extra.References > 0 {
+			result.Extras = append(result.Extras, extra)
+			continue
+		}
+		result.Pruned = append(result.Pruned, extra)
+		if opts.DryRun {
+			continue
+		}
+		// 確認してから削除するまでの間に参照された場合は外部キーの制約で失敗する
+		query := db.Rebind(`DELETE FROM ` + t.Type.Name + ` WHERE id = ?`)
+		if _, err := db.ExecContext(ctx, query, r.ID); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// match はtermと同じ語の行のうち、まだ使っていない最初の行の位置を返す。ない場合は-1
+func match(rows []row, matched []bool, term Term) int {
+	for _, same := range []func(string) bool{
+		func(v string) bool { return v == term.Ja },
+		func(v string) bool { return strings.EqualFold(v, term.En) || strings.EqualFold(v, term.Romaji) },
+	} {
+		for i, r := range rows {
+			if !matched[i] && same(strings.TrimSpace(r.Value)) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// countReferences は*_typeのidごとに参照しているentryの数を返す
+func countReferences(ctx context.Context, db *database.DB, t Table) (map[int64]int64, error) {
+	counts := []struct {
+		ID    int64 `db:"id"`
+		Count int64 `db:"n"`
+	}{}
+	query := `SELECT ` + t.Attribute.Column + ` AS id, COUNT(*) AS n FROM ` + t.Attribute.Name + ` GROUP BY ` + t.Attribute.Column
+	if err := db.SelectContext(ctx, &counts, query); err != nil {
+		return nil, err
+	}
+	references := make(map[int64]int64, len(counts))
+	for _, c := range counts {
+		references[c.ID] = c.Count
+	}
+	return references, nil
+}
@@ -0,0 +1,123 @@
+// Package vocabulary は*_typeテーブルの正規の語彙
+// 表記がチームごとに揺れないよう、日本語・英語・ローマ字の組をプロジェクトで管理する
+package vocabulary
+
+import "maguro-alternative/varcel-go/pkg/model"
+
+// Version は語彙の版。語を追加・変更したら上げる
+const Version = 1
+
+// Term は語彙の1語。Jaが*_typeテーブルの値になる
+// ローマ字はヘボン式で、長音は母音を重ねてASCIIだけで書く(ツインテール → tsuinteeru)
+type Term struct {
+	Ja     string `json:"ja"`
+	En     string `json:"en"`
+	Romaji string `json:"romaji"`
+}
+
+// Table は*_typeテーブルと、そのidを参照する属性テーブルの語彙
+// Termsはよく使われる順に並べる
+type Table struct {
+	Type      model.TypeTable
+	Attribute model.AttributeTable
+	Terms     []Term
+}
+
+var Tables = []Table{
+	{
+		Type:      model.HairColorTypeTable,
+		Attribute: model.HairColorTable,
+		Terms: []Term{
+			{Ja: "黒", En: "black", Romaji: "kuro"},
+			{Ja: "茶", En: "brown", Romaji: "cha"},
+			{Ja: "金", En: "blonde", Romaji: "kin"},
+			{Ja: "銀", En: "silver", Romaji: "gin"},
+			{Ja: "白", En: "white", Romaji: "shiro"},
+			{Ja: "赤", En: "red", Romaji: "aka"},
+			{Ja: "ピンク", En: "pink", Romaji: "pinku"},
+			{Ja: "青", En: "blue", Romaji: "ao"},
+			{Ja: "紫", En: "purple", Romaji: "murasaki"},
+			{Ja: "緑", En: "green", Romaji: "midori"},
+			{Ja: "水色", En: "light blue", Romaji: "mizuiro"},
+			{Ja: "灰", En: "gray", Romaji: "hai"},
+			{Ja: "オレンジ", En: "orange", Romaji: "orenji"},
+		},
+	},
+	{
+		Type:      model.EyeColorTypeTable,
+		Attribute: model.EyeColorTable,
+		Terms: []Term{
+			{Ja: "黒", En: "black", Romaji: "kuro"},
+			{Ja: "茶", En: "brown", Romaji: "cha"},
+			{Ja: "青", En: "blue", Romaji: "ao"},
+			{Ja: "緑", En: "green", Romaji: "midori"},
+			{Ja: "赤", En: "red", Romaji: "aka"},
+			{Ja: "金", En: "gold", Romaji: "kin"},
+			{Ja: "紫", En: "purple", Romaji: "murasaki"},
+			{Ja: "灰", En: "gray", Romaji: "hai"},
+			{Ja: "ピンク", En: "pink", Romaji: "pinku"},
+			{Ja: "水色", En: "light blue", Romaji: "mizuiro"},
+		},
+	},
+	{
+		Type:      model.HairStyleTypeTable,
+		Attribute: model.HairStyleTable,
+		Terms: []Term{
+			{Ja: "ストレート", En: "straight", Romaji: "sutoreeto"},
+			{Ja: "ツインテール", En: "twin tails", Romaji: "tsuinteeru"},
+			{Ja: "ポニーテール", En: "ponytail", Romaji: "poniiteeru"},
+			{Ja: "ボブ", En: "bob", Romaji: "bobu"},
+			{Ja: "ショート", En: "short cut", Romaji: "shooto"},
+			{Ja: "サイドテール", En: "side tail", Romaji: "saidoteeru"},
+			{Ja: "お団子", En: "bun", Romaji: "odango"},
+			{Ja: "三つ編み", En: "braid", Romaji: "mitsuami"},
+			{Ja: "姫カット", En: "hime cut", Romaji: "himekatto"},
+			{Ja: "ウルフカット", En: "wolf cut", Romaji: "urufukatto"},
+			{Ja: "縦ロール", En: "drill curls", Romaji: "taterooru"},
+			{Ja: "ハーフアップ", En: "half up", Romaji: "haafuappu"},
+			{Ja: "ツーサイドアップ", En: "two side up", Romaji: "tsuusaidoappu"},
+			{Ja: "ウェーブ", En: "wavy", Romaji: "weebu"},
+		},
+	},
+	{
+		Type:      model.HairLengthTypeTable,
+		Attribute: model.HairLengthTable,
+		Terms: []Term{
+			{Ja: "ベリーショート", En: "very short", Romaji: "beriishooto"},
+			{Ja: "ショート", En: "short", Romaji: "shooto"},
+			{Ja: "ミディアム", En: "medium", Romaji: "midiamu"},
+			{Ja: "セミロング", En: "semi-long", Romaji: "semirongu"},
+			{Ja: "ロング", En: "long", Romaji: "rongu"},
+			{Ja: "スーパーロング", En: "super long", Romaji: "suupaarongu"},
+		},
+	},
+	{
+		Type:      model.PersonalityTypeTable,
+		Attribute: model.PersonalityTable,
+		Terms: []Term{
+			{Ja: "ツンデレ", En: "tsundere", Romaji: "tsundere"},
+			{Ja: "クーデレ", En: "kuudere", Romaji: "kuudere"},
+			{Ja: "ヤンデレ", En: "yandere", Romaji: "yandere"},
+			{Ja: "天然", En: "airhead", Romaji: "tennen"},
+			{Ja: "元気", En: "energetic", Romaji: "genki"},
+			{Ja: "真面目", En: "serious", Romaji: "majime"},
+			{Ja: "小悪魔", En: "teasing", Romaji: "koakuma"},
+			{Ja: "無口", En: "quiet", Romaji: "mukuchi"},
+			{Ja: "姉御肌", En: "big-sister type", Romaji: "anegohada"},
+			{Ja: "甘えん坊", En: "clingy", Romaji: "amaenboo"},
+			{Ja: "毒舌", En: "sharp-tongued", Romaji: "dokuzetsu"},
+			{Ja: "臆病", En: "timid", Romaji: "okubyoo"},
+			{Ja: "中二病", En: "chuunibyou", Romaji: "chuunibyoo"},
+		},
+	},
+}
+
+// Lookup は*_typeテーブルの名前から語彙を返す
+func Lookup(name string) (Table, bool) {
+	for _, t := range Tables {
+		if t.Type.Name == name {
+			return t, true
+		}
+	}
+	return Table{}, false
+}